@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Creates a team-scoped API key for automation (owner/admin only). The token is only returned in this response; send it as "Authorization: Bearer <token>".
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param request body apiKeyRequest true "API key create request"
+// @Success 201 {object} response.SuccessResponse "API key created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req apiKeyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	now := time.Now().UTC()
+	if err := validateExpiry(req.ExpiresAt, now); err != nil {
+		return err
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot manage API keys")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceAPIKey, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create API keys for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	token, prefix, err := encrypt.GenerateAPIKey()
+	if err != nil {
+		zap.L().Error("Failed to generate API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate API key")
+	}
+
+	keyID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate API key ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate API key ID")
+	}
+
+	key := models.APIKey{
+		ID:          keyID,
+		TeamID:      teamID,
+		CreatedBy:   *userID,
+		Name:        req.Name,
+		Scope:       req.Scope,
+		TokenPrefix: prefix,
+		TokenHash:   encrypt.HashToken(token),
+		ExpiresAt:   req.ExpiresAt,
+		UpdatedAt:   now,
+		CreatedAt:   now,
+	}
+
+	if err := h.Repo.CreateAPIKey(ctx, tx, key); err != nil {
+		zap.L().Error("Failed to create API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create API key")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceAPIKey,
+		ResourceID:   key.ID,
+		After:        key,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusCreated, response.Success("API key created successfully", createAPIKeyResponse{APIKey: key, Token: token}))
+}
@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// DeleteAPIKey godoc
+// @Summary Delete an API key
+// @Description Revokes an API key immediately (owner/admin only)
+// @Tags api-keys
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "API key ID"
+// @Success 200 {object} response.SuccessResponse "API key deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or API key not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/api-keys/{id} [delete]
+func (h *APIKeyHandler) DeleteAPIKey(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid API key ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot manage API keys")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceAPIKey, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete API keys for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetAPIKeyByID(ctx, tx, teamID, keyID)
+	if err != nil {
+		zap.L().Error("Failed to get API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get API key")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	}
+
+	if err := h.Repo.DeleteAPIKey(ctx, tx, teamID, keyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+		}
+
+		zap.L().Error("Failed to delete API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete API key")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceAPIKey,
+		ResourceID:   keyID,
+		Before:       *existing,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("API key deleted successfully"))
+}
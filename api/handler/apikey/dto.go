@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+)
+
+type apiKeyRequest struct {
+	Name      string             `json:"name" validate:"required,min=1,max=255"`
+	Scope     models.APIKeyScope `json:"scope" validate:"required,oneof=read write"`
+	ExpiresAt *time.Time         `json:"expires_at"`
+}
+
+// createAPIKeyResponse carries the plaintext token, which is only ever returned once.
+type createAPIKeyResponse struct {
+	models.APIKey
+	Token string `json:"token"`
+}
+
+func validateExpiry(expiresAt *time.Time, now time.Time) error {
+	if expiresAt != nil && !expiresAt.After(now) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Expiry must be in the future")
+	}
+	return nil
+}
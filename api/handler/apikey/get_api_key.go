@@ -0,0 +1,80 @@
+package apikey
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// GetAPIKey godoc
+// @Summary Get an API key
+// @Description Gets an API key of a team without its token (owner/admin only)
+// @Tags api-keys
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "API key ID"
+// @Success 200 {object} response.SuccessResponse "API key retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or API key not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid API key ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot manage API keys")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceAPIKey, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view API keys for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	key, err := h.Repo.GetAPIKeyByID(ctx, tx, teamID, keyID)
+	if err != nil {
+		zap.L().Error("Failed to get API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get API key")
+	}
+
+	if key == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("API key retrieved successfully", key))
+}
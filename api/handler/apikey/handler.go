@@ -0,0 +1,8 @@
+package apikey
+
+import "github.com/yorukot/knocker/repository"
+
+// APIKeyHandler groups dependencies for team API key endpoints.
+type APIKeyHandler struct {
+	Repo repository.Repository
+}
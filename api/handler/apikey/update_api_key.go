@@ -0,0 +1,129 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// UpdateAPIKey godoc
+// @Summary Update an API key
+// @Description Updates the name, scope and expiry of an API key (owner/admin only). The token itself cannot be changed.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "API key ID"
+// @Param request body apiKeyRequest true "API key update request"
+// @Success 200 {object} response.SuccessResponse "API key updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or API key not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/api-keys/{id} [put]
+func (h *APIKeyHandler) UpdateAPIKey(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid API key ID")
+	}
+
+	var req apiKeyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	now := time.Now().UTC()
+	if err := validateExpiry(req.ExpiresAt, now); err != nil {
+		return err
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot manage API keys")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceAPIKey, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update API keys for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetAPIKeyByID(ctx, tx, teamID, keyID)
+	if err != nil {
+		zap.L().Error("Failed to get API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get API key")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	}
+
+	key, err := h.Repo.UpdateAPIKey(ctx, tx, models.APIKey{
+		ID:        keyID,
+		TeamID:    teamID,
+		Name:      req.Name,
+		Scope:     req.Scope,
+		ExpiresAt: req.ExpiresAt,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		zap.L().Error("Failed to update API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update API key")
+	}
+
+	if key == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceAPIKey,
+		ResourceID:   keyID,
+		Before:       *existing,
+		After:        *key,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("API key updated successfully", key))
+}
@@ -0,0 +1,8 @@
+package auditlog
+
+import "github.com/yorukot/knocker/repository"
+
+// AuditLogHandler groups dependencies for team audit log endpoints.
+type AuditLogHandler struct {
+	Repo repository.Repository
+}
@@ -0,0 +1,172 @@
+package auditlog
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+)
+
+type auditLogResponse struct {
+	Events []models.AuditEvent `json:"events"`
+	// NextCursor is passed back as before to fetch the next page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListAuditEvents godoc
+// @Summary List audit events
+// @Description Lists a team's audit log newest first, filtered and paginated by cursor (owner/admin only)
+// @Tags audit-log
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param action query string false "Action, e.g. create, update or delete"
+// @Param resource_type query string false "Resource type, e.g. monitor"
+// @Param resource_id query string false "Resource ID"
+// @Param actor_user_id query string false "Acting user ID"
+// @Param actor_api_key_id query string false "Acting API key ID"
+// @Param from query string false "Only events at or after this time (RFC3339)"
+// @Param to query string false "Only events before this time (RFC3339)"
+// @Param before query string false "Cursor from next_cursor of the previous page"
+// @Param limit query int false "Page size, 1-200 (default 50)"
+// @Success 200 {object} response.SuccessResponse{data=auditLogResponse} "Audit events retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameter"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/audit-log [get]
+func (h *AuditLogHandler) ListAuditEvents(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	filter, err := parseAuditEventFilter(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceAuditLog, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view the audit log of this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	// Ask for one extra event to know whether another page exists
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	events, err := h.Repo.ListAuditEvents(ctx, tx, teamID, filter)
+	if err != nil {
+		zap.L().Error("Failed to list audit events", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list audit events")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	resp := auditLogResponse{Events: events}
+	if resp.Events == nil {
+		resp.Events = []models.AuditEvent{}
+	}
+
+	if len(resp.Events) > pageSize {
+		resp.Events = resp.Events[:pageSize]
+		resp.NextCursor = strconv.FormatInt(resp.Events[pageSize-1].ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Audit events retrieved successfully", resp))
+}
+
+// parseAuditEventFilter reads the audit log filters from the query string.
+func parseAuditEventFilter(c echo.Context) (models.AuditEventFilter, error) {
+	filter := models.AuditEventFilter{
+		Action:       models.AuditAction(c.QueryParam("action")),
+		ResourceType: models.AuditResourceType(c.QueryParam("resource_type")),
+		Limit:        defaultAuditLogLimit,
+	}
+
+	ids := []struct {
+		param  string
+		target **int64
+	}{
+		{"resource_id", &filter.ResourceID},
+		{"actor_user_id", &filter.ActorUserID},
+		{"actor_api_key_id", &filter.ActorAPIKeyID},
+		{"before", &filter.BeforeID},
+	}
+	for _, p := range ids {
+		raw := c.QueryParam(p.param)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid "+p.param)
+		}
+		*p.target = &value
+	}
+
+	times := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	}
+	for _, p := range times {
+		raw := c.QueryParam(p.param)
+		if raw == "" {
+			continue
+		}
+
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid "+p.param+" time format")
+		}
+		value = value.UTC()
+		*p.target = &value
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxAuditLogLimit {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 200")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,118 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestListAuditEvents_Paginates(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListAuditEvents", mock.Anything, mock.Anything, int64(10), mock.MatchedBy(func(filter models.AuditEventFilter) bool {
+		return filter.Limit == 3 &&
+			filter.ResourceType == models.AuditResourceMonitor &&
+			filter.BeforeID != nil && *filter.BeforeID == 100 &&
+			filter.From != nil && filter.To == nil
+	})).Return([]models.AuditEvent{{ID: 99}, {ID: 98}, {ID: 97}}, nil)
+
+	h := &AuditLogHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/teams/10/audit-log?resource_type=monitor&before=100&from=2026-01-01T00:00:00Z&limit=2", nil)
+	c.SetParamNames("teamID")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleAdmin})
+
+	err := h.ListAuditEvents(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data struct {
+			Events     []map[string]any `json:"events"`
+			NextCursor string           `json:"next_cursor"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Events, 2)
+	require.Equal(t, "98", resp.Data.NextCursor)
+}
+
+func TestListAuditEvents_LastPage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListAuditEvents", mock.Anything, mock.Anything, int64(10), mock.MatchedBy(func(filter models.AuditEventFilter) bool {
+		return filter.Limit == defaultAuditLogLimit+1
+	})).Return([]models.AuditEvent(nil), nil)
+
+	h := &AuditLogHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/teams/10/audit-log", nil)
+	c.SetParamNames("teamID")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleOwner})
+
+	err := h.ListAuditEvents(c)
+	require.NoError(t, err)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]any)
+	require.Equal(t, []any{}, data["events"])
+	require.NotContains(t, data, "next_cursor")
+}
+
+func TestListAuditEvents_Forbidden(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	h := &AuditLogHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/teams/10/audit-log", nil)
+	c.SetParamNames("teamID")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleMember})
+
+	err := h.ListAuditEvents(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "ListAuditEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListAuditEvents_InvalidQuery(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	for _, query := range []string{"limit=0", "limit=201", "before=abc", "from=yesterday", "from=2026-02-01T00:00:00Z&to=2026-01-01T00:00:00Z"} {
+		h := &AuditLogHandler{Repo: &repository.MockRepository{}}
+		c, _ := testutil.NewEchoContext(http.MethodGet, "/teams/10/audit-log?"+query, nil)
+		c.SetParamNames("teamID")
+		c.SetParamValues("10")
+		testutil.Authenticate(c, 123)
+
+		err := h.ListAuditEvents(c)
+		require.Error(t, err, query)
+		httpErr, ok := err.(*echo.HTTPError)
+		require.True(t, ok, query)
+		require.Equal(t, http.StatusBadRequest, httpErr.Code, query)
+	}
+}
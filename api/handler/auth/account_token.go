@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Password reset and email verification part   |
+// +----------------------------------------------+
+
+// accountTokenLength is the length of the random token mailed to the user
+const accountTokenLength = 48
+
+// issueAccountToken creates a single-use token for the account and returns its plaintext.
+// Tokens issued earlier for the same purpose stop working, so only the latest email is valid.
+func issueAccountToken(ctx context.Context, repo repository.Repository, tx pgx.Tx, accountID int64, purpose models.AccountTokenPurpose, ttl time.Duration, now time.Time) (string, error) {
+	if err := repo.UseAccountTokens(ctx, tx, accountID, purpose, now); err != nil {
+		return "", fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	plaintext, err := encrypt.GenerateRandomString(accountTokenLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	tokenID, err := id.GetID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	if err := repo.CreateAccountToken(ctx, tx, models.AccountToken{
+		ID:        tokenID,
+		AccountID: accountID,
+		Purpose:   purpose,
+		TokenHash: encrypt.HashToken(plaintext),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}); err != nil {
+		return "", fmt.Errorf("failed to create token: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// frontendLink builds a link to a frontend page carrying the token
+func frontendLink(path, token string) string {
+	return strings.TrimRight(config.Env().FrontendURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func passwordResetEmail(to, token string) tasks.SendEmailPayload {
+	return tasks.SendEmailPayload{
+		To:      to,
+		Subject: "Reset your Knocker password",
+		Text: fmt.Sprintf("Someone asked to reset the password of your Knocker account.\n\n"+
+			"Open the link below to choose a new password. It expires in %d minutes and can only be used once.\n\n%s\n\n"+
+			"If you didn't ask for this, you can ignore this email.",
+			int(models.PasswordResetTokenTTL.Minutes()), frontendLink("/reset-password", token)),
+	}
+}
+
+func emailVerificationEmail(to, token string) tasks.SendEmailPayload {
+	return tasks.SendEmailPayload{
+		To:      to,
+		Subject: "Verify your Knocker email address",
+		Text: fmt.Sprintf("Confirm that this address belongs to your Knocker account by opening the link below. "+
+			"It expires in %d hours.\n\n%s\n\n"+
+			"You need a verified address to receive team invites.",
+			int(models.EmailVerificationTokenTTL.Hours()), frontendLink("/verify-email", token)),
+	}
+}
+
+// enqueueEmail queues an email for the worker. Delivery failures are logged, not returned:
+// the token is already committed and the user can ask for a new email.
+func enqueueEmail(client tasks.Enqueuer, payload tasks.SendEmailPayload) {
+	task, err := tasks.NewSendEmail(payload)
+	if err == nil {
+		_, err = client.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue email", zap.String("subject", payload.Subject), zap.Error(err))
+	}
+}
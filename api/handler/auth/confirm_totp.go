@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// confirmTOTPRequest is the request body for confirming a TOTP enrollment
+type confirmTOTPRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"`
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enables TOTP once a code from the authenticator app matches the pending secret, and returns one-time recovery codes that are never shown again
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body confirmTOTPRequest true "Code from the authenticator app"
+// @Success 200 {object} response.SuccessResponse{data=recoveryCodesResponse} "TOTP enabled successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or invalid code"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot manage account security"
+// @Failure 404 {object} response.ErrorResponse "No pending TOTP enrollment"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/mfa/totp/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c echo.Context) error {
+	userID, err := requireUserSession(c)
+	if err != nil {
+		return err
+	}
+
+	var req confirmTOTPRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	if totp == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "No pending TOTP enrollment")
+	}
+
+	if totp.Enabled() {
+		return echo.NewHTTPError(http.StatusConflict, "TOTP is already enabled")
+	}
+
+	now := time.Now()
+	step, ok, err := encrypt.ValidateTOTP(totp.Secret, req.Code, now)
+	if err != nil {
+		zap.L().Error("Failed to validate totp code", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to validate code")
+	}
+
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid verification code")
+	}
+
+	totp.EnabledAt = &now
+	totp.LastUsedStep = &step
+	totp.UpdatedAt = now
+	if err := h.Repo.UpdateUserTOTPState(c.Request().Context(), tx, *totp); err != nil {
+		zap.L().Error("Failed to enable totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to enable totp")
+	}
+
+	plaintext, codes, err := generateRecoveryCodes(userID, now)
+	if err != nil {
+		zap.L().Error("Failed to generate recovery codes", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate recovery codes")
+	}
+
+	if err := h.Repo.ReplaceRecoveryCodes(c.Request().Context(), tx, userID, codes); err != nil {
+		zap.L().Error("Failed to save recovery codes", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save recovery codes")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("TOTP enabled successfully", recoveryCodesResponse{
+		RecoveryCodes: plaintext,
+	}))
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// enrollTOTPResponse is what the authenticator app needs; the provisioning URI is usually shown as a QR code
+type enrollTOTPResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/knocker:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=knocker"`
+}
+
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generates a new TOTP secret for the authenticated user. It stays pending until confirmed with a code from the authenticator app
+// @Tags auth
+// @Produce json
+// @Success 201 {object} response.SuccessResponse{data=enrollTOTPResponse} "TOTP enrollment started"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot manage account security"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/mfa/totp [post]
+func (h *AuthHandler) EnrollTOTP(c echo.Context) error {
+	userID, err := requireUserSession(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	existing, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	if existing.Enabled() {
+		return echo.NewHTTPError(http.StatusConflict, "TOTP is already enabled")
+	}
+
+	email, err := h.Repo.GetUserEmailByID(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user email", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user email")
+	}
+
+	secret, err := encrypt.GenerateTOTPSecret()
+	if err != nil {
+		zap.L().Error("Failed to generate totp secret", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate totp secret")
+	}
+
+	// Starting over replaces any pending secret that was never confirmed
+	now := time.Now()
+	if err := h.Repo.UpsertUserTOTP(c.Request().Context(), tx, models.UserTOTP{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		zap.L().Error("Failed to save user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save user totp")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusCreated, response.Success("TOTP enrollment started", enrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: encrypt.TOTPProvisioningURI(config.Env().AppName, email, secret),
+	}))
+}
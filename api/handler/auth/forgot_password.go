@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Forgot Password                              |
+// +----------------------------------------------+
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=255" example:"user@example.com"`
+}
+
+// forgotPasswordMessage is returned whether or not the account exists, so the endpoint cannot be used to probe emails
+const forgotPasswordMessage = "If an account exists for this email, a password reset link has been sent"
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Emails a single-use password reset link to an email/password account. The response is the same whether or not the account exists
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body forgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} response.SuccessResponse "Password reset link sent if the account exists"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c echo.Context) error {
+	var forgotPasswordRequest forgotPasswordRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&forgotPasswordRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(forgotPasswordRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	// Only email/password accounts have a password to reset
+	account, err := h.Repo.GetAccountByProviderEmail(c.Request().Context(), tx, models.ProviderEmail, forgotPasswordRequest.Email)
+	if err != nil {
+		zap.L().Error("Failed to get account by email", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get account by email")
+	}
+
+	if account == nil {
+		return c.JSON(http.StatusOK, response.SuccessMessage(forgotPasswordMessage))
+	}
+
+	token, err := issueAccountToken(c.Request().Context(), h.Repo, tx, account.ID, models.AccountTokenPasswordReset, models.PasswordResetTokenTTL, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to issue password reset token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to issue password reset token")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	enqueueEmail(h.AsynqClient, passwordResetEmail(account.Email, token))
+
+	return c.JSON(http.StatusOK, response.SuccessMessage(forgotPasswordMessage))
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// mfaStatusResponse describes the second factors of the signed-in user
+type mfaStatusResponse struct {
+	TOTPEnabled            bool       `json:"totp_enabled"`
+	TOTPEnabledAt          *time.Time `json:"totp_enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// GetMFAStatus godoc
+// @Summary Get MFA status
+// @Description Returns whether TOTP is enabled for the authenticated user and how many recovery codes are left
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=mfaStatusResponse} "MFA status retrieved successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot manage account security"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/mfa [get]
+func (h *AuthHandler) GetMFAStatus(c echo.Context) error {
+	userID, err := requireUserSession(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	status := mfaStatusResponse{}
+	if totp.Enabled() {
+		codes, err := h.Repo.ListUnusedRecoveryCodes(c.Request().Context(), tx, userID)
+		if err != nil {
+			zap.L().Error("Failed to list recovery codes", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list recovery codes")
+		}
+
+		status.TOTPEnabled = true
+		status.TOTPEnabledAt = totp.EnabledAt
+		status.RecoveryCodesRemaining = len(codes)
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("MFA status retrieved successfully", status))
+}
@@ -3,9 +3,11 @@ package auth
 import (
 	"github.com/yorukot/knocker/repository"
 	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/worker/tasks"
 )
 
 type AuthHandler struct {
 	Repo        repository.Repository
 	OAuthConfig *config.OAuthConfig
+	AsynqClient tasks.Enqueuer
 }
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/response"
+)
+
+type oauthProviderResponse struct {
+	Key  models.Provider `json:"key" example:"github"`
+	Name string          `json:"name" example:"GitHub"`
+}
+
+// ListProviders godoc
+// @Summary List login providers
+// @Description Returns the OAuth providers configured on this instance, in configured order, so the login page can offer them
+// @Tags oauth
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=[]oauthProviderResponse} "Providers retrieved successfully"
+// @Router /auth/providers [get]
+func (h *AuthHandler) ListProviders(c echo.Context) error {
+	providers := make([]oauthProviderResponse, 0, len(h.OAuthConfig.Keys))
+	for _, key := range h.OAuthConfig.Keys {
+		providers = append(providers, oauthProviderResponse{
+			Key:  key,
+			Name: h.OAuthConfig.Providers[key].Name,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Providers retrieved successfully", providers))
+}
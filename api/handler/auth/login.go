@@ -21,15 +21,22 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required,min=8,max=255"`
 }
 
+// loginResponse tells the client whether a second factor is needed to finish signing in
+type loginResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
+
 // Login godoc
 // @Summary User login
-// @Description Authenticates a user with email and password, sets refresh/access token cookies
+// @Description Authenticates a user with email and password, sets refresh/access token cookies. With TOTP enabled no cookies are set and the returned mfa_token must be completed at /auth/login/mfa
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "Login request with email and password"
-// @Success 200 {object} response.SuccessResponse "Login successful, refresh token set in cookie"
+// @Success 200 {object} response.SuccessResponse{data=loginResponse} "Login successful, refresh token set in cookie, or MFA required"
 // @Failure 400 {object} response.ErrorResponse "Invalid request body or invalid credentials"
+// @Failure 429 {object} response.ErrorResponse "Too many failed attempts for this account or IP"
 // @Failure 500 {object} response.ErrorResponse "Internal server error (transaction, database, or password verification failure)"
 // @Failure 502 {object} response.ErrorResponse "Invalid request body format"
 // @Router /auth/login [post]
@@ -45,6 +52,11 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
+	// Refuse early while the account or the client is locked out after failed attempts
+	if err := checkLoginLockout(c, loginRequest.Email); err != nil {
+		return err
+	}
+
 	// Begin the transaction
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
@@ -62,7 +74,9 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	// TODO: Need to change this
 	// If the user is not found, return an error
-	if user == nil {
+	// Unknown addresses count as failures too, so lockouts do not reveal which accounts exist
+	if user == nil || user.PasswordHash == nil {
+		recordLoginFailure(c, loginRequest.Email)
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid credentials")
 	}
 
@@ -75,17 +89,42 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	// If the password is not correct, return an error
 	if !match {
+		if recordLoginFailure(c, loginRequest.Email) {
+			h.reportLoginLocked(c, tx, user.ID)
+		}
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid credentials")
 	}
 
+	resetLoginFailures(c.Request().Context(), loginRequest.Email)
+
+	// With TOTP enabled the password only earns a short-lived challenge for /auth/login/mfa
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, user.ID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	if totp.Enabled() {
+		mfaToken, err := generateMFAChallenge(user.ID)
+		if err != nil {
+			zap.L().Error("Failed to generate mfa challenge", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate mfa challenge")
+		}
+
+		return c.JSON(http.StatusOK, response.Success("MFA required", loginResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}))
+	}
+
 	// Generate the refresh token
-	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, user.ID)
+	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, user.ID, nil)
 	if err != nil {
 		zap.L().Error("Failed to generate refresh token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate refresh token")
 	}
 
-	accessTokenCookie, err := generateAccessTokenCookieForUser(user.ID)
+	accessTokenCookie, err := generateAccessTokenCookieForUser(refreshToken)
 	if err != nil {
 		zap.L().Error("Failed to generate access token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate access token")
@@ -99,5 +138,5 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	c.SetCookie(&refreshTokenCookie)
 	c.SetCookie(&accessTokenCookie)
 
-	return c.JSON(http.StatusOK, response.SuccessMessage("Login successful"))
+	return c.JSON(http.StatusOK, response.Success("Login successful", loginResponse{}))
 }
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Login MFA                                    |
+// +----------------------------------------------+
+
+// loginMFARequest is the request body for completing a login with a second factor
+type loginMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	secondFactorRequest
+}
+
+// LoginMFA godoc
+// @Summary Complete login with a second factor
+// @Description Exchanges the mfa_token returned by /auth/login and a TOTP or recovery code for refresh/access token cookies
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body loginMFARequest true "MFA token with a TOTP code or a recovery code"
+// @Success 200 {object} response.SuccessResponse "Login successful, refresh token set in cookie"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body"
+// @Failure 401 {object} response.ErrorResponse "Invalid or expired MFA challenge, or invalid code"
+// @Failure 429 {object} response.ErrorResponse "Too many failed attempts"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/login/mfa [post]
+func (h *AuthHandler) LoginMFA(c echo.Context) error {
+	var req loginMFARequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := validateMFAChallenge(req.MFAToken)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired MFA challenge")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	// MFA was disabled after the challenge was issued
+	if !totp.Enabled() {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired MFA challenge")
+	}
+
+	now := time.Now()
+	if totp.Locked(now) {
+		return echo.NewHTTPError(http.StatusTooManyRequests, "Too many failed attempts, try again later")
+	}
+
+	valid, err := verifySecondFactor(c.Request().Context(), h.Repo, tx, totp, req.secondFactorRequest, now)
+	if err != nil {
+		zap.L().Error("Failed to verify second factor", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify code")
+	}
+
+	if !valid {
+		if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+			zap.L().Error("Failed to commit transaction", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		}
+
+		zap.L().Warn("Invalid second factor at login",
+			zap.Int64("user_id", userID),
+			zap.Int("failed_attempts", totp.FailedAttempts),
+			zap.String("ip", c.RealIP()))
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid verification code")
+	}
+
+	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, userID, nil)
+	if err != nil {
+		zap.L().Error("Failed to generate refresh token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate refresh token")
+	}
+
+	accessTokenCookie, err := generateAccessTokenCookieForUser(refreshToken)
+	if err != nil {
+		zap.L().Error("Failed to generate access token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate access token")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	refreshTokenCookie := generateRefreshTokenCookie(refreshToken)
+	c.SetCookie(&refreshTokenCookie)
+	c.SetCookie(&accessTokenCookie)
+
+	return c.JSON(http.StatusOK, response.Success("Login successful", loginResponse{}))
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/encrypt"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func enabledTOTP(userID int64) *models.UserTOTP {
+	enabledAt := time.Now().Add(-time.Hour)
+	return &models.UserTOTP{UserID: userID, Secret: testTOTPSecret, EnabledAt: &enabledAt}
+}
+
+func TestLogin_RequiresMFAWhenTOTPEnabled(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	passwordHash, err := encrypt.CreateArgon2idHash("correct-password")
+	require.NoError(t, err)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetUserByEmail", mock.Anything, mock.Anything, "alice@example.com").
+		Return(&models.User{ID: 123, PasswordHash: &passwordHash}, nil)
+	mockRepo.On("GetUserTOTP", mock.Anything, mock.Anything, int64(123)).Return(enabledTOTP(123), nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	body := `{"email":"alice@example.com","password":"correct-password"}`
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/login", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+
+	err = h.Login(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Result().Cookies())
+
+	var resp struct {
+		Data loginResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.True(t, resp.Data.MFARequired)
+
+	userID, ok := validateMFAChallenge(resp.Data.MFAToken)
+	require.True(t, ok)
+	require.Equal(t, int64(123), userID)
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginMFA_ValidCodeIssuesSession(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	code, err := encrypt.GenerateTOTPCode(testTOTPSecret, time.Now())
+	require.NoError(t, err)
+	challenge, err := generateMFAChallenge(123)
+	require.NoError(t, err)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetUserTOTP", mock.Anything, mock.Anything, int64(123)).Return(enabledTOTP(123), nil)
+	mockRepo.On("UpdateUserTOTPState", mock.Anything, mock.Anything, mock.MatchedBy(func(totp models.UserTOTP) bool {
+		return totp.LastUsedStep != nil && totp.FailedAttempts == 0
+	})).Return(nil)
+	mockRepo.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.MatchedBy(func(token models.RefreshToken) bool {
+		return token.UserID == 123
+	})).Return(nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	body := `{"mfa_token":"` + challenge + `","code":"` + code + `"}`
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/login/mfa", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+
+	err = h.LoginMFA(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, rec.Result().Cookies(), 2)
+}
+
+func TestLoginMFA_WrongCodeCountsFailure(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	challenge, err := generateMFAChallenge(123)
+	require.NoError(t, err)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetUserTOTP", mock.Anything, mock.Anything, int64(123)).Return(enabledTOTP(123), nil)
+	mockRepo.On("UpdateUserTOTPState", mock.Anything, mock.Anything, mock.MatchedBy(func(totp models.UserTOTP) bool {
+		return totp.FailedAttempts == 1 && totp.LastFailedAt != nil
+	})).Return(nil)
+	mockRepo.On("ListUnusedRecoveryCodes", mock.Anything, mock.Anything, int64(123)).Return([]models.RecoveryCode{}, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	body := `{"mfa_token":"` + challenge + `","recovery_code":"wrong-code"}`
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/auth/login/mfa", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+
+	err = h.LoginMFA(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	mockRepo.AssertCalled(t, "CommitTransaction", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginMFA_LockedAfterTooManyFailures(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	challenge, err := generateMFAChallenge(123)
+	require.NoError(t, err)
+
+	totp := enabledTOTP(123)
+	lastFailedAt := time.Now().Add(-time.Minute)
+	totp.FailedAttempts = models.MFAMaxFailedAttempts
+	totp.LastFailedAt = &lastFailedAt
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetUserTOTP", mock.Anything, mock.Anything, int64(123)).Return(totp, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	body := `{"mfa_token":"` + challenge + `","code":"123456"}`
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/auth/login/mfa", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+
+	err = h.LoginMFA(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusTooManyRequests, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "UpdateUserTOTPState", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginMFA_RejectsAccessTokenAsChallenge(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	accessToken, err := generateAccessToken(123, "token-id")
+	require.NoError(t, err)
+
+	h := &AuthHandler{Repo: &repository.MockRepository{}}
+	body := `{"mfa_token":"` + accessToken + `","code":"123456"}`
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/auth/login/mfa", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+
+	err = h.LoginMFA(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
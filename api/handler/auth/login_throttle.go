@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/ratelimit"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Login throttling part                        |
+// +----------------------------------------------+
+
+var (
+	// loginAccountLockout slows down password guessing against one account from any number of IPs
+	loginAccountLockout = ratelimit.Lockout{
+		Name:          "login:account",
+		Threshold:     5,
+		BaseDelay:     30 * time.Second,
+		MaxDelay:      time.Hour,
+		FailureWindow: 24 * time.Hour,
+	}
+
+	// loginIPLockout slows down credential stuffing from one IP across many accounts
+	loginIPLockout = ratelimit.Lockout{
+		Name:          "login:ip",
+		Threshold:     20,
+		BaseDelay:     time.Minute,
+		MaxDelay:      time.Hour,
+		FailureWindow: time.Hour,
+	}
+)
+
+// loginAccountKey identifies an account by its email without storing the address in Redis
+func loginAccountKey(email string) string {
+	return encrypt.HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// checkLoginLockout returns a 429 error when the account or the client IP is locked.
+// Failures of the limiter are logged and the login goes ahead.
+func checkLoginLockout(c echo.Context, email string) error {
+	ctx := c.Request().Context()
+
+	accountLocked, err := loginAccountLockout.Check(ctx, loginAccountKey(email))
+	if err != nil {
+		zap.L().Error("Failed to check login lockout", zap.String("lockout", loginAccountLockout.Name), zap.Error(err))
+	}
+
+	ipLocked, err := loginIPLockout.Check(ctx, c.RealIP())
+	if err != nil {
+		zap.L().Error("Failed to check login lockout", zap.String("lockout", loginIPLockout.Name), zap.Error(err))
+	}
+
+	retryAfter := max(accountLocked, ipLocked)
+	if retryAfter == 0 {
+		return nil
+	}
+
+	zap.L().Warn("Login attempt while locked out", zap.String("ip", c.RealIP()), zap.Duration("retry_after", retryAfter))
+	return middleware.TooManyRequests(c, retryAfter)
+}
+
+// recordLoginFailure counts a failed login for the account and the client IP.
+// It reports whether this failure is the one that locked the account.
+func recordLoginFailure(c echo.Context, email string) bool {
+	ctx := c.Request().Context()
+
+	failures, accountLocked, err := loginAccountLockout.Fail(ctx, loginAccountKey(email))
+	if err != nil {
+		zap.L().Error("Failed to record login failure", zap.String("lockout", loginAccountLockout.Name), zap.Error(err))
+	}
+
+	_, ipLocked, err := loginIPLockout.Fail(ctx, c.RealIP())
+	if err != nil {
+		zap.L().Error("Failed to record login failure", zap.String("lockout", loginIPLockout.Name), zap.Error(err))
+	}
+
+	if ipLocked > 0 {
+		zap.L().Warn("Client IP locked out of login", zap.String("ip", c.RealIP()), zap.Duration("locked_for", ipLocked))
+	}
+
+	if accountLocked > 0 {
+		zap.L().Warn("Account locked out of login",
+			zap.String("ip", c.RealIP()),
+			zap.Int64("failures", failures),
+			zap.Duration("locked_for", accountLocked))
+	}
+
+	return failures == loginAccountLockout.Threshold
+}
+
+// resetLoginFailures forgets the failed logins of an account after it signed in
+func resetLoginFailures(ctx context.Context, email string) {
+	if err := loginAccountLockout.Reset(ctx, loginAccountKey(email)); err != nil {
+		zap.L().Error("Failed to reset login failures", zap.Error(err))
+	}
+}
+
+// reportLoginLocked records that failed logins locked the user's account and alerts the user.
+// The login has already failed, so problems here are only logged.
+func (h *AuthHandler) reportLoginLocked(c echo.Context, tx pgx.Tx, userID int64) {
+	ctx := c.Request().Context()
+	now := time.Now()
+	userAgent := c.Request().UserAgent()
+
+	eventID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate security event ID", zap.Error(err))
+		return
+	}
+
+	if err := h.Repo.CreateSecurityEvent(ctx, tx, models.SecurityEvent{
+		ID:        eventID,
+		UserID:    userID,
+		Type:      models.SecurityEventLoginLocked,
+		IP:        net.ParseIP(c.RealIP()),
+		UserAgent: &userAgent,
+		CreatedAt: now,
+	}); err != nil {
+		zap.L().Error("Failed to create security event", zap.Error(err))
+		return
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return
+	}
+
+	task, err := tasks.NewSecurityAlert(tasks.SecurityAlertPayload{
+		UserID:     userID,
+		Type:       models.SecurityEventLoginLocked,
+		IP:         c.RealIP(),
+		UserAgent:  userAgent,
+		OccurredAt: now,
+	})
+	if err == nil {
+		_, err = h.AsynqClient.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue security alert", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/denylist"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Logout                                       |
+// +----------------------------------------------+
+
+// Logout godoc
+// @Summary Log out
+// @Description Signs the current session out: revokes its refresh tokens, denylists the access token and clears the auth cookies
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.SuccessResponse "Logged out successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot log out"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c echo.Context) error {
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot log out")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	// The session is found through the access token; the refresh cookie is only sent to /auth/refresh
+	var session *models.RefreshToken
+	accessTokenID, accessTokenExpiresAt, hasAccessTokenID := authutil.GetAccessTokenFromContext(c)
+	if hasAccessTokenID {
+		session, err = h.Repo.GetRefreshTokenByAccessTokenID(c.Request().Context(), tx, accessTokenID)
+		if err != nil {
+			zap.L().Error("Failed to get refresh token by access token ID", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get session")
+		}
+	}
+
+	if session == nil {
+		if refreshTokenCookie, err := c.Cookie(models.CookieNameRefreshToken); err == nil {
+			session, err = h.Repo.GetRefreshTokenByToken(c.Request().Context(), tx, refreshTokenCookie.Value)
+			if err != nil {
+				zap.L().Error("Failed to get refresh token by token", zap.Error(err))
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get session")
+			}
+		}
+	}
+
+	now := time.Now()
+
+	var accessTokens []models.IssuedAccessToken
+	if session != nil && session.UserID == *userID {
+		if _, err := h.Repo.RevokeRefreshTokenFamily(c.Request().Context(), tx, *userID, session.FamilyID, now); err != nil {
+			zap.L().Error("Failed to revoke session", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke session")
+		}
+
+		accessTokens, err = h.Repo.ListIssuedAccessTokens(c.Request().Context(), tx, *userID, &session.FamilyID, now.Add(-accessTokenLifetime()))
+		if err != nil {
+			zap.L().Error("Failed to list session access tokens", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list session access tokens")
+		}
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	clearAuthCookies(c)
+
+	if hasAccessTokenID {
+		if err := denylist.Add(c.Request().Context(), accessTokenID, accessTokenExpiresAt); err != nil {
+			zap.L().Error("Failed to denylist access token", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access token")
+		}
+	}
+
+	if err := denylist.AddIssued(c.Request().Context(), accessTokens, accessTokenLifetime()); err != nil {
+		zap.L().Error("Failed to denylist session access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access token")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Logged out successfully"))
+}
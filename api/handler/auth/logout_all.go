@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/denylist"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// LogoutAll godoc
+// @Summary Log out everywhere
+// @Description Signs the user out of every session; all refresh tokens are revoked and every access token still valid is denylisted
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.SuccessResponse "Logged out of all sessions successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot log out"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys cannot log out")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	if _, err := h.Repo.RevokeAllRefreshTokensByUserID(c.Request().Context(), tx, *userID, now); err != nil {
+		zap.L().Error("Failed to revoke sessions", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke sessions")
+	}
+
+	accessTokens, err := h.Repo.ListIssuedAccessTokens(c.Request().Context(), tx, *userID, nil, now.Add(-accessTokenLifetime()))
+	if err != nil {
+		zap.L().Error("Failed to list access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list access tokens")
+	}
+
+	eventID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate security event ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate security event ID")
+	}
+
+	userAgent := c.Request().UserAgent()
+	if err := h.Repo.CreateSecurityEvent(c.Request().Context(), tx, models.SecurityEvent{
+		ID:        eventID,
+		UserID:    *userID,
+		Type:      models.SecurityEventSessionRevoked,
+		IP:        net.ParseIP(c.RealIP()),
+		UserAgent: &userAgent,
+		CreatedAt: now,
+	}); err != nil {
+		zap.L().Error("Failed to create security event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create security event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	clearAuthCookies(c)
+
+	// Tokens issued before jti existed are not listed, so the current one is added explicitly
+	if accessTokenID, accessTokenExpiresAt, ok := authutil.GetAccessTokenFromContext(c); ok {
+		if err := denylist.Add(c.Request().Context(), accessTokenID, accessTokenExpiresAt); err != nil {
+			zap.L().Error("Failed to denylist access token", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access tokens")
+		}
+	}
+
+	if err := denylist.AddIssued(c.Request().Context(), accessTokens, accessTokenLifetime()); err != nil {
+		zap.L().Error("Failed to denylist access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access tokens")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Logged out of all sessions successfully"))
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestLogout_RevokesSessionFromRefreshCookie(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetRefreshTokenByToken", mock.Anything, mock.Anything, "refresh-token").
+		Return(&models.RefreshToken{ID: 2, UserID: 123, FamilyID: 1}, nil)
+	mockRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, int64(123), int64(1), mock.AnythingOfType("time.Time")).
+		Return(int64(1), nil)
+	mockRepo.On("ListIssuedAccessTokens", mock.Anything, mock.Anything, int64(123), mock.MatchedBy(func(familyID *int64) bool {
+		return familyID != nil && *familyID == 1
+	}), mock.AnythingOfType("time.Time")).Return([]models.IssuedAccessToken{}, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/logout", nil)
+	c.Request().AddCookie(&http.Cookie{Name: models.CookieNameRefreshToken, Value: "refresh-token"})
+	testutil.Authenticate(c, 123)
+
+	err := h.Logout(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 2)
+	for _, cookie := range cookies {
+		require.Empty(t, cookie.Value)
+		require.Less(t, cookie.MaxAge, 0)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLogout_IgnoresOtherUsersSession(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetRefreshTokenByToken", mock.Anything, mock.Anything, "refresh-token").
+		Return(&models.RefreshToken{ID: 2, UserID: 456, FamilyID: 1}, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/logout", nil)
+	c.Request().AddCookie(&http.Cookie{Name: models.CookieNameRefreshToken, Value: "refresh-token"})
+	testutil.Authenticate(c, 123)
+
+	err := h.Logout(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogout_APIKeyForbidden(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/auth/logout", nil)
+	testutil.Authenticate(c, 123)
+	c.Set(string(middleware.APIKeyIDKey), "77")
+
+	err := h.Logout(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "StartTransaction", mock.Anything)
+}
+
+func TestLogoutAll_RevokesEverySession(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("RevokeAllRefreshTokensByUserID", mock.Anything, mock.Anything, int64(123), mock.AnythingOfType("time.Time")).
+		Return(int64(3), nil)
+	mockRepo.On("ListIssuedAccessTokens", mock.Anything, mock.Anything, int64(123), (*int64)(nil), mock.AnythingOfType("time.Time")).
+		Return([]models.IssuedAccessToken{}, nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.SecurityEvent) bool {
+		return event.UserID == 123 && event.Type == models.SecurityEventSessionRevoked && event.SessionID == nil
+	})).Return(nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/logout-all", nil)
+	testutil.Authenticate(c, 123)
+
+	err := h.LogoutAll(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+)
+
+// +----------------------------------------------+
+// | Multi-factor authentication part             |
+// +----------------------------------------------+
+
+// secondFactorRequest carries either a TOTP code or a recovery code
+type secondFactorRequest struct {
+	Code         string `json:"code,omitempty" validate:"required_without=RecoveryCode,omitempty,len=6,numeric" example:"123456"`
+	RecoveryCode string `json:"recovery_code,omitempty" validate:"required_without=Code,omitempty,max=32" example:"a1b2c-d3e4f"`
+}
+
+// recoveryCodesResponse returns freshly generated recovery codes; they are never shown again
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// verifySecondFactor checks a TOTP or recovery code for an enabled enrollment and records the outcome:
+// a TOTP step cannot be reused, a recovery code is burned, and failures count towards the lockout.
+// Callers commit even when the code is wrong so that failed attempts are counted.
+func verifySecondFactor(ctx context.Context, repo repository.Repository, tx pgx.Tx, totp *models.UserTOTP, request secondFactorRequest, now time.Time) (bool, error) {
+	var valid bool
+
+	if request.Code != "" {
+		step, ok, err := encrypt.ValidateTOTP(totp.Secret, request.Code, now)
+		if err != nil {
+			return false, fmt.Errorf("failed to validate totp code: %w", err)
+		}
+
+		// A code already used at login cannot be replayed within its window
+		if ok && (totp.LastUsedStep == nil || step > *totp.LastUsedStep) {
+			valid = true
+			totp.LastUsedStep = &step
+		}
+	} else {
+		codes, err := repo.ListUnusedRecoveryCodes(ctx, tx, totp.UserID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list recovery codes: %w", err)
+		}
+
+		recoveryCode := encrypt.NormalizeRecoveryCode(request.RecoveryCode)
+		for _, code := range codes {
+			match, err := encrypt.ComparePasswordAndHash(recoveryCode, code.CodeHash)
+			if err != nil {
+				return false, fmt.Errorf("failed to compare recovery code: %w", err)
+			}
+			if !match {
+				continue
+			}
+
+			if err := repo.MarkRecoveryCodeUsed(ctx, tx, code.ID, now); err != nil {
+				return false, fmt.Errorf("failed to redeem recovery code: %w", err)
+			}
+			valid = true
+			break
+		}
+	}
+
+	if valid {
+		totp.FailedAttempts = 0
+		totp.LastFailedAt = nil
+	} else {
+		totp.FailedAttempts++
+		totp.LastFailedAt = &now
+	}
+	totp.UpdatedAt = now
+
+	if err := repo.UpdateUserTOTPState(ctx, tx, *totp); err != nil {
+		return false, fmt.Errorf("failed to update totp state: %w", err)
+	}
+
+	return valid, nil
+}
+
+// generateRecoveryCodes creates a new set of recovery codes, returning the plaintext codes and their hashed rows
+func generateRecoveryCodes(userID int64, now time.Time) ([]string, []models.RecoveryCode, error) {
+	plaintext, err := encrypt.GenerateRecoveryCodes(models.RecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codes := make([]models.RecoveryCode, 0, len(plaintext))
+	for _, code := range plaintext {
+		codeID, err := id.GetID()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code ID: %w", err)
+		}
+
+		codeHash, err := encrypt.CreateArgon2idHash(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		codes = append(codes, models.RecoveryCode{
+			ID:        codeID,
+			UserID:    userID,
+			CodeHash:  codeHash,
+			CreatedAt: now,
+		})
+	}
+
+	return plaintext, codes, nil
+}
+
+// generateMFAChallenge issues the short-lived token exchanged for a session once the second factor is verified
+func generateMFAChallenge(userID int64) (string, error) {
+	secret := encrypt.JWTSecret{
+		Secret: config.Env().JWTSecretKey,
+	}
+
+	return secret.GenerateMFAChallenge(strconv.FormatInt(userID, 10), time.Now().Add(models.MFAChallengeTTL))
+}
+
+// mfaChallengeLink sends an OAuth login that still needs a second factor to the frontend's MFA step,
+// which completes it at /auth/login/mfa and then continues to next
+func mfaChallengeLink(mfaToken, next string) string {
+	return frontendLink("/login/mfa", mfaToken) + "&next=" + url.QueryEscape(next)
+}
+
+// validateMFAChallenge returns the user ID of a valid MFA challenge, or false if it is invalid or expired
+func validateMFAChallenge(challenge string) (int64, bool) {
+	secret := encrypt.JWTSecret{
+		Secret: config.Env().JWTSecretKey,
+	}
+
+	valid, claims, err := secret.ValidateMFAChallengeAndGetClaims(challenge)
+	if err != nil || !valid || claims.ExpiresAt < time.Now().Unix() {
+		return 0, false
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return userID, true
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,10 +22,10 @@ import (
 // @Tags oauth
 // @Accept json
 // @Produce json
-// @Param provider path string true "OAuth provider (e.g., google, github)"
+// @Param provider path string true "OAuth provider key from OAUTH_PROVIDERS (e.g., google, github, keycloak)"
 // @Param code query string true "Authorization code from OAuth provider"
 // @Param state query string true "OAuth state parameter for CSRF protection"
-// @Success 307 {string} string "Redirect to success URL with authentication cookies set"
+// @Success 307 {string} string "Redirect to success URL with authentication cookies set, or to the frontend MFA step with an mfa_token when TOTP is enabled"
 // @Failure 400 {object} response.ErrorResponse "Invalid provider, oauth state, or verification failed"
 // @Failure 500 {object} response.ErrorResponse "Internal server error during user creation or token generation"
 // @Router /auth/oauth/{provider}/callback [get]
@@ -41,15 +42,11 @@ func (h *AuthHandler) OAuthCallback(c echo.Context) error {
 	}
 
 	// Parse the provider
-	provider, err := parseProvider(c.Param("provider"))
+	oauthProvider, err := parseProvider(h.OAuthConfig, c.Param("provider"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid provider")
 	}
-
-	// No need to check if the provider is valid because it's checked in the parseProvider function
-	oauthConfig := h.OAuthConfig.Providers[provider]
-	// Get the oidc provider
-	oidcProvider := h.OAuthConfig.OIDCProviders[provider]
+	provider := oauthProvider.Key
 
 	// Validate the oauth state
 	valid, payload, err := oauthValidateStateWithPayload(oauthSessionCookie.Value)
@@ -78,21 +75,18 @@ func (h *AuthHandler) OAuthCallback(c echo.Context) error {
 	defer cancel()
 
 	// Exchange the code for a token
-	token, err := oauthConfig.Exchange(ctx, code)
+	token, err := oauthProvider.OAuth2.Exchange(ctx, code)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Failed to exchange code")
 	}
 
-	// Get the raw ID token
-	rawIDToken, ok := token.Extra("id_token").(string)
-	if !ok {
-		zap.L().Error("Failed to get id token from oauth response", zap.Any("token", token))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get id token")
+	// Verify the token and fetch the user's profile from the provider
+	userInfo, err := fetchOAuthUserInfo(ctx, oauthProvider, token)
+	if errors.Is(err, errOAuthEmailMissing) {
+		return echo.NewHTTPError(http.StatusBadRequest, "OAuth provider did not return a verified email")
 	}
-
-	// Verify the token
-	userInfo, err := oauthVerifyTokenAndGetUserInfo(c.Request().Context(), rawIDToken, token, oidcProvider, oauthConfig)
 	if err != nil {
+		zap.L().Warn("Failed to get oauth user info", zap.String("provider", string(provider)), zap.Error(err))
 		return echo.NewHTTPError(http.StatusBadRequest, "Failed to verify token")
 	}
 
@@ -187,14 +181,36 @@ func (h *AuthHandler) OAuthCallback(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create oauth token")
 	}
 
+	// With TOTP enabled the provider only stands in for the password, so the session waits for /auth/login/mfa
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	if totp.Enabled() {
+		mfaToken, err := generateMFAChallenge(userID)
+		if err != nil {
+			zap.L().Error("Failed to generate mfa challenge", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate mfa challenge")
+		}
+
+		if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+			zap.L().Error("Failed to commit transaction", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		}
+
+		return c.Redirect(http.StatusTemporaryRedirect, mfaChallengeLink(mfaToken, payload.RedirectURI))
+	}
+
 	// Generate the refresh token
-	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, userID)
+	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, userID, nil)
 	if err != nil {
 		zap.L().Error("Failed to create refresh token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create refresh token")
 	}
 
-	accessTokenCookie, err := generateAccessTokenCookieForUser(userID)
+	accessTokenCookie, err := generateAccessTokenCookieForUser(refreshToken)
 	if err != nil {
 		zap.L().Error("Failed to generate access token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate access token")
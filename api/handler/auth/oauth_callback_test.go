@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/config"
+	"golang.org/x/oauth2"
+)
+
+// newGitHubProvider serves the token exchange and the profile of GitHub user 42
+func newGitHubProvider(t *testing.T) *config.OAuthProvider {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "provider-token", "token_type": "bearer"})
+		case "/user":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 42, "login": "octocat"})
+		case "/user/emails":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"email": "octo@example.com", "primary": true, "verified": true}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return &config.OAuthProvider{
+		Key:  "github",
+		Type: config.OAuthProviderTypeGitHub,
+		OAuth2: &oauth2.Config{
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Endpoint:     oauth2.Endpoint{TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams},
+		},
+		APIURL: server.URL,
+	}
+}
+
+func TestOAuthCallback_RequiresMFAWhenTOTPEnabled(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	provider := newGitHubProvider(t)
+	session, state, err := oauthGenerateStateWithPayload("/dashboard", time.Now().Add(time.Minute), "")
+	require.NoError(t, err)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetAccountWithUserByProviderUserID", mock.Anything, mock.Anything, models.Provider("github"), "42").
+		Return(&models.Account{ID: 9, UserID: 123}, &models.User{ID: 123}, nil)
+	mockRepo.On("CreateOAuthToken", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetUserTOTP", mock.Anything, mock.Anything, int64(123)).Return(enabledTOTP(123), nil)
+
+	h := &AuthHandler{Repo: mockRepo, OAuthConfig: &config.OAuthConfig{
+		Providers: map[models.Provider]*config.OAuthProvider{"github": provider},
+	}}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/auth/oauth/github/callback?code=abc&state="+url.QueryEscape(state), nil)
+	c.Request().AddCookie(&http.Cookie{Name: models.CookieNameOAuthSession, Value: session})
+	c.SetParamNames("provider")
+	c.SetParamValues("github")
+
+	require.NoError(t, h.OAuthCallback(c))
+	require.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+
+	for _, cookie := range rec.Result().Cookies() {
+		require.NotEqual(t, models.CookieNameAccessToken, cookie.Name)
+		require.NotEqual(t, models.CookieNameRefreshToken, cookie.Name)
+	}
+
+	location, err := url.Parse(rec.Header().Get(echo.HeaderLocation))
+	require.NoError(t, err)
+	require.Equal(t, "/login/mfa", location.Path)
+	require.Equal(t, "/dashboard", location.Query().Get("next"))
+
+	userID, ok := validateMFAChallenge(location.Query().Get("token"))
+	require.True(t, ok)
+	require.Equal(t, int64(123), userID)
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -19,7 +19,7 @@ import (
 // @Summary Initiate OAuth flow
 // @Description Redirects user to OAuth provider for authentication
 // @Tags oauth
-// @Param provider path string true "OAuth provider (e.g., google, github)"
+// @Param provider path string true "OAuth provider key from OAUTH_PROVIDERS (e.g., google, github, keycloak)"
 // @Param next query string false "Redirect URL after successful OAuth linking"
 // @Success 307 {string} string "Redirect to OAuth provider"
 // @Failure 400 {object} response.ErrorResponse "Invalid provider or bad request"
@@ -27,7 +27,7 @@ import (
 // @Router /auth/oauth/{provider} [get]
 func (h *AuthHandler) OAuthEntry(c echo.Context) error {
 	// Parse provider
-	provider, err := parseProvider(c.Param("provider"))
+	provider, err := parseProvider(h.OAuthConfig, c.Param("provider"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid provider")
 	}
@@ -50,9 +50,7 @@ func (h *AuthHandler) OAuthEntry(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate oauth state")
 	}
 
-	oauthConfig := h.OAuthConfig.Providers[provider]
-
-	authURL := oauthConfig.AuthCodeURL(
+	authURL := provider.OAuth2.AuthCodeURL(
 		oauthState,
 		oauth2.AccessTypeOffline,
 		oauth2.SetAuthURLParam("prompt", "consent"),
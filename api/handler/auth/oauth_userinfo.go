@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/yorukot/knocker/utils/config"
+	"golang.org/x/oauth2"
+)
+
+// errOAuthEmailMissing is returned when a provider gives no usable email for the user
+var errOAuthEmailMissing = errors.New("oauth provider did not return a verified email")
+
+// oauthUserInfo is the profile of a user signing in with an OAuth provider
+type oauthUserInfo struct {
+	Subject     string
+	Email       string
+	DisplayName string
+	Avatar      *string
+}
+
+// fetchOAuthUserInfo returns the profile of the user owning token, using the protocol of the provider
+func fetchOAuthUserInfo(ctx context.Context, provider *config.OAuthProvider, token *oauth2.Token) (*oauthUserInfo, error) {
+	var (
+		userInfo *oauthUserInfo
+		err      error
+	)
+
+	switch provider.Type {
+	case config.OAuthProviderTypeOIDC:
+		userInfo, err = fetchOIDCUserInfo(ctx, provider, token)
+	case config.OAuthProviderTypeGitHub:
+		userInfo, err = fetchGitHubUserInfo(ctx, provider, token)
+	case config.OAuthProviderTypeGitLab:
+		userInfo, err = fetchGitLabUserInfo(ctx, provider, token)
+	default:
+		return nil, fmt.Errorf("unsupported oauth provider type: %s", provider.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userInfo.Subject == "" {
+		return nil, errors.New("oauth provider did not return a user ID")
+	}
+
+	if userInfo.Email == "" {
+		return nil, errOAuthEmailMissing
+	}
+
+	return userInfo, nil
+}
+
+// fetchOIDCUserInfo verifies the ID token and reads the profile from its claims,
+// asking the userinfo endpoint only for what the ID token left out
+func fetchOIDCUserInfo(ctx context.Context, provider *config.OAuthProvider, token *oauth2.Token) (*oauthUserInfo, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("id token missing from oauth response")
+	}
+
+	// Create verifier with client ID for audience validation
+	verifier := provider.OIDC.Verifier(&oidc.Config{ClientID: provider.OAuth2.ClientID})
+
+	verifiedToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ID token: %w", err)
+	}
+
+	var claims oidcProfileClaims
+	if err := verifiedToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to extract claims: %w", err)
+	}
+
+	if claims.Email == "" {
+		// Some issuers (e.g. Google with minimal ID tokens) only expose the email through userinfo
+		userInfo, err := provider.OIDC.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user info: %w", err)
+		}
+
+		if userInfo.Subject != verifiedToken.Subject {
+			return nil, errors.New("userinfo subject does not match ID token")
+		}
+
+		if err := userInfo.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("failed to extract user info claims: %w", err)
+		}
+	}
+
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return nil, errOAuthEmailMissing
+	}
+
+	return &oauthUserInfo{
+		Subject:     verifiedToken.Subject,
+		Email:       strings.ToLower(claims.Email),
+		DisplayName: claims.displayName(),
+		Avatar:      optionalString(claims.Picture),
+	}, nil
+}
+
+// oidcProfileClaims are the standard OIDC profile claims used to create a user
+type oidcProfileClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     *bool  `json:"email_verified"`
+	Name              string `json:"name"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+}
+
+func (c oidcProfileClaims) displayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	if name := strings.TrimSpace(c.GivenName + " " + c.FamilyName); name != "" {
+		return name
+	}
+
+	return c.PreferredUsername
+}
+
+// fetchGitHubUserInfo reads the profile from the GitHub REST API.
+// GitHub hides private emails from /user, so the primary verified one is looked up separately.
+func fetchGitHubUserInfo(ctx context.Context, provider *config.OAuthProvider, token *oauth2.Token) (*oauthUserInfo, error) {
+	client := provider.OAuth2.Client(ctx, token)
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getOAuthJSON(ctx, client, provider.APIURL+"/user", &user); err != nil {
+		return nil, err
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getOAuthJSON(ctx, client, provider.APIURL+"/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	var email string
+	for _, candidate := range emails {
+		if !candidate.Verified {
+			continue
+		}
+		if candidate.Primary || email == "" {
+			email = candidate.Email
+		}
+	}
+
+	displayName := user.Name
+	if displayName == "" {
+		displayName = user.Login
+	}
+
+	return &oauthUserInfo{
+		Subject:     strconv.FormatInt(user.ID, 10),
+		Email:       strings.ToLower(email),
+		DisplayName: displayName,
+		Avatar:      optionalString(user.AvatarURL),
+	}, nil
+}
+
+// fetchGitLabUserInfo reads the profile from the GitLab REST API.
+// GitLab only returns the primary email, which it requires to be confirmed.
+func fetchGitLabUserInfo(ctx context.Context, provider *config.OAuthProvider, token *oauth2.Token) (*oauthUserInfo, error) {
+	client := provider.OAuth2.Client(ctx, token)
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Username  string `json:"username"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getOAuthJSON(ctx, client, provider.APIURL+"/user", &user); err != nil {
+		return nil, err
+	}
+
+	displayName := user.Name
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	return &oauthUserInfo{
+		Subject:     strconv.FormatInt(user.ID, 10),
+		Email:       strings.ToLower(user.Email),
+		DisplayName: displayName,
+		Avatar:      optionalString(user.AvatarURL),
+	}, nil
+}
+
+// getOAuthJSON GETs a provider API endpoint and decodes the JSON response into out
+func getOAuthJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+
+	return nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/utils/config"
+	"golang.org/x/oauth2"
+)
+
+func newOAuthAPIServer(t *testing.T, routes map[string]any) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer provider-token", r.Header.Get("Authorization"))
+
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFetchOAuthUserInfo_GitHubUsesPrimaryVerifiedEmail(t *testing.T) {
+	server := newOAuthAPIServer(t, map[string]any{
+		"/user": map[string]any{"id": 42, "login": "octocat", "name": "", "email": nil, "avatar_url": "https://example.com/a.png"},
+		"/user/emails": []map[string]any{
+			{"email": "unverified@example.com", "primary": false, "verified": false},
+			{"email": "Octo@Example.com", "primary": true, "verified": true},
+			{"email": "other@example.com", "primary": false, "verified": true},
+		},
+	})
+
+	provider := &config.OAuthProvider{Type: config.OAuthProviderTypeGitHub, OAuth2: &oauth2.Config{}, APIURL: server.URL}
+	userInfo, err := fetchOAuthUserInfo(context.Background(), provider, &oauth2.Token{AccessToken: "provider-token"})
+	require.NoError(t, err)
+	require.Equal(t, "42", userInfo.Subject)
+	require.Equal(t, "octo@example.com", userInfo.Email)
+	require.Equal(t, "octocat", userInfo.DisplayName)
+	require.NotNil(t, userInfo.Avatar)
+}
+
+func TestFetchOAuthUserInfo_GitHubWithoutVerifiedEmail(t *testing.T) {
+	server := newOAuthAPIServer(t, map[string]any{
+		"/user": map[string]any{"id": 42, "login": "octocat"},
+		"/user/emails": []map[string]any{
+			{"email": "unverified@example.com", "primary": true, "verified": false},
+		},
+	})
+
+	provider := &config.OAuthProvider{Type: config.OAuthProviderTypeGitHub, OAuth2: &oauth2.Config{}, APIURL: server.URL}
+	_, err := fetchOAuthUserInfo(context.Background(), provider, &oauth2.Token{AccessToken: "provider-token"})
+	require.ErrorIs(t, err, errOAuthEmailMissing)
+}
+
+func TestFetchOAuthUserInfo_GitLab(t *testing.T) {
+	server := newOAuthAPIServer(t, map[string]any{
+		"/user": map[string]any{"id": 7, "username": "tanuki", "name": "Tanuki", "email": "tanuki@example.com"},
+	})
+
+	provider := &config.OAuthProvider{Type: config.OAuthProviderTypeGitLab, OAuth2: &oauth2.Config{}, APIURL: server.URL}
+	userInfo, err := fetchOAuthUserInfo(context.Background(), provider, &oauth2.Token{AccessToken: "provider-token"})
+	require.NoError(t, err)
+	require.Equal(t, "7", userInfo.Subject)
+	require.Equal(t, "tanuki@example.com", userInfo.Email)
+	require.Equal(t, "Tanuki", userInfo.DisplayName)
+	require.Nil(t, userInfo.Avatar)
+}
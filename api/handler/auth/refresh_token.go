@@ -1,14 +1,19 @@
 package auth
 
 import (
+	"fmt"
+	"net"
 	"net/http"
-	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils/config"
-	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/denylist"
+	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
+	"github.com/yorukot/knocker/worker/tasks"
 	"go.uber.org/zap"
 )
 
@@ -23,7 +28,7 @@ import (
 // @Accept json
 // @Produce json
 // @Success 201 {object} response.SuccessResponse "Access token generated successfully, new refresh token set in cookie"
-// @Failure 401 {object} response.ErrorResponse "Refresh token not found, invalid, or already used"
+// @Failure 401 {object} response.ErrorResponse "Refresh token not found, expired, revoked, or already used (reuse revokes the whole session)"
 // @Failure 500 {object} response.ErrorResponse "Internal server error (transaction, database, or token generation failure)"
 // @Router /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c echo.Context) error {
@@ -52,26 +57,36 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Refresh token not found")
 	}
 
-	// TODO: Need to tell the user might just been hacked
+	// A revoked family is already dead; replays of its tokens are not reported again.
+	if checkedRefreshToken.RevokedAt != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Session has been revoked")
+	}
+
+	// A used token coming back means two parties hold the same session, so the whole family is revoked.
 	if checkedRefreshToken.UsedAt != nil {
-		zap.L().Warn("Refresh token already used",
-			zap.Int64("user_id", checkedRefreshToken.UserID),
-			zap.String("ip", c.RealIP()),
-			zap.String("user_agent", c.Request().UserAgent()),
-		)
-		return echo.NewHTTPError(http.StatusUnauthorized, "Refresh token already used")
+		return h.rejectReusedRefreshToken(c, tx, *checkedRefreshToken)
 	}
 
-	// Update the refresh token used_at
+	refreshTokenTTL := time.Duration(config.Env().RefreshTokenExpiresAt) * time.Second
+	if time.Since(checkedRefreshToken.CreatedAt) > refreshTokenTTL {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Refresh token expired")
+	}
+
+	// Update the refresh token used_at; losing the race to a concurrent refresh with the same token is a reuse too
 	now := time.Now()
 	checkedRefreshToken.UsedAt = &now
-	if err = h.Repo.UpdateRefreshTokenUsedAt(c.Request().Context(), tx, *checkedRefreshToken); err != nil {
+	claimed, err := h.Repo.UpdateRefreshTokenUsedAt(c.Request().Context(), tx, *checkedRefreshToken)
+	if err != nil {
 		zap.L().Error("Failed to update refresh token used_at", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update refresh token used_at")
 	}
 
+	if !claimed {
+		return h.rejectReusedRefreshToken(c, tx, *checkedRefreshToken)
+	}
+
 	// Generate new refresh token
-	newRefreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, checkedRefreshToken.UserID)
+	newRefreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, checkedRefreshToken.UserID, checkedRefreshToken)
 	if err != nil {
 		zap.L().Error("Failed to generate refresh token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate refresh token")
@@ -84,20 +99,91 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	refreshTokenCookie := generateRefreshTokenCookie(newRefreshToken)
 	c.SetCookie(&refreshTokenCookie)
 
-	// Generate AccessTokenClaims
-	accessTokenClaims := encrypt.JWTSecret{
-		Secret: config.Env().JWTSecretKey,
-	}
-
 	// Generate the access token
-	accessToken, err := accessTokenClaims.GenerateAccessToken(config.Env().AppName, strconv.FormatInt(checkedRefreshToken.UserID, 10), time.Now().Add(time.Duration(config.Env().AccessTokenExpiresAt)*time.Second))
+	accessTokenCookie, err := generateAccessTokenCookieForUser(newRefreshToken)
 	if err != nil {
 		zap.L().Error("Failed to generate access token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate access token")
 	}
-
-	accessTokenCookie := generateAccessTokenCookie(accessToken)
 	c.SetCookie(&accessTokenCookie)
 
 	return c.JSON(http.StatusCreated, response.SuccessMessage("Access token refreshed successfully"))
 }
+
+// rejectReusedRefreshToken revokes the session of a refresh token presented after it was already used
+func (h *AuthHandler) rejectReusedRefreshToken(c echo.Context, tx pgx.Tx, token models.RefreshToken) error {
+	zap.L().Warn("Refresh token reuse detected, revoking session",
+		zap.Int64("user_id", token.UserID),
+		zap.Int64("family_id", token.FamilyID),
+		zap.String("ip", c.RealIP()),
+		zap.String("user_agent", c.Request().UserAgent()),
+	)
+
+	if err := h.revokeReusedRefreshTokenFamily(c, tx, token); err != nil {
+		zap.L().Error("Failed to revoke reused refresh token family", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke session")
+	}
+
+	return echo.NewHTTPError(http.StatusUnauthorized, "Refresh token already used")
+}
+
+// revokeReusedRefreshTokenFamily revokes every token in the family of a replayed refresh token,
+// records a security event and queues an alert for the user.
+func (h *AuthHandler) revokeReusedRefreshTokenFamily(c echo.Context, tx pgx.Tx, token models.RefreshToken) error {
+	ctx := c.Request().Context()
+	now := time.Now()
+
+	if _, err := h.Repo.RevokeRefreshTokenFamily(ctx, tx, token.UserID, token.FamilyID, now); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	eventID, err := id.GetID()
+	if err != nil {
+		return fmt.Errorf("failed to generate security event ID: %w", err)
+	}
+
+	userAgent := c.Request().UserAgent()
+	event := models.SecurityEvent{
+		ID:        eventID,
+		UserID:    token.UserID,
+		Type:      models.SecurityEventRefreshTokenReuse,
+		SessionID: &token.FamilyID,
+		IP:        net.ParseIP(c.RealIP()),
+		UserAgent: &userAgent,
+		CreatedAt: now,
+	}
+
+	if err := h.Repo.CreateSecurityEvent(ctx, tx, event); err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	accessTokens, err := h.Repo.ListIssuedAccessTokens(ctx, tx, token.UserID, &token.FamilyID, now.Add(-accessTokenLifetime()))
+	if err != nil {
+		return fmt.Errorf("failed to list session access tokens: %w", err)
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := denylist.AddIssued(ctx, accessTokens, accessTokenLifetime()); err != nil {
+		return fmt.Errorf("failed to denylist session access tokens: %w", err)
+	}
+
+	// The revocation is already committed; a lost alert should not turn into a 500.
+	task, err := tasks.NewSecurityAlert(tasks.SecurityAlertPayload{
+		UserID:     token.UserID,
+		Type:       event.Type,
+		IP:         c.RealIP(),
+		UserAgent:  userAgent,
+		OccurredAt: now,
+	})
+	if err == nil {
+		_, err = h.AsynqClient.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue security alert", zap.Int64("user_id", token.UserID), zap.Error(err))
+	}
+
+	return nil
+}
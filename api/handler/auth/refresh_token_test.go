@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+func TestRefreshToken_ConcurrentUseRevokesFamily(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	// The token looked unused when read, but a concurrent refresh marked it used first.
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetRefreshTokenByToken", mock.Anything, mock.Anything, "refresh-token").
+		Return(&models.RefreshToken{ID: 2, UserID: 123, FamilyID: 1, CreatedAt: time.Now()}, nil)
+	mockRepo.On("UpdateRefreshTokenUsedAt", mock.Anything, mock.Anything, mock.MatchedBy(func(token models.RefreshToken) bool {
+		return token.ID == 2 && token.UsedAt != nil
+	})).Return(false, nil)
+	mockRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, int64(123), int64(1), mock.AnythingOfType("time.Time")).
+		Return(int64(2), nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.SecurityEvent) bool {
+		return event.UserID == 123 && event.Type == models.SecurityEventRefreshTokenReuse
+	})).Return(nil)
+	mockRepo.On("ListIssuedAccessTokens", mock.Anything, mock.Anything, int64(123), mock.Anything, mock.AnythingOfType("time.Time")).
+		Return([]models.IssuedAccessToken{}, nil)
+
+	enqueuer := &testutil.RecordingEnqueuer{}
+	h := &AuthHandler{Repo: mockRepo, AsynqClient: enqueuer}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/refresh", nil)
+	c.Request().AddCookie(&http.Cookie{Name: models.CookieNameRefreshToken, Value: "refresh-token"})
+
+	err := h.RefreshToken(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	require.Empty(t, rec.Result().Cookies())
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+
+	var alert tasks.SecurityAlertPayload
+	require.Equal(t, tasks.TypeSecurityAlert, enqueuer.OnlyTask(t, &alert).Type())
+	require.Equal(t, models.SecurityEventRefreshTokenReuse, alert.Type)
+}
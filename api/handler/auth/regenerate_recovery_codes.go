@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replaces all recovery codes of the authenticated user after checking a current TOTP or recovery code. The new codes are shown once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body secondFactorRequest true "TOTP code or recovery code"
+// @Success 200 {object} response.SuccessResponse{data=recoveryCodesResponse} "Recovery codes regenerated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or invalid code"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot manage account security"
+// @Failure 404 {object} response.ErrorResponse "TOTP is not enabled"
+// @Failure 429 {object} response.ErrorResponse "Too many failed attempts"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/mfa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	userID, err := requireUserSession(c)
+	if err != nil {
+		return err
+	}
+
+	var req secondFactorRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	totp, err := h.Repo.GetUserTOTP(c.Request().Context(), tx, userID)
+	if err != nil {
+		zap.L().Error("Failed to get user totp", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user totp")
+	}
+
+	if !totp.Enabled() {
+		return echo.NewHTTPError(http.StatusNotFound, "TOTP is not enabled")
+	}
+
+	now := time.Now()
+	if totp.Locked(now) {
+		return echo.NewHTTPError(http.StatusTooManyRequests, "Too many failed attempts, try again later")
+	}
+
+	valid, err := verifySecondFactor(c.Request().Context(), h.Repo, tx, totp, req, now)
+	if err != nil {
+		zap.L().Error("Failed to verify second factor", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify code")
+	}
+
+	var plaintext []string
+	if valid {
+		var codes []models.RecoveryCode
+		plaintext, codes, err = generateRecoveryCodes(userID, now)
+		if err != nil {
+			zap.L().Error("Failed to generate recovery codes", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate recovery codes")
+		}
+
+		if err := h.Repo.ReplaceRecoveryCodes(c.Request().Context(), tx, userID, codes); err != nil {
+			zap.L().Error("Failed to save recovery codes", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save recovery codes")
+		}
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	if !valid {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid verification code")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Recovery codes regenerated successfully", recoveryCodesResponse{
+		RecoveryCodes: plaintext,
+	}))
+}
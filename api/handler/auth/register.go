@@ -3,9 +3,11 @@ package auth
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
 )
@@ -22,7 +24,7 @@ type registerRequest struct {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Creates a new user account with email and password
+// @Description Creates a new user account with email and password and emails a link to verify the address
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -78,13 +80,19 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	}
 
 	// Generate the refresh token
-	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, user.ID)
+	refreshToken, err := generateTokenAndSaveRefreshToken(c, h.Repo, tx, user.ID, nil)
 	if err != nil {
 		zap.L().Error("Failed to generate refresh token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate refresh token")
 	}
 
-	accessTokenCookie, err := generateAccessTokenCookieForUser(user.ID)
+	verificationToken, err := issueAccountToken(c.Request().Context(), h.Repo, tx, account.ID, models.AccountTokenEmailVerification, models.EmailVerificationTokenTTL, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to issue email verification token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to issue email verification token")
+	}
+
+	accessTokenCookie, err := generateAccessTokenCookieForUser(refreshToken)
 	if err != nil {
 		zap.L().Error("Failed to generate access token", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate access token")
@@ -101,6 +109,8 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	c.SetCookie(&refreshTokenCookie)
 	c.SetCookie(&accessTokenCookie)
 
+	enqueueEmail(h.AsynqClient, emailVerificationEmail(account.Email, verificationToken))
+
 	// Respond with the success message
 	return c.JSON(http.StatusOK, response.SuccessMessage("User registered successfully"))
 }
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Sends a new verification email for the email/password account of the authenticated user. Earlier links stop working
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.SuccessResponse "Verification email sent"
+// @Failure 400 {object} response.ErrorResponse "Email is already verified"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "API keys cannot manage account security"
+// @Failure 404 {object} response.ErrorResponse "No email/password account"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/verify/resend [post]
+func (h *AuthHandler) ResendVerification(c echo.Context) error {
+	userID, err := requireUserSession(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	account, err := h.Repo.GetAccountByUserIDAndProvider(c.Request().Context(), tx, userID, models.ProviderEmail)
+	if err != nil {
+		zap.L().Error("Failed to get account", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get account")
+	}
+
+	if account == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "No email/password account")
+	}
+
+	if account.EmailVerifiedAt != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Email is already verified")
+	}
+
+	token, err := issueAccountToken(c.Request().Context(), h.Repo, tx, account.ID, models.AccountTokenEmailVerification, models.EmailVerificationTokenTTL, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to issue email verification token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to issue email verification token")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	enqueueEmail(h.AsynqClient, emailVerificationEmail(account.Email, token))
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Verification email sent"))
+}
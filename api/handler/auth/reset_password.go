@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/denylist"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Reset Password                               |
+// +----------------------------------------------+
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=255" example:"password123"`
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Sets a new password with a token from a password reset email. Every session of the user is signed out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body resetPasswordRequest true "Reset password request"
+// @Success 200 {object} response.SuccessResponse "Password reset successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or invalid or expired token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c echo.Context) error {
+	var resetPasswordRequest resetPasswordRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&resetPasswordRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(resetPasswordRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	now := time.Now()
+
+	token, err := h.Repo.GetUsableAccountToken(ctx, tx, encrypt.HashToken(resetPasswordRequest.Token), models.AccountTokenPasswordReset, now)
+	if err != nil {
+		zap.L().Error("Failed to get password reset token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get password reset token")
+	}
+
+	if token == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid or expired token")
+	}
+
+	passwordHash, err := encrypt.CreateArgon2idHash(resetPasswordRequest.Password)
+	if err != nil {
+		zap.L().Error("Failed to hash password", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to hash password")
+	}
+
+	if err := h.Repo.UpdateUserPassword(ctx, tx, token.UserID, passwordHash, now); err != nil {
+		zap.L().Error("Failed to update password", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update password")
+	}
+
+	if err := h.Repo.UseAccountTokens(ctx, tx, token.AccountID, models.AccountTokenPasswordReset, now); err != nil {
+		zap.L().Error("Failed to use password reset token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to use password reset token")
+	}
+
+	// The link was opened from the inbox, which proves the address as well
+	if err := h.Repo.MarkAccountEmailVerified(ctx, tx, token.AccountID, now); err != nil {
+		zap.L().Error("Failed to mark email verified", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mark email verified")
+	}
+
+	// Whoever knew the old password must not keep a session
+	if _, err := h.Repo.RevokeAllRefreshTokensByUserID(ctx, tx, token.UserID, now); err != nil {
+		zap.L().Error("Failed to revoke sessions", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke sessions")
+	}
+
+	accessTokens, err := h.Repo.ListIssuedAccessTokens(ctx, tx, token.UserID, nil, now.Add(-accessTokenLifetime()))
+	if err != nil {
+		zap.L().Error("Failed to list access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list access tokens")
+	}
+
+	eventID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate security event ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate security event ID")
+	}
+
+	userAgent := c.Request().UserAgent()
+	if err := h.Repo.CreateSecurityEvent(ctx, tx, models.SecurityEvent{
+		ID:        eventID,
+		UserID:    token.UserID,
+		Type:      models.SecurityEventPasswordReset,
+		IP:        net.ParseIP(c.RealIP()),
+		UserAgent: &userAgent,
+		CreatedAt: now,
+	}); err != nil {
+		zap.L().Error("Failed to create security event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create security event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	clearAuthCookies(c)
+
+	if err := denylist.AddIssued(ctx, accessTokens, accessTokenLifetime()); err != nil {
+		zap.L().Error("Failed to denylist access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access tokens")
+	}
+
+	// The password is already changed; a lost alert should not turn into a 500.
+	task, err := tasks.NewSecurityAlert(tasks.SecurityAlertPayload{
+		UserID:     token.UserID,
+		Type:       models.SecurityEventPasswordReset,
+		IP:         c.RealIP(),
+		UserAgent:  userAgent,
+		OccurredAt: now,
+	})
+	if err == nil {
+		_, err = h.AsynqClient.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue security alert", zap.Int64("user_id", token.UserID), zap.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Password reset successfully"))
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+func TestForgotPassword_UnknownEmailLooksTheSame(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetAccountByProviderEmail", mock.Anything, mock.Anything, models.ProviderEmail, "nobody@example.com").
+		Return(nil, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/password/forgot", strings.NewReader(`{"email":"nobody@example.com"}`))
+	testutil.SetJSONHeader(c)
+
+	err := h.ForgotPassword(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), forgotPasswordMessage)
+	mockRepo.AssertNotCalled(t, "CreateAccountToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestForgotPassword_IssuesSingleToken(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAccountByProviderEmail", mock.Anything, mock.Anything, models.ProviderEmail, "user@example.com").
+		Return(&models.Account{ID: 7, UserID: 123, Email: "user@example.com"}, nil)
+	mockRepo.On("UseAccountTokens", mock.Anything, mock.Anything, int64(7), models.AccountTokenPasswordReset, mock.AnythingOfType("time.Time")).
+		Return(nil)
+	mockRepo.On("CreateAccountToken", mock.Anything, mock.Anything, mock.MatchedBy(func(token models.AccountToken) bool {
+		return token.AccountID == 7 &&
+			token.Purpose == models.AccountTokenPasswordReset &&
+			len(token.TokenHash) == 64 &&
+			token.ExpiresAt.Sub(token.CreatedAt) == models.PasswordResetTokenTTL
+	})).Return(nil)
+
+	enqueuer := &testutil.RecordingEnqueuer{}
+	h := &AuthHandler{Repo: mockRepo, AsynqClient: enqueuer}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/password/forgot", strings.NewReader(`{"email":"user@example.com"}`))
+	testutil.SetJSONHeader(c)
+
+	err := h.ForgotPassword(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
+
+	var email tasks.SendEmailPayload
+	require.Equal(t, tasks.TypeSendEmail, enqueuer.OnlyTask(t, &email).Type())
+	require.Equal(t, "user@example.com", email.To)
+	require.Contains(t, email.Text, "/reset-password?token=")
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetUsableAccountToken", mock.Anything, mock.Anything, encrypt.HashToken("used-token"), models.AccountTokenPasswordReset, mock.AnythingOfType("time.Time")).
+		Return(nil, nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/auth/password/reset", strings.NewReader(`{"token":"used-token","password":"new-password"}`))
+	testutil.SetJSONHeader(c)
+
+	err := h.ResetPassword(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResetPassword_ChangesPasswordAndRevokesSessions(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	token := &models.AccountTokenWithAccount{
+		AccountToken: models.AccountToken{ID: 1, AccountID: 7, Purpose: models.AccountTokenPasswordReset},
+		UserID:       123,
+		Email:        "user@example.com",
+	}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetUsableAccountToken", mock.Anything, mock.Anything, encrypt.HashToken("reset-token"), models.AccountTokenPasswordReset, mock.AnythingOfType("time.Time")).
+		Return(token, nil)
+	mockRepo.On("UpdateUserPassword", mock.Anything, mock.Anything, int64(123), mock.MatchedBy(func(hash string) bool {
+		match, err := encrypt.ComparePasswordAndHash("new-password", hash)
+		return err == nil && match
+	}), mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("UseAccountTokens", mock.Anything, mock.Anything, int64(7), models.AccountTokenPasswordReset, mock.AnythingOfType("time.Time")).
+		Return(nil)
+	mockRepo.On("MarkAccountEmailVerified", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	mockRepo.On("RevokeAllRefreshTokensByUserID", mock.Anything, mock.Anything, int64(123), mock.AnythingOfType("time.Time")).
+		Return(int64(2), nil)
+	mockRepo.On("ListIssuedAccessTokens", mock.Anything, mock.Anything, int64(123), (*int64)(nil), mock.AnythingOfType("time.Time")).
+		Return([]models.IssuedAccessToken{}, nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.SecurityEvent) bool {
+		return event.UserID == 123 && event.Type == models.SecurityEventPasswordReset
+	})).Return(nil)
+
+	enqueuer := &testutil.RecordingEnqueuer{}
+	h := &AuthHandler{Repo: mockRepo, AsynqClient: enqueuer}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/password/reset", strings.NewReader(`{"token":"reset-token","password":"new-password"}`))
+	testutil.SetJSONHeader(c)
+
+	err := h.ResetPassword(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
+
+	var alert tasks.SecurityAlertPayload
+	require.Equal(t, tasks.TypeSecurityAlert, enqueuer.OnlyTask(t, &alert).Type())
+	require.Equal(t, int64(123), alert.UserID)
+	require.Equal(t, models.SecurityEventPasswordReset, alert.Type)
+}
+
+func TestVerifyEmail_MarksAccountVerified(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	token := &models.AccountTokenWithAccount{
+		AccountToken: models.AccountToken{ID: 1, AccountID: 7, Purpose: models.AccountTokenEmailVerification},
+		UserID:       123,
+		Email:        "user@example.com",
+	}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetUsableAccountToken", mock.Anything, mock.Anything, encrypt.HashToken("verify-token"), models.AccountTokenEmailVerification, mock.AnythingOfType("time.Time")).
+		Return(token, nil)
+	mockRepo.On("UseAccountTokens", mock.Anything, mock.Anything, int64(7), models.AccountTokenEmailVerification, mock.AnythingOfType("time.Time")).
+		Return(nil)
+	mockRepo.On("MarkAccountEmailVerified", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(nil)
+
+	h := &AuthHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/auth/verify", strings.NewReader(`{"token":"verify-token"}`))
+	testutil.SetJSONHeader(c)
+
+	err := h.VerifyEmail(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
+}
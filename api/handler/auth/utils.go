@@ -1,22 +1,21 @@
 package auth
 
 import (
-	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/repository"
+	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/config"
 	"github.com/yorukot/knocker/utils/encrypt"
 	"github.com/yorukot/knocker/utils/id"
-	"golang.org/x/oauth2"
+	"go.uber.org/zap"
 )
 
 // +----------------------------------------------+
@@ -64,8 +63,9 @@ func GenerateUser(registerRequest registerRequest) (models.User, models.Account,
 	return user, account, nil
 }
 
-// generateRefreshToken generates a refresh token for the user
-func generateRefreshToken(userID int64, userAgent string, ip string) (models.RefreshToken, error) {
+// generateRefreshToken generates a refresh token for the user.
+// A token without a parent starts a new family (session); a rotated token joins its parent's family.
+func generateRefreshToken(userID int64, userAgent string, ip string, parent *models.RefreshToken) (models.RefreshToken, error) {
 	refreshTokenID, err := id.GetID()
 	if err != nil {
 		return models.RefreshToken{}, fmt.Errorf("failed to generate refresh token ID: %w", err)
@@ -84,14 +84,30 @@ func generateRefreshToken(userID int64, userAgent string, ip string) (models.Ref
 		return models.RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
+	// The access token issued alongside carries this as its jti, so the session can revoke it
+	accessTokenID, err := encrypt.GenerateRandomString(32)
+	if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("failed to generate access token ID: %w", err)
+	}
+
+	familyID := refreshTokenID
+	var parentID *int64
+	if parent != nil {
+		familyID = parent.FamilyID
+		parentID = &parent.ID
+	}
+
 	return models.RefreshToken{
-		ID:        refreshTokenID,
-		UserID:    userID,
-		Token:     refreshToken,
-		UserAgent: &userAgent,
-		IP:        parsedIP,
-		UsedAt:    nil,
-		CreatedAt: time.Now(),
+		ID:            refreshTokenID,
+		UserID:        userID,
+		Token:         refreshToken,
+		UserAgent:     &userAgent,
+		IP:            parsedIP,
+		UsedAt:        nil,
+		FamilyID:      familyID,
+		ParentID:      parentID,
+		AccessTokenID: &accessTokenID,
+		CreatedAt:     time.Now(),
 	}, nil
 }
 
@@ -109,7 +125,12 @@ func generateRefreshTokenCookie(refreshToken models.RefreshToken) http.Cookie {
 	}
 }
 
-func generateAccessToken(userID int64) (string, error) {
+// accessTokenLifetime is how long an access token stays valid after it is issued
+func accessTokenLifetime() time.Duration {
+	return time.Duration(config.Env().AccessTokenExpiresAt) * time.Second
+}
+
+func generateAccessToken(userID int64, tokenID string) (string, error) {
 	accessTokenClaims := encrypt.JWTSecret{
 		Secret: config.Env().JWTSecretKey,
 	}
@@ -117,12 +138,19 @@ func generateAccessToken(userID int64) (string, error) {
 	return accessTokenClaims.GenerateAccessToken(
 		config.Env().AppName,
 		strconv.FormatInt(userID, 10),
-		time.Now().Add(time.Duration(config.Env().AccessTokenExpiresAt)*time.Second),
+		tokenID,
+		time.Now().Add(accessTokenLifetime()),
 	)
 }
 
-func generateAccessTokenCookieForUser(userID int64) (http.Cookie, error) {
-	accessToken, err := generateAccessToken(userID)
+// generateAccessTokenCookieForUser generates the access token cookie paired with a refresh token
+func generateAccessTokenCookieForUser(refreshToken models.RefreshToken) (http.Cookie, error) {
+	var tokenID string
+	if refreshToken.AccessTokenID != nil {
+		tokenID = *refreshToken.AccessTokenID
+	}
+
+	accessToken, err := generateAccessToken(refreshToken.UserID, tokenID)
 	if err != nil {
 		return http.Cookie{}, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -139,17 +167,31 @@ func generateAccessTokenCookie(accessToken string) http.Cookie {
 		Value:    accessToken,
 		HttpOnly: true,
 		Secure:   config.Env().AppEnv == config.AppEnvProd,
-		Expires:  time.Now().Add(time.Duration(config.Env().AccessTokenExpiresAt) * time.Second),
+		Expires:  time.Now().Add(accessTokenLifetime()),
 		SameSite: http.SameSiteLaxMode,
 	}
 }
 
-// generateTokenAndSaveRefreshToken generates a refresh token and saves it to the database
-func generateTokenAndSaveRefreshToken(e echo.Context, repo repository.Repository, tx pgx.Tx, userID int64) (models.RefreshToken, error) {
+// clearAuthCookies expires the access and refresh token cookies in the browser
+func clearAuthCookies(c echo.Context) {
+	accessTokenCookie := generateAccessTokenCookie("")
+	accessTokenCookie.Expires = time.Unix(0, 0)
+	accessTokenCookie.MaxAge = -1
+	c.SetCookie(&accessTokenCookie)
+
+	refreshTokenCookie := generateRefreshTokenCookie(models.RefreshToken{})
+	refreshTokenCookie.Expires = time.Unix(0, 0)
+	refreshTokenCookie.MaxAge = -1
+	c.SetCookie(&refreshTokenCookie)
+}
+
+// generateTokenAndSaveRefreshToken generates a refresh token and saves it to the database.
+// Pass the token being rotated as parent, or nil when signing in.
+func generateTokenAndSaveRefreshToken(e echo.Context, repo repository.Repository, tx pgx.Tx, userID int64, parent *models.RefreshToken) (models.RefreshToken, error) {
 	userAgent := e.Request().UserAgent()
 	ip := e.RealIP()
 
-	refreshToken, err := generateRefreshToken(userID, userAgent, ip)
+	refreshToken, err := generateRefreshToken(userID, userAgent, ip, parent)
 	if err != nil {
 		return models.RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -161,18 +203,38 @@ func generateTokenAndSaveRefreshToken(e echo.Context, repo repository.Repository
 	return refreshToken, nil
 }
 
+// requireUserSession returns the signed-in user's ID for handlers that manage the account's own security.
+// API keys act on behalf of their creator but must never change how that person signs in.
+func requireUserSession(c echo.Context) (int64, error) {
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return 0, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if authutil.IsAPIKeyRequest(c) {
+		return 0, echo.NewHTTPError(http.StatusForbidden, "API keys cannot manage account security")
+	}
+
+	return *userID, nil
+}
+
 // +----------------------------------------------+
 // | OAuth part                                   |
 // +----------------------------------------------+
 
-// parseProvider parse the provider from the request
-func parseProvider(provider string) (models.Provider, error) {
-	switch provider {
-	case string(models.ProviderGoogle):
-		return models.ProviderGoogle, nil
-	default:
-		return "", fmt.Errorf("invalid provider: %s", provider)
+// parseProvider looks up a configured OAuth provider by its key
+func parseProvider(oauthConfig *config.OAuthConfig, key string) (*config.OAuthProvider, error) {
+	provider, ok := oauthConfig.Providers[models.Provider(key)]
+	if !ok {
+		return nil, fmt.Errorf("invalid provider: %s", key)
 	}
+
+	return provider, nil
 }
 
 // oauthGenerateStateWithPayload generate the oauth state with the payload
@@ -212,53 +274,14 @@ func oauthValidateStateWithPayload(oauthState string) (bool, encrypt.OAuthStateC
 	return valid, payload, nil
 }
 
-// oauthVerifyTokenAndGetUserInfo verifies the token for the OAuth flow
-func oauthVerifyTokenAndGetUserInfo(ctx context.Context, rawIDToken string, token *oauth2.Token, oidcProvider *oidc.Provider, oauthConfig *oauth2.Config) (*oidc.UserInfo, error) {
-
-	// Create verifier with client ID for audience validation
-	verifier := oidcProvider.Verifier(&oidc.Config{ClientID: oauthConfig.ClientID})
-
-	// Verify the ID token
-	verifiedToken, err := verifier.Verify(ctx, rawIDToken)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify ID token: %w", err)
-	}
-
-	// Extract claims from verified token
-	var tokenClaims map[string]any
-	if err := verifiedToken.Claims(&tokenClaims); err != nil {
-		return nil, fmt.Errorf("failed to extract claims: %w", err)
-	}
-
-	userInfo, err := oidcProvider.UserInfo(ctx, oauth2.StaticTokenSource(token))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
-	}
-
-	return userInfo, nil
-}
-
 // generateUserFromOAuthUserInfo generate the user and account from the oauth user info
-func generateUserFromOAuthUserInfo(userInfo *oidc.UserInfo, provider models.Provider) (models.User, models.Account, error) {
+func generateUserFromOAuthUserInfo(userInfo *oauthUserInfo, provider models.Provider) (models.User, models.Account, error) {
 	userID, err := id.GetID()
 	if err != nil {
 		return models.User{}, models.Account{}, fmt.Errorf("failed to generate user ID: %w", err)
 	}
 
-	// Get the picture from the user info
-	var picture *string
-	var displayName string
-	var claims struct {
-		Picture    string `json:"picture"`
-		FamilyName string `json:"family_name"`
-		GivenName  string `json:"given_name"`
-	}
-	if err := userInfo.Claims(&claims); err == nil && claims.Picture != "" {
-		picture = &claims.Picture
-	}
-
-	displayName = fmt.Sprintf("%s %s", claims.GivenName, claims.FamilyName)
-
+	displayName := userInfo.DisplayName
 	if displayName == "" {
 		displayName = encrypt.GenerateRandomUserDisplayName()
 	}
@@ -268,46 +291,38 @@ func generateUserFromOAuthUserInfo(userInfo *oidc.UserInfo, provider models.Prov
 		ID:           userID,
 		PasswordHash: nil,
 		DisplayName:  displayName,
-		Avatar:       picture,
+		Avatar:       userInfo.Avatar,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	accountID, err := id.GetID()
+	account, err := generateUserAccountFromOAuthUserInfo(userInfo, provider, userID)
 	if err != nil {
-		return models.User{}, models.Account{}, fmt.Errorf("failed to generate account ID: %w", err)
-	}
-
-	// create the account
-	account := models.Account{
-		ID:             accountID,
-		UserID:         userID,
-		Provider:       provider,
-		ProviderUserID: userInfo.Subject,
-		Email:          userInfo.Email,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		return models.User{}, models.Account{}, err
 	}
 
 	return user, account, nil
 }
 
 // generateUserAccountFromOAuthUserInfo generate the user and account from the oauth user info
-func generateUserAccountFromOAuthUserInfo(userInfo *oidc.UserInfo, provider models.Provider, userID int64) (models.Account, error) {
+func generateUserAccountFromOAuthUserInfo(userInfo *oauthUserInfo, provider models.Provider, userID int64) (models.Account, error) {
 	accountID, err := id.GetID()
 	if err != nil {
 		return models.Account{}, fmt.Errorf("failed to generate account ID: %w", err)
 	}
 
-	// create the account
+	now := time.Now()
+
+	// create the account, providers only return verified addresses (see fetchOAuthUserInfo)
 	account := models.Account{
-		ID:             accountID,
-		UserID:         userID,
-		Provider:       provider,
-		ProviderUserID: userInfo.Subject,
-		Email:          userInfo.Email,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		ID:              accountID,
+		UserID:          userID,
+		Provider:        provider,
+		ProviderUserID:  userInfo.Subject,
+		Email:           userInfo.Email,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}
 
 	return account, nil
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Verify Email                                 |
+// +----------------------------------------------+
+
+type verifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=255"`
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirms the email address of an account with a token from a verification email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body verifyEmailRequest true "Verify email request"
+// @Success 200 {object} response.SuccessResponse "Email verified successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or invalid or expired token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/verify [post]
+func (h *AuthHandler) VerifyEmail(c echo.Context) error {
+	var verifyEmailRequest verifyEmailRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&verifyEmailRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(verifyEmailRequest); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	token, err := h.Repo.GetUsableAccountToken(c.Request().Context(), tx, encrypt.HashToken(verifyEmailRequest.Token), models.AccountTokenEmailVerification, now)
+	if err != nil {
+		zap.L().Error("Failed to get email verification token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get email verification token")
+	}
+
+	if token == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid or expired token")
+	}
+
+	if err := h.Repo.UseAccountTokens(c.Request().Context(), tx, token.AccountID, models.AccountTokenEmailVerification, now); err != nil {
+		zap.L().Error("Failed to use email verification token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to use email verification token")
+	}
+
+	if err := h.Repo.MarkAccountEmailVerified(c.Request().Context(), tx, token.AccountID, now); err != nil {
+		zap.L().Error("Failed to mark email verified", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mark email verified")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Email verified successfully"))
+}
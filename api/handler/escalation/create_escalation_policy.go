@@ -0,0 +1,119 @@
+package escalation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// CreateEscalationPolicy godoc
+// @Summary Create an escalation policy
+// @Description Creates an escalation policy with ordered steps for the given team (owner/admin only)
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param request body escalationPolicyRequest true "Escalation policy create request"
+// @Success 200 {object} response.SuccessResponse "Escalation policy created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/escalation-policies [post]
+func (h *EscalationHandler) CreateEscalationPolicy(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req escalationPolicyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceEscalationPolicy, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create escalation policies for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	policyID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate escalation policy ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate escalation policy ID")
+	}
+
+	now := time.Now().UTC()
+	steps, err := h.buildEscalationSteps(ctx, tx, teamID, policyID, req.Steps, now)
+	if err != nil {
+		return err
+	}
+
+	policy := models.EscalationPolicy{
+		ID:          policyID,
+		TeamID:      teamID,
+		Name:        req.Name,
+		Description: req.Description,
+		Steps:       steps,
+		UpdatedAt:   now,
+		CreatedAt:   now,
+	}
+
+	if err := h.Repo.CreateEscalationPolicy(ctx, tx, policy); err != nil {
+		zap.L().Error("Failed to create escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create escalation policy")
+	}
+
+	if err := h.Repo.CreateEscalationSteps(ctx, tx, steps); err != nil {
+		zap.L().Error("Failed to create escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create escalation steps")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceEscalationPolicy,
+		ResourceID:   policy.ID,
+		After:        policy,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Escalation policy created successfully", policy))
+}
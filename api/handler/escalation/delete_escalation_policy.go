@@ -0,0 +1,104 @@
+package escalation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// DeleteEscalationPolicy godoc
+// @Summary Delete an escalation policy
+// @Description Deletes an escalation policy; monitors using it fall back to their notification list (owner/admin only)
+// @Tags escalation-policies
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Escalation policy ID"
+// @Success 200 {object} response.SuccessResponse "Escalation policy deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team or escalation policy ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Escalation policy not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/escalation-policies/{id} [delete]
+func (h *EscalationHandler) DeleteEscalationPolicy(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	policyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid escalation policy ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceEscalationPolicy, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this escalation policy")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetEscalationPolicyByID(ctx, tx, teamID, policyID)
+	if err != nil {
+		zap.L().Error("Failed to get escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get escalation policy")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Escalation policy not found")
+	}
+
+	existingPolicies := []models.EscalationPolicy{*existing}
+	if err := h.attachSteps(ctx, tx, existingPolicies); err != nil {
+		zap.L().Error("Failed to list escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list escalation steps")
+	}
+
+	if err := h.Repo.DeleteEscalationPolicy(ctx, tx, teamID, policyID); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Escalation policy not found")
+		}
+
+		zap.L().Error("Failed to delete escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete escalation policy")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceEscalationPolicy,
+		ResourceID:   policyID,
+		Before:       existingPolicies[0],
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Escalation policy deleted successfully"))
+}
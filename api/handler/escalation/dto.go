@@ -0,0 +1,137 @@
+package escalation
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/id"
+	"go.uber.org/zap"
+)
+
+type escalationPolicyRequest struct {
+	Name        string                  `json:"name" validate:"required,min=1,max=255"`
+	Description *string                 `json:"description" validate:"omitempty,max=1024"`
+	Steps       []escalationStepRequest `json:"steps" validate:"required,min=1,max=20,dive"`
+}
+
+type escalationStepRequest struct {
+	// DelaySeconds is how long to wait after the previous step before paging this one.
+	DelaySeconds int                       `json:"delay_seconds" validate:"min=0,max=86400"`
+	Targets      []escalationTargetRequest `json:"targets" validate:"required,min=1,dive"`
+}
+
+type escalationTargetRequest struct {
+	Type     models.EscalationTargetType `json:"type" validate:"required,oneof=notification user schedule"`
+	TargetID string                      `json:"target_id" validate:"required,numeric"`
+}
+
+// buildEscalationSteps converts request steps into models, checking every target belongs to the team.
+func (h *EscalationHandler) buildEscalationSteps(ctx context.Context, tx pgx.Tx, teamID, policyID int64, steps []escalationStepRequest, now time.Time) ([]models.EscalationStep, error) {
+	result := make([]models.EscalationStep, 0, len(steps))
+	for i, step := range steps {
+		stepID, err := id.GetID()
+		if err != nil {
+			zap.L().Error("Failed to generate escalation step ID", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate escalation step ID")
+		}
+
+		targets := make([]models.EscalationTarget, 0, len(step.Targets))
+		for _, target := range step.Targets {
+			targetID, err := strconv.ParseInt(target.TargetID, 10, 64)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid escalation target ID")
+			}
+
+			if err := h.validateTarget(ctx, tx, teamID, target.Type, targetID); err != nil {
+				return nil, err
+			}
+
+			targets = append(targets, models.EscalationTarget{
+				StepID:   stepID,
+				Type:     target.Type,
+				TargetID: targetID,
+			})
+		}
+
+		result = append(result, models.EscalationStep{
+			ID:           stepID,
+			PolicyID:     policyID,
+			Position:     int16(i + 1),
+			DelaySeconds: step.DelaySeconds,
+			Targets:      targets,
+			UpdatedAt:    now,
+			CreatedAt:    now,
+		})
+	}
+
+	return result, nil
+}
+
+func (h *EscalationHandler) validateTarget(ctx context.Context, tx pgx.Tx, teamID int64, targetType models.EscalationTargetType, targetID int64) error {
+	var found bool
+	switch targetType {
+	case models.EscalationTargetTypeNotification:
+		notification, err := h.Repo.GetNotificationByID(ctx, tx, teamID, targetID)
+		if err != nil {
+			zap.L().Error("Failed to get notification", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification")
+		}
+		found = notification != nil
+	case models.EscalationTargetTypeUser:
+		member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, targetID)
+		if err != nil {
+			zap.L().Error("Failed to get team membership", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		}
+		found = member != nil
+	case models.EscalationTargetTypeSchedule:
+		schedule, err := h.Repo.GetOnCallScheduleByID(ctx, tx, teamID, targetID)
+		if err != nil {
+			zap.L().Error("Failed to get on-call schedule", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call schedule")
+		}
+		found = schedule != nil
+	}
+
+	if !found {
+		return echo.NewHTTPError(http.StatusBadRequest, "One or more escalation targets do not exist")
+	}
+
+	return nil
+}
+
+// attachSteps loads the steps of the given policies and attaches them in place.
+func (h *EscalationHandler) attachSteps(ctx context.Context, tx pgx.Tx, policies []models.EscalationPolicy) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	policyIDs := make([]int64, len(policies))
+	for i, policy := range policies {
+		policyIDs[i] = policy.ID
+	}
+
+	steps, err := h.Repo.ListEscalationStepsByPolicyIDs(ctx, tx, policyIDs)
+	if err != nil {
+		return err
+	}
+
+	stepsByPolicy := make(map[int64][]models.EscalationStep, len(policies))
+	for _, step := range steps {
+		stepsByPolicy[step.PolicyID] = append(stepsByPolicy[step.PolicyID], step)
+	}
+
+	for i := range policies {
+		policies[i].Steps = stepsByPolicy[policies[i].ID]
+		if policies[i].Steps == nil {
+			policies[i].Steps = []models.EscalationStep{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package escalation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// GetEscalationPolicy godoc
+// @Summary Get an escalation policy
+// @Description Retrieves an escalation policy and its steps for a team the user belongs to
+// @Tags escalation-policies
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Escalation policy ID"
+// @Success 200 {object} response.SuccessResponse "Escalation policy retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team or escalation policy ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Escalation policy not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/escalation-policies/{id} [get]
+func (h *EscalationHandler) GetEscalationPolicy(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	policyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid escalation policy ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceEscalationPolicy, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view escalation policies for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	policy, err := h.Repo.GetEscalationPolicyByID(ctx, tx, teamID, policyID)
+	if err != nil {
+		zap.L().Error("Failed to get escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get escalation policy")
+	}
+
+	if policy == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Escalation policy not found")
+	}
+
+	policies := []models.EscalationPolicy{*policy}
+	if err := h.attachSteps(ctx, tx, policies); err != nil {
+		zap.L().Error("Failed to list escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list escalation steps")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Escalation policy retrieved successfully", policies[0]))
+}
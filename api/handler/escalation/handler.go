@@ -0,0 +1,8 @@
+package escalation
+
+import "github.com/yorukot/knocker/repository"
+
+// EscalationHandler groups dependencies for escalation policy endpoints.
+type EscalationHandler struct {
+	Repo repository.Repository
+}
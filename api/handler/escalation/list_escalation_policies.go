@@ -0,0 +1,75 @@
+package escalation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// ListEscalationPolicies godoc
+// @Summary List escalation policies
+// @Description Lists escalation policies and their steps for a team the user belongs to
+// @Tags escalation-policies
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse "Escalation policies retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/escalation-policies [get]
+func (h *EscalationHandler) ListEscalationPolicies(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceEscalationPolicy, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view escalation policies for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	policies, err := h.Repo.ListEscalationPoliciesByTeamID(ctx, tx, teamID)
+	if err != nil {
+		zap.L().Error("Failed to list escalation policies", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list escalation policies")
+	}
+
+	if err := h.attachSteps(ctx, tx, policies); err != nil {
+		zap.L().Error("Failed to list escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list escalation steps")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	if policies == nil {
+		policies = []models.EscalationPolicy{}
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Escalation policies retrieved successfully", policies))
+}
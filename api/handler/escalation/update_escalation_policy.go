@@ -0,0 +1,144 @@
+package escalation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// UpdateEscalationPolicy godoc
+// @Summary Update an escalation policy
+// @Description Replaces an escalation policy's name, description and steps (owner/admin only)
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Escalation policy ID"
+// @Param request body escalationPolicyRequest true "Escalation policy update request"
+// @Success 200 {object} response.SuccessResponse "Escalation policy updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Escalation policy not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/escalation-policies/{id} [put]
+func (h *EscalationHandler) UpdateEscalationPolicy(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	policyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid escalation policy ID")
+	}
+
+	var req escalationPolicyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceEscalationPolicy, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update escalation policies for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetEscalationPolicyByID(ctx, tx, teamID, policyID)
+	if err != nil {
+		zap.L().Error("Failed to get escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get escalation policy")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Escalation policy not found")
+	}
+
+	existingPolicies := []models.EscalationPolicy{*existing}
+	if err := h.attachSteps(ctx, tx, existingPolicies); err != nil {
+		zap.L().Error("Failed to list escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list escalation steps")
+	}
+
+	now := time.Now().UTC()
+	updated, err := h.Repo.UpdateEscalationPolicy(ctx, tx, models.EscalationPolicy{
+		ID:          policyID,
+		TeamID:      teamID,
+		Name:        req.Name,
+		Description: req.Description,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		zap.L().Error("Failed to update escalation policy", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update escalation policy")
+	}
+
+	if updated == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Escalation policy not found")
+	}
+
+	steps, err := h.buildEscalationSteps(ctx, tx, teamID, policyID, req.Steps, now)
+	if err != nil {
+		return err
+	}
+
+	// Replace the steps wholesale; positions are derived from request order.
+	if err := h.Repo.DeleteEscalationStepsByPolicyID(ctx, tx, policyID); err != nil {
+		zap.L().Error("Failed to delete escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete escalation steps")
+	}
+
+	if err := h.Repo.CreateEscalationSteps(ctx, tx, steps); err != nil {
+		zap.L().Error("Failed to create escalation steps", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create escalation steps")
+	}
+
+	updated.Steps = steps
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceEscalationPolicy,
+		ResourceID:   policyID,
+		Before:       existingPolicies[0],
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Escalation policy updated successfully", updated))
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
@@ -30,7 +32,7 @@ type createIncidentRequest struct {
 
 // CreateIncident godoc
 // @Summary Create a new incident
-// @Description Manually creates an incident for a monitor the user has access to
+// @Description Manually creates an incident for a monitor the user has access to (owner, admin or member)
 // @Tags incidents
 // @Accept json
 // @Produce json
@@ -39,6 +41,7 @@ type createIncidentRequest struct {
 // @Success 200 {object} response.SuccessResponse "Incident created successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid request"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Monitor not found"
 // @Failure 409 {object} response.ErrorResponse "An open incident already exists"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
@@ -68,6 +71,10 @@ func (h *IncidentHandler) CreateIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create incidents for this team")
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
@@ -76,16 +83,6 @@ func (h *IncidentHandler) CreateIncident(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Monitor not found")
-	}
-
 	monitorIDs := utils.UniqueInt64s(req.MonitorIDs.Int64s())
 	if len(monitorIDs) == 0 {
 		return echo.NewHTTPError(http.StatusBadRequest, "At least one monitor_id is required")
@@ -191,10 +188,27 @@ func (h *IncidentHandler) CreateIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create incident event")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceIncident,
+		ResourceID:   incident.ID,
+		After:        incident,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	if incident.ResolvedAt == nil {
+		notifySubscribers(h.AsynqClient, incident, models.StatusPageUpdateOpened, msg)
+	}
+
 	resp := struct {
 		Incident models.Incident      `json:"incident"`
 		Event    models.EventTimeline `json:"event"`
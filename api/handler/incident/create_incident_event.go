@@ -9,7 +9,9 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
 )
@@ -21,7 +23,7 @@ type createIncidentEventRequest struct {
 
 // CreateIncidentEvent godoc
 // @Summary Create an incident event
-// @Description Adds a new event to an incident timeline
+// @Description Adds a new event to an incident timeline (owner, admin or member)
 // @Tags incidents
 // @Accept json
 // @Produce json
@@ -31,6 +33,7 @@ type createIncidentEventRequest struct {
 // @Success 200 {object} response.SuccessResponse "Incident event created successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid request"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Incident not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/incidents/{incidentID}/events [post]
@@ -64,6 +67,10 @@ func (h *IncidentHandler) CreateIncidentEvent(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update incidents for this team")
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
@@ -72,16 +79,6 @@ func (h *IncidentHandler) CreateIncidentEvent(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
-	}
-
 	incident, err := h.Repo.GetIncidentByIDForTeam(ctx, tx, teamID, incidentID)
 	if err != nil {
 		zap.L().Error("Failed to get incident", zap.Error(err))
@@ -97,8 +94,15 @@ func (h *IncidentHandler) CreateIncidentEvent(c echo.Context) error {
 		eventType = models.IncidentEventTypeUpdate
 	}
 
+	eventID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate incident event ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create incident event")
+	}
+
 	now := time.Now().UTC()
 	event := models.EventTimeline{
+		ID:         eventID,
 		IncidentID: incident.ID,
 		CreatedBy:  userID,
 		Message:    req.Message,
@@ -112,9 +116,25 @@ func (h *IncidentHandler) CreateIncidentEvent(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create incident event")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceIncidentEvent,
+		ResourceID:   event.ID,
+		After:        event,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
+	// Internal bookkeeping such as notification_sent stays off the status page.
+	if eventType.IsPublic() {
+		notifySubscribers(h.AsynqClient, *incident, models.StatusPageUpdateUpdated, event.Message)
+	}
+
 	return c.JSON(http.StatusOK, response.Success("Incident event created successfully", event))
 }
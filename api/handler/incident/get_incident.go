@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -20,6 +21,7 @@ import (
 // @Success 200 {object} response.SuccessResponse "Incident retrieved successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid IDs"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Incident not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/incidents/{incidentID} [get]
@@ -44,6 +46,10 @@ func (h *IncidentHandler) GetIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view incidents for this team")
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
@@ -52,16 +58,6 @@ func (h *IncidentHandler) GetIncident(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
-	}
-
 	incident, err := h.Repo.GetIncidentByIDForTeam(ctx, tx, teamID, incidentID)
 	if err != nil {
 		zap.L().Error("Failed to get incident", zap.Error(err))
@@ -1,8 +1,13 @@
 package incident
 
-import "github.com/yorukot/knocker/repository"
+import (
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/repository"
+)
 
 // IncidentHandler groups dependencies for incident endpoints.
 type IncidentHandler struct {
-	Repo repository.Repository
+	Repo        repository.Repository
+	AsynqClient *asynq.Client
+	Inspector   *asynq.Inspector
 }
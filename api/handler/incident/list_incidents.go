@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -19,6 +20,7 @@ import (
 // @Success 200 {object} response.SuccessResponse "Incidents retrieved successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid team ID"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Team not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/incidents [get]
@@ -40,6 +42,10 @@ func (h *IncidentHandler) ListIncidents(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view incidents for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -47,16 +53,6 @@ func (h *IncidentHandler) ListIncidents(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
 	incidents, err := h.Repo.ListIncidentsByTeamID(ctx, tx, teamID)
 	if err != nil {
 		zap.L().Error("Failed to list incidents", zap.Error(err))
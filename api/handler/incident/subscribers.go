@@ -0,0 +1,31 @@
+package incident
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// notifySubscribers queues a public incident update for the status page subscribers.
+// Failures are logged, not returned: the incident change is already committed.
+func notifySubscribers(client *asynq.Client, incident models.Incident, kind models.StatusPageUpdateKind, message string) {
+	if client == nil || !incident.IsPublic {
+		return
+	}
+
+	task, err := tasks.NewStatusPageUpdate(tasks.StatusPageUpdatePayload{
+		Incident:   incident,
+		Kind:       kind,
+		Message:    message,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err == nil {
+		_, err = client.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue status page update", zap.Int64("incident_id", incident.ID), zap.Error(err))
+	}
+}
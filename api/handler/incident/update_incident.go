@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -19,7 +22,7 @@ type updateIncidentRequest struct {
 
 // UpdateIncident godoc
 // @Summary Update incident settings
-// @Description Updates an incident's visibility and auto-resolve settings
+// @Description Updates an incident's visibility and auto-resolve settings (owner, admin or member)
 // @Tags incidents
 // @Accept json
 // @Produce json
@@ -29,6 +32,7 @@ type updateIncidentRequest struct {
 // @Success 200 {object} response.SuccessResponse "Incident updated successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid request"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Incident not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/incidents/{incidentID} [patch]
@@ -62,6 +66,10 @@ func (h *IncidentHandler) UpdateIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update incidents for this team")
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
@@ -70,16 +78,6 @@ func (h *IncidentHandler) UpdateIncident(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
-	}
-
 	existing, err := h.Repo.GetIncidentByIDForTeam(ctx, tx, teamID, incidentID)
 	if err != nil {
 		zap.L().Error("Failed to get incident", zap.Error(err))
@@ -111,9 +109,28 @@ func (h *IncidentHandler) UpdateIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceIncident,
+		ResourceID:   existing.ID,
+		Before:       *existing,
+		After:        *updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	// Publishing an ongoing incident announces it to subscribers as if it had just opened.
+	if !existing.IsPublic && updated.IsPublic && updated.ResolvedAt == nil {
+		notifySubscribers(h.AsynqClient, *updated, models.StatusPageUpdateOpened, "")
+	}
+
 	return c.JSON(http.StatusOK, response.Success("Incident updated successfully", updated))
 }
@@ -8,7 +8,10 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	escalationcore "github.com/yorukot/knocker/core/escalation"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -22,7 +25,7 @@ type updateIncidentStatusRequest struct {
 
 // UpdateIncidentStatus godoc
 // @Summary Update incident status
-// @Description Updates an incident's status and records a timeline event
+// @Description Updates an incident's status and records a timeline event (owner, admin or member)
 // @Tags incidents
 // @Accept json
 // @Produce json
@@ -32,6 +35,7 @@ type updateIncidentStatusRequest struct {
 // @Success 200 {object} response.SuccessResponse "Incident status updated successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid request"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Incident not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/incidents/{incidentID}/status [post]
@@ -65,6 +69,10 @@ func (h *IncidentHandler) UpdateIncidentStatus(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update incidents for this team")
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.Repo.StartTransaction(ctx)
 	if err != nil {
@@ -73,16 +81,6 @@ func (h *IncidentHandler) UpdateIncidentStatus(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, ctx)
 
-	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
-	}
-
 	existing, err := h.Repo.GetIncidentByIDForTeam(ctx, tx, teamID, incidentID)
 	if err != nil {
 		zap.L().Error("Failed to get incident", zap.Error(err))
@@ -110,6 +108,16 @@ func (h *IncidentHandler) UpdateIncidentStatus(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
 	}
 
+	// Moving an incident out of detected acknowledges it and stops further escalation.
+	var cancelled []models.IncidentEscalation
+	if req.Status != models.IncidentStatusDetected {
+		cancelled, err = h.Repo.CancelPendingIncidentEscalations(ctx, tx, existing.ID, now)
+		if err != nil {
+			zap.L().Error("Failed to cancel incident escalations", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to cancel incident escalations")
+		}
+	}
+
 	eventType := eventTypeFromStatus(req.Status)
 
 	msg := req.Message
@@ -131,10 +139,32 @@ func (h *IncidentHandler) UpdateIncidentStatus(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record incident status event")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceIncident,
+		ResourceID:   existing.ID,
+		Before:       *existing,
+		After:        *updatedIncident,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	escalationcore.Cancel(h.Inspector, cancelled)
+
+	kind := models.StatusPageUpdateUpdated
+	if req.Status == models.IncidentStatusResolved {
+		kind = models.StatusPageUpdateResolved
+	}
+	notifySubscribers(h.AsynqClient, *updatedIncident, kind, msg)
+
 	resp := struct {
 		Incident models.Incident      `json:"incident"`
 		Event    models.EventTimeline `json:"event"`
@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
@@ -17,14 +18,22 @@ import (
 )
 
 type createMonitorRequest struct {
-	Name              string             `json:"name" validate:"required,min=1,max=255"`
-	Type              models.MonitorType `json:"type" validate:"required,oneof=http ping"`
-	Interval          int                `json:"interval" validate:"required,min=30,max=2592000"`
-	Config            json.RawMessage    `json:"config" validate:"required"`
-	FailureThreshold  int16              `json:"failure_threshold" validate:"required,gt=0"`
-	RecoveryThreshold int16              `json:"recovery_threshold" validate:"required,gt=0"`
-	Regions           regionIDList       `json:"regions" validate:"required,min=1"`
-	NotificationIDs   notificationIDList `json:"notification"`
+	Name               string             `json:"name" validate:"required,min=1,max=255"`
+	Type               models.MonitorType `json:"type" validate:"required,oneof=http ping"`
+	Interval           int                `json:"interval" validate:"required,min=30,max=2592000"`
+	Config             json.RawMessage    `json:"config" validate:"required"`
+	FailureThreshold   int16              `json:"failure_threshold" validate:"required,gt=0"`
+	RecoveryThreshold  int16              `json:"recovery_threshold" validate:"required,gt=0"`
+	Regions            regionIDList       `json:"regions" validate:"required,min=1"`
+	NotificationIDs    notificationIDList `json:"notification"`
+	Parents            monitorIDList      `json:"parents"`
+	EscalationPolicyID *string            `json:"escalation_policy_id"`
+	Tags               []string           `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"`
+	FlapWindow         *int16             `json:"flap_window" validate:"omitempty,min=2,max=100"`
+	FlapStartThreshold *int16             `json:"flap_start_threshold" validate:"omitempty,min=0,max=100"`
+	FlapStopThreshold  *int16             `json:"flap_stop_threshold" validate:"omitempty,min=0,max=100"`
+	LatencyWarnMs      *int               `json:"latency_warn_ms" validate:"omitempty,min=0,max=600000"`
+	LatencyCriticalMs  *int               `json:"latency_critical_ms" validate:"omitempty,min=0,max=600000"`
 }
 
 // CreateMonitor godocit
@@ -75,6 +84,10 @@ func (h *MonitorHandler) CreateMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -82,20 +95,6 @@ func (h *MonitorHandler) CreateMonitor(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create monitors for this team")
-	}
-
 	monitorID, err := id.GetID()
 	if err != nil {
 		zap.L().Error("Failed to generate monitor ID", zap.Error(err))
@@ -114,23 +113,45 @@ func (h *MonitorHandler) CreateMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "One or more regions do not exist")
 	}
 
+	escalationPolicyID, err := h.resolveEscalationPolicyID(c.Request().Context(), tx, teamID, req.EscalationPolicyID)
+	if err != nil {
+		return err
+	}
+
+	parentIDs, err := h.resolveParentIDs(c.Request().Context(), tx, teamID, monitorID, req.Parents)
+	if err != nil {
+		return err
+	}
+
 	notificationIDs := req.NotificationIDs.Int64s()
 	monitor := models.Monitor{
-		ID:                monitorID,
-		TeamID:            teamID,
-		Name:              req.Name,
-		Type:              req.Type,
-		Status:            models.MonitorStatusUp, // newly created monitors start in healthy state
-		Interval:          req.Interval,
-		Config:            req.Config,
-		LastChecked:       now,
-		NextCheck:         now.Add(time.Duration(req.Interval) * time.Second),
-		FailureThreshold:  req.FailureThreshold,
-		RecoveryThreshold: req.RecoveryThreshold,
-		RegionIDs:         regionIDs,
-		NotificationIDs:   notificationIDs,
-		UpdatedAt:         now,
-		CreatedAt:         now,
+		ID:                 monitorID,
+		TeamID:             teamID,
+		Name:               req.Name,
+		Type:               req.Type,
+		Status:             models.MonitorStatusUp, // newly created monitors start in healthy state
+		Interval:           req.Interval,
+		Config:             req.Config,
+		LastChecked:        now,
+		NextCheck:          now.Add(time.Duration(req.Interval) * time.Second),
+		FailureThreshold:   req.FailureThreshold,
+		RecoveryThreshold:  req.RecoveryThreshold,
+		FlapWindow:         models.DefaultFlapWindow,
+		RegionIDs:          regionIDs,
+		ParentIDs:          parentIDs,
+		NotificationIDs:    notificationIDs,
+		EscalationPolicyID: escalationPolicyID,
+		Tags:               utils.NormalizeTags(req.Tags),
+		UpdatedAt:          now,
+		CreatedAt:          now,
+	}
+
+	if err := applyFlapThresholds(&monitor, req.FlapWindow, req.FlapStartThreshold, req.FlapStopThreshold); err != nil {
+		return err
+	}
+
+	if err := applyLatencyThresholds(&monitor, req.LatencyWarnMs, req.LatencyCriticalMs); err != nil {
+		return err
 	}
 
 	if err := h.Repo.CreateMonitor(c.Request().Context(), tx, monitor); err != nil {
@@ -146,17 +167,34 @@ func (h *MonitorHandler) CreateMonitor(c echo.Context) error {
 		}
 	}
 
+	if err := h.Repo.CreateMonitorDependencies(c.Request().Context(), tx, monitorID, parentIDs); err != nil {
+		zap.L().Error("Failed to create monitor dependencies", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create monitor dependencies")
+	}
+
 	if err := h.Repo.CreateMonitorRegions(c.Request().Context(), tx, monitorID, regions); err != nil {
 		zap.L().Error("Failed to create monitor regions", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create monitor regions")
 	}
 
+	monitor.NotificationIDs = notificationIDs
+	monitor.ParentIDs = parentIDs
+	monitor.RegionIDs = regionIDs
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceMonitor,
+		ResourceID:   monitor.ID,
+		After:        monitor,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
-	monitor.NotificationIDs = notificationIDs
-	monitor.RegionIDs = regionIDs
-
 	return c.JSON(http.StatusOK, response.Success("Monitor created successfully", newMonitorResponse(monitor)))
 }
@@ -6,7 +6,9 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -47,6 +49,10 @@ func (h *MonitorHandler) DeleteMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -54,20 +60,16 @@ func (h *MonitorHandler) DeleteMonitor(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
+	existing, err := h.Repo.GetMonitorByID(c.Request().Context(), tx, teamID, monitorID)
 	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		zap.L().Error("Failed to get monitor", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get monitor")
 	}
 
-	if member == nil {
+	if existing == nil {
 		return echo.NewHTTPError(http.StatusNotFound, "Monitor not found")
 	}
 
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete monitors for this team")
-	}
-
 	if err := h.Repo.DeleteMonitor(c.Request().Context(), tx, teamID, monitorID); err != nil {
 		if err == pgx.ErrNoRows {
 			return echo.NewHTTPError(http.StatusNotFound, "Monitor not found")
@@ -77,9 +79,22 @@ func (h *MonitorHandler) DeleteMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete monitor")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceMonitor,
+		ResourceID:   monitorID,
+		Before:       existing,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
+	statuspagecore.InvalidateTeamPages(c.Request().Context(), teamID)
+
 	return c.JSON(http.StatusOK, response.SuccessMessage("Monitor deleted successfully"))
 }
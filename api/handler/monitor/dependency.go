@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	monitorcore "github.com/yorukot/knocker/core/monitor"
+	"github.com/yorukot/knocker/utils"
+	"go.uber.org/zap"
+)
+
+// resolveParentIDs validates the parent monitors a monitor depends on: they must belong
+// to the team and must not make the monitor depend on itself, directly or transitively.
+func (h *MonitorHandler) resolveParentIDs(ctx context.Context, tx pgx.Tx, teamID, monitorID int64, raw monitorIDList) ([]int64, error) {
+	parentIDs := utils.UniqueInt64s(raw.Int64s())
+	if len(parentIDs) == 0 {
+		return parentIDs, nil
+	}
+
+	if slices.Contains(parentIDs, monitorID) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "A monitor cannot depend on itself")
+	}
+
+	parents, err := h.Repo.ListMonitorsByIDs(ctx, tx, teamID, parentIDs)
+	if err != nil {
+		zap.L().Error("Failed to load parent monitors", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load parent monitors")
+	}
+
+	if len(parents) != len(parentIDs) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "One or more parent monitors do not exist")
+	}
+
+	edges, err := h.Repo.ListMonitorDependenciesByTeamID(ctx, tx, teamID)
+	if err != nil {
+		zap.L().Error("Failed to load monitor dependencies", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load monitor dependencies")
+	}
+
+	if monitorcore.CreatesDependencyCycle(edges, monitorID, parentIDs) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Monitor dependencies cannot form a cycle")
+	}
+
+	return parentIDs, nil
+}
@@ -10,21 +10,29 @@ import (
 )
 
 type monitorResponse struct {
-	ID                string             `json:"id"`
-	TeamID            string             `json:"team_id"`
-	Name              string             `json:"name"`
-	Type              models.MonitorType `json:"type"`
-	Config            json.RawMessage    `json:"config"`
-	Interval          int                `json:"interval"`
-	LastChecked       time.Time          `json:"last_checked"`
-	NextCheck         time.Time          `json:"next_check"`
-	FailureThreshold  int16              `json:"failure_threshold"`
-	RecoveryThreshold int16              `json:"recovery_threshold"`
-	RegionIDs         []string           `json:"regions"`
-	NotificationIDs   []string           `json:"notification"`
-	Incidents         []incidentResponse `json:"incidents,omitempty"`
-	UpdatedAt         time.Time          `json:"updated_at"`
-	CreatedAt         time.Time          `json:"created_at"`
+	ID                 string             `json:"id"`
+	TeamID             string             `json:"team_id"`
+	Name               string             `json:"name"`
+	Type               models.MonitorType `json:"type"`
+	Config             json.RawMessage    `json:"config"`
+	Interval           int                `json:"interval"`
+	LastChecked        time.Time          `json:"last_checked"`
+	NextCheck          time.Time          `json:"next_check"`
+	FailureThreshold   int16              `json:"failure_threshold"`
+	RecoveryThreshold  int16              `json:"recovery_threshold"`
+	FlapWindow         int16              `json:"flap_window"`
+	FlapStartThreshold int16              `json:"flap_start_threshold"`
+	FlapStopThreshold  int16              `json:"flap_stop_threshold"`
+	LatencyWarnMs      *int               `json:"latency_warn_ms,omitempty"`
+	LatencyCriticalMs  *int               `json:"latency_critical_ms,omitempty"`
+	RegionIDs          []string           `json:"regions"`
+	NotificationIDs    []string           `json:"notification"`
+	ParentIDs          []string           `json:"parents"`
+	EscalationPolicyID *string            `json:"escalation_policy_id,omitempty"`
+	Tags               []string           `json:"tags"`
+	Incidents          []incidentResponse `json:"incidents,omitempty"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	CreatedAt          time.Time          `json:"created_at"`
 }
 
 type incidentResponse struct {
@@ -39,24 +47,33 @@ type incidentResponse struct {
 
 type notificationIDList = utils.IDList
 type regionIDList = utils.IDList
+type monitorIDList = utils.IDList
 
 func newMonitorResponse(m models.Monitor) monitorResponse {
 	return monitorResponse{
-		ID:                strconv.FormatInt(m.ID, 10),
-		TeamID:            strconv.FormatInt(m.TeamID, 10),
-		Name:              m.Name,
-		Type:              m.Type,
-		Config:            m.Config,
-		Interval:          m.Interval,
-		LastChecked:       m.LastChecked,
-		NextCheck:         m.NextCheck,
-		FailureThreshold:  m.FailureThreshold,
-		RecoveryThreshold: m.RecoveryThreshold,
-		RegionIDs:         formatRegionIDs(m.RegionIDs),
-		NotificationIDs:   formatNotificationIDs(m.NotificationIDs),
-		Incidents:         []incidentResponse{},
-		UpdatedAt:         m.UpdatedAt,
-		CreatedAt:         m.CreatedAt,
+		ID:                 strconv.FormatInt(m.ID, 10),
+		TeamID:             strconv.FormatInt(m.TeamID, 10),
+		Name:               m.Name,
+		Type:               m.Type,
+		Config:             m.Config,
+		Interval:           m.Interval,
+		LastChecked:        m.LastChecked,
+		NextCheck:          m.NextCheck,
+		FailureThreshold:   m.FailureThreshold,
+		RecoveryThreshold:  m.RecoveryThreshold,
+		FlapWindow:         m.FlapWindow,
+		FlapStartThreshold: m.FlapStartThreshold,
+		FlapStopThreshold:  m.FlapStopThreshold,
+		LatencyWarnMs:      m.LatencyWarnMs,
+		LatencyCriticalMs:  m.LatencyCriticalMs,
+		RegionIDs:          formatRegionIDs(m.RegionIDs),
+		NotificationIDs:    formatNotificationIDs(m.NotificationIDs),
+		ParentIDs:          formatMonitorIDs(m.ParentIDs),
+		EscalationPolicyID: formatOptionalID(m.EscalationPolicyID),
+		Tags:               utils.NormalizeTags(m.Tags),
+		Incidents:          []incidentResponse{},
+		UpdatedAt:          m.UpdatedAt,
+		CreatedAt:          m.CreatedAt,
 	}
 }
 
@@ -94,6 +111,15 @@ func formatNotificationIDs(ids []int64) []string {
 	return result
 }
 
+func formatOptionalID(id *int64) *string {
+	if id == nil {
+		return nil
+	}
+
+	formatted := strconv.FormatInt(*id, 10)
+	return &formatted
+}
+
 func formatRegionIDs(ids []int64) []string {
 	if len(ids) == 0 {
 		return []string{}
@@ -106,6 +132,18 @@ func formatRegionIDs(ids []int64) []string {
 	return result
 }
 
+func formatMonitorIDs(ids []int64) []string {
+	if len(ids) == 0 {
+		return []string{}
+	}
+
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.FormatInt(id, 10)
+	}
+	return result
+}
+
 func formatIncidents(monitorID int64, incidents []models.Incident) []incidentResponse {
 	if len(incidents) == 0 {
 		return []incidentResponse{}
@@ -0,0 +1,37 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// resolveEscalationPolicyID parses the optional escalation policy reference and
+// ensures the policy belongs to the team. An empty value clears the policy.
+func (h *MonitorHandler) resolveEscalationPolicyID(ctx context.Context, tx pgx.Tx, teamID int64, raw *string) (*int64, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+
+	policyID, err := strconv.ParseInt(strings.TrimSpace(*raw), 10, 64)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid escalation policy ID")
+	}
+
+	policy, err := h.Repo.GetEscalationPolicyByID(ctx, tx, teamID, policyID)
+	if err != nil {
+		zap.L().Error("Failed to get escalation policy", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get escalation policy")
+	}
+
+	if policy == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Escalation policy does not exist")
+	}
+
+	return &policy.ID, nil
+}
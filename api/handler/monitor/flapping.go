@@ -0,0 +1,33 @@
+package monitor
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+)
+
+// applyFlapThresholds overrides the monitor's flap settings with the provided values.
+// Nil values keep whatever the monitor already carries.
+func applyFlapThresholds(monitor *models.Monitor, window, start, stop *int16) error {
+	if window != nil {
+		monitor.FlapWindow = *window
+	}
+	if start != nil {
+		monitor.FlapStartThreshold = *start
+	}
+	if stop != nil {
+		monitor.FlapStopThreshold = *stop
+	}
+
+	// Without a stop threshold a flapping monitor would never settle; default it to half the start threshold.
+	if monitor.FlapStartThreshold > 0 && monitor.FlapStopThreshold == 0 {
+		monitor.FlapStopThreshold = monitor.FlapStartThreshold / 2
+	}
+
+	if monitor.FlapStartThreshold > 0 && monitor.FlapStopThreshold > monitor.FlapStartThreshold {
+		return echo.NewHTTPError(http.StatusBadRequest, "Flap stop threshold must not exceed the start threshold")
+	}
+
+	return nil
+}
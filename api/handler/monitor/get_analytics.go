@@ -80,6 +80,7 @@ type monitorAnalyticsResponse struct {
 // @Success 200 {object} response.SuccessResponse "Analytics returned"
 // @Failure 400 {object} response.ErrorResponse "Invalid parameters"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Monitor or team not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/monitors/{id}/analytics [get]
@@ -140,6 +141,10 @@ func (h *MonitorHandler) GetAnalytics(c echo.Context) error {
 		regionFilter = &regionVal
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -147,15 +152,6 @@ func (h *MonitorHandler) GetAnalytics(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
 	monitor, err := h.Repo.GetMonitorByID(c.Request().Context(), tx, teamID, monitorID)
 	if err != nil {
 		zap.L().Error("Failed to get monitor", zap.Error(err))
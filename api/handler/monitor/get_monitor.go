@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -20,6 +21,7 @@ import (
 // @Success 200 {object} response.SuccessResponse "Monitor retrieved successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid team ID or monitor ID"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Monitor not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/monitors/{id} [get]
@@ -44,6 +46,10 @@ func (h *MonitorHandler) GetMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -51,16 +57,6 @@ func (h *MonitorHandler) GetMonitor(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Monitor not found")
-	}
-
 	monitor, err := h.Repo.GetMonitorByID(c.Request().Context(), tx, teamID, monitorID)
 	if err != nil {
 		zap.L().Error("Failed to get monitor", zap.Error(err))
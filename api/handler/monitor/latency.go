@@ -0,0 +1,31 @@
+package monitor
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+)
+
+// applyLatencyThresholds overrides the monitor's latency thresholds with the provided values.
+// Nil values keep whatever the monitor already carries and a zero threshold disables it.
+func applyLatencyThresholds(monitor *models.Monitor, warnMs, criticalMs *int) error {
+	if warnMs != nil {
+		monitor.LatencyWarnMs = warnMs
+		if *warnMs == 0 {
+			monitor.LatencyWarnMs = nil
+		}
+	}
+	if criticalMs != nil {
+		monitor.LatencyCriticalMs = criticalMs
+		if *criticalMs == 0 {
+			monitor.LatencyCriticalMs = nil
+		}
+	}
+
+	if monitor.LatencyWarnMs != nil && monitor.LatencyCriticalMs != nil && *monitor.LatencyWarnMs >= *monitor.LatencyCriticalMs {
+		return echo.NewHTTPError(http.StatusBadRequest, "Latency warn threshold must be below the critical threshold")
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 // @Success 200 {object} response.SuccessResponse "Monitors retrieved successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid team ID"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Team not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/monitors [get]
@@ -41,6 +42,10 @@ func (h *MonitorHandler) ListMonitors(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -48,16 +53,6 @@ func (h *MonitorHandler) ListMonitors(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
 	monitors, err := h.Repo.ListMonitorsByTeamID(c.Request().Context(), tx, teamID)
 	if err != nil {
 		zap.L().Error("Failed to list monitors", zap.Error(err))
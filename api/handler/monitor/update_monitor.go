@@ -9,22 +9,32 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
 )
 
 type updateMonitorRequest struct {
-	Name              string             `json:"name" validate:"required,min=1,max=255"`
-	Type              models.MonitorType `json:"type" validate:"required,oneof=http ping"`
-	Interval          int                `json:"interval" validate:"required,min=30,max=2592000"`
-	Config            json.RawMessage    `json:"config" validate:"required"`
-	FailureThreshold  int16              `json:"failure_threshold" validate:"required,gt=0"`
-	RecoveryThreshold int16              `json:"recovery_threshold" validate:"required,gt=0"`
-	Regions           regionIDList       `json:"regions" validate:"required,min=1"`
-	NotificationIDs   notificationIDList `json:"notification"`
+	Name               string             `json:"name" validate:"required,min=1,max=255"`
+	Type               models.MonitorType `json:"type" validate:"required,oneof=http ping"`
+	Interval           int                `json:"interval" validate:"required,min=30,max=2592000"`
+	Config             json.RawMessage    `json:"config" validate:"required"`
+	FailureThreshold   int16              `json:"failure_threshold" validate:"required,gt=0"`
+	RecoveryThreshold  int16              `json:"recovery_threshold" validate:"required,gt=0"`
+	Regions            regionIDList       `json:"regions" validate:"required,min=1"`
+	NotificationIDs    notificationIDList `json:"notification"`
+	Parents            monitorIDList      `json:"parents"`
+	EscalationPolicyID *string            `json:"escalation_policy_id"`
+	Tags               []string           `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"`
+	FlapWindow         *int16             `json:"flap_window" validate:"omitempty,min=2,max=100"`
+	FlapStartThreshold *int16             `json:"flap_start_threshold" validate:"omitempty,min=0,max=100"`
+	FlapStopThreshold  *int16             `json:"flap_stop_threshold" validate:"omitempty,min=0,max=100"`
+	LatencyWarnMs      *int               `json:"latency_warn_ms" validate:"omitempty,min=0,max=600000"`
+	LatencyCriticalMs  *int               `json:"latency_critical_ms" validate:"omitempty,min=0,max=600000"`
 }
 
 // UpdateMonitor godoc
@@ -81,6 +91,10 @@ func (h *MonitorHandler) UpdateMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update monitors for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -88,20 +102,6 @@ func (h *MonitorHandler) UpdateMonitor(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Monitor not found")
-	}
-
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update monitors for this team")
-	}
-
 	existing, err := h.Repo.GetMonitorByID(c.Request().Context(), tx, teamID, monitorID)
 	if err != nil {
 		zap.L().Error("Failed to get monitor", zap.Error(err))
@@ -124,23 +124,49 @@ func (h *MonitorHandler) UpdateMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "One or more regions do not exist")
 	}
 
+	escalationPolicyID, err := h.resolveEscalationPolicyID(c.Request().Context(), tx, teamID, req.EscalationPolicyID)
+	if err != nil {
+		return err
+	}
+
+	parentIDs, err := h.resolveParentIDs(c.Request().Context(), tx, teamID, monitorID, req.Parents)
+	if err != nil {
+		return err
+	}
+
 	notificationIDs := req.NotificationIDs.Int64s()
 	monitor := models.Monitor{
-		ID:                monitorID,
-		TeamID:            teamID,
-		Name:              req.Name,
-		Type:              req.Type,
-		Status:            existing.Status, // preserve current status when updating config
-		Interval:          req.Interval,
-		Config:            req.Config,
-		LastChecked:       existing.LastChecked,
-		NextCheck:         now.Add(time.Duration(req.Interval) * time.Second),
-		FailureThreshold:  req.FailureThreshold,
-		RecoveryThreshold: req.RecoveryThreshold,
-		RegionIDs:         regionIDs,
-		NotificationIDs:   notificationIDs,
-		UpdatedAt:         now,
-		CreatedAt:         existing.CreatedAt,
+		ID:                 monitorID,
+		TeamID:             teamID,
+		Name:               req.Name,
+		Type:               req.Type,
+		Status:             existing.Status, // preserve current status when updating config
+		Interval:           req.Interval,
+		Config:             req.Config,
+		LastChecked:        existing.LastChecked,
+		NextCheck:          now.Add(time.Duration(req.Interval) * time.Second),
+		FailureThreshold:   req.FailureThreshold,
+		RecoveryThreshold:  req.RecoveryThreshold,
+		FlapWindow:         existing.FlapWindow,
+		FlapStartThreshold: existing.FlapStartThreshold,
+		FlapStopThreshold:  existing.FlapStopThreshold,
+		LatencyWarnMs:      existing.LatencyWarnMs,
+		LatencyCriticalMs:  existing.LatencyCriticalMs,
+		RegionIDs:          regionIDs,
+		ParentIDs:          parentIDs,
+		NotificationIDs:    notificationIDs,
+		EscalationPolicyID: escalationPolicyID,
+		Tags:               utils.NormalizeTags(req.Tags),
+		UpdatedAt:          now,
+		CreatedAt:          existing.CreatedAt,
+	}
+
+	if err := applyFlapThresholds(&monitor, req.FlapWindow, req.FlapStartThreshold, req.FlapStopThreshold); err != nil {
+		return err
+	}
+
+	if err := applyLatencyThresholds(&monitor, req.LatencyWarnMs, req.LatencyCriticalMs); err != nil {
+		return err
 	}
 
 	updated, err := h.Repo.UpdateMonitor(c.Request().Context(), tx, monitor)
@@ -169,6 +195,11 @@ func (h *MonitorHandler) UpdateMonitor(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete monitor regions")
 	}
 
+	if err := h.Repo.DeleteMonitorDependencies(c.Request().Context(), tx, monitorID); err != nil {
+		zap.L().Error("Failed to delete monitor dependencies", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete monitor dependencies")
+	}
+
 	// Then create new associations
 	if len(notificationIDs) > 0 {
 		if err := h.Repo.CreateMonitorNotifications(c.Request().Context(), tx, monitorID, notificationIDs); err != nil {
@@ -177,17 +208,37 @@ func (h *MonitorHandler) UpdateMonitor(c echo.Context) error {
 		}
 	}
 
+	if err := h.Repo.CreateMonitorDependencies(c.Request().Context(), tx, monitorID, parentIDs); err != nil {
+		zap.L().Error("Failed to create monitor dependencies", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create monitor dependencies")
+	}
+
 	if err := h.Repo.CreateMonitorRegions(c.Request().Context(), tx, monitorID, regions); err != nil {
 		zap.L().Error("Failed to create monitor regions", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create monitor regions")
 	}
 
+	updated.NotificationIDs = notificationIDs
+	updated.ParentIDs = parentIDs
+	updated.RegionIDs = regionIDs
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceMonitor,
+		ResourceID:   monitorID,
+		Before:       existing,
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
-	updated.NotificationIDs = notificationIDs
-	updated.RegionIDs = regionIDs
+	statuspagecore.InvalidateTeamPages(c.Request().Context(), teamID)
 
 	return c.JSON(http.StatusOK, response.Success("Monitor updated successfully", newMonitorResponse(*updated)))
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
@@ -65,6 +66,10 @@ func (h *NotificationHandler) New(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceNotification, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create notifications for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -72,20 +77,6 @@ func (h *NotificationHandler) New(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create notifications for this team")
-	}
-
 	notificationID, err := id.GetID()
 	if err != nil {
 		zap.L().Error("Failed to generate notification ID", zap.Error(err))
@@ -108,6 +99,18 @@ func (h *NotificationHandler) New(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create notification")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceNotification,
+		ResourceID:   notification.ID,
+		After:        notification,
+		Redact:       []string{"config"},
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
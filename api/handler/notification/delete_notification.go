@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -47,6 +48,10 @@ func (h *NotificationHandler) DeleteNotification(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceNotification, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this notification")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -54,20 +59,16 @@ func (h *NotificationHandler) DeleteNotification(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
+	existing, err := h.Repo.GetNotificationByID(c.Request().Context(), tx, teamID, notificationID)
 	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		zap.L().Error("Failed to get notification", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification")
 	}
 
-	if member == nil {
+	if existing == nil {
 		return echo.NewHTTPError(http.StatusNotFound, "Notification not found")
 	}
 
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this notification")
-	}
-
 	if err := h.Repo.DeleteNotification(c.Request().Context(), tx, teamID, notificationID); err != nil {
 		if err == pgx.ErrNoRows {
 			return echo.NewHTTPError(http.StatusNotFound, "Notification not found")
@@ -77,6 +78,18 @@ func (h *NotificationHandler) DeleteNotification(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete notification")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceNotification,
+		ResourceID:   notificationID,
+		Before:       existing,
+		Redact:       []string{"config"},
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
@@ -0,0 +1,128 @@
+package notificationroute
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils"
+	"go.uber.org/zap"
+)
+
+type notificationRouteRequest struct {
+	Name            string                         `json:"name" validate:"required,min=1,max=255"`
+	Position        int16                          `json:"position" validate:"min=0,max=1000"`
+	EventKinds      []models.NotificationEventKind `json:"event_kinds" validate:"omitempty,dive,oneof=opened resolved reminder cert_expiry flapping_started flapping_ended"`
+	Severities      []models.IncidentSeverity      `json:"severities" validate:"omitempty,dive,oneof=emergency critical major minor info"`
+	RegionIDs       utils.IDList                   `json:"region_ids"`
+	MonitorTypes    []models.MonitorType           `json:"monitor_types" validate:"omitempty,dive,oneof=http ping"`
+	Tags            []string                       `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"`
+	NotificationIDs utils.IDList                   `json:"notification_ids"`
+	Timezone        string                         `json:"timezone" validate:"required,timezone"`
+	QuietHoursStart *string                        `json:"quiet_hours_start" validate:"required_with=QuietHoursEnd,omitempty,datetime=15:04"`
+	QuietHoursEnd   *string                        `json:"quiet_hours_end" validate:"required_with=QuietHoursStart,omitempty,datetime=15:04"`
+}
+
+type notificationRouteResponse struct {
+	ID              string                         `json:"id"`
+	TeamID          string                         `json:"team_id"`
+	Name            string                         `json:"name"`
+	Position        int16                          `json:"position"`
+	EventKinds      []models.NotificationEventKind `json:"event_kinds"`
+	Severities      []models.IncidentSeverity      `json:"severities"`
+	RegionIDs       []string                       `json:"region_ids"`
+	MonitorTypes    []models.MonitorType           `json:"monitor_types"`
+	Tags            []string                       `json:"tags"`
+	NotificationIDs []string                       `json:"notification_ids"`
+	Timezone        string                         `json:"timezone"`
+	QuietHoursStart *string                        `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string                        `json:"quiet_hours_end,omitempty"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+	CreatedAt       time.Time                      `json:"created_at"`
+}
+
+func newNotificationRouteResponse(route models.NotificationRoute) notificationRouteResponse {
+	return notificationRouteResponse{
+		ID:              strconv.FormatInt(route.ID, 10),
+		TeamID:          strconv.FormatInt(route.TeamID, 10),
+		Name:            route.Name,
+		Position:        route.Position,
+		EventKinds:      nonNil(route.EventKinds),
+		Severities:      nonNil(route.Severities),
+		RegionIDs:       formatIDs(route.RegionIDs),
+		MonitorTypes:    nonNil(route.MonitorTypes),
+		Tags:            nonNil(route.Tags),
+		NotificationIDs: formatIDs(route.NotificationIDs),
+		Timezone:        route.Timezone,
+		QuietHoursStart: route.QuietHoursStart,
+		QuietHoursEnd:   route.QuietHoursEnd,
+		UpdatedAt:       route.UpdatedAt,
+		CreatedAt:       route.CreatedAt,
+	}
+}
+
+func formatIDs(ids []int64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.FormatInt(id, 10)
+	}
+	return result
+}
+
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
+// buildRoute converts a request into a route, checking referenced regions and channels exist.
+func (h *NotificationRouteHandler) buildRoute(ctx context.Context, tx pgx.Tx, teamID, routeID int64, req notificationRouteRequest, now time.Time) (models.NotificationRoute, error) {
+	regionIDs := utils.UniqueInt64s(req.RegionIDs.Int64s())
+	if len(regionIDs) > 0 {
+		regions, err := h.Repo.ListRegionsByIDs(ctx, tx, regionIDs)
+		if err != nil {
+			zap.L().Error("Failed to load regions", zap.Error(err))
+			return models.NotificationRoute{}, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load regions")
+		}
+
+		if len(regions) != len(regionIDs) {
+			return models.NotificationRoute{}, echo.NewHTTPError(http.StatusBadRequest, "One or more regions do not exist")
+		}
+	}
+
+	notificationIDs := utils.UniqueInt64s(req.NotificationIDs.Int64s())
+	for _, notificationID := range notificationIDs {
+		notification, err := h.Repo.GetNotificationByID(ctx, tx, teamID, notificationID)
+		if err != nil {
+			zap.L().Error("Failed to get notification", zap.Error(err))
+			return models.NotificationRoute{}, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification")
+		}
+
+		if notification == nil {
+			return models.NotificationRoute{}, echo.NewHTTPError(http.StatusBadRequest, "One or more notifications do not exist")
+		}
+	}
+
+	return models.NotificationRoute{
+		ID:              routeID,
+		TeamID:          teamID,
+		Name:            req.Name,
+		Position:        req.Position,
+		EventKinds:      nonNil(req.EventKinds),
+		Severities:      nonNil(req.Severities),
+		RegionIDs:       regionIDs,
+		MonitorTypes:    nonNil(req.MonitorTypes),
+		Tags:            utils.NormalizeTags(req.Tags),
+		NotificationIDs: notificationIDs,
+		Timezone:        req.Timezone,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		UpdatedAt:       now,
+		CreatedAt:       now,
+	}, nil
+}
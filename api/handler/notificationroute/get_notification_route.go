@@ -0,0 +1,76 @@
+package notificationroute
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// GetNotificationRoute godoc
+// @Summary Get a notification route
+// @Description Retrieves a notification route by ID
+// @Tags notification-routes
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Notification route ID"
+// @Success 200 {object} response.SuccessResponse "Notification route retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team or route ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Notification route not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/notification-routes/{id} [get]
+func (h *NotificationRouteHandler) GetNotificationRoute(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	routeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid notification route ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceNotificationRoute, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view notification routes for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	route, err := h.Repo.GetNotificationRouteByID(ctx, tx, teamID, routeID)
+	if err != nil {
+		zap.L().Error("Failed to get notification route", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification route")
+	}
+
+	if route == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Notification route not found")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Notification route retrieved successfully", newNotificationRouteResponse(*route)))
+}
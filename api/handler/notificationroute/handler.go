@@ -0,0 +1,8 @@
+package notificationroute
+
+import "github.com/yorukot/knocker/repository"
+
+// NotificationRouteHandler groups dependencies for notification routing endpoints.
+type NotificationRouteHandler struct {
+	Repo repository.Repository
+}
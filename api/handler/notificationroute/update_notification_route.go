@@ -0,0 +1,118 @@
+package notificationroute
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// UpdateNotificationRoute godoc
+// @Summary Update a notification route
+// @Description Replaces a notification route's conditions, channels and quiet hours (owner/admin only)
+// @Tags notification-routes
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Notification route ID"
+// @Param request body notificationRouteRequest true "Notification route update request"
+// @Success 200 {object} response.SuccessResponse "Notification route updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Notification route not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/notification-routes/{id} [put]
+func (h *NotificationRouteHandler) UpdateNotificationRoute(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	routeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid notification route ID")
+	}
+
+	var req notificationRouteRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceNotificationRoute, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update notification routes for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetNotificationRouteByID(ctx, tx, teamID, routeID)
+	if err != nil {
+		zap.L().Error("Failed to get notification route", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification route")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Notification route not found")
+	}
+
+	route, err := h.buildRoute(ctx, tx, teamID, routeID, req, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.Repo.UpdateNotificationRoute(ctx, tx, route)
+	if err != nil {
+		zap.L().Error("Failed to update notification route", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update notification route")
+	}
+
+	if updated == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Notification route not found")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceNotificationRoute,
+		ResourceID:   routeID,
+		Before:       existing,
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Notification route updated successfully", newNotificationRouteResponse(*updated)))
+}
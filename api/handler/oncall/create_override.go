@@ -0,0 +1,134 @@
+package oncall
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// CreateOverride godoc
+// @Summary Create an on-call override
+// @Description Puts a team member on call for a time range, taking precedence over the schedule's layers (owner/admin only)
+// @Tags on-call-schedules
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Schedule ID"
+// @Param request body overrideRequest true "On-call override request"
+// @Success 200 {object} response.SuccessResponse "On-call override created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "On-call schedule not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules/{id}/overrides [post]
+func (h *OnCallHandler) CreateOverride(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule ID")
+	}
+
+	var req overrideRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	overrideUserID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallOverride, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to override this on-call schedule")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	schedule, err := h.Repo.GetOnCallScheduleByID(ctx, tx, teamID, scheduleID)
+	if err != nil {
+		zap.L().Error("Failed to get on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call schedule")
+	}
+
+	if schedule == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call schedule not found")
+	}
+
+	if err := h.ensureTeamMember(ctx, tx, teamID, overrideUserID); err != nil {
+		return err
+	}
+
+	overrideID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate override ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate override ID")
+	}
+
+	now := time.Now().UTC()
+	override := models.OnCallOverride{
+		ID:         overrideID,
+		ScheduleID: scheduleID,
+		UserID:     overrideUserID,
+		StartsAt:   req.StartsAt.UTC(),
+		EndsAt:     req.EndsAt.UTC(),
+		UpdatedAt:  now,
+		CreatedAt:  now,
+	}
+
+	if err := h.Repo.CreateOnCallOverride(ctx, tx, override); err != nil {
+		zap.L().Error("Failed to create on-call override", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create on-call override")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceOnCallOverride,
+		ResourceID:   override.ID,
+		After:        override,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("On-call override created successfully", override))
+}
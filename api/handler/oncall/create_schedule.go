@@ -0,0 +1,119 @@
+package oncall
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// CreateSchedule godoc
+// @Summary Create an on-call schedule
+// @Description Creates a rotating on-call schedule with one or more layers (owner/admin only)
+// @Tags on-call-schedules
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param request body scheduleRequest true "On-call schedule create request"
+// @Success 200 {object} response.SuccessResponse "On-call schedule created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules [post]
+func (h *OnCallHandler) CreateSchedule(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req scheduleRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallSchedule, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create on-call schedules for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	scheduleID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate schedule ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate schedule ID")
+	}
+
+	now := time.Now().UTC()
+	layers, err := h.buildLayers(ctx, tx, teamID, scheduleID, req.Layers, now)
+	if err != nil {
+		return err
+	}
+
+	schedule := models.OnCallSchedule{
+		ID:        scheduleID,
+		TeamID:    teamID,
+		Name:      req.Name,
+		Timezone:  req.Timezone,
+		Layers:    layers,
+		UpdatedAt: now,
+		CreatedAt: now,
+	}
+
+	if err := h.Repo.CreateOnCallSchedule(ctx, tx, schedule); err != nil {
+		zap.L().Error("Failed to create on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create on-call schedule")
+	}
+
+	if err := h.Repo.CreateOnCallLayers(ctx, tx, layers); err != nil {
+		zap.L().Error("Failed to create on-call layers", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create on-call layers")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceOnCallSchedule,
+		ResourceID:   schedule.ID,
+		After:        schedule,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("On-call schedule created successfully", newScheduleResponse(schedule, now)))
+}
@@ -0,0 +1,114 @@
+package oncall
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// DeleteOverride godoc
+// @Summary Delete an on-call override
+// @Description Removes an override from an on-call schedule (owner/admin only)
+// @Tags on-call-schedules
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Schedule ID"
+// @Param overrideID path string true "Override ID"
+// @Success 200 {object} response.SuccessResponse "On-call override deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "On-call override not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules/{id}/overrides/{overrideID} [delete]
+func (h *OnCallHandler) DeleteOverride(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule ID")
+	}
+
+	overrideID, err := strconv.ParseInt(c.Param("overrideID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid override ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallOverride, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this on-call override")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	schedule, err := h.Repo.GetOnCallScheduleByID(ctx, tx, teamID, scheduleID)
+	if err != nil {
+		zap.L().Error("Failed to get on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call schedule")
+	}
+
+	if schedule == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call override not found")
+	}
+
+	existing, err := h.Repo.GetOnCallOverrideByID(ctx, tx, scheduleID, overrideID)
+	if err != nil {
+		zap.L().Error("Failed to get on-call override", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call override")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call override not found")
+	}
+
+	if err := h.Repo.DeleteOnCallOverride(ctx, tx, scheduleID, overrideID); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "On-call override not found")
+		}
+
+		zap.L().Error("Failed to delete on-call override", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete on-call override")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceOnCallOverride,
+		ResourceID:   overrideID,
+		Before:       *existing,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("On-call override deleted successfully"))
+}
@@ -0,0 +1,105 @@
+package oncall
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// DeleteSchedule godoc
+// @Summary Delete an on-call schedule
+// @Description Deletes an on-call schedule with its layers and overrides (owner/admin only)
+// @Tags on-call-schedules
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} response.SuccessResponse "On-call schedule deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team or schedule ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "On-call schedule not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules/{id} [delete]
+func (h *OnCallHandler) DeleteSchedule(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallSchedule, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this on-call schedule")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	existing, err := h.Repo.GetOnCallScheduleByID(ctx, tx, teamID, scheduleID)
+	if err != nil {
+		zap.L().Error("Failed to get on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call schedule")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call schedule not found")
+	}
+
+	existingSchedules := []models.OnCallSchedule{*existing}
+	if err := h.attachRotations(ctx, tx, existingSchedules, time.Now().UTC()); err != nil {
+		zap.L().Error("Failed to load on-call rotations", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load on-call rotations")
+	}
+
+	if err := h.Repo.DeleteOnCallSchedule(ctx, tx, teamID, scheduleID); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "On-call schedule not found")
+		}
+
+		zap.L().Error("Failed to delete on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete on-call schedule")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceOnCallSchedule,
+		ResourceID:   scheduleID,
+		Before:       existingSchedules[0],
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("On-call schedule deleted successfully"))
+}
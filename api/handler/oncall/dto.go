@@ -0,0 +1,181 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	escalationcore "github.com/yorukot/knocker/core/escalation"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/id"
+	"go.uber.org/zap"
+)
+
+type scheduleRequest struct {
+	Name     string         `json:"name" validate:"required,min=1,max=255"`
+	Timezone string         `json:"timezone" validate:"required,timezone"`
+	Layers   []layerRequest `json:"layers" validate:"required,min=1,max=10,dive"`
+}
+
+type layerRequest struct {
+	Name            string       `json:"name" validate:"required,min=1,max=255"`
+	RotationSeconds int          `json:"rotation_seconds" validate:"required,min=3600,max=31536000"`
+	StartsAt        time.Time    `json:"starts_at" validate:"required"`
+	UserIDs         utils.IDList `json:"user_ids" validate:"required,min=1,max=100"`
+}
+
+type overrideRequest struct {
+	UserID   string    `json:"user_id" validate:"required,numeric"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+type scheduleResponse struct {
+	ID            string                  `json:"id"`
+	TeamID        string                  `json:"team_id"`
+	Name          string                  `json:"name"`
+	Timezone      string                  `json:"timezone"`
+	Layers        []layerResponse         `json:"layers"`
+	Overrides     []models.OnCallOverride `json:"overrides"`
+	OnCallUserIDs []string                `json:"on_call_user_ids"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+type layerResponse struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Position        int16     `json:"position"`
+	RotationSeconds int       `json:"rotation_seconds"`
+	StartsAt        time.Time `json:"starts_at"`
+	UserIDs         []string  `json:"user_ids"`
+}
+
+func newScheduleResponse(schedule models.OnCallSchedule, now time.Time) scheduleResponse {
+	layers := make([]layerResponse, len(schedule.Layers))
+	for i, layer := range schedule.Layers {
+		layers[i] = layerResponse{
+			ID:              strconv.FormatInt(layer.ID, 10),
+			Name:            layer.Name,
+			Position:        layer.Position,
+			RotationSeconds: layer.RotationSeconds,
+			StartsAt:        layer.StartsAt,
+			UserIDs:         formatIDs(layer.UserIDs),
+		}
+	}
+
+	overrides := schedule.Overrides
+	if overrides == nil {
+		overrides = []models.OnCallOverride{}
+	}
+
+	return scheduleResponse{
+		ID:            strconv.FormatInt(schedule.ID, 10),
+		TeamID:        strconv.FormatInt(schedule.TeamID, 10),
+		Name:          schedule.Name,
+		Timezone:      schedule.Timezone,
+		Layers:        layers,
+		Overrides:     overrides,
+		OnCallUserIDs: formatIDs(escalationcore.OnCallUserIDs(schedule, now)),
+		UpdatedAt:     schedule.UpdatedAt,
+		CreatedAt:     schedule.CreatedAt,
+	}
+}
+
+func formatIDs(ids []int64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.FormatInt(id, 10)
+	}
+	return result
+}
+
+// buildLayers converts request layers into models, checking every user is a member of the team.
+func (h *OnCallHandler) buildLayers(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64, layers []layerRequest, now time.Time) ([]models.OnCallLayer, error) {
+	result := make([]models.OnCallLayer, 0, len(layers))
+	for i, layer := range layers {
+		layerID, err := id.GetID()
+		if err != nil {
+			zap.L().Error("Failed to generate layer ID", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate layer ID")
+		}
+
+		userIDs := layer.UserIDs.Int64s()
+		for _, userID := range userIDs {
+			if err := h.ensureTeamMember(ctx, tx, teamID, userID); err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, models.OnCallLayer{
+			ID:              layerID,
+			ScheduleID:      scheduleID,
+			Name:            layer.Name,
+			Position:        int16(i + 1),
+			RotationSeconds: layer.RotationSeconds,
+			StartsAt:        layer.StartsAt.UTC(),
+			UserIDs:         userIDs,
+			UpdatedAt:       now,
+			CreatedAt:       now,
+		})
+	}
+
+	return result, nil
+}
+
+func (h *OnCallHandler) ensureTeamMember(ctx context.Context, tx pgx.Tx, teamID, userID int64) error {
+	member, err := h.Repo.GetTeamMemberByUserID(ctx, tx, teamID, userID)
+	if err != nil {
+		zap.L().Error("Failed to get team membership", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+	}
+
+	if member == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "On-call users must be members of the team")
+	}
+
+	return nil
+}
+
+// attachRotations loads layers and current overrides of the given schedules and attaches them in place.
+func (h *OnCallHandler) attachRotations(ctx context.Context, tx pgx.Tx, schedules []models.OnCallSchedule, now time.Time) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	scheduleIDs := make([]int64, len(schedules))
+	for i, schedule := range schedules {
+		scheduleIDs[i] = schedule.ID
+	}
+
+	layers, err := h.Repo.ListOnCallLayersByScheduleIDs(ctx, tx, scheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	overrides, err := h.Repo.ListOnCallOverridesByScheduleIDs(ctx, tx, scheduleIDs, now)
+	if err != nil {
+		return err
+	}
+
+	layersBySchedule := make(map[int64][]models.OnCallLayer, len(schedules))
+	for _, layer := range layers {
+		layersBySchedule[layer.ScheduleID] = append(layersBySchedule[layer.ScheduleID], layer)
+	}
+
+	overridesBySchedule := make(map[int64][]models.OnCallOverride, len(schedules))
+	for _, override := range overrides {
+		overridesBySchedule[override.ScheduleID] = append(overridesBySchedule[override.ScheduleID], override)
+	}
+
+	for i := range schedules {
+		schedules[i].Layers = layersBySchedule[schedules[i].ID]
+		schedules[i].Overrides = overridesBySchedule[schedules[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package oncall
+
+import "github.com/yorukot/knocker/repository"
+
+// OnCallHandler groups dependencies for on-call schedule endpoints.
+type OnCallHandler struct {
+	Repo repository.Repository
+}
@@ -0,0 +1,78 @@
+package oncall
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// ListSchedules godoc
+// @Summary List on-call schedules
+// @Description Lists on-call schedules with their layers, upcoming overrides and who is on call now
+// @Tags on-call-schedules
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse "On-call schedules retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules [get]
+func (h *OnCallHandler) ListSchedules(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallSchedule, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view on-call schedules for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	schedules, err := h.Repo.ListOnCallSchedulesByTeamID(ctx, tx, teamID)
+	if err != nil {
+		zap.L().Error("Failed to list on-call schedules", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list on-call schedules")
+	}
+
+	now := time.Now().UTC()
+	if err := h.attachRotations(ctx, tx, schedules, now); err != nil {
+		zap.L().Error("Failed to load on-call rotations", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load on-call rotations")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	responses := make([]scheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		responses[i] = newScheduleResponse(schedule, now)
+	}
+
+	return c.JSON(http.StatusOK, response.Success("On-call schedules retrieved successfully", responses))
+}
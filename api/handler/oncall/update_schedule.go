@@ -0,0 +1,151 @@
+package oncall
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// UpdateSchedule godoc
+// @Summary Update an on-call schedule
+// @Description Replaces an on-call schedule's name, timezone and layers; overrides are kept (owner/admin only)
+// @Tags on-call-schedules
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Schedule ID"
+// @Param request body scheduleRequest true "On-call schedule update request"
+// @Success 200 {object} response.SuccessResponse "On-call schedule updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "On-call schedule not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/on-call-schedules/{id} [put]
+func (h *OnCallHandler) UpdateSchedule(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule ID")
+	}
+
+	var req scheduleRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceOnCallSchedule, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update on-call schedules for this team")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	now := time.Now().UTC()
+
+	existing, err := h.Repo.GetOnCallScheduleByID(ctx, tx, teamID, scheduleID)
+	if err != nil {
+		zap.L().Error("Failed to get on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get on-call schedule")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call schedule not found")
+	}
+
+	existingSchedules := []models.OnCallSchedule{*existing}
+	if err := h.attachRotations(ctx, tx, existingSchedules, now); err != nil {
+		zap.L().Error("Failed to load on-call rotations", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load on-call rotations")
+	}
+
+	updated, err := h.Repo.UpdateOnCallSchedule(ctx, tx, models.OnCallSchedule{
+		ID:        scheduleID,
+		TeamID:    teamID,
+		Name:      req.Name,
+		Timezone:  req.Timezone,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		zap.L().Error("Failed to update on-call schedule", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update on-call schedule")
+	}
+
+	if updated == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "On-call schedule not found")
+	}
+
+	layers, err := h.buildLayers(ctx, tx, teamID, scheduleID, req.Layers, now)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.DeleteOnCallLayersByScheduleID(ctx, tx, scheduleID); err != nil {
+		zap.L().Error("Failed to delete on-call layers", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete on-call layers")
+	}
+
+	if err := h.Repo.CreateOnCallLayers(ctx, tx, layers); err != nil {
+		zap.L().Error("Failed to create on-call layers", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create on-call layers")
+	}
+
+	overrides, err := h.Repo.ListOnCallOverridesByScheduleIDs(ctx, tx, []int64{scheduleID}, now)
+	if err != nil {
+		zap.L().Error("Failed to list on-call overrides", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list on-call overrides")
+	}
+
+	updated.Layers = layers
+	updated.Overrides = overrides
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceOnCallSchedule,
+		ResourceID:   scheduleID,
+		Before:       existingSchedules[0],
+		After:        *updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("On-call schedule updated successfully", newScheduleResponse(*updated, now)))
+}
@@ -0,0 +1,223 @@
+package statuspage
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Visibility                                   |
+// +----------------------------------------------+
+
+// Errors for visitors who may not see a page. The frontend tells them apart by status code:
+// 401 asks for the page password or a sign in, 403 means the network is not allowed.
+var (
+	errStatusPagePasswordRequired = echo.NewHTTPError(http.StatusUnauthorized, "This status page is password protected")
+	errStatusPageSignInRequired   = echo.NewHTTPError(http.StatusUnauthorized, "Sign in to view this status page")
+	errStatusPageIPNotAllowed     = echo.NewHTTPError(http.StatusForbidden, "This status page is not available from your network")
+)
+
+type unlockStatusPageRequest struct {
+	Password string `json:"password" form:"password" validate:"required,max=128"`
+}
+
+// authorizeStatusPage checks that the visitor may see the page. It returns an echo error when not.
+// Team pages need AuthOptionalMiddleware in front of the route.
+func (h *Handler) authorizeStatusPage(c echo.Context, tx pgx.Tx, page models.StatusPage) error {
+	if page.Visibility != "" && page.Visibility != models.StatusPageVisibilityPublic {
+		c.Set(privateCacheKey, true)
+	}
+
+	switch page.Visibility {
+	case models.StatusPageVisibilityPassword:
+		if page.PasswordHash == nil {
+			return errStatusPagePasswordRequired
+		}
+		cookie, err := c.Cookie(statusPageAccessCookieName(page.ID))
+		if err != nil || !statuspagecore.VerifyAccessToken(cookie.Value, page.ID, *page.PasswordHash, time.Now()) {
+			return errStatusPagePasswordRequired
+		}
+		return nil
+
+	case models.StatusPageVisibilityIPAllowlist:
+		if !statuspagecore.IPAllowed(clientIP(c), page.AllowedIPRanges) {
+			return errStatusPageIPNotAllowed
+		}
+		return nil
+
+	case models.StatusPageVisibilityTeam:
+		userID, err := authutil.GetUserIDFromContext(c)
+		if err != nil || userID == nil {
+			return errStatusPageSignInRequired
+		}
+
+		member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, page.TeamID, *userID)
+		if err != nil {
+			zap.L().Error("Failed to get team membership", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		}
+		if member == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// clientIP returns the address an IP allowlist is checked against. Forwarded headers are only
+// believed when TRUSTED_PROXIES configured an extractor, otherwise anyone could claim any address.
+func clientIP(c echo.Context) string {
+	if c.Echo().IPExtractor != nil {
+		return c.RealIP()
+	}
+	return echo.ExtractIPDirect()(c.Request())
+}
+
+func statusPageAccessCookieName(statusPageID int64) string {
+	return models.CookieNameStatusPageAccess + formatID(statusPageID)
+}
+
+// statusPageAccessCookie is host-only with path /, so it works on the API host and on custom domains alike
+func statusPageAccessCookie(statusPageID int64, value string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     statusPageAccessCookieName(statusPageID),
+		Path:     "/",
+		Value:    value,
+		HttpOnly: true,
+		Secure:   config.Env().AppEnv == config.AppEnvProd,
+		Expires:  expiresAt,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// applyStatusPageVisibility sets who may see the page from the request. An empty visibility keeps
+// the existing one on update and means public on create; an empty password keeps the existing hash.
+func applyStatusPageVisibility(page *models.StatusPage, req statusPageUpsertRequest, existing *models.StatusPage) error {
+	page.Visibility = models.StatusPageVisibilityPublic
+	page.AllowedIPRanges = []string{}
+	if existing != nil {
+		page.Visibility = existing.Visibility
+		page.PasswordHash = existing.PasswordHash
+		if existing.AllowedIPRanges != nil {
+			page.AllowedIPRanges = existing.AllowedIPRanges
+		}
+	}
+
+	if req.Visibility != "" {
+		page.Visibility = req.Visibility
+	}
+
+	if req.Password != "" {
+		hash, err := encrypt.CreateArgon2idHash(req.Password)
+		if err != nil {
+			zap.L().Error("Failed to hash status page password", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to hash password")
+		}
+		page.PasswordHash = &hash
+	}
+
+	if req.AllowedIPRanges != nil {
+		ranges, err := statuspagecore.NormalizeIPRanges(req.AllowedIPRanges)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		page.AllowedIPRanges = ranges
+	}
+
+	switch page.Visibility {
+	case models.StatusPageVisibilityPassword:
+		if page.PasswordHash == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "password is required for password protected status pages")
+		}
+	case models.StatusPageVisibilityIPAllowlist:
+		if len(page.AllowedIPRanges) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "allowed_ip_ranges is required for IP restricted status pages")
+		}
+	}
+
+	return nil
+}
+
+// UnlockStatusPage godoc
+// @Summary Unlock a password protected status page
+// @Description Checks the password of a status page and sets a cookie that lets the visitor in for 24 hours. HTML form posts are redirected back to the page
+// @Tags status-pages
+// @Accept json
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Param request body unlockStatusPageRequest true "Status page password"
+// @Success 200 {object} response.SuccessResponse "Status page unlocked"
+// @Success 303 {string} string "Redirect back to the HTML page"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or page not password protected"
+// @Failure 401 {object} response.ErrorResponse "Invalid password"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 429 {object} response.ErrorResponse "Too many requests"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/unlock [post]
+func (h *Handler) UnlockStatusPage(c echo.Context) error {
+	var req unlockStatusPageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, c.Param("slug"))
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	if page.Visibility != models.StatusPageVisibilityPassword || page.PasswordHash == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Status page is not password protected")
+	}
+
+	match, err := encrypt.ComparePasswordAndHash(req.Password, *page.PasswordHash)
+	if err != nil {
+		zap.L().Error("Failed to compare status page password", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check password")
+	}
+	if !match {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid password")
+	}
+
+	expiresAt := time.Now().Add(statuspagecore.AccessTokenTTL)
+	c.SetCookie(statusPageAccessCookie(page.ID, statuspagecore.AccessToken(page.ID, *page.PasswordHash, expiresAt), expiresAt))
+
+	if isFormContentType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return c.Redirect(http.StatusSeeOther, "index.html")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Status page unlocked"))
+}
@@ -0,0 +1,166 @@
+package statuspage
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/encrypt"
+)
+
+// Argon2id is slow on purpose, so the hash is computed once for the package
+var passwordHash = sync.OnceValues(func() (string, error) {
+	return encrypt.CreateArgon2idHash("correct horse")
+})
+
+func newPasswordPage(t *testing.T) *models.StatusPage {
+	hash, err := passwordHash()
+	require.NoError(t, err)
+	return &models.StatusPage{ID: 5, TeamID: 9, Slug: "acme", Visibility: models.StatusPageVisibilityPassword, PasswordHash: &hash}
+}
+
+func newAccessRepo(page *models.StatusPage) *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageBySlug", mock.Anything, mock.Anything, "acme").Return(page, nil)
+	mockRepo.On("ListStatusPageImages", mock.Anything, mock.Anything, int64(5)).Return([]models.StatusPageImage{}, nil)
+	return mockRepo
+}
+
+func requireHTTPStatus(t *testing.T, err error, code int) {
+	t.Helper()
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, code, httpErr.Code)
+}
+
+func TestUnlockStatusPage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	page := newPasswordPage(t)
+	h := &Handler{Repo: newAccessRepo(page)}
+
+	form := url.Values{"password": {"correct horse"}}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/api/status-pages/acme/unlock", strings.NewReader(form.Encode()))
+	c.Request().Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.UnlockStatusPage(c))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	require.Equal(t, "index.html", rec.Header().Get(echo.HeaderLocation))
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, statusPageAccessCookieName(5), cookies[0].Name)
+	require.True(t, cookies[0].HttpOnly)
+
+	// The cookie opens the page, and the response must not land in shared caches
+	c, _ = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.Request().AddCookie(cookies[0])
+	require.NoError(t, h.authorizeStatusPage(c, nil, *page))
+	require.Equal(t, true, c.Get(privateCacheKey))
+}
+
+func TestUnlockStatusPage_WrongPassword(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newAccessRepo(newPasswordPage(t))}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/api/status-pages/acme/unlock", strings.NewReader(`{"password":"wrong guess"}`))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	requireHTTPStatus(t, h.UnlockStatusPage(c), http.StatusUnauthorized)
+	require.Empty(t, rec.Result().Cookies())
+}
+
+func TestGetPublicStatusPage_PasswordRequired(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newAccessRepo(newPasswordPage(t))}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	requireHTTPStatus(t, h.GetPublicStatusPage(c), http.StatusUnauthorized)
+}
+
+func TestGetPublicStatusPageHTML_PasswordForm(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newAccessRepo(newPasswordPage(t))}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme/index.html", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.GetPublicStatusPageHTML(c))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	require.Contains(t, rec.Body.String(), `action="unlock"`)
+}
+
+func TestAuthorizeStatusPage_IPAllowlist(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	page := models.StatusPage{ID: 5, Visibility: models.StatusPageVisibilityIPAllowlist, AllowedIPRanges: []string{"10.0.0.0/8"}}
+	h := &Handler{Repo: &repository.MockRepository{}}
+
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.Request().RemoteAddr = "10.1.2.3:5000"
+	require.NoError(t, h.authorizeStatusPage(c, nil, page))
+
+	// Forwarded headers are ignored unless trusted proxies are configured
+	c, _ = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.Request().RemoteAddr = "203.0.113.7:5000"
+	c.Request().Header.Set(echo.HeaderXForwardedFor, "10.1.2.3")
+	requireHTTPStatus(t, h.authorizeStatusPage(c, nil, page), http.StatusForbidden)
+}
+
+func TestAuthorizeStatusPage_Team(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	page := models.StatusPage{ID: 5, TeamID: 9, Visibility: models.StatusPageVisibilityTeam}
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(9), int64(123)).
+		Return(&models.TeamMember{TeamID: 9, UserID: 123}, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(9), int64(456)).
+		Return(nil, nil)
+	h := &Handler{Repo: mockRepo}
+
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	requireHTTPStatus(t, h.authorizeStatusPage(c, nil, page), http.StatusUnauthorized)
+
+	c, _ = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	testutil.Authenticate(c, 456)
+	requireHTTPStatus(t, h.authorizeStatusPage(c, nil, page), http.StatusNotFound)
+
+	c, _ = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	testutil.Authenticate(c, 123)
+	require.NoError(t, h.authorizeStatusPage(c, nil, page))
+}
+
+func TestApplyStatusPageVisibility(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	var page models.StatusPage
+	require.NoError(t, applyStatusPageVisibility(&page, statusPageUpsertRequest{}, nil))
+	require.Equal(t, models.StatusPageVisibilityPublic, page.Visibility)
+	require.NotNil(t, page.AllowedIPRanges)
+
+	err := applyStatusPageVisibility(&page, statusPageUpsertRequest{Visibility: models.StatusPageVisibilityPassword}, nil)
+	requireHTTPStatus(t, err, http.StatusBadRequest)
+
+	// An update without visibility fields keeps what the page had
+	existing := models.StatusPage{Visibility: models.StatusPageVisibilityIPAllowlist, AllowedIPRanges: []string{"10.0.0.0/8"}}
+	require.NoError(t, applyStatusPageVisibility(&page, statusPageUpsertRequest{}, &existing))
+	require.Equal(t, models.StatusPageVisibilityIPAllowlist, page.Visibility)
+	require.Equal(t, []string{"10.0.0.0/8"}, page.AllowedIPRanges)
+}
@@ -0,0 +1,32 @@
+package statuspage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// statusPageAuditSnapshot flattens a status page and its elements so audit diffs stay field-level.
+type statusPageAuditSnapshot struct {
+	models.StatusPage
+	Elements []statusPageElementResponse `json:"elements"`
+}
+
+// loadStatusPageAuditSnapshot reads the current state of a status page for the audit log.
+func (h *Handler) loadStatusPageAuditSnapshot(ctx context.Context, tx pgx.Tx, page models.StatusPage) (*statusPageAuditSnapshot, error) {
+	groups, err := h.Repo.ListStatusPageGroupsByStatusPageID(ctx, tx, page.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := h.Repo.ListStatusPageMonitorsByStatusPageID(ctx, tx, page.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statusPageAuditSnapshot{
+		StatusPage: page,
+		Elements:   buildStatusPageElementResponses(groups, monitors),
+	}, nil
+}
@@ -0,0 +1,342 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/badge"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Badges                                       |
+// +----------------------------------------------+
+
+// badgeKeyLength is the length of the random key that identifies a monitor's badges
+const badgeKeyLength = 24
+
+// badgeMaxAge is how long image proxies such as GitHub's camo may reuse a badge
+const badgeMaxAge = 5 * time.Minute
+
+// Uptime and latency badges cover the last days, 30 unless the days query asks otherwise
+const (
+	badgeDefaultDays = 30
+	badgeMaxDays     = 90
+)
+
+// badgeMaxLabelLength caps the label query so a badge cannot be turned into an arbitrary banner
+const badgeMaxLabelLength = 64
+
+// badgeFormat is how a badge is rendered, picked by the extension of the badge name
+type badgeFormat string
+
+const (
+	badgeFormatSVG  badgeFormat = "svg"
+	badgeFormatJSON badgeFormat = "json"
+)
+
+// parseBadgeName splits a badge name such as uptime.svg into its kind and format.
+func parseBadgeName(name string) (string, badgeFormat, bool) {
+	kind, ext, ok := strings.Cut(name, ".")
+	if !ok || kind == "" {
+		return "", "", false
+	}
+
+	switch badgeFormat(ext) {
+	case badgeFormatSVG, badgeFormatJSON:
+		return kind, badgeFormat(ext), true
+	default:
+		return "", "", false
+	}
+}
+
+// GetStatusPageBadge godoc
+// @Summary Get status page badge
+// @Description Returns the overall status of a public status page as a Shields.io style SVG badge, or as a Shields.io endpoint JSON with the .json extension
+// @Tags status-pages
+// @Produce image/svg+xml
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Param badge path string true "Badge name: status.svg or status.json"
+// @Param label query string false "Label shown on the left of the badge"
+// @Success 200 {string} string "Badge"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /badges/{slug}/{badge} [get]
+func (h *Handler) GetStatusPageBadge(c echo.Context) error {
+	kind, format, ok := parseBadgeName(c.Param("badge"))
+	if !ok || kind != "status" {
+		return echo.NewHTTPError(http.StatusNotFound, "Badge not found")
+	}
+
+	// The badge agrees with the page, overrides and maintenance included
+	resp, err := h.loadPublicStatusPage(c)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]models.ComponentStatus, 0, len(resp.Elements))
+	for _, element := range resp.Elements {
+		statuses = append(statuses, element.ComponentStatus)
+	}
+
+	b := badge.Badge{Label: "status", Message: "no data", Color: badge.ColorLightGrey}
+	if len(statuses) > 0 {
+		b = componentStatusBadge(groupComponentStatus(models.ComponentStatusOverride{}, statuses, time.Now()))
+	}
+
+	return writeBadge(c, b, format)
+}
+
+// GetMonitorBadge godoc
+// @Summary Get monitor badge
+// @Description Returns the status, uptime or median latency of a monitor that has badges enabled on a status page, as a Shields.io style SVG badge or a Shields.io endpoint JSON
+// @Tags status-pages
+// @Produce image/svg+xml
+// @Produce json
+// @Param key path string true "Badge key of the status page monitor"
+// @Param badge path string true "Badge name: status, uptime or latency, with a .svg or .json extension"
+// @Param days query int false "Days covered by uptime and latency badges (1-90, default 30)"
+// @Param label query string false "Label shown on the left of the badge"
+// @Success 200 {string} string "Badge"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} response.ErrorResponse "Invalid days"
+// @Failure 404 {object} response.ErrorResponse "Badge not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /badges/monitor/{key}/{badge} [get]
+func (h *Handler) GetMonitorBadge(c echo.Context) error {
+	kind, format, ok := parseBadgeName(c.Param("badge"))
+	if !ok || (kind != "status" && kind != "uptime" && kind != "latency") {
+		return echo.NewHTTPError(http.StatusNotFound, "Badge not found")
+	}
+
+	days := badgeDefaultDays
+	if raw := c.QueryParam("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > badgeMaxDays {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", badgeMaxDays))
+		}
+		days = parsed
+	}
+
+	key := c.Param("key")
+	if key == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "Badge not found")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	pageMonitor, err := h.Repo.GetStatusPageMonitorByBadgeKey(ctx, tx, key)
+	if err != nil {
+		zap.L().Error("Failed to get status page monitor by badge key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get badge")
+	}
+	// Badges are embedded through image proxies that cannot sign in, so only public pages serve them
+	if pageMonitor == nil || pageMonitor.Visibility != models.StatusPageVisibilityPublic {
+		return echo.NewHTTPError(http.StatusNotFound, "Badge not found")
+	}
+
+	monitorIDs := []int64{pageMonitor.MonitorID}
+	var b badge.Badge
+	switch kind {
+	case "status":
+		monitorByID, openPublicIncident, err := h.loadMonitorStatus(c, tx, pageMonitor.TeamID, monitorIDs)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		maintenances, err := h.Repo.ListUpcomingStatusPageMaintenances(ctx, tx, pageMonitor.StatusPageID, now)
+		if err != nil {
+			zap.L().Error("Failed to list status page maintenances", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list maintenances")
+		}
+		b = statusBadge(monitorComponentStatus(pageMonitor.ComponentStatusOverride, pageMonitor.MonitorID,
+			computeMonitorStatus(pageMonitor.MonitorID, monitorByID, openPublicIncident), maintenances, now))
+	default:
+		end := time.Now().UTC()
+		start := end.AddDate(0, 0, -days)
+		summaries, err := h.Repo.ListMonitorDailySummaryByMonitorIDs(ctx, tx, monitorIDs, start, end)
+		if err != nil {
+			zap.L().Error("Failed to list daily summaries", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list badge data")
+		}
+		if kind == "uptime" {
+			b = uptimeBadge(summaries, days)
+		} else {
+			b = latencyBadge(summaries, days)
+		}
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return writeBadge(c, b, format)
+}
+
+// loadMonitorStatus reads what computeMonitorStatus needs for the monitors.
+func (h *Handler) loadMonitorStatus(c echo.Context, tx pgx.Tx, teamID int64, monitorIDs []int64) (map[int64]models.Monitor, map[int64]bool, error) {
+	ctx := c.Request().Context()
+
+	monitors, err := h.Repo.ListMonitorsByIDs(ctx, tx, teamID, monitorIDs)
+	if err != nil {
+		zap.L().Error("Failed to list monitors by ids", zap.Error(err))
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list monitors")
+	}
+
+	monitorByID := make(map[int64]models.Monitor, len(monitors))
+	for _, monitor := range monitors {
+		monitorByID[monitor.ID] = monitor
+	}
+
+	incidents, err := h.Repo.ListPublicIncidentsByMonitorIDs(ctx, tx, monitorIDs)
+	if err != nil {
+		zap.L().Error("Failed to list public incidents", zap.Error(err))
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incidents")
+	}
+
+	openPublicIncident := make(map[int64]bool)
+	for _, incident := range incidents {
+		if incident.Status != models.IncidentStatusResolved {
+			openPublicIncident[incident.MonitorID] = true
+		}
+	}
+
+	return monitorByID, openPublicIncident, nil
+}
+
+// statusBadge shows the status of a monitor. Monitors are up or down unless the page says more.
+func statusBadge(status models.ComponentStatus) badge.Badge {
+	switch status {
+	case models.ComponentStatusOperational:
+		return badge.Badge{Label: "status", Message: "up", Color: badge.ColorBrightGreen}
+	case models.ComponentStatusMajorOutage:
+		return badge.Badge{Label: "status", Message: "down", Color: badge.ColorRed}
+	default:
+		return componentStatusBadge(status)
+	}
+}
+
+// componentStatusBadge shows the status of a page or component in the words of the status page.
+func componentStatusBadge(status models.ComponentStatus) badge.Badge {
+	b := badge.Badge{Label: "status"}
+	switch status {
+	case models.ComponentStatusUnderMaintenance:
+		b.Message, b.Color = "maintenance", badge.ColorBlue
+	case models.ComponentStatusDegradedPerformance:
+		b.Message, b.Color = "degraded performance", badge.ColorYellow
+	case models.ComponentStatusPartialOutage:
+		b.Message, b.Color = "partial outage", badge.ColorOrange
+	case models.ComponentStatusMajorOutage:
+		b.Message, b.Color = "major outage", badge.ColorRed
+	default:
+		b.Message, b.Color = "operational", badge.ColorBrightGreen
+	}
+	return b
+}
+
+// uptimeBadge shows the share of good checks over the days, colored like the Shields.io uptime badges.
+func uptimeBadge(summaries []models.MonitorDailySummary, days int) badge.Badge {
+	b := badge.Badge{Label: fmt.Sprintf("uptime %dd", days)}
+
+	var good, total int64
+	for _, summary := range summaries {
+		good += summary.GoodCount
+		total += summary.TotalCount
+	}
+	if total == 0 {
+		b.Message, b.Color = "no data", badge.ColorLightGrey
+		return b
+	}
+
+	uptime := percentage(good, total)
+	b.Message = strconv.FormatFloat(uptime, 'f', 2, 64) + "%"
+	switch {
+	case uptime >= 99.9:
+		b.Color = badge.ColorBrightGreen
+	case uptime >= 99:
+		b.Color = badge.ColorGreen
+	case uptime >= 97:
+		b.Color = badge.ColorYellowGreen
+	case uptime >= 95:
+		b.Color = badge.ColorYellow
+	case uptime >= 90:
+		b.Color = badge.ColorOrange
+	default:
+		b.Color = badge.ColorRed
+	}
+	return b
+}
+
+// latencyBadge shows the median response time over the days, weighting each day by its check count.
+func latencyBadge(summaries []models.MonitorDailySummary, days int) badge.Badge {
+	b := badge.Badge{Label: fmt.Sprintf("latency %dd", days)}
+
+	var weighted float64
+	var total int64
+	for _, summary := range summaries {
+		if summary.P50Ms <= 0 {
+			continue
+		}
+		weighted += summary.P50Ms * float64(summary.TotalCount)
+		total += summary.TotalCount
+	}
+	if total == 0 {
+		b.Message, b.Color = "no data", badge.ColorLightGrey
+		return b
+	}
+
+	latency := weighted / float64(total)
+	b.Message = strconv.FormatFloat(latency, 'f', 0, 64) + "ms"
+	switch {
+	case latency < 200:
+		b.Color = badge.ColorBrightGreen
+	case latency < 500:
+		b.Color = badge.ColorGreen
+	case latency < 1000:
+		b.Color = badge.ColorYellow
+	case latency < 2000:
+		b.Color = badge.ColorOrange
+	default:
+		b.Color = badge.ColorRed
+	}
+	return b
+}
+
+// writeBadge renders the badge in the format asked for, with the label query applied.
+func writeBadge(c echo.Context, b badge.Badge, format badgeFormat) error {
+	if label := strings.TrimSpace(c.QueryParam("label")); label != "" {
+		if runes := []rune(label); len(runes) > badgeMaxLabelLength {
+			label = string(runes[:badgeMaxLabelLength])
+		}
+		b.Label = label
+	}
+
+	if format == badgeFormatJSON {
+		body, err := json.Marshal(b.Endpoint())
+		if err != nil {
+			zap.L().Error("Failed to marshal badge", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render badge")
+		}
+		return writeCacheable(c, echo.MIMEApplicationJSON, body, time.Time{}, badgeMaxAge)
+	}
+
+	return writeCacheable(c, "image/svg+xml; charset=utf-8", b.SVG(), time.Time{}, badgeMaxAge)
+}
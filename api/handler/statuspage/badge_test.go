@@ -0,0 +1,130 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/badge"
+)
+
+func TestGetStatusPageBadge_PartialOutage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageBySlug", mock.Anything, mock.Anything, "acme").
+		Return(&models.StatusPage{ID: 5, TeamID: 9, Slug: "acme", Visibility: models.StatusPageVisibilityPublic}, nil)
+	mockRepo.On("ListStatusPageImages", mock.Anything, mock.Anything, int64(5)).Return([]models.StatusPageImage{}, nil)
+	mockRepo.On("ListStatusPageGroupsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageGroup{}, nil)
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageMonitor{{ID: 1, MonitorID: 42}, {ID: 2, MonitorID: 43}}, nil)
+	mockRepo.On("ListMonitorsByIDs", mock.Anything, mock.Anything, int64(9), []int64{42, 43}).
+		Return([]models.Monitor{{ID: 42, Status: models.MonitorStatusUp}, {ID: 43, Status: models.MonitorStatusDown}}, nil)
+	mockRepo.On("ListPublicIncidentsByMonitorIDs", mock.Anything, mock.Anything, []int64{42, 43}).
+		Return([]models.IncidentWithMonitorID{}, nil)
+	mockRepo.On("ListUpcomingStatusPageMaintenances", mock.Anything, mock.Anything, int64(5), mock.Anything).
+		Return([]models.StatusPageMaintenance{}, nil)
+	mockRepo.On("ListMonitorDailySummaryByMonitorIDs", mock.Anything, mock.Anything, []int64{42, 43}, mock.Anything, mock.Anything).
+		Return([]models.MonitorDailySummary{}, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/badges/acme/status.svg", nil)
+	c.SetParamNames("slug", "badge")
+	c.SetParamValues("acme", "status.svg")
+
+	require.NoError(t, h.GetStatusPageBadge(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "image/svg+xml")
+	require.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+	require.Empty(t, rec.Header().Get("Last-Modified"))
+	require.Contains(t, rec.Body.String(), "status: partial outage")
+}
+
+func TestGetMonitorBadge_UptimeJSON(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageMonitorByBadgeKey", mock.Anything, mock.Anything, "k3y").
+		Return(&models.StatusPageBadgeMonitor{StatusPageMonitor: models.StatusPageMonitor{MonitorID: 42}, TeamID: 9, Visibility: models.StatusPageVisibilityPublic}, nil)
+	mockRepo.On("ListMonitorDailySummaryByMonitorIDs", mock.Anything, mock.Anything, []int64{42}, mock.Anything, mock.Anything).
+		Return([]models.MonitorDailySummary{{MonitorID: 42, TotalCount: 1000, GoodCount: 995}}, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/badges/monitor/k3y/uptime.json?days=7", nil)
+	c.SetParamNames("key", "badge")
+	c.SetParamValues("k3y", "uptime.json")
+
+	require.NoError(t, h.GetMonitorBadge(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var endpoint badge.Endpoint
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &endpoint))
+	require.Equal(t, badge.Endpoint{SchemaVersion: 1, Label: "uptime 7d", Message: "99.50%", Color: badge.ColorGreen}, endpoint)
+}
+
+func TestLatencyBadge(t *testing.T) {
+	summaries := []models.MonitorDailySummary{
+		{TotalCount: 300, P50Ms: 100},
+		{TotalCount: 100, P50Ms: 500},
+		{TotalCount: 50},
+	}
+
+	require.Equal(t, badge.Badge{Label: "latency 30d", Message: "200ms", Color: badge.ColorGreen}, latencyBadge(summaries, 30))
+	require.Equal(t, "no data", latencyBadge(nil, 30).Message)
+}
+
+func TestGetMonitorBadge_UnknownKey(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageMonitorByBadgeKey", mock.Anything, mock.Anything, "missing").
+		Return(nil, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/badges/monitor/missing/status.svg", nil)
+	c.SetParamNames("key", "badge")
+	c.SetParamValues("missing", "status.svg")
+
+	err := h.GetMonitorBadge(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestGetMonitorBadge_RestrictedPage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageMonitorByBadgeKey", mock.Anything, mock.Anything, "k3y").
+		Return(&models.StatusPageBadgeMonitor{StatusPageMonitor: models.StatusPageMonitor{MonitorID: 42}, TeamID: 9, Visibility: models.StatusPageVisibilityTeam}, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/badges/monitor/k3y/status.svg", nil)
+	c.SetParamNames("key", "badge")
+	c.SetParamValues("k3y", "status.svg")
+
+	err := h.GetMonitorBadge(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestGetMonitorBadge_InvalidDays(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: testutil.NewMockRepo()}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/badges/monitor/k3y/uptime.svg?days=365", nil)
+	c.SetParamNames("key", "badge")
+	c.SetParamValues("k3y", "uptime.svg")
+
+	err := h.GetMonitorBadge(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
@@ -33,14 +33,21 @@ func isFormContentType(contentType string) bool {
 
 func parseStatusPageUpsertForm(values url.Values) (statusPageUpsertRequest, error) {
 	req := statusPageUpsertRequest{
-		Title: firstNonEmpty(values, "name", "title"),
-		Slug:  firstNonEmpty(values, "slug"),
+		Title:      firstNonEmpty(values, "name", "title"),
+		Slug:       firstNonEmpty(values, "slug"),
+		Visibility: models.StatusPageVisibility(firstNonEmpty(values, "visibility")),
+		Password:   firstNonEmpty(values, "password"),
+	}
+	if ranges, ok := values["allowed_ip_ranges"]; ok {
+		req.AllowedIPRanges = ranges
 	}
 
 	elementInputs := make(map[int]*statusPageElementInput)
 	elementMonitors := make(map[int]map[int]*statusPageMonitorInput)
 	groupInputs := make(map[int]*statusPageGroupInput)
 	monitorInputs := make(map[int]*statusPageMonitorInput)
+	var branding *statusPageBrandingInput
+	brandingLinks := make(map[int]*statusPageLinkInput)
 
 	for key, vals := range values {
 		if len(vals) == 0 {
@@ -58,12 +65,21 @@ func parseStatusPageUpsertForm(values url.Values) (statusPageUpsertRequest, erro
 			parseGroupFormField(groupInputs, key, value)
 		case strings.HasPrefix(key, "monitors."):
 			parseMonitorFormField(monitorInputs, key, value)
+		case strings.HasPrefix(key, "branding."):
+			if branding == nil {
+				branding = &statusPageBrandingInput{}
+			}
+			parseBrandingFormField(branding, brandingLinks, key, value)
 		}
 	}
 
 	req.Elements = buildElementInputs(elementInputs, elementMonitors)
 	req.Groups = buildGroupInputs(groupInputs)
 	req.Monitors = buildMonitorInputs(monitorInputs)
+	if branding != nil {
+		branding.HeaderLinks = buildLinkInputs(brandingLinks)
+		req.Branding = branding
+	}
 
 	return req, nil
 }
@@ -133,6 +149,49 @@ func parseMonitorFormField(monitors map[int]*statusPageMonitorInput, key string,
 	setMonitorField(monitor, parts[2], value)
 }
 
+func parseBrandingFormField(branding *statusPageBrandingInput, links map[int]*statusPageLinkInput, key string, value string) {
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 {
+		return
+	}
+
+	switch parts[1] {
+	case "brandColor", "brand_color":
+		branding.BrandColor = value
+	case "backgroundColor", "background_color":
+		branding.BackgroundColor = value
+	case "textColor", "text_color":
+		branding.TextColor = value
+	case "footerMarkdown", "footer_markdown":
+		branding.FooterMarkdown = value
+	case "aboutMarkdown", "about_markdown":
+		branding.AboutMarkdown = value
+	case "timezone":
+		branding.Timezone = value
+	case "language":
+		branding.Language = value
+	case "headerLinks", "header_links":
+		if len(parts) < 4 {
+			return
+		}
+		linkIndex, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return
+		}
+		link, ok := links[linkIndex]
+		if !ok {
+			link = &statusPageLinkInput{}
+			links[linkIndex] = link
+		}
+		switch parts[3] {
+		case "label":
+			link.Label = value
+		case "url":
+			link.URL = value
+		}
+	}
+}
+
 func ensureElementInput(elements map[int]*statusPageElementInput, index int) *statusPageElementInput {
 	element, ok := elements[index]
 	if !ok {
@@ -198,6 +257,8 @@ func setElementField(element *statusPageElementInput, field string, value string
 		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
 			element.MonitorID = &parsed
 		}
+	case "badge":
+		element.Badge = parseBool(value)
 	}
 }
 
@@ -240,6 +301,8 @@ func setMonitorField(monitor *statusPageMonitorInput, field string, value string
 		if parsed, err := strconv.Atoi(value); err == nil {
 			monitor.SortOrder = parsed
 		}
+	case "badge":
+		monitor.Badge = parseBool(value)
 	}
 }
 
@@ -301,6 +364,20 @@ func buildMonitorInputs(monitors map[int]*statusPageMonitorInput) []statusPageMo
 	return result
 }
 
+func buildLinkInputs(links map[int]*statusPageLinkInput) []statusPageLinkInput {
+	indices := make([]int, 0, len(links))
+	for index := range links {
+		indices = append(indices, index)
+	}
+	sortInts(indices)
+
+	result := make([]statusPageLinkInput, 0, len(indices))
+	for _, index := range indices {
+		result = append(result, *links[index])
+	}
+	return result
+}
+
 func sortInts(values []int) {
 	if len(values) < 2 {
 		return
@@ -0,0 +1,112 @@
+package statuspage
+
+import (
+	"net/http"
+	"strings"
+	// Time zone names are validated and shown without relying on the host's zoneinfo
+	_ "time/tzdata"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+)
+
+// +----------------------------------------------+
+// | Branding                                     |
+// +----------------------------------------------+
+
+// Defaults of a page whose branding was never set
+const (
+	defaultStatusPageTimezone = "UTC"
+	defaultStatusPageLanguage = "en"
+)
+
+type statusPageLinkInput struct {
+	Label string `json:"label" validate:"required,min=1,max=50"`
+	URL   string `json:"url" validate:"required,http_url,max=2048"`
+}
+
+// statusPageBrandingInput replaces the whole branding of a page; empty fields fall back to the defaults
+type statusPageBrandingInput struct {
+	BrandColor      string                `json:"brand_color,omitempty" validate:"omitempty,hexcolor" example:"#1c7ed6"`
+	BackgroundColor string                `json:"background_color,omitempty" validate:"omitempty,hexcolor"`
+	TextColor       string                `json:"text_color,omitempty" validate:"omitempty,hexcolor"`
+	HeaderLinks     []statusPageLinkInput `json:"header_links" validate:"max=10,dive"`
+	FooterMarkdown  string                `json:"footer_markdown" validate:"max=10000"`
+	AboutMarkdown   string                `json:"about_markdown" validate:"max=20000"`
+	Timezone        string                `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Europe/Berlin"`
+	Language        string                `json:"language,omitempty" validate:"omitempty,max=10" example:"de"`
+}
+
+// applyStatusPageBranding sets the branding of the page from the request. A request without
+// branding keeps the existing one on update and gets the defaults on create.
+func applyStatusPageBranding(page *models.StatusPage, req statusPageUpsertRequest, existing *models.StatusPage) error {
+	page.StatusPageBranding = models.StatusPageBranding{
+		HeaderLinks: []models.StatusPageLink{},
+		Timezone:    defaultStatusPageTimezone,
+		Language:    defaultStatusPageLanguage,
+	}
+	if existing != nil {
+		page.StatusPageBranding = existing.StatusPageBranding
+		if page.HeaderLinks == nil {
+			page.HeaderLinks = []models.StatusPageLink{}
+		}
+	}
+
+	input := req.Branding
+	if input == nil {
+		return nil
+	}
+
+	if input.Language != "" && !supportedStatusPageLanguage(input.Language) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Unsupported language")
+	}
+
+	links := make([]models.StatusPageLink, 0, len(input.HeaderLinks))
+	for _, link := range input.HeaderLinks {
+		links = append(links, models.StatusPageLink{Label: strings.TrimSpace(link.Label), URL: link.URL})
+	}
+
+	page.StatusPageBranding = models.StatusPageBranding{
+		BrandColor:      normalizeHexColor(input.BrandColor),
+		BackgroundColor: normalizeHexColor(input.BackgroundColor),
+		TextColor:       normalizeHexColor(input.TextColor),
+		HeaderLinks:     links,
+		FooterMarkdown:  strings.TrimSpace(input.FooterMarkdown),
+		AboutMarkdown:   strings.TrimSpace(input.AboutMarkdown),
+		Timezone:        defaultStatusPageTimezone,
+		Language:        defaultStatusPageLanguage,
+	}
+	if input.Timezone != "" {
+		page.Timezone = input.Timezone
+	}
+	if input.Language != "" {
+		page.Language = input.Language
+	}
+
+	return nil
+}
+
+// normalizeHexColor lowercases a validated color and turns an empty one into nil
+func normalizeHexColor(color string) *string {
+	if color == "" {
+		return nil
+	}
+	color = strings.ToLower(color)
+	return &color
+}
+
+// applyPublicBranding adds what the public page needs beyond the page settings. It is applied after
+// the cache, like the page itself, so branding changes show at once.
+func applyPublicBranding(resp *publicStatusPageResponse, images []models.StatusPageImage) {
+	resp.images = images
+	resp.LogoURL, resp.FaviconURL = "", ""
+	for _, image := range images {
+		switch image.Kind {
+		case models.StatusPageImageKindLogo:
+			resp.LogoURL = statusPageImageURL(resp.StatusPage.Slug, image)
+		case models.StatusPageImageKindFavicon:
+			resp.FaviconURL = statusPageImageURL(resp.StatusPage.Slug, image)
+		}
+	}
+	resp.Strings = statusPageStrings(resp.StatusPage.Language)
+}
@@ -0,0 +1,65 @@
+package statuspage
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestApplyStatusPageBranding(t *testing.T) {
+	var page models.StatusPage
+	require.NoError(t, applyStatusPageBranding(&page, statusPageUpsertRequest{}, nil))
+	require.Equal(t, "UTC", page.Timezone)
+	require.Equal(t, "en", page.Language)
+	require.NotNil(t, page.HeaderLinks)
+
+	brand := "#1c7ed6"
+	existing := models.StatusPage{StatusPageBranding: models.StatusPageBranding{BrandColor: &brand, Timezone: "Europe/Berlin", Language: "de"}}
+	page = models.StatusPage{}
+	require.NoError(t, applyStatusPageBranding(&page, statusPageUpsertRequest{}, &existing))
+	require.Equal(t, existing.Timezone, page.Timezone, "branding is kept when the request has none")
+	require.Equal(t, &brand, page.BrandColor)
+
+	req := statusPageUpsertRequest{Branding: &statusPageBrandingInput{
+		BrandColor:     "#FF0000",
+		HeaderLinks:    []statusPageLinkInput{{Label: " Docs ", URL: "https://docs.acme.com"}},
+		FooterMarkdown: " © Acme ",
+		Language:       "fr",
+	}}
+	require.NoError(t, applyStatusPageBranding(&page, req, &existing))
+	require.Equal(t, "#ff0000", *page.BrandColor)
+	require.Nil(t, page.TextColor)
+	require.Equal(t, []models.StatusPageLink{{Label: "Docs", URL: "https://docs.acme.com"}}, page.HeaderLinks)
+	require.Equal(t, "© Acme", page.FooterMarkdown)
+	require.Equal(t, "UTC", page.Timezone, "an omitted time zone falls back to the default")
+	require.Equal(t, "fr", page.Language)
+
+	req.Branding.Language = "xx"
+	requireHTTPStatus(t, applyStatusPageBranding(&page, req, nil), http.StatusBadRequest)
+}
+
+func TestStatusPageStrings(t *testing.T) {
+	for language := range statusPageTranslations {
+		require.Len(t, statusPageStrings(language), len(statusPageTranslations[defaultStatusPageLanguage]), language)
+	}
+	require.Equal(t, "Alle Systeme betriebsbereit", statusPageStrings("de")["all_systems_operational"])
+	require.Equal(t, "All systems operational", statusPageStrings("xx")["all_systems_operational"])
+	require.Equal(t, "99.50% uptime over 90 days", fillPlaceholders(statusPageStrings("en")["uptime_90_days"], "uptime", "99.50"))
+}
+
+func TestApplyPublicBranding(t *testing.T) {
+	resp := publicStatusPageResponse{
+		StatusPage: models.StatusPage{Slug: "acme", StatusPageBranding: models.StatusPageBranding{Language: "de"}},
+		LogoURL:    "/api/status-pages/acme/images/logo?v=1",
+	}
+	applyPublicBranding(&resp, []models.StatusPageImage{
+		{Kind: models.StatusPageImageKindFavicon, UpdatedAt: time.Unix(1700000000, 0)},
+	})
+
+	require.Empty(t, resp.LogoURL, "a removed logo is not served from the cache")
+	require.Equal(t, "/api/status-pages/acme/images/favicon?v=1700000000", resp.FaviconURL)
+	require.Equal(t, "Komponenten", resp.Strings["components"])
+}
@@ -0,0 +1,63 @@
+package statuspage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// privateCacheKey marks a response as private in the echo context. authorizeStatusPage sets it for
+// restricted pages, so shared caches and proxies never hand one visitor's copy to another.
+const privateCacheKey = "status_page_private_cache"
+
+// writeCacheable sends a cacheable response with an ETag, and a Last-Modified when lastModified is set.
+// Clients holding the current copy get a 304 without the body.
+func writeCacheable(c echo.Context, contentType string, body []byte, lastModified time.Time, maxAge time.Duration) error {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	scope := "public"
+	if private, _ := c.Get(privateCacheKey).(bool); private {
+		scope = "private"
+	}
+	header.Set("Cache-Control", scope+", max-age="+strconv.Itoa(int(maxAge.Seconds())))
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// notModified evaluates the conditional request headers. If-None-Match wins over
+// If-Modified-Since when both are sent, as RFC 9110 asks.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := r.Header.Get("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(since); err == nil {
+			return !lastModified.After(t)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,573 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Statuspage.io compatible API                 |
+// +----------------------------------------------+
+
+// The public v2 API of Atlassian Statuspage, so tools built for it can read our pages unchanged.
+// Groups and monitors become components, public incidents become incidents.
+
+// compatIncidentLimit caps incidents.json like Statuspage does
+const compatIncidentLimit = 50
+
+type compatPage struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	TimeZone  string    `json:"time_zone"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type compatStatus struct {
+	Indicator   string `json:"indicator"` // none, minor, major, critical or maintenance
+	Description string `json:"description"`
+}
+
+type compatComponent struct {
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	Status             models.ComponentStatus `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	Position           int                    `json:"position"`
+	Description        *string                `json:"description"`
+	Showcase           bool                   `json:"showcase"`
+	StartDate          *string                `json:"start_date"`
+	GroupID            *string                `json:"group_id"`
+	PageID             string                 `json:"page_id"`
+	Group              bool                   `json:"group"`
+	OnlyShowIfDegraded bool                   `json:"only_show_if_degraded"`
+	Components         []string               `json:"components,omitempty"` // members of a group
+}
+
+type compatIncidentUpdate struct {
+	ID                 string    `json:"id"`
+	Status             string    `json:"status"`
+	Body               string    `json:"body"`
+	IncidentID         string    `json:"incident_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	DisplayAt          time.Time `json:"display_at"`
+	AffectedComponents []string  `json:"affected_components"`
+}
+
+// compatIncident is an incident or, with the maintenance fields set, scheduled maintenance
+type compatIncident struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Status          string                 `json:"status"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	MonitoringAt    *time.Time             `json:"monitoring_at"`
+	ResolvedAt      *time.Time             `json:"resolved_at"`
+	Impact          string                 `json:"impact"`
+	Shortlink       string                 `json:"shortlink"`
+	StartedAt       time.Time              `json:"started_at"`
+	PageID          string                 `json:"page_id"`
+	IncidentUpdates []compatIncidentUpdate `json:"incident_updates"`
+	Components      []compatComponent      `json:"components"`
+	ScheduledFor    *time.Time             `json:"scheduled_for,omitempty"`
+	ScheduledUntil  *time.Time             `json:"scheduled_until,omitempty"`
+}
+
+// compatStatusPage holds everything the endpoints serve; each picks its own fields
+type compatStatusPage struct {
+	Page                  compatPage
+	Status                compatStatus
+	Components            []compatComponent
+	Incidents             []compatIncident // newest first
+	ScheduledMaintenances []compatIncident
+}
+
+type compatSummaryResponse struct {
+	Page                  compatPage        `json:"page"`
+	Components            []compatComponent `json:"components"`
+	Incidents             []compatIncident  `json:"incidents"`
+	ScheduledMaintenances []compatIncident  `json:"scheduled_maintenances"`
+	Status                compatStatus      `json:"status"`
+}
+
+type compatStatusResponse struct {
+	Page   compatPage   `json:"page"`
+	Status compatStatus `json:"status"`
+}
+
+type compatComponentsResponse struct {
+	Page       compatPage        `json:"page"`
+	Components []compatComponent `json:"components"`
+}
+
+type compatIncidentsResponse struct {
+	Page      compatPage       `json:"page"`
+	Incidents []compatIncident `json:"incidents"`
+}
+
+type compatScheduledMaintenancesResponse struct {
+	Page                  compatPage       `json:"page"`
+	ScheduledMaintenances []compatIncident `json:"scheduled_maintenances"`
+}
+
+// compatIndicators are the Statuspage indicators of the overall component status
+var compatIndicators = map[models.ComponentStatus]compatStatus{
+	models.ComponentStatusOperational:         {Indicator: "none", Description: "All Systems Operational"},
+	models.ComponentStatusUnderMaintenance:    {Indicator: "maintenance", Description: "Service Under Maintenance"},
+	models.ComponentStatusDegradedPerformance: {Indicator: "minor", Description: "Minor Service Outage"},
+	models.ComponentStatusPartialOutage:       {Indicator: "major", Description: "Partial System Outage"},
+	models.ComponentStatusMajorOutage:         {Indicator: "critical", Description: "Major Service Outage"},
+}
+
+// compatIndicatorRank orders indicators from best to worst
+var compatIndicatorRank = map[string]int{"none": 0, "maintenance": 1, "minor": 2, "major": 3, "critical": 4}
+
+// GetStatusPageCompatSummary godoc
+// @Summary Get status page summary (Statuspage.io format)
+// @Description Returns the status, components, unresolved incidents and scheduled maintenance of a status page in the Statuspage.io v2 format
+// @Tags status-pages
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {object} compatSummaryResponse "Summary"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/api/v2/summary.json [get]
+func (h *Handler) GetStatusPageCompatSummary(c echo.Context) error {
+	page, err := h.loadCompatStatusPage(c, true)
+	if err != nil {
+		return err
+	}
+
+	unresolved := make([]compatIncident, 0)
+	for _, incident := range page.Incidents {
+		if incident.Status != "resolved" {
+			unresolved = append(unresolved, incident)
+		}
+	}
+
+	return writeCompat(c, compatSummaryResponse{
+		Page:                  page.Page,
+		Components:            page.Components,
+		Incidents:             unresolved,
+		ScheduledMaintenances: page.ScheduledMaintenances,
+		Status:                page.Status,
+	})
+}
+
+// GetStatusPageCompatStatus godoc
+// @Summary Get status page rollup (Statuspage.io format)
+// @Description Returns the overall status indicator of a status page in the Statuspage.io v2 format
+// @Tags status-pages
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {object} compatStatusResponse "Status"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/api/v2/status.json [get]
+func (h *Handler) GetStatusPageCompatStatus(c echo.Context) error {
+	page, err := h.loadCompatStatusPage(c, false)
+	if err != nil {
+		return err
+	}
+
+	return writeCompat(c, compatStatusResponse{Page: page.Page, Status: page.Status})
+}
+
+// GetStatusPageCompatComponents godoc
+// @Summary Get status page components (Statuspage.io format)
+// @Description Returns the groups and monitors of a status page as components in the Statuspage.io v2 format
+// @Tags status-pages
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {object} compatComponentsResponse "Components"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/api/v2/components.json [get]
+func (h *Handler) GetStatusPageCompatComponents(c echo.Context) error {
+	page, err := h.loadCompatStatusPage(c, false)
+	if err != nil {
+		return err
+	}
+
+	return writeCompat(c, compatComponentsResponse{Page: page.Page, Components: page.Components})
+}
+
+// GetStatusPageCompatIncidents godoc
+// @Summary Get status page incidents (Statuspage.io format)
+// @Description Returns the 50 latest public incidents of a status page and their updates in the Statuspage.io v2 format
+// @Tags status-pages
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {object} compatIncidentsResponse "Incidents"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/api/v2/incidents.json [get]
+func (h *Handler) GetStatusPageCompatIncidents(c echo.Context) error {
+	page, err := h.loadCompatStatusPage(c, true)
+	if err != nil {
+		return err
+	}
+
+	return writeCompat(c, compatIncidentsResponse{Page: page.Page, Incidents: page.Incidents})
+}
+
+// GetStatusPageCompatScheduledMaintenances godoc
+// @Summary Get status page scheduled maintenance (Statuspage.io format)
+// @Description Returns the upcoming and in progress maintenance of a status page in the Statuspage.io v2 format
+// @Tags status-pages
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {object} compatScheduledMaintenancesResponse "Scheduled maintenance"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/api/v2/scheduled-maintenances.json [get]
+func (h *Handler) GetStatusPageCompatScheduledMaintenances(c echo.Context) error {
+	page, err := h.loadCompatStatusPage(c, false)
+	if err != nil {
+		return err
+	}
+
+	return writeCompat(c, compatScheduledMaintenancesResponse{Page: page.Page, ScheduledMaintenances: page.ScheduledMaintenances})
+}
+
+// loadCompatStatusPage reads the public status page of the slug route in the Statuspage format.
+// Incident updates are only read for the endpoints that show them.
+func (h *Handler) loadCompatStatusPage(c echo.Context, withUpdates bool) (*compatStatusPage, error) {
+	resp, err := h.loadPublicStatusPage(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open pages are meant to be read by dashboards on any site; restricted ones stay same-origin.
+	// Our own frontend keeps the origin the CORS middleware already allowed.
+	header := c.Response().Header()
+	if resp.StatusPage.Visibility == models.StatusPageVisibilityPublic && header.Get(echo.HeaderAccessControlAllowOrigin) == "" {
+		header.Set(echo.HeaderAccessControlAllowOrigin, "*")
+	}
+
+	var events []models.EventTimeline
+	if withUpdates && len(resp.Incidents) > 0 {
+		ctx := c.Request().Context()
+
+		incidentIDs := make([]int64, 0, len(resp.Incidents))
+		for _, incident := range resp.Incidents {
+			if !slices.Contains(incidentIDs, incident.ID) {
+				incidentIDs = append(incidentIDs, incident.ID)
+			}
+			if len(incidentIDs) == compatIncidentLimit {
+				break
+			}
+		}
+
+		tx, err := h.Repo.StartTransaction(ctx)
+		if err != nil {
+			zap.L().Error("Failed to begin transaction", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+		}
+		defer h.Repo.DeferRollback(tx, ctx)
+
+		events, err = h.Repo.ListEventTimelinesByIncidentIDs(ctx, tx, incidentIDs)
+		if err != nil {
+			zap.L().Error("Failed to list incident events", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incident events")
+		}
+
+		if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		}
+	}
+
+	page := buildCompatStatusPage(*resp, events, time.Now())
+	return &page, nil
+}
+
+// buildCompatStatusPage maps the public response onto the Statuspage schema.
+func buildCompatStatusPage(resp publicStatusPageResponse, events []models.EventTimeline, now time.Time) compatStatusPage {
+	page := resp.StatusPage
+	pageID := formatID(page.ID)
+	shortlink := statuspagecore.PageURL(page.Slug)
+
+	result := compatStatusPage{
+		Page: compatPage{
+			ID:        pageID,
+			Name:      page.Title,
+			URL:       shortlink,
+			TimeZone:  page.Timezone,
+			UpdatedAt: page.UpdatedAt,
+		},
+		Components:            make([]compatComponent, 0, len(resp.Elements)),
+		Incidents:             make([]compatIncident, 0),
+		ScheduledMaintenances: make([]compatIncident, 0, len(resp.Maintenances)),
+	}
+	if result.Page.TimeZone == "" {
+		result.Page.TimeZone = defaultStatusPageTimezone
+	}
+
+	// Elements carry no timestamps of their own, so components share those of the page
+	newComponent := func(id, name string, status models.ComponentStatus, elementType models.StatusPageElementType) compatComponent {
+		return compatComponent{
+			ID:        id,
+			Name:      name,
+			Status:    status,
+			CreatedAt: page.CreatedAt,
+			UpdatedAt: page.UpdatedAt,
+			Position:  len(result.Components) + 1,
+			Showcase:  elementType == models.StatusPageElementTypeHistoricalTimeline,
+			PageID:    pageID,
+		}
+	}
+
+	elements := append([]publicStatusPageElement(nil), resp.Elements...)
+	sort.SliceStable(elements, func(i, j int) bool { return elements[i].SortOrder < elements[j].SortOrder })
+
+	componentsByMonitor := make(map[string][]compatComponent)
+	statuses := make([]models.ComponentStatus, 0, len(elements))
+	for _, element := range elements {
+		statuses = append(statuses, element.ComponentStatus)
+
+		component := newComponent(element.ID, element.Name, element.ComponentStatus, element.Type)
+		if element.Monitor {
+			result.Components = append(result.Components, component)
+			if element.MonitorID != nil {
+				componentsByMonitor[*element.MonitorID] = append(componentsByMonitor[*element.MonitorID], component)
+			}
+			continue
+		}
+
+		component.Group = true
+		component.Components = make([]string, 0, len(element.Monitors))
+		groupIndex := len(result.Components)
+		result.Components = append(result.Components, component)
+
+		monitors := append([]publicStatusPageMonitor(nil), element.Monitors...)
+		sort.SliceStable(monitors, func(i, j int) bool { return monitors[i].SortOrder < monitors[j].SortOrder })
+		for _, monitor := range monitors {
+			groupID := element.ID
+			member := newComponent(monitor.ID, monitor.Name, monitor.ComponentStatus, monitor.Type)
+			member.GroupID = &groupID
+			result.Components = append(result.Components, member)
+			result.Components[groupIndex].Components = append(result.Components[groupIndex].Components, monitor.ID)
+			componentsByMonitor[monitor.MonitorID] = append(componentsByMonitor[monitor.MonitorID], member)
+		}
+	}
+
+	result.Status = compatIndicators[groupComponentStatus(models.ComponentStatusOverride{}, statuses, now)]
+
+	eventsByIncident := make(map[int64][]models.EventTimeline)
+	for _, event := range events {
+		if event.EventType.IsPublic() {
+			eventsByIncident[event.IncidentID] = append(eventsByIncident[event.IncidentID], event)
+		}
+	}
+
+	// Incidents repeat once per affected monitor and come newest first
+	incidentIndex := make(map[int64]int)
+	for _, incident := range resp.Incidents {
+		index, seen := incidentIndex[incident.ID]
+		if !seen {
+			if len(result.Incidents) == compatIncidentLimit {
+				continue
+			}
+			index = len(result.Incidents)
+			incidentIndex[incident.ID] = index
+			result.Incidents = append(result.Incidents, buildCompatIncident(incident.Incident, eventsByIncident[incident.ID], pageID, shortlink))
+		}
+		for _, component := range componentsByMonitor[incident.MonitorID] {
+			if !slices.ContainsFunc(result.Incidents[index].Components, func(c compatComponent) bool { return c.ID == component.ID }) {
+				result.Incidents[index].Components = append(result.Incidents[index].Components, component)
+			}
+		}
+	}
+
+	for i, incident := range result.Incidents {
+		names := make([]string, 0, len(incident.Components))
+		for _, component := range incident.Components {
+			names = append(names, component.Name)
+		}
+		result.Incidents[i].Name = incidentFeedTitle(names)
+
+		if incident.Status != "resolved" {
+			result.Status = worseCompatStatus(result.Status, incident.Impact)
+		}
+		if incident.UpdatedAt.After(result.Page.UpdatedAt) {
+			result.Page.UpdatedAt = incident.UpdatedAt
+		}
+	}
+
+	for _, maintenance := range resp.Maintenances {
+		result.ScheduledMaintenances = append(result.ScheduledMaintenances, buildCompatMaintenance(maintenance, componentsByMonitor, pageID, shortlink))
+		if maintenance.UpdatedAt.After(result.Page.UpdatedAt) {
+			result.Page.UpdatedAt = maintenance.UpdatedAt
+		}
+	}
+
+	return result
+}
+
+// buildCompatIncident maps an incident and its public updates, newest update first as Statuspage lists them.
+func buildCompatIncident(incident models.Incident, events []models.EventTimeline, pageID, shortlink string) compatIncident {
+	result := compatIncident{
+		ID:              formatID(incident.ID),
+		Status:          compatIncidentStatus(incident.Status),
+		CreatedAt:       incident.CreatedAt,
+		UpdatedAt:       incident.UpdatedAt,
+		ResolvedAt:      incident.ResolvedAt,
+		Impact:          compatIncidentImpact(incident.Severity),
+		Shortlink:       shortlink,
+		StartedAt:       incident.StartedAt,
+		PageID:          pageID,
+		IncidentUpdates: make([]compatIncidentUpdate, 0, len(events)),
+		Components:      make([]compatComponent, 0),
+	}
+
+	// Plain updates keep the status the incident was in when they were posted
+	status := "investigating"
+	for _, event := range events {
+		switch event.EventType {
+		case models.IncidentEventTypeInvestigating:
+			status = "investigating"
+		case models.IncidentEventTypeIdentified:
+			status = "identified"
+		case models.IncidentEventTypeMonitoring:
+			status = "monitoring"
+			if result.MonitoringAt == nil {
+				monitoringAt := event.CreatedAt
+				result.MonitoringAt = &monitoringAt
+			}
+		case models.IncidentEventTypeManuallyResolved, models.IncidentEventTypeAutoResolved:
+			status = "resolved"
+		}
+
+		result.IncidentUpdates = append(result.IncidentUpdates, compatIncidentUpdate{
+			ID:         formatID(event.ID),
+			Status:     status,
+			Body:       event.Message,
+			IncidentID: result.ID,
+			CreatedAt:  event.CreatedAt,
+			UpdatedAt:  event.UpdatedAt,
+			DisplayAt:  event.CreatedAt,
+		})
+	}
+	slices.Reverse(result.IncidentUpdates)
+
+	return result
+}
+
+// buildCompatMaintenance maps scheduled maintenance; its message becomes its only update.
+func buildCompatMaintenance(maintenance publicMaintenanceResponse, componentsByMonitor map[string][]compatComponent, pageID, shortlink string) compatIncident {
+	status := "scheduled"
+	if maintenance.InProgress {
+		status = "in_progress"
+	}
+
+	startsAt, endsAt := maintenance.StartsAt, maintenance.EndsAt
+	result := compatIncident{
+		ID:              maintenance.ID,
+		Name:            maintenance.Title,
+		Status:          status,
+		CreatedAt:       maintenance.CreatedAt,
+		UpdatedAt:       maintenance.UpdatedAt,
+		Impact:          "maintenance",
+		Shortlink:       shortlink,
+		StartedAt:       maintenance.StartsAt,
+		PageID:          pageID,
+		IncidentUpdates: make([]compatIncidentUpdate, 0, 1),
+		Components:      make([]compatComponent, 0, len(maintenance.MonitorIDs)),
+		ScheduledFor:    &startsAt,
+		ScheduledUntil:  &endsAt,
+	}
+
+	for _, monitorID := range maintenance.MonitorIDs {
+		result.Components = append(result.Components, componentsByMonitor[monitorID]...)
+	}
+
+	if maintenance.Message != "" {
+		result.IncidentUpdates = append(result.IncidentUpdates, compatIncidentUpdate{
+			ID:         maintenance.ID,
+			Status:     status,
+			Body:       maintenance.Message,
+			IncidentID: maintenance.ID,
+			CreatedAt:  maintenance.CreatedAt,
+			UpdatedAt:  maintenance.UpdatedAt,
+			DisplayAt:  maintenance.CreatedAt,
+		})
+	}
+
+	return result
+}
+
+// compatIncidentStatus maps to the Statuspage incident statuses; detected and flapping incidents are still being looked at
+func compatIncidentStatus(status models.IncidentStatus) string {
+	switch status {
+	case models.IncidentStatusIdentified:
+		return "identified"
+	case models.IncidentStatusMonitoring:
+		return "monitoring"
+	case models.IncidentStatusResolved:
+		return "resolved"
+	default:
+		return "investigating"
+	}
+}
+
+func compatIncidentImpact(severity models.IncidentSeverity) string {
+	switch severity {
+	case models.IncidentSeverityEmergency, models.IncidentSeverityCritical:
+		return "critical"
+	case models.IncidentSeverityMajor:
+		return "major"
+	case models.IncidentSeverityMinor:
+		return "minor"
+	default:
+		return "none"
+	}
+}
+
+// worseCompatStatus raises the page status to the impact of an open incident when that is worse
+func worseCompatStatus(status compatStatus, impact string) compatStatus {
+	if compatIndicatorRank[impact] <= compatIndicatorRank[status.Indicator] {
+		return status
+	}
+	for _, candidate := range compatIndicators {
+		if candidate.Indicator == impact {
+			return candidate
+		}
+	}
+	return status
+}
+
+// writeCompat writes a response without the usual envelope, as Statuspage clients expect.
+func writeCompat(c echo.Context, body any) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		zap.L().Error("Failed to marshal status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render status page")
+	}
+
+	return writeCacheable(c, echo.MIMEApplicationJSON, encoded, time.Time{}, publicStatusPageMaxAge)
+}
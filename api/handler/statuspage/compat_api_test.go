@@ -0,0 +1,106 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestBuildCompatStatusPage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	now := time.Now()
+	groupMonitorID := "42"
+	resp := publicStatusPageResponse{
+		StatusPage: models.StatusPage{ID: 5, Title: "Acme", Slug: "acme", UpdatedAt: now.Add(-time.Hour)},
+		Elements: []publicStatusPageElement{
+			{ID: "200", Name: "Website", SortOrder: 2, Monitor: true, MonitorID: &groupMonitorID, ComponentStatus: models.ComponentStatusOperational},
+			{ID: "100", Name: "Backend", SortOrder: 1, ComponentStatus: models.ComponentStatusPartialOutage, Monitors: []publicStatusPageMonitor{
+				{ID: "102", MonitorID: "43", Name: "Database", SortOrder: 2, ComponentStatus: models.ComponentStatusOperational},
+				{ID: "101", MonitorID: "44", Name: "API", SortOrder: 1, ComponentStatus: models.ComponentStatusMajorOutage},
+			}},
+		},
+		Incidents: []publicIncidentResponse{
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusMonitoring, Severity: models.IncidentSeverityEmergency, UpdatedAt: now}, MonitorID: "44"},
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusMonitoring, Severity: models.IncidentSeverityEmergency, UpdatedAt: now}, MonitorID: "42"},
+			{Incident: models.Incident{ID: 6, Status: models.IncidentStatusResolved, Severity: models.IncidentSeverityMinor}, MonitorID: "43"},
+		},
+		Maintenances: []publicMaintenanceResponse{
+			{ID: "77", Title: "Database upgrade", Message: "Read only for a few minutes", MonitorIDs: []string{"43"}, InProgress: true},
+		},
+	}
+	events := []models.EventTimeline{
+		{ID: 1, IncidentID: 7, EventType: models.IncidentEventTypeDetected, Message: "internal"},
+		{ID: 2, IncidentID: 7, EventType: models.IncidentEventTypeIdentified, Message: "Disk full", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: 3, IncidentID: 7, EventType: models.IncidentEventTypeUpdate, Message: "Cleaning up", CreatedAt: now.Add(-time.Minute)},
+		{ID: 4, IncidentID: 7, EventType: models.IncidentEventTypeMonitoring, Message: "Recovered", CreatedAt: now},
+	}
+
+	page := buildCompatStatusPage(resp, events, now)
+
+	require.Equal(t, "5", page.Page.ID)
+	require.Equal(t, "UTC", page.Page.TimeZone)
+	require.Equal(t, now, page.Page.UpdatedAt)
+
+	ids := make([]string, 0, len(page.Components))
+	for _, component := range page.Components {
+		ids = append(ids, component.ID)
+		require.Equal(t, len(ids), component.Position)
+	}
+	require.Equal(t, []string{"100", "101", "102", "200"}, ids)
+	require.True(t, page.Components[0].Group)
+	require.Equal(t, []string{"101", "102"}, page.Components[0].Components)
+	require.Equal(t, "100", *page.Components[1].GroupID)
+
+	require.Equal(t, compatStatus{Indicator: "critical", Description: "Major Service Outage"}, page.Status, "the open emergency incident outranks the partial outage")
+
+	require.Len(t, page.Incidents, 2)
+	incident := page.Incidents[0]
+	require.Equal(t, "Incident affecting API, Website", incident.Name)
+	require.Equal(t, "monitoring", incident.Status)
+	require.Equal(t, "critical", incident.Impact)
+	require.NotNil(t, incident.MonitoringAt)
+	require.Len(t, incident.IncidentUpdates, 3, "internal events are left out")
+	require.Equal(t, "monitoring", incident.IncidentUpdates[0].Status)
+	require.Equal(t, "identified", incident.IncidentUpdates[1].Status, "a plain update keeps the status of its time")
+	require.Equal(t, "resolved", page.Incidents[1].Status)
+
+	require.Len(t, page.ScheduledMaintenances, 1)
+	maintenance := page.ScheduledMaintenances[0]
+	require.Equal(t, "in_progress", maintenance.Status)
+	require.Equal(t, "maintenance", maintenance.Impact)
+	require.Equal(t, "102", maintenance.Components[0].ID)
+	require.Equal(t, "Read only for a few minutes", maintenance.IncidentUpdates[0].Body)
+}
+
+func TestGetStatusPageCompatSummary(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newComputedPageRepo()}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme/api/v2/summary.json", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.GetStatusPageCompatSummary(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.ElementsMatch(t, []string{"page", "components", "incidents", "scheduled_maintenances", "status"}, keys(body))
+	require.JSONEq(t, `{"indicator":"none","description":"All Systems Operational"}`, string(body["status"]))
+	require.JSONEq(t, `[]`, string(body["incidents"]))
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package statuspage
+
+import (
+	"time"
+
+	"github.com/yorukot/knocker/models"
+)
+
+// componentStatusRank orders component statuses from best to worst
+var componentStatusRank = map[models.ComponentStatus]int{
+	models.ComponentStatusOperational:         0,
+	models.ComponentStatusUnderMaintenance:    1,
+	models.ComponentStatusDegradedPerformance: 2,
+	models.ComponentStatusPartialOutage:       3,
+	models.ComponentStatusMajorOutage:         4,
+}
+
+// monitorComponentStatus is the status shown for a monitor: an active override wins, then
+// maintenance in progress, then what the monitor and its public incidents say.
+func monitorComponentStatus(override models.ComponentStatusOverride, monitorID int64, computed string, maintenances []models.StatusPageMaintenance, now time.Time) models.ComponentStatus {
+	if status := override.ActiveOverride(now); status != nil {
+		return *status
+	}
+
+	for _, maintenance := range maintenances {
+		if maintenance.InProgress(now) && maintenance.Affects(monitorID) {
+			return models.ComponentStatusUnderMaintenance
+		}
+	}
+
+	switch computed {
+	case "down":
+		return models.ComponentStatusMajorOutage
+	case "degraded":
+		return models.ComponentStatusDegradedPerformance
+	}
+	return models.ComponentStatusOperational
+}
+
+// groupComponentStatus is the status shown for a group: an active override wins, otherwise the
+// worst status of its monitors. Only a group whose monitors are all down is in major outage.
+func groupComponentStatus(override models.ComponentStatusOverride, members []models.ComponentStatus, now time.Time) models.ComponentStatus {
+	if status := override.ActiveOverride(now); status != nil {
+		return *status
+	}
+
+	worst := models.ComponentStatusOperational
+	majorOutages := 0
+	for _, member := range members {
+		if member == models.ComponentStatusMajorOutage {
+			majorOutages++
+		}
+		if componentStatusRank[member] > componentStatusRank[worst] {
+			worst = member
+		}
+	}
+
+	if worst == models.ComponentStatusMajorOutage && majorOutages < len(members) {
+		return models.ComponentStatusPartialOutage
+	}
+	return worst
+}
+
+// legacyStatus maps a component status to the up/down status older clients read
+func legacyStatus(status models.ComponentStatus) string {
+	if status == models.ComponentStatusPartialOutage || status == models.ComponentStatusMajorOutage {
+		return "down"
+	}
+	return "up"
+}
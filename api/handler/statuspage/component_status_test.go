@@ -0,0 +1,52 @@
+package statuspage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestMonitorComponentStatus(t *testing.T) {
+	now := time.Now()
+	degraded := models.ComponentStatusDegradedPerformance
+	expired := now.Add(-time.Minute)
+	maintenance := models.StatusPageMaintenance{MonitorIDs: []int64{42}, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	upcoming := models.StatusPageMaintenance{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)}
+
+	none := models.ComponentStatusOverride{}
+	require.Equal(t, models.ComponentStatusOperational, monitorComponentStatus(none, 42, "up", nil, now))
+	require.Equal(t, models.ComponentStatusMajorOutage, monitorComponentStatus(none, 42, "down", nil, now))
+	require.Equal(t, models.ComponentStatusDegradedPerformance, monitorComponentStatus(none, 42, "degraded", nil, now))
+	require.Equal(t, models.ComponentStatusUnderMaintenance, monitorComponentStatus(none, 42, "down", []models.StatusPageMaintenance{maintenance}, now))
+	require.Equal(t, models.ComponentStatusMajorOutage, monitorComponentStatus(none, 43, "down", []models.StatusPageMaintenance{maintenance}, now), "other monitor")
+	require.Equal(t, models.ComponentStatusOperational, monitorComponentStatus(none, 42, "up", []models.StatusPageMaintenance{upcoming}, now), "not started")
+
+	override := models.ComponentStatusOverride{StatusOverride: &degraded}
+	require.Equal(t, models.ComponentStatusDegradedPerformance, monitorComponentStatus(override, 42, "down", []models.StatusPageMaintenance{maintenance}, now))
+
+	override.StatusOverrideExpiresAt = &expired
+	require.Equal(t, models.ComponentStatusMajorOutage, monitorComponentStatus(override, 42, "down", nil, now), "expired override")
+}
+
+func TestGroupComponentStatus(t *testing.T) {
+	now := time.Now()
+	none := models.ComponentStatusOverride{}
+
+	require.Equal(t, models.ComponentStatusOperational, groupComponentStatus(none, nil, now))
+	require.Equal(t, models.ComponentStatusPartialOutage, groupComponentStatus(none, []models.ComponentStatus{
+		models.ComponentStatusOperational, models.ComponentStatusMajorOutage,
+	}, now))
+	require.Equal(t, models.ComponentStatusMajorOutage, groupComponentStatus(none, []models.ComponentStatus{
+		models.ComponentStatusMajorOutage, models.ComponentStatusMajorOutage,
+	}, now))
+	require.Equal(t, models.ComponentStatusUnderMaintenance, groupComponentStatus(none, []models.ComponentStatus{
+		models.ComponentStatusOperational, models.ComponentStatusUnderMaintenance,
+	}, now))
+
+	operational := models.ComponentStatusOperational
+	require.Equal(t, models.ComponentStatusOperational, groupComponentStatus(models.ComponentStatusOverride{StatusOverride: &operational}, []models.ComponentStatus{
+		models.ComponentStatusMajorOutage,
+	}, now))
+}
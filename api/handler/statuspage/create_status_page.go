@@ -8,7 +8,9 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/encrypt"
 	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -63,6 +65,10 @@ func (h *Handler) CreateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create status pages for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -70,22 +76,8 @@ func (h *Handler) CreateStatusPage(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-	}
-
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to create status pages for this team")
-	}
-
 	// ensure slug uniqueness
-	existingSlug, err := h.Repo.GetStatusPageBySlug(c.Request().Context(), tx, normalizedReq.Slug)
+	existingSlug, err := h.Repo.GetStatusPageIDBySlug(c.Request().Context(), tx, normalizedReq.Slug)
 	if err != nil {
 		zap.L().Error("Failed to check slug uniqueness", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check slug uniqueness")
@@ -106,17 +98,24 @@ func (h *Handler) CreateStatusPage(c echo.Context) error {
 		TeamID:    teamID,
 		Title:     normalizedReq.Title,
 		Slug:      normalizedReq.Slug,
-		Icon:      normalizedReq.Icon,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
+	if err := applyStatusPageBranding(&page, normalizedReq, nil); err != nil {
+		return err
+	}
+
+	if err := applyStatusPageVisibility(&page, normalizedReq, nil); err != nil {
+		return err
+	}
+
 	if err := h.Repo.CreateStatusPage(c.Request().Context(), tx, page); err != nil {
 		zap.L().Error("Failed to create status page", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create status page")
 	}
 
-	groups, monitors, err := buildStatusPageElements(normalizedReq, page.ID)
+	groups, monitors, err := buildStatusPageElements(normalizedReq, page.ID, nil)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
@@ -131,10 +130,6 @@ func (h *Handler) CreateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create status page monitors")
 	}
 
-	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
-	}
-
 	elements := buildStatusPageElementResponses(groups, monitors)
 
 	resp := statusPageResponse{
@@ -142,11 +137,27 @@ func (h *Handler) CreateStatusPage(c echo.Context) error {
 		Elements:   elements,
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		After:        statusPageAuditSnapshot{StatusPage: page, Elements: elements},
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
 	return c.JSON(http.StatusOK, response.Success("Status page created successfully", resp))
 }
 
 // buildStatusPageElements assigns IDs and fan-out relationships.
-func buildStatusPageElements(req statusPageUpsertRequest, statusPageID int64) ([]models.StatusPageGroup, []models.StatusPageMonitor, error) {
+// badgeKeys holds the badge keys already handed out per monitor, so embedded badges survive edits of the page.
+func buildStatusPageElements(req statusPageUpsertRequest, statusPageID int64, badgeKeys map[int64]string) ([]models.StatusPageGroup, []models.StatusPageMonitor, error) {
 	groupIDMap := make(map[int64]int64) // client temp ID -> generated ID
 	groups := make([]models.StatusPageGroup, 0, len(req.Groups))
 	for _, g := range req.Groups {
@@ -180,6 +191,19 @@ func buildStatusPageElements(req statusPageUpsertRequest, statusPageID int64) ([
 				groupID = m.GroupID
 			}
 		}
+		var badgeKey *string
+		if m.Badge {
+			key, ok := badgeKeys[m.MonitorID]
+			if !ok {
+				key, err = encrypt.GenerateRandomString(badgeKeyLength)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			// A monitor shown twice gets a second key rather than sharing one.
+			delete(badgeKeys, m.MonitorID)
+			badgeKey = &key
+		}
 		monitors = append(monitors, models.StatusPageMonitor{
 			ID:           mid,
 			StatusPageID: statusPageID,
@@ -188,6 +212,7 @@ func buildStatusPageElements(req statusPageUpsertRequest, statusPageID int64) ([
 			Name:         m.Name,
 			Type:         m.Type,
 			SortOrder:    m.SortOrder,
+			BadgeKey:     badgeKey,
 		})
 	}
 
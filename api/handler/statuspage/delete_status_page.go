@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -47,6 +48,10 @@ func (h *Handler) DeleteStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete status pages for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -54,18 +59,20 @@ func (h *Handler) DeleteStatusPage(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
+	existing, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
 	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
 	}
 
-	if member == nil {
+	if existing == nil {
 		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
 	}
 
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete status pages for this team")
+	before, err := h.loadStatusPageAuditSnapshot(c.Request().Context(), tx, *existing)
+	if err != nil {
+		zap.L().Error("Failed to load status page elements", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load status page elements")
 	}
 
 	if err := h.Repo.DeleteStatusPage(c.Request().Context(), tx, teamID, statusPageID); err != nil {
@@ -77,6 +84,17 @@ func (h *Handler) DeleteStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete status page")
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   statusPageID,
+		Before:       *before,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
@@ -0,0 +1,487 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Custom domains                               |
+// +----------------------------------------------+
+
+// domainVerificationTokenLength is the length of the random value of the ownership TXT record
+const domainVerificationTokenLength = 32
+
+// domainLookupTimeout bounds the DNS lookup of a verification
+const domainLookupTimeout = 10 * time.Second
+
+type customDomainRequest struct {
+	Domain string `json:"domain" validate:"required,max=253" example:"status.example.com"`
+}
+
+type dnsRecord struct {
+	Type  string `json:"type" example:"TXT"`
+	Name  string `json:"name" example:"_knocker-challenge.status.example.com"`
+	Value string `json:"value" example:"knocker-verification=abc123"`
+}
+
+// customDomainResponse tells the team which DNS records to create for the domain
+type customDomainResponse struct {
+	Domain     string      `json:"domain" example:"status.example.com"`
+	Verified   bool        `json:"verified"`
+	VerifiedAt *time.Time  `json:"verified_at,omitempty"`
+	Records    []dnsRecord `json:"records"`
+}
+
+func newCustomDomainResponse(page models.StatusPage) customDomainResponse {
+	domain := *page.CustomDomain
+	resp := customDomainResponse{
+		Domain:     domain,
+		Verified:   page.DomainVerifiedAt != nil,
+		VerifiedAt: page.DomainVerifiedAt,
+		Records: []dnsRecord{
+			{Type: "CNAME", Name: domain, Value: config.Env().StatusPageDomainTarget},
+		},
+	}
+	if page.DomainVerificationToken != nil {
+		resp.Records = append(resp.Records, dnsRecord{
+			Type:  "TXT",
+			Name:  statuspagecore.VerificationRecordName(domain),
+			Value: statuspagecore.VerificationRecordValue(*page.DomainVerificationToken),
+		})
+	}
+	return resp
+}
+
+// resolver returns the DNS resolver used to verify domains
+func (h *Handler) resolver() statuspagecore.TXTResolver {
+	if h.Resolver != nil {
+		return h.Resolver
+	}
+	return net.DefaultResolver
+}
+
+// GetStatusPageDomain godoc
+// @Summary Get a status page custom domain
+// @Description Returns the custom domain of a status page, whether it is verified and the DNS records it needs
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Success 200 {object} response.SuccessResponse{data=customDomainResponse} "Custom domain retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID or status page ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or custom domain not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/domain [get]
+func (h *Handler) GetStatusPageDomain(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view status pages for this team")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	page, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if page.CustomDomain == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page has no custom domain")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Custom domain retrieved successfully", newCustomDomainResponse(*page)))
+}
+
+// SetStatusPageDomain godoc
+// @Summary Set a status page custom domain
+// @Description Sets the custom domain of a status page. The page is served on it once the returned TXT record is published and verified
+// @Tags status_pages
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param request body customDomainRequest true "Custom domain"
+// @Success 200 {object} response.SuccessResponse{data=customDomainResponse} "Custom domain set successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or domain"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/domain [put]
+func (h *Handler) SetStatusPageDomain(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	if config.Env().StatusPageDomainTarget == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Custom domains are not enabled on this server")
+	}
+
+	var req customDomainRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	domain, err := statuspagecore.NormalizeDomain(req.Domain)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid custom domain")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	page, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	// Setting the same domain again keeps the record already published and its verification.
+	if page.CustomDomain != nil && *page.CustomDomain == domain {
+		if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		}
+		return c.JSON(http.StatusOK, response.Success("Custom domain set successfully", newCustomDomainResponse(*page)))
+	}
+
+	token, err := encrypt.GenerateRandomString(domainVerificationTokenLength)
+	if err != nil {
+		zap.L().Error("Failed to generate domain verification token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set custom domain")
+	}
+
+	updated := *page
+	updated.CustomDomain = &domain
+	updated.DomainVerificationToken = &token
+	updated.DomainVerifiedAt = nil
+	updated.UpdatedAt = time.Now().UTC()
+
+	if err := h.Repo.UpdateStatusPageCustomDomain(c.Request().Context(), tx, updated); err != nil {
+		zap.L().Error("Failed to update status page custom domain", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set custom domain")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *page,
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Custom domain set successfully", newCustomDomainResponse(updated)))
+}
+
+// VerifyStatusPageDomain godoc
+// @Summary Verify a status page custom domain
+// @Description Checks the ownership TXT record of the custom domain and starts serving the status page on it once found
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Success 200 {object} response.SuccessResponse{data=customDomainResponse} "Custom domain verified successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID or status page ID, or TXT record not found"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or custom domain not found"
+// @Failure 409 {object} response.ErrorResponse "Domain already used by another status page"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 502 {object} response.ErrorResponse "DNS lookup failed"
+// @Router /teams/{teamID}/status-pages/{id}/domain/verify [post]
+func (h *Handler) VerifyStatusPageDomain(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	page, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if page.CustomDomain == nil || page.DomainVerificationToken == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page has no custom domain")
+	}
+
+	if page.DomainVerifiedAt != nil {
+		if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		}
+		return c.JSON(http.StatusOK, response.Success("Custom domain verified successfully", newCustomDomainResponse(*page)))
+	}
+
+	lookupCtx, cancel := context.WithTimeout(c.Request().Context(), domainLookupTimeout)
+	defer cancel()
+
+	verified, err := statuspagecore.VerifyDomainOwnership(lookupCtx, h.resolver(), *page.CustomDomain, *page.DomainVerificationToken)
+	if err != nil {
+		zap.L().Warn("Failed to look up domain verification record", zap.String("domain", *page.CustomDomain), zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadGateway, "Failed to look up the TXT record, try again later")
+	}
+
+	if !verified {
+		return echo.NewHTTPError(http.StatusBadRequest, "TXT record "+statuspagecore.VerificationRecordName(*page.CustomDomain)+" not found")
+	}
+
+	owner, err := h.Repo.GetStatusPageByCustomDomain(c.Request().Context(), tx, *page.CustomDomain)
+	if err != nil {
+		zap.L().Error("Failed to get status page by custom domain", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify custom domain")
+	}
+
+	if owner != nil && owner.ID != page.ID {
+		return echo.NewHTTPError(http.StatusConflict, "Domain is already used by another status page")
+	}
+
+	now := time.Now().UTC()
+	updated := *page
+	updated.DomainVerifiedAt = &now
+	updated.UpdatedAt = now
+
+	if err := h.Repo.UpdateStatusPageCustomDomain(c.Request().Context(), tx, updated); err != nil {
+		// A page of a soft-deleted team still holds the domain but is hidden from the lookup above.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return echo.NewHTTPError(http.StatusConflict, "Domain is already used by another status page")
+		}
+		zap.L().Error("Failed to update status page custom domain", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify custom domain")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *page,
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Custom domain verified successfully", newCustomDomainResponse(updated)))
+}
+
+// DeleteStatusPageDomain godoc
+// @Summary Remove a status page custom domain
+// @Description Stops serving the status page on its custom domain
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Success 200 {object} response.SuccessResponse "Custom domain removed successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID or status page ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or custom domain not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/domain [delete]
+func (h *Handler) DeleteStatusPageDomain(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	page, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if page.CustomDomain == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page has no custom domain")
+	}
+
+	updated := *page
+	updated.CustomDomain = nil
+	updated.DomainVerificationToken = nil
+	updated.DomainVerifiedAt = nil
+	updated.UpdatedAt = time.Now().UTC()
+
+	if err := h.Repo.UpdateStatusPageCustomDomain(c.Request().Context(), tx, updated); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+		}
+
+		zap.L().Error("Failed to update status page custom domain", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove custom domain")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *page,
+		After:        updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Custom domain removed successfully"))
+}
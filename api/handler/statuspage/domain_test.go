@@ -0,0 +1,152 @@
+package statuspage
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/config"
+)
+
+type fakeTXTResolver map[string][]string
+
+func (f fakeTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := f[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+// enableCustomDomains turns custom domains on for the test
+func enableCustomDomains(t *testing.T) {
+	previous := config.Env().StatusPageDomainTarget
+	config.Env().StatusPageDomainTarget = "pages.knocker.test"
+	t.Cleanup(func() { config.Env().StatusPageDomainTarget = previous })
+}
+
+func newDomainContext(method, path, body string) echo.Context {
+	c, _ := testutil.NewEchoContext(method, "/api/teams/10/status-pages/5"+path, strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("teamID", "id")
+	c.SetParamValues("10", "5")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{TeamID: 10, UserID: 123, Role: models.MemberRoleAdmin})
+	return c
+}
+
+func newDomainRepo(page *models.StatusPage) *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetStatusPageByID", mock.Anything, mock.Anything, int64(10), int64(5)).Return(page, nil)
+	return mockRepo
+}
+
+func TestSetStatusPageDomain(t *testing.T) {
+	testutil.InitTestEnv(t)
+	enableCustomDomains(t)
+
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})
+	mockRepo.On("UpdateStatusPageCustomDomain", mock.Anything, mock.Anything, mock.MatchedBy(func(p models.StatusPage) bool {
+		return p.CustomDomain != nil && *p.CustomDomain == "status.acme.com" &&
+			p.DomainVerificationToken != nil && len(*p.DomainVerificationToken) == domainVerificationTokenLength &&
+			p.DomainVerifiedAt == nil
+	})).Return(nil)
+
+	h := &Handler{Repo: mockRepo}
+	c := newDomainContext(http.MethodPut, "/domain", `{"domain":"Status.Acme.com"}`)
+
+	require.NoError(t, h.SetStatusPageDomain(c))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetStatusPageDomain_Disabled(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newDomainRepo(nil)}
+	c := newDomainContext(http.MethodPut, "/domain", `{"domain":"status.acme.com"}`)
+
+	err := h.SetStatusPageDomain(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestVerifyStatusPageDomain(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	domain, token := "status.acme.com", "tok3n"
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme", CustomDomain: &domain, DomainVerificationToken: &token})
+	mockRepo.On("GetStatusPageByCustomDomain", mock.Anything, mock.Anything, domain).Return(nil, nil)
+	mockRepo.On("UpdateStatusPageCustomDomain", mock.Anything, mock.Anything, mock.MatchedBy(func(p models.StatusPage) bool {
+		return p.DomainVerifiedAt != nil && *p.CustomDomain == domain
+	})).Return(nil)
+
+	h := &Handler{Repo: mockRepo, Resolver: fakeTXTResolver{
+		"_knocker-challenge.status.acme.com": {"knocker-verification=tok3n"},
+	}}
+
+	require.NoError(t, h.VerifyStatusPageDomain(newDomainContext(http.MethodPost, "/domain/verify", "")))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVerifyStatusPageDomain_RecordMissing(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	domain, token := "status.acme.com", "tok3n"
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme", CustomDomain: &domain, DomainVerificationToken: &token})
+
+	h := &Handler{Repo: mockRepo, Resolver: fakeTXTResolver{}}
+
+	err := h.VerifyStatusPageDomain(newDomainContext(http.MethodPost, "/domain/verify", ""))
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "UpdateStatusPageCustomDomain", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyStatusPageDomain_TakenByAnotherPage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	domain, token := "status.acme.com", "tok3n"
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme", CustomDomain: &domain, DomainVerificationToken: &token})
+	mockRepo.On("GetStatusPageByCustomDomain", mock.Anything, mock.Anything, domain).
+		Return(&models.StatusPage{ID: 6, Slug: "other"}, nil)
+
+	h := &Handler{Repo: mockRepo, Resolver: fakeTXTResolver{
+		"_knocker-challenge.status.acme.com": {"knocker-verification=tok3n"},
+	}}
+
+	err := h.VerifyStatusPageDomain(newDomainContext(http.MethodPost, "/domain/verify", ""))
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestVerifyStatusPageDomain_HeldByDeletedTeam(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	domain, token := "status.acme.com", "tok3n"
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme", CustomDomain: &domain, DomainVerificationToken: &token})
+	mockRepo.On("GetStatusPageByCustomDomain", mock.Anything, mock.Anything, domain).Return(nil, nil)
+	mockRepo.On("UpdateStatusPageCustomDomain", mock.Anything, mock.Anything, mock.Anything).
+		Return(&pgconn.PgError{Code: "23505"})
+
+	h := &Handler{Repo: mockRepo, Resolver: fakeTXTResolver{
+		"_knocker-challenge.status.acme.com": {"knocker-verification=tok3n"},
+	}}
+
+	err := h.VerifyStatusPageDomain(newDomainContext(http.MethodPost, "/domain/verify", ""))
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
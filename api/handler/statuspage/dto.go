@@ -18,6 +18,7 @@ type statusPageMonitorInput struct {
 	Name      string                       `json:"name" form:"name" validate:"required,min=1,max=255"`
 	Type      models.StatusPageElementType `json:"type" form:"type" validate:"required,oneof=historical_timeline current_status_indicator"`
 	SortOrder int                          `json:"sort_order" form:"sort_order" validate:"min=1"`
+	Badge     bool                         `json:"badge" form:"badge"`
 }
 
 type statusPageElementInput struct {
@@ -27,16 +28,22 @@ type statusPageElementInput struct {
 	SortOrder int                          `json:"sort_order" form:"sort_order" validate:"min=1"`
 	Monitor   bool                         `json:"monitor" form:"monitor"`
 	MonitorID *int64                       `json:"monitor_id,string,omitempty" form:"monitor_id"`
+	Badge     bool                         `json:"badge" form:"badge"`
 	Monitors  []statusPageMonitorInput     `json:"monitors" form:"monitors" validate:"dive"`
 }
 
 type statusPageUpsertRequest struct {
 	Title    string                   `json:"title" form:"name" validate:"required,min=1,max=255"`
 	Slug     string                   `json:"slug" form:"slug" validate:"required,min=3,max=255"`
-	Icon     []byte                   `json:"icon,omitempty" form:"icon"`
 	Elements []statusPageElementInput `json:"elements" form:"elements" validate:"dive"`
 	Groups   []statusPageGroupInput   `json:"groups" form:"groups" validate:"dive"`
 	Monitors []statusPageMonitorInput `json:"monitors" form:"monitors" validate:"dive"`
+
+	Visibility      models.StatusPageVisibility `json:"visibility,omitempty" form:"visibility" validate:"omitempty,oneof=public password ip_allowlist team"`
+	Password        string                      `json:"password,omitempty" form:"password" validate:"omitempty,min=8,max=128"`
+	AllowedIPRanges []string                    `json:"allowed_ip_ranges,omitempty" form:"allowed_ip_ranges" validate:"omitempty,max=100"`
+
+	Branding *statusPageBrandingInput `json:"branding,omitempty"`
 }
 
 type statusPageElementResponse struct {
@@ -48,9 +55,11 @@ type statusPageElementResponse struct {
 	Monitor      bool                         `json:"monitor"`
 	MonitorID    *string                      `json:"monitor_id,omitempty"`
 	Monitors     []models.StatusPageMonitor   `json:"monitors"`
+	models.ComponentStatusOverride
 }
 
 type statusPageResponse struct {
 	StatusPage models.StatusPage           `json:"status_page"`
 	Elements   []statusPageElementResponse `json:"elements"`
+	Images     []models.StatusPageImage    `json:"images,omitempty"` // uploaded logo and favicon, without their data
 }
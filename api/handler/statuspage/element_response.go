@@ -33,6 +33,8 @@ func buildStatusPageElementResponses(groups []models.StatusPageGroup, monitors [
 			SortOrder:    group.SortOrder,
 			Monitor:      false,
 			Monitors:     monitorList,
+
+			ComponentStatusOverride: group.ComponentStatusOverride,
 		}
 
 		elements = append(elements, element)
@@ -49,6 +51,8 @@ func buildStatusPageElementResponses(groups []models.StatusPageGroup, monitors [
 			Monitor:      true,
 			MonitorID:    &monitorID,
 			Monitors:     []models.StatusPageMonitor{},
+
+			ComponentStatusOverride: monitor.ComponentStatusOverride,
 		}
 
 		elements = append(elements, element)
@@ -0,0 +1,377 @@
+package statuspage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | RSS and Atom feeds                           |
+// +----------------------------------------------+
+
+// feedIncidentLimit caps how many of the latest incidents a feed carries
+const feedIncidentLimit = 50
+
+// feedMaxAge is how long readers and proxies may reuse a feed without asking again
+const feedMaxAge = time.Minute
+
+// statusPageFeed is a format-neutral feed of a status page's public incidents and their updates
+type statusPageFeed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	Updated     time.Time
+	Entries     []feedEntry
+}
+
+// feedEntry is one incident or one incident update. ID never changes, so readers don't show an entry twice.
+type feedEntry struct {
+	ID        string
+	Title     string
+	Content   string
+	Link      string
+	Published time.Time
+	Updated   time.Time
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GetStatusPageRSS godoc
+// @Summary Get status page RSS feed
+// @Description Returns the public incidents of a status page and their updates as an RSS 2.0 feed. Supports conditional requests with ETag and Last-Modified
+// @Tags status-pages
+// @Produce application/rss+xml
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/feed.rss [get]
+func (h *Handler) GetStatusPageRSS(c echo.Context) error {
+	return h.serveFeed(c, "application/rss+xml; charset=utf-8", renderRSS)
+}
+
+// GetStatusPageAtom godoc
+// @Summary Get status page Atom feed
+// @Description Returns the public incidents of a status page and their updates as an Atom feed. Supports conditional requests with ETag and Last-Modified
+// @Tags status-pages
+// @Produce application/atom+xml
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/feed.atom [get]
+func (h *Handler) GetStatusPageAtom(c echo.Context) error {
+	return h.serveFeed(c, "application/atom+xml; charset=utf-8", renderAtom)
+}
+
+func (h *Handler) serveFeed(c echo.Context, contentType string, render func(statusPageFeed) ([]byte, error)) error {
+	feed, err := h.loadStatusPageFeed(c)
+	if err != nil {
+		return err
+	}
+
+	body, err := render(*feed)
+	if err != nil {
+		zap.L().Error("Failed to render status page feed", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render feed")
+	}
+
+	return writeCacheable(c, contentType, body, feed.Updated, feedMaxAge)
+}
+
+// loadStatusPageFeed reads the latest public incidents of the status page and their public updates.
+func (h *Handler) loadStatusPageFeed(c echo.Context) (*statusPageFeed, error) {
+	ctx := c.Request().Context()
+
+	slug := c.Param("slug")
+	if slug == "" {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, slug)
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if err := h.authorizeStatusPage(c, tx, *page); err != nil {
+		return nil, err
+	}
+
+	monitors, err := h.Repo.ListStatusPageMonitorsByStatusPageID(ctx, tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page monitors", zap.Error(err), zap.Int64("status_page_id", page.ID))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page monitors")
+	}
+
+	monitorIDs := make([]int64, 0, len(monitors))
+	componentNames := make(map[int64]string, len(monitors))
+	for _, m := range monitors {
+		if _, exists := componentNames[m.MonitorID]; exists {
+			continue
+		}
+		componentNames[m.MonitorID] = m.Name
+		monitorIDs = append(monitorIDs, m.MonitorID)
+	}
+
+	rows, err := h.Repo.ListPublicIncidentsByMonitorIDs(ctx, tx, monitorIDs)
+	if err != nil {
+		zap.L().Error("Failed to list public incidents", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incidents")
+	}
+
+	// Rows repeat an incident once per affected monitor; keep the newest incidents in order.
+	incidents := make([]models.Incident, 0)
+	affected := make(map[int64][]string)
+	for _, row := range rows {
+		if _, seen := affected[row.ID]; !seen {
+			if len(incidents) == feedIncidentLimit {
+				continue
+			}
+			incidents = append(incidents, row.Incident)
+		}
+		affected[row.ID] = append(affected[row.ID], componentNames[row.MonitorID])
+	}
+
+	incidentIDs := make([]int64, 0, len(incidents))
+	for _, incident := range incidents {
+		incidentIDs = append(incidentIDs, incident.ID)
+	}
+
+	events, err := h.Repo.ListEventTimelinesByIncidentIDs(ctx, tx, incidentIDs)
+	if err != nil {
+		zap.L().Error("Failed to list incident events", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incident events")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	feed := buildStatusPageFeed(*page, incidents, affected, events)
+	return &feed, nil
+}
+
+// buildStatusPageFeed turns incidents and their timelines into feed entries, newest first.
+// Internal events such as notification_sent never make it into the feed.
+func buildStatusPageFeed(page models.StatusPage, incidents []models.Incident, affected map[int64][]string, events []models.EventTimeline) statusPageFeed {
+	link := statuspagecore.PageURL(page.Slug)
+	feed := statusPageFeed{
+		ID:          fmt.Sprintf("urn:knocker:status-page:%d", page.ID),
+		Title:       page.Title,
+		Description: fmt.Sprintf("Incidents and updates from the %s status page", page.Title),
+		Link:        link,
+		Updated:     page.UpdatedAt,
+		Entries:     make([]feedEntry, 0, len(incidents)),
+	}
+
+	titles := make(map[int64]string, len(incidents))
+	for _, incident := range incidents {
+		title := incidentFeedTitle(affected[incident.ID])
+		titles[incident.ID] = title
+
+		content := fmt.Sprintf("Status: %s. Started %s.", feedLabel(string(incident.Status)), incident.StartedAt.UTC().Format(time.RFC1123))
+		if incident.ResolvedAt != nil {
+			content += fmt.Sprintf(" Resolved %s.", incident.ResolvedAt.UTC().Format(time.RFC1123))
+		}
+
+		feed.Entries = append(feed.Entries, feedEntry{
+			ID:        fmt.Sprintf("urn:knocker:incident:%d", incident.ID),
+			Title:     title,
+			Content:   content,
+			Link:      link,
+			Published: incident.StartedAt,
+			Updated:   incident.UpdatedAt,
+		})
+
+		if incident.UpdatedAt.After(feed.Updated) {
+			feed.Updated = incident.UpdatedAt
+		}
+	}
+
+	for _, event := range events {
+		title, ok := titles[event.IncidentID]
+		if !ok || !event.EventType.IsPublic() {
+			continue
+		}
+
+		feed.Entries = append(feed.Entries, feedEntry{
+			ID:        fmt.Sprintf("urn:knocker:incident:%d:update:%d", event.IncidentID, event.ID),
+			Title:     fmt.Sprintf("%s: %s", title, feedLabel(string(event.EventType))),
+			Content:   event.Message,
+			Link:      link,
+			Published: event.CreatedAt,
+			Updated:   event.UpdatedAt,
+		})
+
+		if event.UpdatedAt.After(feed.Updated) {
+			feed.Updated = event.UpdatedAt
+		}
+	}
+
+	sort.SliceStable(feed.Entries, func(i, j int) bool {
+		return feed.Entries[i].Published.After(feed.Entries[j].Published)
+	})
+
+	return feed
+}
+
+func incidentFeedTitle(components []string) string {
+	names := make([]string, 0, len(components))
+	seen := make(map[string]struct{}, len(components))
+	for _, name := range components {
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return "Incident"
+	}
+	return "Incident affecting " + strings.Join(names, ", ")
+}
+
+// feedLabel turns an enum value such as manually_resolved into "Manually resolved"
+func feedLabel(value string) string {
+	if value == "" {
+		return value
+	}
+	value = strings.ReplaceAll(value, "_", " ")
+	return strings.ToUpper(value[:1]) + value[1:]
+}
+
+func renderRSS(feed statusPageFeed) ([]byte, error) {
+	doc := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.Link,
+			Description:   feed.Description,
+			LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(feed.Entries)),
+		},
+	}
+
+	for _, entry := range feed.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Content,
+			GUID:        rssGUID{Value: entry.ID},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return marshalFeed(doc)
+}
+
+func renderAtom(feed statusPageFeed) ([]byte, error) {
+	doc := atomFeed{
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: feed.Link},
+		Entries: make([]atomEntry, 0, len(feed.Entries)),
+	}
+
+	for _, entry := range feed.Entries {
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Link:      atomLink{Href: entry.Link},
+			Content:   atomContent{Type: "text", Value: entry.Content},
+		})
+	}
+
+	return marshalFeed(doc)
+}
+
+func marshalFeed(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
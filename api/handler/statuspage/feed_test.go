@@ -0,0 +1,106 @@
+package statuspage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newFeedRepo() *repository.MockRepository {
+	startedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	resolvedAt := startedAt.Add(time.Hour)
+
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageBySlug", mock.Anything, mock.Anything, "acme").
+		Return(&models.StatusPage{ID: 5, Title: "Acme", Slug: "acme", UpdatedAt: startedAt.Add(-time.Hour)}, nil)
+	mockRepo.On("ListStatusPageImages", mock.Anything, mock.Anything, int64(5)).Return([]models.StatusPageImage{}, nil)
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageMonitor{{MonitorID: 42, Name: "API"}, {MonitorID: 43, Name: "Website"}}, nil)
+	mockRepo.On("ListPublicIncidentsByMonitorIDs", mock.Anything, mock.Anything, []int64{42, 43}).
+		Return([]models.IncidentWithMonitorID{
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusResolved, StartedAt: startedAt, ResolvedAt: &resolvedAt, UpdatedAt: resolvedAt}, MonitorID: 42},
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusResolved, StartedAt: startedAt, ResolvedAt: &resolvedAt, UpdatedAt: resolvedAt}, MonitorID: 43},
+		}, nil)
+	mockRepo.On("ListEventTimelinesByIncidentIDs", mock.Anything, mock.Anything, []int64{7}).
+		Return([]models.EventTimeline{
+			{ID: 100, IncidentID: 7, Message: "Looking into elevated errors", EventType: models.IncidentEventTypeInvestigating, CreatedAt: startedAt.Add(time.Minute), UpdatedAt: startedAt.Add(time.Minute)},
+			{ID: 101, IncidentID: 7, Message: "Paged on-call", EventType: models.IncidentEventTypeNotificationSent, CreatedAt: startedAt.Add(2 * time.Minute), UpdatedAt: startedAt.Add(2 * time.Minute)},
+			{ID: 102, IncidentID: 7, Message: "Fixed", EventType: models.IncidentEventTypeManuallyResolved, CreatedAt: resolvedAt, UpdatedAt: resolvedAt},
+		}, nil)
+	return mockRepo
+}
+
+func newFeedContext(path string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme"+path, nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+	return c, rec
+}
+
+func TestGetStatusPageRSS(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newFeedRepo()}
+	c, rec := newFeedContext("/feed.rss")
+
+	require.NoError(t, h.GetStatusPageRSS(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "application/rss+xml")
+	require.NotEmpty(t, rec.Header().Get("ETag"))
+	require.Equal(t, "Sun, 01 Mar 2026 11:00:00 GMT", rec.Header().Get("Last-Modified"))
+
+	body := rec.Body.String()
+	require.Contains(t, body, `<guid isPermaLink="false">urn:knocker:incident:7</guid>`)
+	require.Contains(t, body, `<guid isPermaLink="false">urn:knocker:incident:7:update:100</guid>`)
+	require.Contains(t, body, "Incident affecting API, Website: Manually resolved")
+	require.NotContains(t, body, "Paged on-call")
+}
+
+func TestGetStatusPageAtom(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newFeedRepo()}
+	c, rec := newFeedContext("/feed.atom")
+
+	require.NoError(t, h.GetStatusPageAtom(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "application/atom+xml")
+
+	body := rec.Body.String()
+	require.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	require.Contains(t, body, "<id>urn:knocker:incident:7:update:102</id>")
+	require.Contains(t, body, "<updated>2026-03-01T11:00:00Z</updated>")
+}
+
+func TestGetStatusPageRSS_NotModified(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newFeedRepo()}
+	c, rec := newFeedContext("/feed.rss")
+	require.NoError(t, h.GetStatusPageRSS(c))
+	etag := rec.Header().Get("ETag")
+
+	c, rec = newFeedContext("/feed.rss")
+	c.Request().Header.Set("If-None-Match", "W/"+etag)
+	require.NoError(t, h.GetStatusPageRSS(c))
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+
+	c, rec = newFeedContext("/feed.rss")
+	c.Request().Header.Set("If-Modified-Since", "Sun, 01 Mar 2026 11:00:00 GMT")
+	require.NoError(t, h.GetStatusPageRSS(c))
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	c, rec = newFeedContext("/feed.rss")
+	c.Request().Header.Set("If-Modified-Since", "Sun, 01 Mar 2026 10:59:59 GMT")
+	require.NoError(t, h.GetStatusPageRSS(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
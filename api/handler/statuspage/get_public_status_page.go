@@ -1,16 +1,21 @@
 package statuspage
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
 )
 
+// publicStatusPageMaxAge is how long clients may reuse the page before revalidating it by ETag
+const publicStatusPageMaxAge = 10 * time.Second
+
 type publicTimelinePoint struct {
 	Day     time.Time `json:"day"`
 	Success int64     `json:"success"`
@@ -18,32 +23,34 @@ type publicTimelinePoint struct {
 }
 
 type publicStatusPageMonitor struct {
-	ID          string                       `json:"id"`
-	MonitorID   string                       `json:"monitor_id"`
-	GroupID     *string                      `json:"group_id,omitempty"`
-	Name        string                       `json:"name"`
-	Type        models.StatusPageElementType `json:"type"`
-	SortOrder   int                          `json:"sort_order"`
-	Status      string                       `json:"status,omitempty"`
-	UptimeSLI30 float64                      `json:"uptime_sli_30,omitempty"`
-	UptimeSLI60 float64                      `json:"uptime_sli_60,omitempty"`
-	UptimeSLI90 float64                      `json:"uptime_sli_90,omitempty"`
-	Timeline    []publicTimelinePoint        `json:"timeline,omitempty"`
+	ID              string                       `json:"id"`
+	MonitorID       string                       `json:"monitor_id"`
+	GroupID         *string                      `json:"group_id,omitempty"`
+	Name            string                       `json:"name"`
+	Type            models.StatusPageElementType `json:"type"`
+	SortOrder       int                          `json:"sort_order"`
+	Status          string                       `json:"status,omitempty"`
+	ComponentStatus models.ComponentStatus       `json:"component_status"`
+	UptimeSLI30     float64                      `json:"uptime_sli_30,omitempty"`
+	UptimeSLI60     float64                      `json:"uptime_sli_60,omitempty"`
+	UptimeSLI90     float64                      `json:"uptime_sli_90,omitempty"`
+	Timeline        []publicTimelinePoint        `json:"timeline,omitempty"`
 }
 
 type publicStatusPageElement struct {
-	ID          string                       `json:"id"`
-	Name        string                       `json:"name"`
-	Type        models.StatusPageElementType `json:"type"`
-	SortOrder   int                          `json:"sort_order"`
-	Status      string                       `json:"status,omitempty"`
-	Monitor     bool                         `json:"monitor"`
-	MonitorID   *string                      `json:"monitor_id,omitempty"`
-	UptimeSLI30 float64                      `json:"uptime_sli_30,omitempty"`
-	UptimeSLI60 float64                      `json:"uptime_sli_60,omitempty"`
-	UptimeSLI90 float64                      `json:"uptime_sli_90,omitempty"`
-	Timeline    []publicTimelinePoint        `json:"timeline,omitempty"`
-	Monitors    []publicStatusPageMonitor    `json:"monitors"`
+	ID              string                       `json:"id"`
+	Name            string                       `json:"name"`
+	Type            models.StatusPageElementType `json:"type"`
+	SortOrder       int                          `json:"sort_order"`
+	Status          string                       `json:"status,omitempty"`
+	ComponentStatus models.ComponentStatus       `json:"component_status"`
+	Monitor         bool                         `json:"monitor"`
+	MonitorID       *string                      `json:"monitor_id,omitempty"`
+	UptimeSLI30     float64                      `json:"uptime_sli_30,omitempty"`
+	UptimeSLI60     float64                      `json:"uptime_sli_60,omitempty"`
+	UptimeSLI90     float64                      `json:"uptime_sli_90,omitempty"`
+	Timeline        []publicTimelinePoint        `json:"timeline,omitempty"`
+	Monitors        []publicStatusPageMonitor    `json:"monitors"`
 }
 
 type publicIncidentResponse struct {
@@ -52,54 +59,117 @@ type publicIncidentResponse struct {
 	MonitorID string `json:"monitor_id"`
 }
 
+// publicMaintenanceResponse is scheduled maintenance that has not ended yet
+type publicMaintenanceResponse struct {
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message"`
+	MonitorIDs []string  `json:"monitor_ids"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	InProgress bool      `json:"in_progress"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 type publicStatusPageResponse struct {
-	StatusPage models.StatusPage         `json:"status_page"`
-	Elements   []publicStatusPageElement `json:"elements"`
-	Incidents  []publicIncidentResponse  `json:"incidents"`
+	StatusPage   models.StatusPage           `json:"status_page"`
+	LogoURL      string                      `json:"logo_url,omitempty"`
+	FaviconURL   string                      `json:"favicon_url,omitempty"`
+	Strings      map[string]string           `json:"strings"` // UI strings in the page's language
+	Elements     []publicStatusPageElement   `json:"elements"`
+	Incidents    []publicIncidentResponse    `json:"incidents"`
+	Maintenances []publicMaintenanceResponse `json:"maintenances"`
+
+	images []models.StatusPageImage
 }
 
 // GetPublicStatusPage godoc
 // @Summary Get public status page
-// @Description Fetches a public status page by slug with computed status/timeline data
+// @Description Fetches a public status page by slug with computed status/timeline data. Supports conditional requests with ETag
 // @Tags status-pages
 // @Produce json
 // @Param slug path string true "Status Page Slug"
 // @Success 200 {object} response.SuccessResponse "Public status page returned"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
 // @Failure 404 {object} response.ErrorResponse "Status page not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /status-pages/{slug} [get]
 func (h *Handler) GetPublicStatusPage(c echo.Context) error {
+	resp, err := h.loadPublicStatusPage(c)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(response.Success("Status page returned", resp))
+	if err != nil {
+		zap.L().Error("Failed to marshal status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render status page")
+	}
+
+	return writeCacheable(c, echo.MIMEApplicationJSON, body, time.Time{}, publicStatusPageMaxAge)
+}
+
+// loadPublicStatusPage reads the status page of the slug route with its computed status and timelines.
+// The page itself is always read, so visibility changes apply at once; what is computed from its
+// monitors and incidents is served from the cache until the team changes something.
+func (h *Handler) loadPublicStatusPage(c echo.Context) (*publicStatusPageResponse, error) {
 	slug := c.Param("slug")
 	if slug == "" {
-		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Status page not found")
 	}
 
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
 	page, err := h.Repo.GetStatusPageBySlug(c.Request().Context(), tx, slug)
 	if err != nil {
 		zap.L().Error("Failed to get status page by slug", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
 	}
 	if page == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if err := h.authorizeStatusPage(c, tx, *page); err != nil {
+		return nil, err
+	}
+
+	images, err := h.Repo.ListStatusPageImages(c.Request().Context(), tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page images", zap.Error(err), zap.Int64("status_page_id", page.ID))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page images")
+	}
+
+	cached, generation, ok := statuspagecore.CachedPage(c.Request().Context(), page.TeamID, page.ID)
+	if ok {
+		var resp publicStatusPageResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+			}
+			resp.StatusPage = *page
+			applyPublicBranding(&resp, images)
+			return &resp, nil
+		}
 	}
 
 	groups, err := h.Repo.ListStatusPageGroupsByStatusPageID(c.Request().Context(), tx, page.ID)
 	if err != nil {
 		zap.L().Error("Failed to list status page groups", zap.Error(err), zap.Int64("status_page_id", page.ID))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page groups")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page groups")
 	}
 
 	monitors, err := h.Repo.ListStatusPageMonitorsByStatusPageID(c.Request().Context(), tx, page.ID)
 	if err != nil {
 		zap.L().Error("Failed to list status page monitors", zap.Error(err), zap.Int64("status_page_id", page.ID))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page monitors")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page monitors")
 	}
 
 	monitorIDs := make([]int64, 0, len(monitors))
@@ -115,7 +185,7 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	monitorRows, err := h.Repo.ListMonitorsByIDs(c.Request().Context(), tx, page.TeamID, monitorIDs)
 	if err != nil {
 		zap.L().Error("Failed to list monitors by ids", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list monitors")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list monitors")
 	}
 
 	monitorByID := make(map[int64]models.Monitor, len(monitorRows))
@@ -126,7 +196,7 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	incidents, err := h.Repo.ListPublicIncidentsByMonitorIDs(c.Request().Context(), tx, monitorIDs)
 	if err != nil {
 		zap.L().Error("Failed to list public incidents", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incidents")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list incidents")
 	}
 
 	openPublicIncident := make(map[int64]bool)
@@ -141,15 +211,26 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 		})
 	}
 
-	start, end := publicTimelineWindow()
-	dailySummaries, err := h.Repo.ListMonitorDailySummaryByMonitorIDs(c.Request().Context(), tx, monitorIDs, start, end)
+	now := time.Now()
+	maintenances, err := h.Repo.ListUpcomingStatusPageMaintenances(c.Request().Context(), tx, page.ID, now)
 	if err != nil {
-		zap.L().Error("Failed to list daily summaries", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list timeline data")
+		zap.L().Error("Failed to list status page maintenances", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list maintenances")
+	}
+
+	start, end := publicTimelineWindow()
+	dailySummaries, ok := statuspagecore.CachedTimeline(c.Request().Context(), page.ID, monitorIDs, start)
+	if !ok {
+		dailySummaries, err = h.Repo.ListMonitorDailySummaryByMonitorIDs(c.Request().Context(), tx, monitorIDs, start, end)
+		if err != nil {
+			zap.L().Error("Failed to list daily summaries", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list timeline data")
+		}
+		statuspagecore.CacheTimeline(c.Request().Context(), page.ID, monitorIDs, start, dailySummaries)
 	}
 
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
 	days := buildTimelineDays(start, end)
@@ -166,7 +247,9 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	groupMonitorResponses := make(map[int64][]publicStatusPageMonitor, len(groups))
 	ungroupedMonitors := make([]publicStatusPageMonitor, 0)
 	for _, monitor := range monitors {
-		status := computeMonitorStatus(monitor.MonitorID, monitorByID, openPublicIncident)
+		componentStatus := monitorComponentStatus(monitor.ComponentStatusOverride, monitor.MonitorID,
+			computeMonitorStatus(monitor.MonitorID, monitorByID, openPublicIncident), maintenances, now)
+		status := legacyStatus(componentStatus)
 		timeline, sli30, sli60, sli90 := buildTimelineSummary([]int64{monitor.MonitorID}, days, perMonitorDaily)
 
 		var groupID *string
@@ -176,13 +259,14 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 		}
 
 		responseMonitor := publicStatusPageMonitor{
-			ID:        formatID(monitor.ID),
-			MonitorID: formatID(monitor.MonitorID),
-			GroupID:   groupID,
-			Name:      monitor.Name,
-			Type:      monitor.Type,
-			SortOrder: monitor.SortOrder,
-			Status:    status,
+			ID:              formatID(monitor.ID),
+			MonitorID:       formatID(monitor.MonitorID),
+			GroupID:         groupID,
+			Name:            monitor.Name,
+			Type:            monitor.Type,
+			SortOrder:       monitor.SortOrder,
+			Status:          status,
+			ComponentStatus: componentStatus,
 		}
 
 		if monitor.Type == models.StatusPageElementTypeHistoricalTimeline {
@@ -202,7 +286,6 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	elements := make([]publicStatusPageElement, 0, len(groups)+len(ungroupedMonitors))
 	for _, group := range groups {
 		monitorIDs := groupMonitorIDs[group.ID]
-		status := computeGroupStatus(monitorIDs, monitorByID, openPublicIncident)
 		timeline, sli30, sli60, sli90 := buildTimelineSummary(monitorIDs, days, perMonitorDaily)
 
 		monitorList := groupMonitorResponses[group.ID]
@@ -210,14 +293,22 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 			monitorList = []publicStatusPageMonitor{}
 		}
 
+		memberStatuses := make([]models.ComponentStatus, 0, len(monitorList))
+		for _, monitor := range monitorList {
+			memberStatuses = append(memberStatuses, monitor.ComponentStatus)
+		}
+		componentStatus := groupComponentStatus(group.ComponentStatusOverride, memberStatuses, now)
+		status := legacyStatus(componentStatus)
+
 		responseElement := publicStatusPageElement{
-			ID:        formatID(group.ID),
-			Name:      group.Name,
-			Type:      group.Type,
-			SortOrder: group.SortOrder,
-			Status:    status,
-			Monitor:   false,
-			Monitors:  monitorList,
+			ID:              formatID(group.ID),
+			Name:            group.Name,
+			Type:            group.Type,
+			SortOrder:       group.SortOrder,
+			Status:          status,
+			ComponentStatus: componentStatus,
+			Monitor:         false,
+			Monitors:        monitorList,
 		}
 
 		if group.Type == models.StatusPageElementTypeHistoricalTimeline {
@@ -233,14 +324,15 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	for _, monitor := range ungroupedMonitors {
 		monitorID := monitor.MonitorID
 		element := publicStatusPageElement{
-			ID:        monitor.ID,
-			Name:      monitor.Name,
-			Type:      monitor.Type,
-			SortOrder: monitor.SortOrder,
-			Status:    monitor.Status,
-			Monitor:   true,
-			MonitorID: &monitorID,
-			Monitors:  []publicStatusPageMonitor{},
+			ID:              monitor.ID,
+			Name:            monitor.Name,
+			Type:            monitor.Type,
+			SortOrder:       monitor.SortOrder,
+			Status:          monitor.Status,
+			ComponentStatus: monitor.ComponentStatus,
+			Monitor:         true,
+			MonitorID:       &monitorID,
+			Monitors:        []publicStatusPageMonitor{},
 		}
 
 		if monitor.Type == models.StatusPageElementTypeHistoricalTimeline {
@@ -254,19 +346,44 @@ func (h *Handler) GetPublicStatusPage(c echo.Context) error {
 	}
 
 	resp := publicStatusPageResponse{
-		StatusPage: *page,
-		Elements:   elements,
-		Incidents:  incidentResponses,
+		StatusPage:   *page,
+		Elements:     elements,
+		Incidents:    incidentResponses,
+		Maintenances: buildPublicMaintenances(maintenances, now),
 	}
 
-	return c.JSON(http.StatusOK, response.Success("Status page returned", resp))
+	if body, err := json.Marshal(resp); err == nil {
+		statuspagecore.CachePage(c.Request().Context(), page.ID, generation, body)
+	}
+
+	applyPublicBranding(&resp, images)
+	return &resp, nil
+}
+
+func buildPublicMaintenances(maintenances []models.StatusPageMaintenance, now time.Time) []publicMaintenanceResponse {
+	responses := make([]publicMaintenanceResponse, 0, len(maintenances))
+	for _, maintenance := range maintenances {
+		monitorIDs := make([]string, 0, len(maintenance.MonitorIDs))
+		for _, monitorID := range maintenance.MonitorIDs {
+			monitorIDs = append(monitorIDs, formatID(monitorID))
+		}
+		responses = append(responses, publicMaintenanceResponse{
+			ID:         formatID(maintenance.ID),
+			Title:      maintenance.Title,
+			Message:    maintenance.Message,
+			MonitorIDs: monitorIDs,
+			StartsAt:   maintenance.StartsAt,
+			EndsAt:     maintenance.EndsAt,
+			InProgress: maintenance.InProgress(now),
+			CreatedAt:  maintenance.CreatedAt,
+			UpdatedAt:  maintenance.UpdatedAt,
+		})
+	}
+	return responses
 }
 
 func publicTimelineWindow() (time.Time, time.Time) {
-	now := time.Now().UTC()
-	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(24 * time.Hour)
-	start := end.AddDate(0, 0, -90)
-	return start, end
+	return statuspagecore.TimelineWindow(time.Now())
 }
 
 func buildTimelineDays(start time.Time, end time.Time) []time.Time {
@@ -358,25 +475,16 @@ func computeMonitorStatus(monitorID int64, monitorByID map[int64]models.Monitor,
 		return "down"
 	}
 
-	if monitor.Status == models.MonitorStatusDown {
+	switch monitor.Status {
+	case models.MonitorStatusDown:
 		return "down"
+	case models.MonitorStatusDegraded:
+		return "degraded"
 	}
 
 	return "up"
 }
 
-func computeGroupStatus(monitorIDs []int64, monitorByID map[int64]models.Monitor, openPublicIncident map[int64]bool) string {
-	if len(monitorIDs) == 0 {
-		return "up"
-	}
-	for _, monitorID := range monitorIDs {
-		if computeMonitorStatus(monitorID, monitorByID, openPublicIncident) == "down" {
-			return "down"
-		}
-	}
-	return "up"
-}
-
 func formatID(id int64) string {
 	return strconv.FormatInt(id, 10)
 }
@@ -0,0 +1,76 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newComputedPageRepo(maintenances ...models.StatusPageMaintenance) *repository.MockRepository {
+	if maintenances == nil {
+		maintenances = []models.StatusPageMaintenance{}
+	}
+	mockRepo := newAccessRepo(&models.StatusPage{ID: 5, TeamID: 9, Title: "Acme", Slug: "acme", Visibility: models.StatusPageVisibilityPublic})
+	mockRepo.On("ListStatusPageGroupsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageGroup{}, nil)
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageMonitor{{ID: 1, MonitorID: 42, Name: "API", Type: models.StatusPageElementTypeHistoricalTimeline}}, nil)
+	mockRepo.On("ListMonitorsByIDs", mock.Anything, mock.Anything, int64(9), []int64{42}).
+		Return([]models.Monitor{{ID: 42, Status: models.MonitorStatusUp}}, nil)
+	mockRepo.On("ListPublicIncidentsByMonitorIDs", mock.Anything, mock.Anything, []int64{42}).
+		Return([]models.IncidentWithMonitorID{}, nil)
+	mockRepo.On("ListMonitorDailySummaryByMonitorIDs", mock.Anything, mock.Anything, []int64{42}, mock.Anything, mock.Anything).
+		Return([]models.MonitorDailySummary{}, nil)
+	mockRepo.On("ListUpcomingStatusPageMaintenances", mock.Anything, mock.Anything, int64(5), mock.Anything).
+		Return(maintenances, nil)
+	return mockRepo
+}
+
+func TestGetPublicStatusPage_ConditionalGet(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newComputedPageRepo()}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.GetPublicStatusPage(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "public, max-age=10", rec.Header().Get("Cache-Control"))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var body struct {
+		Data publicStatusPageResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Data.Elements, 1)
+	require.Equal(t, "up", body.Data.Elements[0].Status)
+
+	c, rec = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.Request().Header.Set("If-None-Match", etag)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.GetPublicStatusPage(c))
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+}
+
+func TestGetPublicStatusPage_DeletedTeamNotFound(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	// The slug lookup hides pages of soft-deleted teams
+	h := &Handler{Repo: newAccessRepo(nil)}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	requireHTTPStatus(t, h.GetPublicStatusPage(c), http.StatusNotFound)
+}
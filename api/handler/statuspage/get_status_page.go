@@ -21,6 +21,7 @@ import (
 // @Success 200 {object} response.SuccessResponse "Status page retrieved successfully"
 // @Failure 400 {object} response.ErrorResponse "Invalid team ID or status page ID"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
 // @Failure 404 {object} response.ErrorResponse "Status page not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{teamID}/status-pages/{id} [get]
@@ -45,6 +46,10 @@ func (h *Handler) GetStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view status pages for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -52,16 +57,6 @@ func (h *Handler) GetStatusPage(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
-	}
-
 	page, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
 	if err != nil {
 		zap.L().Error("Failed to get status page", zap.Error(err))
@@ -90,6 +85,12 @@ func (h *Handler) GetStatusPage(c echo.Context) error {
 		monitors = []models.StatusPageMonitor{}
 	}
 
+	images, err := h.Repo.ListStatusPageImages(c.Request().Context(), tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page images", zap.Error(err), zap.Int64("status_page_id", page.ID))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page images")
+	}
+
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
@@ -99,6 +100,7 @@ func (h *Handler) GetStatusPage(c echo.Context) error {
 	resp := statusPageResponse{
 		StatusPage: *page,
 		Elements:   elements,
+		Images:     images,
 	}
 
 	return c.JSON(http.StatusOK, response.Success("Status page retrieved successfully", resp))
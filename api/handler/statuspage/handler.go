@@ -1,8 +1,14 @@
 package statuspage
 
-import "github.com/yorukot/knocker/repository"
+import (
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
 
 // Handler contains dependencies for status page endpoints.
 type Handler struct {
-	Repo repository.Repository
+	Repo        repository.Repository
+	AsynqClient tasks.Enqueuer
+	Resolver    statuspagecore.TXTResolver // Verifies custom domains, net.DefaultResolver when nil
 }
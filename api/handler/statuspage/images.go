@@ -0,0 +1,367 @@
+package statuspage
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Logo and favicon                             |
+// +----------------------------------------------+
+
+// statusPageImageMaxAge is how long browsers may reuse an image; its URL changes with every upload
+const statusPageImageMaxAge = 24 * time.Hour
+
+// statusPageImageFormOverhead is room for the multipart framing around the uploaded file
+const statusPageImageFormOverhead = 16 << 10
+
+type statusPageImageRule struct {
+	MaxBytes     int64
+	ContentTypes []string
+}
+
+// statusPageImageRules limit uploads per image kind. The type is sniffed from the bytes, never taken
+// from the client, and SVG is not accepted since it can carry scripts.
+var statusPageImageRules = map[models.StatusPageImageKind]statusPageImageRule{
+	models.StatusPageImageKindLogo:    {MaxBytes: 512 << 10, ContentTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp"}},
+	models.StatusPageImageKindFavicon: {MaxBytes: 64 << 10, ContentTypes: []string{"image/png", "image/x-icon"}},
+}
+
+// UploadStatusPageImage godoc
+// @Summary Upload a status page logo or favicon
+// @Description Replaces the logo (PNG, JPEG, GIF or WebP up to 512 KiB) or favicon (PNG or ICO up to 64 KiB) of a status page
+// @Tags status_pages
+// @Accept mpfd
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param kind path string true "logo or favicon"
+// @Param file formData file true "Image"
+// @Success 200 {object} response.SuccessResponse{data=models.StatusPageImage} "Image uploaded successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 413 {object} response.ErrorResponse "Image is too large"
+// @Failure 415 {object} response.ErrorResponse "Unsupported image type"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/images/{kind} [put]
+func (h *Handler) UploadStatusPageImage(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	kind := models.StatusPageImageKind(c.Param("kind"))
+	rule, ok := statusPageImageRules[kind]
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid image kind")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	data, err := readStatusPageImage(c, rule)
+	if err != nil {
+		return err
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(rule.ContentTypes, contentType) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Unsupported image type")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	existing, err := h.Repo.GetStatusPageImage(ctx, tx, page.ID, kind)
+	if err != nil {
+		zap.L().Error("Failed to get status page image", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get image")
+	}
+
+	image := models.StatusPageImage{
+		StatusPageID: page.ID,
+		Kind:         kind,
+		ContentType:  contentType,
+		Data:         data,
+		UpdatedAt:    time.Now(),
+	}
+
+	if err := h.Repo.UpsertStatusPageImage(ctx, tx, image); err != nil {
+		zap.L().Error("Failed to store status page image", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to store image")
+	}
+
+	var before any
+	if existing != nil {
+		before = *existing
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       before,
+		After:        image,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Image uploaded successfully", image))
+}
+
+// DeleteStatusPageImage godoc
+// @Summary Delete a status page logo or favicon
+// @Description Removes the logo or favicon of a status page (owner/admin only)
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param kind path string true "logo or favicon"
+// @Success 200 {object} response.SuccessResponse "Image deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID, status page ID or image kind"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or image not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/images/{kind} [delete]
+func (h *Handler) DeleteStatusPageImage(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	kind := models.StatusPageImageKind(c.Param("kind"))
+	if _, ok := statusPageImageRules[kind]; !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid image kind")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	existing, err := h.Repo.GetStatusPageImage(ctx, tx, page.ID, kind)
+	if err != nil {
+		zap.L().Error("Failed to get status page image", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get image")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+	}
+
+	if err := h.Repo.DeleteStatusPageImage(ctx, tx, page.ID, kind); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+		}
+		zap.L().Error("Failed to delete status page image", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete image")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *existing,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Image deleted successfully", nil))
+}
+
+// GetPublicStatusPageImage godoc
+// @Summary Get a status page logo or favicon
+// @Description Serves the logo or favicon of a status page to the visitors who may see the page
+// @Tags status-pages
+// @Produce png,jpeg,gif,webp,x-icon
+// @Param slug path string true "Status Page Slug"
+// @Param kind path string true "logo or favicon"
+// @Success 200 {file} binary "Image"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page or image not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/images/{kind} [get]
+func (h *Handler) GetPublicStatusPageImage(c echo.Context) error {
+	kind := models.StatusPageImageKind(c.Param("kind"))
+	if _, ok := statusPageImageRules[kind]; !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, c.Param("slug"))
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if err := h.authorizeStatusPage(c, tx, *page); err != nil {
+		return err
+	}
+
+	image, err := h.Repo.GetStatusPageImage(ctx, tx, page.ID, kind)
+	if err != nil {
+		zap.L().Error("Failed to get status page image", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get image")
+	}
+	if image == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return writeCacheable(c, image.ContentType, image.Data, image.UpdatedAt, statusPageImageMaxAge)
+}
+
+// readStatusPageImage reads the uploaded file of the request, refusing anything over the rule's size.
+func readStatusPageImage(c echo.Context, rule statusPageImageRule) ([]byte, error) {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, rule.MaxBytes+statusPageImageFormOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Image is too large")
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Missing image file")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		zap.L().Error("Failed to open uploaded image", zap.Error(err))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid image file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, rule.MaxBytes+1))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid image file")
+	}
+	if int64(len(data)) > rule.MaxBytes {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Image is too large")
+	}
+	if len(data) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Missing image file")
+	}
+
+	return data, nil
+}
+
+// statusPageImageURL is where visitors of the page load the image. The version changes with every
+// upload, so the image can be cached for long.
+func statusPageImageURL(slug string, image models.StatusPageImage) string {
+	return "/api/status-pages/" + url.PathEscape(slug) + "/" + statusPageImagePath(image)
+}
+
+// statusPageImagePath is the image URL relative to the page, which also works on custom domains
+func statusPageImagePath(image models.StatusPageImage) string {
+	return "images/" + string(image.Kind) + "?v=" + strconv.FormatInt(image.UpdatedAt.Unix(), 10)
+}
@@ -0,0 +1,113 @@
+package statuspage
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+)
+
+// pngHeader is enough of a PNG for its type to be sniffed
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newImageUploadContext(t *testing.T, kind string, data []byte) (echo.Context, *bytes.Buffer) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "logo.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	c, rec := testutil.NewEchoContext(http.MethodPut, "/api/teams/10/status-pages/5/images/"+kind, &body)
+	c.Request().Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	c.SetParamNames("teamID", "id", "kind")
+	c.SetParamValues("10", "5", kind)
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{TeamID: 10, UserID: 123, Role: models.MemberRoleAdmin})
+	return c, rec.Body
+}
+
+func TestUploadStatusPageImage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})
+	mockRepo.On("GetStatusPageImage", mock.Anything, mock.Anything, int64(5), models.StatusPageImageKindLogo).Return(nil, nil)
+	mockRepo.On("UpsertStatusPageImage", mock.Anything, mock.Anything, mock.MatchedBy(func(image models.StatusPageImage) bool {
+		return image.StatusPageID == 5 && image.Kind == models.StatusPageImageKindLogo && image.ContentType == "image/png"
+	})).Return(nil)
+
+	h := &Handler{Repo: mockRepo}
+
+	c, body := newImageUploadContext(t, "logo", pngHeader)
+	require.NoError(t, h.UploadStatusPageImage(c))
+	require.Contains(t, body.String(), `"content_type":"image/png"`)
+	mockRepo.AssertCalled(t, "UpsertStatusPageImage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUploadStatusPageImage_Rejected(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})}
+
+	for name, tc := range map[string]struct {
+		kind string
+		data []byte
+		code int
+	}{
+		"unknown kind":      {kind: "banner", data: pngHeader, code: http.StatusBadRequest},
+		"empty file":        {kind: "logo", data: nil, code: http.StatusBadRequest},
+		"svg logo":          {kind: "logo", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`), code: http.StatusUnsupportedMediaType},
+		"jpeg favicon":      {kind: "favicon", data: []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), code: http.StatusUnsupportedMediaType},
+		"oversized favicon": {kind: "favicon", data: append(append([]byte{}, pngHeader...), make([]byte, 64<<10)...), code: http.StatusRequestEntityTooLarge},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newImageUploadContext(t, tc.kind, tc.data)
+			requireHTTPStatus(t, h.UploadStatusPageImage(c), tc.code)
+		})
+	}
+}
+
+func TestDeleteStatusPageImage_NotFound(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})
+	mockRepo.On("GetStatusPageImage", mock.Anything, mock.Anything, int64(5), models.StatusPageImageKindFavicon).Return(nil, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c := newDomainContext(http.MethodDelete, "/images/favicon", "")
+	c.SetParamNames("teamID", "id", "kind")
+	c.SetParamValues("10", "5", "favicon")
+	requireHTTPStatus(t, h.DeleteStatusPageImage(c), http.StatusNotFound)
+}
+
+func TestGetPublicStatusPageImage(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newAccessRepo(&models.StatusPage{ID: 5, TeamID: 9, Slug: "acme", Visibility: models.StatusPageVisibilityPublic})
+	mockRepo.On("GetStatusPageImage", mock.Anything, mock.Anything, int64(5), models.StatusPageImageKindLogo).
+		Return(&models.StatusPageImage{StatusPageID: 5, Kind: models.StatusPageImageKindLogo, ContentType: "image/png", Data: pngHeader, UpdatedAt: time.Now()}, nil)
+	mockRepo.On("GetStatusPageImage", mock.Anything, mock.Anything, int64(5), models.StatusPageImageKindFavicon).Return(nil, nil)
+
+	h := &Handler{Repo: mockRepo}
+
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme/images/logo", nil)
+	c.SetParamNames("slug", "kind")
+	c.SetParamValues("acme", "logo")
+	require.NoError(t, h.GetPublicStatusPageImage(c))
+	require.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, pngHeader, rec.Body.Bytes())
+
+	c, _ = testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme/images/favicon", nil)
+	c.SetParamNames("slug", "kind")
+	c.SetParamValues("acme", "favicon")
+	requireHTTPStatus(t, h.GetPublicStatusPageImage(c), http.StatusNotFound)
+}
@@ -0,0 +1,462 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Scheduled maintenance                        |
+// +----------------------------------------------+
+
+// statusPageMaintenanceRequest announces maintenance. Without monitor_ids it covers the whole page.
+type statusPageMaintenanceRequest struct {
+	Title      string       `json:"title" validate:"required,min=1,max=255" example:"Database upgrade"`
+	Message    string       `json:"message" validate:"max=10000"`
+	MonitorIDs utils.IDList `json:"monitor_ids" validate:"max=100"`
+	StartsAt   time.Time    `json:"starts_at" validate:"required"`
+	EndsAt     time.Time    `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+// bindStatusPageMaintenance decodes and validates a maintenance request.
+func bindStatusPageMaintenance(c echo.Context) (statusPageMaintenanceRequest, error) {
+	var req statusPageMaintenanceRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return req, echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return req, echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if !req.EndsAt.After(time.Now()) {
+		return req, echo.NewHTTPError(http.StatusBadRequest, "ends_at must be in the future")
+	}
+
+	return req, nil
+}
+
+// ListStatusPageMaintenances godoc
+// @Summary List status page maintenance
+// @Description Lists the scheduled maintenance of a status page, latest first
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Success 200 {object} response.SuccessResponse{data=[]models.StatusPageMaintenance} "Maintenance retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID or status page ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/maintenances [get]
+func (h *Handler) ListStatusPageMaintenances(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionView) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to view status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	maintenances, err := h.Repo.ListStatusPageMaintenances(ctx, tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page maintenances", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list maintenances")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Maintenance retrieved successfully", maintenances))
+}
+
+// CreateStatusPageMaintenance godoc
+// @Summary Schedule status page maintenance
+// @Description Announces maintenance on a status page; the affected components show as under maintenance during the window (owner/admin only)
+// @Tags status_pages
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param request body statusPageMaintenanceRequest true "Maintenance request"
+// @Success 200 {object} response.SuccessResponse{data=models.StatusPageMaintenance} "Maintenance scheduled successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/maintenances [post]
+func (h *Handler) CreateStatusPageMaintenance(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	req, err := bindStatusPageMaintenance(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	monitorIDs, err := h.pageMonitorIDs(ctx, tx, page.ID, req.MonitorIDs.Int64s())
+	if err != nil {
+		return err
+	}
+
+	maintenanceID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate maintenance ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate maintenance ID")
+	}
+
+	now := time.Now().UTC()
+	maintenance := models.StatusPageMaintenance{
+		ID:           maintenanceID,
+		StatusPageID: page.ID,
+		Title:        req.Title,
+		Message:      req.Message,
+		MonitorIDs:   monitorIDs,
+		StartsAt:     req.StartsAt.UTC(),
+		EndsAt:       req.EndsAt.UTC(),
+		UpdatedAt:    now,
+		CreatedAt:    now,
+	}
+
+	if err := h.Repo.CreateStatusPageMaintenance(ctx, tx, maintenance); err != nil {
+		zap.L().Error("Failed to create status page maintenance", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create maintenance")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceStatusPageMaintenance,
+		ResourceID:   maintenance.ID,
+		After:        maintenance,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	return c.JSON(http.StatusOK, response.Success("Maintenance scheduled successfully", maintenance))
+}
+
+// UpdateStatusPageMaintenance godoc
+// @Summary Update status page maintenance
+// @Description Changes the window, text or affected components of scheduled maintenance (owner/admin only)
+// @Tags status_pages
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param maintenanceID path string true "Maintenance ID"
+// @Param request body statusPageMaintenanceRequest true "Maintenance request"
+// @Success 200 {object} response.SuccessResponse{data=models.StatusPageMaintenance} "Maintenance updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or maintenance not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/maintenances/{maintenanceID} [put]
+func (h *Handler) UpdateStatusPageMaintenance(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	maintenanceID, err := strconv.ParseInt(c.Param("maintenanceID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid maintenance ID")
+	}
+
+	req, err := bindStatusPageMaintenance(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	existing, err := h.Repo.GetStatusPageMaintenanceByID(ctx, tx, page.ID, maintenanceID)
+	if err != nil {
+		zap.L().Error("Failed to get status page maintenance", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get maintenance")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Maintenance not found")
+	}
+
+	monitorIDs, err := h.pageMonitorIDs(ctx, tx, page.ID, req.MonitorIDs.Int64s())
+	if err != nil {
+		return err
+	}
+
+	updated := *existing
+	updated.Title = req.Title
+	updated.Message = req.Message
+	updated.MonitorIDs = monitorIDs
+	updated.StartsAt = req.StartsAt.UTC()
+	updated.EndsAt = req.EndsAt.UTC()
+	updated.UpdatedAt = time.Now().UTC()
+
+	maintenance, err := h.Repo.UpdateStatusPageMaintenance(ctx, tx, updated)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Maintenance not found")
+		}
+		zap.L().Error("Failed to update status page maintenance", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update maintenance")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPageMaintenance,
+		ResourceID:   maintenance.ID,
+		Before:       *existing,
+		After:        *maintenance,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	return c.JSON(http.StatusOK, response.Success("Maintenance updated successfully", maintenance))
+}
+
+// DeleteStatusPageMaintenance godoc
+// @Summary Delete status page maintenance
+// @Description Removes scheduled maintenance from a status page (owner/admin only)
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param maintenanceID path string true "Maintenance ID"
+// @Success 200 {object} response.SuccessResponse "Maintenance deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID, status page ID or maintenance ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or maintenance not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/maintenances/{maintenanceID} [delete]
+func (h *Handler) DeleteStatusPageMaintenance(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	maintenanceID, err := strconv.ParseInt(c.Param("maintenanceID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid maintenance ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	existing, err := h.Repo.GetStatusPageMaintenanceByID(ctx, tx, page.ID, maintenanceID)
+	if err != nil {
+		zap.L().Error("Failed to get status page maintenance", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get maintenance")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Maintenance not found")
+	}
+
+	if err := h.Repo.DeleteStatusPageMaintenance(ctx, tx, page.ID, maintenanceID); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Maintenance not found")
+		}
+		zap.L().Error("Failed to delete status page maintenance", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete maintenance")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceStatusPageMaintenance,
+		ResourceID:   maintenanceID,
+		Before:       *existing,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Maintenance deleted successfully"))
+}
@@ -0,0 +1,78 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestCreateStatusPageMaintenance(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageMonitor{{ID: 1, MonitorID: 42}}, nil)
+	mockRepo.On("CreateStatusPageMaintenance", mock.Anything, mock.Anything, mock.MatchedBy(func(m models.StatusPageMaintenance) bool {
+		return m.StatusPageID == 5 && m.Title == "Database upgrade" && len(m.MonitorIDs) == 1 && m.MonitorIDs[0] == 42
+	})).Return(nil)
+
+	h := &Handler{Repo: mockRepo}
+	startsAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	endsAt := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+	c := newDomainContext(http.MethodPost, "/maintenances",
+		`{"title":"Database upgrade","monitor_ids":["42","42"],"starts_at":"`+startsAt+`","ends_at":"`+endsAt+`"}`)
+	require.NoError(t, h.CreateStatusPageMaintenance(c))
+	mockRepo.AssertCalled(t, "CreateStatusPageMaintenance", mock.Anything, mock.Anything, mock.Anything)
+
+	c = newDomainContext(http.MethodPost, "/maintenances",
+		`{"title":"Database upgrade","monitor_ids":["43"],"starts_at":"`+startsAt+`","ends_at":"`+endsAt+`"}`)
+	requireHTTPStatus(t, h.CreateStatusPageMaintenance(c), http.StatusBadRequest)
+}
+
+func TestCreateStatusPageMaintenance_InvalidWindow(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newDomainRepo(nil)}
+	now := time.Now().UTC()
+
+	for name, body := range map[string]string{
+		"ends before start": `{"title":"Upgrade","starts_at":"` + now.Add(2*time.Hour).Format(time.RFC3339) + `","ends_at":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}`,
+		"already over":      `{"title":"Upgrade","starts_at":"` + now.Add(-2*time.Hour).Format(time.RFC3339) + `","ends_at":"` + now.Add(-time.Hour).Format(time.RFC3339) + `"}`,
+		"no title":          `{"starts_at":"` + now.Add(time.Hour).Format(time.RFC3339) + `","ends_at":"` + now.Add(2*time.Hour).Format(time.RFC3339) + `"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newDomainContext(http.MethodPost, "/maintenances", body)
+			requireHTTPStatus(t, h.CreateStatusPageMaintenance(c), http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGetPublicStatusPage_Maintenance(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	now := time.Now()
+	h := &Handler{Repo: newComputedPageRepo(models.StatusPageMaintenance{
+		ID: 77, Title: "Database upgrade", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour),
+	})}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/acme", nil)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+
+	require.NoError(t, h.GetPublicStatusPage(c))
+
+	var body struct {
+		Data publicStatusPageResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Data.Maintenances, 1)
+	require.True(t, body.Data.Maintenances[0].InProgress)
+	require.Equal(t, models.ComponentStatusUnderMaintenance, body.Data.Elements[0].ComponentStatus)
+	require.Equal(t, "up", body.Data.Elements[0].Status)
+}
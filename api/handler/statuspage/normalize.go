@@ -23,6 +23,7 @@ func normalizeStatusPageUpsert(req statusPageUpsertRequest) (statusPageUpsertReq
 				Name:      element.Name,
 				Type:      element.Type,
 				SortOrder: element.SortOrder,
+				Badge:     element.Badge,
 			})
 			continue
 		}
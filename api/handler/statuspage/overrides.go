@@ -0,0 +1,210 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Component status overrides                   |
+// +----------------------------------------------+
+
+type elementStatusOverrideRequest struct {
+	Status    models.ComponentStatus `json:"status" validate:"required,oneof=operational degraded_performance partial_outage major_outage under_maintenance" example:"degraded_performance"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+}
+
+// SetStatusPageElementStatus godoc
+// @Summary Override the status of a status page element
+// @Description Shows the given status for a group or monitor of a status page instead of the one computed from monitors, until it expires or is cleared
+// @Tags status_pages
+// @Accept json
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param elementID path string true "Group or status page monitor ID"
+// @Param request body elementStatusOverrideRequest true "Status override"
+// @Success 200 {object} response.SuccessResponse{data=statusPageResponse} "Status override set successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or element not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/elements/{elementID}/status [put]
+func (h *Handler) SetStatusPageElementStatus(c echo.Context) error {
+	var req elementStatusOverrideRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return echo.NewHTTPError(http.StatusBadRequest, "expires_at must be in the future")
+	}
+
+	status := req.Status
+	return h.writeElementStatusOverride(c, models.ComponentStatusOverride{
+		StatusOverride:          &status,
+		StatusOverrideExpiresAt: req.ExpiresAt,
+	}, "Status override set successfully")
+}
+
+// ClearStatusPageElementStatus godoc
+// @Summary Clear the status override of a status page element
+// @Description Goes back to showing the status computed from monitors for a group or monitor of a status page
+// @Tags status_pages
+// @Produce json
+// @Param teamID path string true "Team ID"
+// @Param id path string true "Status Page ID"
+// @Param elementID path string true "Group or status page monitor ID"
+// @Success 200 {object} response.SuccessResponse{data=statusPageResponse} "Status override cleared successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID, status page ID or element ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Status page or element not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{teamID}/status-pages/{id}/elements/{elementID}/status [delete]
+func (h *Handler) ClearStatusPageElementStatus(c echo.Context) error {
+	return h.writeElementStatusOverride(c, models.ComponentStatusOverride{}, "Status override cleared successfully")
+}
+
+// writeElementStatusOverride stores the override of the element named by the path and answers with the page.
+func (h *Handler) writeElementStatusOverride(c echo.Context, override models.ComponentStatusOverride, message string) error {
+	teamID, err := strconv.ParseInt(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	statusPageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status page ID")
+	}
+
+	elementID, err := strconv.ParseInt(c.Param("elementID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid element ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
+	ctx := c.Request().Context()
+
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageByID(ctx, tx, teamID, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to get status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	before, err := h.loadStatusPageAuditSnapshot(ctx, tx, *page)
+	if err != nil {
+		zap.L().Error("Failed to load status page elements", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load status page elements")
+	}
+
+	if err := h.Repo.UpdateStatusPageElementStatusOverride(ctx, tx, page.ID, elementID, override); err != nil {
+		if err == pgx.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Element not found")
+		}
+		zap.L().Error("Failed to update status page element status", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update element status")
+	}
+
+	after, err := h.loadStatusPageAuditSnapshot(ctx, tx, *page)
+	if err != nil {
+		zap.L().Error("Failed to load status page elements", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load status page elements")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *before,
+		After:        *after,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	statuspagecore.InvalidateTeamPages(ctx, teamID)
+
+	return c.JSON(http.StatusOK, response.Success(message, statusPageResponse{StatusPage: *page, Elements: after.Elements}))
+}
+
+// carryStatusOverrides keeps the overrides of elements that survive an edit of the page, since
+// every edit recreates the elements. Groups are matched by the ID the client sent back, monitors
+// by the monitor they show.
+func carryStatusOverrides(req statusPageUpsertRequest, groups []models.StatusPageGroup, monitors []models.StatusPageMonitor, previousGroups []models.StatusPageGroup, previousMonitors []models.StatusPageMonitor) {
+	groupOverrides := make(map[int64]models.ComponentStatusOverride, len(previousGroups))
+	for _, group := range previousGroups {
+		if group.StatusOverride != nil {
+			groupOverrides[group.ID] = group.ComponentStatusOverride
+		}
+	}
+	for i, input := range req.Groups {
+		if input.ID == nil || i >= len(groups) {
+			continue
+		}
+		if override, ok := groupOverrides[*input.ID]; ok {
+			groups[i].ComponentStatusOverride = override
+		}
+	}
+
+	monitorOverrides := make(map[int64][]models.ComponentStatusOverride)
+	for _, monitor := range previousMonitors {
+		if monitor.StatusOverride != nil {
+			monitorOverrides[monitor.MonitorID] = append(monitorOverrides[monitor.MonitorID], monitor.ComponentStatusOverride)
+		}
+	}
+	for i := range monitors {
+		overrides := monitorOverrides[monitors[i].MonitorID]
+		if len(overrides) == 0 {
+			continue
+		}
+		monitors[i].ComponentStatusOverride = overrides[0]
+		monitorOverrides[monitors[i].MonitorID] = overrides[1:]
+	}
+}
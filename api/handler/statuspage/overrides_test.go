@@ -0,0 +1,71 @@
+package statuspage
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestSetStatusPageElementStatus(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newDomainRepo(&models.StatusPage{ID: 5, TeamID: 10, Slug: "acme"})
+	mockRepo.On("ListStatusPageGroupsByStatusPageID", mock.Anything, mock.Anything, int64(5)).Return([]models.StatusPageGroup{}, nil)
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).Return([]models.StatusPageMonitor{}, nil)
+	mockRepo.On("UpdateStatusPageElementStatusOverride", mock.Anything, mock.Anything, int64(5), int64(7), mock.MatchedBy(func(o models.ComponentStatusOverride) bool {
+		return o.StatusOverride != nil && *o.StatusOverride == models.ComponentStatusPartialOutage && o.StatusOverrideExpiresAt == nil
+	})).Return(nil)
+	mockRepo.On("UpdateStatusPageElementStatusOverride", mock.Anything, mock.Anything, int64(5), int64(8), mock.Anything).Return(pgx.ErrNoRows)
+
+	h := &Handler{Repo: mockRepo}
+
+	c := newDomainContext(http.MethodPut, "/elements/7/status", `{"status":"partial_outage"}`)
+	c.SetParamNames("teamID", "id", "elementID")
+	c.SetParamValues("10", "5", "7")
+	require.NoError(t, h.SetStatusPageElementStatus(c))
+
+	c = newDomainContext(http.MethodPut, "/elements/8/status", `{"status":"partial_outage"}`)
+	c.SetParamNames("teamID", "id", "elementID")
+	c.SetParamValues("10", "5", "8")
+	requireHTTPStatus(t, h.SetStatusPageElementStatus(c), http.StatusNotFound)
+
+	c = newDomainContext(http.MethodPut, "/elements/7/status", `{"status":"on_fire"}`)
+	c.SetParamNames("teamID", "id", "elementID")
+	c.SetParamValues("10", "5", "7")
+	requireHTTPStatus(t, h.SetStatusPageElementStatus(c), http.StatusBadRequest)
+
+	c = newDomainContext(http.MethodPut, "/elements/7/status", `{"status":"major_outage","expires_at":"2020-01-01T00:00:00Z"}`)
+	c.SetParamNames("teamID", "id", "elementID")
+	c.SetParamValues("10", "5", "7")
+	requireHTTPStatus(t, h.SetStatusPageElementStatus(c), http.StatusBadRequest)
+}
+
+func TestCarryStatusOverrides(t *testing.T) {
+	degraded := models.ComponentStatusDegradedPerformance
+	maintenance := models.ComponentStatusUnderMaintenance
+	expiresAt := time.Now().Add(time.Hour)
+
+	previousGroupID := int64(100)
+	tempGroupID := int64(-1)
+	req := statusPageUpsertRequest{Groups: []statusPageGroupInput{{ID: &previousGroupID}, {ID: &tempGroupID}}}
+	groups := []models.StatusPageGroup{{ID: 200}, {ID: 201}}
+	monitors := []models.StatusPageMonitor{{ID: 300, MonitorID: 42}, {ID: 301, MonitorID: 42}, {ID: 302, MonitorID: 43}}
+
+	carryStatusOverrides(req, groups, monitors,
+		[]models.StatusPageGroup{{ID: 100, ComponentStatusOverride: models.ComponentStatusOverride{StatusOverride: &degraded, StatusOverrideExpiresAt: &expiresAt}}},
+		[]models.StatusPageMonitor{{ID: 150, MonitorID: 42, ComponentStatusOverride: models.ComponentStatusOverride{StatusOverride: &maintenance}}},
+	)
+
+	require.Equal(t, &degraded, groups[0].StatusOverride)
+	require.Equal(t, &expiresAt, groups[0].StatusOverrideExpiresAt)
+	require.Nil(t, groups[1].StatusOverride)
+	require.Equal(t, &maintenance, monitors[0].StatusOverride)
+	require.Nil(t, monitors[1].StatusOverride, "a monitor shown twice keeps one override")
+	require.Nil(t, monitors[2].StatusOverride)
+}
@@ -0,0 +1,290 @@
+package statuspage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"go.uber.org/zap"
+)
+
+// statusPageHTMLMaxAge is how long browsers may reuse the server-rendered page
+const statusPageHTMLMaxAge = 30 * time.Second
+
+// statusPageHTML is a plain server-rendered status page for custom domains, readable without JavaScript
+var statusPageHTML = template.Must(template.New("status_page").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} {{.Strings.status}}</title>
+{{if .FaviconPath}}<link rel="icon" href="{{.FaviconPath}}">
+{{end}}<link rel="alternate" type="application/rss+xml" title="{{.Title}} incidents" href="feed.rss">
+<link rel="alternate" type="application/atom+xml" title="{{.Title}} incidents" href="feed.atom">
+<style>
+body{font-family:system-ui,sans-serif;max-width:720px;margin:2rem auto;padding:0 1rem;color:{{.TextColor}};background:{{.BackgroundColor}}}
+header{display:flex;align-items:center;justify-content:space-between;flex-wrap:wrap;gap:1rem}header img{max-height:48px}
+nav a{margin-left:1rem;color:{{.BrandColor}}}
+.banner{padding:1rem;border-radius:6px;color:#fff;font-weight:600}
+.up{background:#2f9e44}.maintenance{background:#1c7ed6}.degraded{background:#f59f00}.partial{background:#f08c00}.down{background:#e03131}
+ul{list-style:none;padding:0}li{display:flex;justify-content:space-between;padding:.6rem 0;border-bottom:1px solid #eee}
+.status-operational{color:#2f9e44}.status-under_maintenance{color:#1c7ed6}.status-degraded_performance{color:#f59f00}
+.status-partial_outage{color:#f08c00}.status-major_outage{color:#e03131}small{color:#666}
+h2{color:{{.BrandColor}}}footer{margin-top:2rem;color:#666}
+</style>
+</head>
+<body>
+<header>{{if .LogoPath}}<img src="{{.LogoPath}}" alt="{{.Title}}">{{else}}<h1>{{.Title}}</h1>{{end}}
+{{if .HeaderLinks}}<nav>{{range .HeaderLinks}}<a href="{{.URL}}" rel="noopener">{{.Label}}</a>{{end}}</nav>{{end}}</header>
+<p class="banner {{.Overall.Class}}">{{.Overall.Text}}</p>
+{{if .Incidents}}<h2>{{.Strings.active_incidents}}</h2>
+<ul>{{range .Incidents}}<li><span>{{.Title}}</span><small>{{.Since}}</small></li>{{end}}</ul>
+{{end}}{{if .Maintenances}}<h2>{{.Strings.scheduled_maintenance}}</h2>
+<ul>{{range .Maintenances}}<li><span>{{.Title}}{{if .InProgress}} <small>{{$.Strings.in_progress}}</small>{{end}}{{if .Message}}<br><small>{{.Message}}</small>{{end}}</span><small>{{.Window}}</small></li>{{end}}</ul>
+{{end}}<h2>{{.Strings.components}}</h2>
+<ul>{{range .Components}}<li><span>{{.Name}}{{if .Uptime}} <small>{{.Uptime}}</small>{{end}}</span><span class="status-{{.Status}}">{{.Label}}</span></li>{{end}}</ul>
+{{if .About}}<h2>{{.Strings.about}}</h2>
+{{range .About}}<p>{{.}}</p>{{end}}
+{{end}}{{if .Footer}}<footer>{{range .Footer}}<p>{{.}}</p>{{end}}</footer>
+{{end}}</body>
+</html>
+`))
+
+// statusPageUnlockHTML asks for the password of a protected page and posts it to the unlock route
+var statusPageUnlockHTML = template.Must(template.New("status_page_unlock").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:420px;margin:4rem auto;padding:0 1rem;color:#222}
+input{width:100%;padding:.5rem;margin:.5rem 0;box-sizing:border-box}button{padding:.5rem 1rem}
+</style>
+</head>
+<body>
+<h1>Password required</h1>
+<p>This status page is password protected.</p>
+<form method="post" action="unlock">
+<input type="password" name="password" aria-label="Password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// Colors of a page that sets none of its own
+const (
+	defaultStatusPageBrandColor      = "#222222"
+	defaultStatusPageBackgroundColor = "#ffffff"
+	defaultStatusPageTextColor       = "#222222"
+)
+
+type statusPageHTMLData struct {
+	Lang            string
+	Title           string
+	Strings         map[string]string
+	LogoPath        string
+	FaviconPath     string
+	BrandColor      string
+	BackgroundColor string
+	TextColor       string
+	HeaderLinks     []models.StatusPageLink
+	Overall         statusPageHTMLBanner
+	Incidents       []statusPageHTMLIncident
+	Maintenances    []statusPageHTMLMaintenance
+	Components      []statusPageHTMLComponent
+	// Markdown is shown as plain paragraphs; the web app renders it
+	About  []string
+	Footer []string
+}
+
+type statusPageHTMLBanner struct {
+	Class string
+	Text  string
+}
+
+type statusPageHTMLIncident struct {
+	Title string
+	Since string
+}
+
+type statusPageHTMLMaintenance struct {
+	Title      string
+	Message    string
+	InProgress bool
+	Window     string
+}
+
+type statusPageHTMLComponent struct {
+	Name   string
+	Status models.ComponentStatus
+	Label  string
+	Uptime string
+}
+
+// GetPublicStatusPageHTML godoc
+// @Summary Get public status page as HTML
+// @Description Renders a public status page as plain HTML, served at the root of its custom domain for browsers
+// @Tags status-pages
+// @Produce html
+// @Param slug path string true "Status Page Slug"
+// @Success 200 {string} string "Status page"
+// @Success 304 {string} string "Not modified"
+// @Failure 401 {string} string "Password form of a protected page"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/index.html [get]
+func (h *Handler) GetPublicStatusPageHTML(c echo.Context) error {
+	resp, err := h.loadPublicStatusPage(c)
+	if errors.Is(err, errStatusPagePasswordRequired) {
+		var buf bytes.Buffer
+		if err := statusPageUnlockHTML.Execute(&buf, nil); err != nil {
+			zap.L().Error("Failed to render status page password form", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render status page")
+		}
+		c.Response().Header().Set("Cache-Control", "no-store")
+		return c.HTMLBlob(http.StatusUnauthorized, buf.Bytes())
+	}
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := statusPageHTML.Execute(&buf, buildStatusPageHTMLData(*resp)); err != nil {
+		zap.L().Error("Failed to render status page", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render status page")
+	}
+
+	return writeCacheable(c, echo.MIMETextHTMLCharsetUTF8, buf.Bytes(), time.Time{}, statusPageHTMLMaxAge)
+}
+
+// buildStatusPageHTMLData flattens the public response into what the HTML page shows, in the
+// language and time zone of the page.
+func buildStatusPageHTMLData(resp publicStatusPageResponse) statusPageHTMLData {
+	elements := append([]publicStatusPageElement(nil), resp.Elements...)
+	sort.SliceStable(elements, func(i, j int) bool { return elements[i].SortOrder < elements[j].SortOrder })
+
+	page := resp.StatusPage
+	translated := statusPageStrings(page.Language)
+	location := statusPageLocation(page.Timezone)
+
+	data := statusPageHTMLData{
+		Lang:            page.Language,
+		Title:           page.Title,
+		Strings:         translated,
+		BrandColor:      colorOr(page.BrandColor, defaultStatusPageBrandColor),
+		BackgroundColor: colorOr(page.BackgroundColor, defaultStatusPageBackgroundColor),
+		TextColor:       colorOr(page.TextColor, defaultStatusPageTextColor),
+		HeaderLinks:     page.HeaderLinks,
+		About:           markdownParagraphs(page.AboutMarkdown),
+		Footer:          markdownParagraphs(page.FooterMarkdown),
+	}
+	if data.Lang == "" {
+		data.Lang = defaultStatusPageLanguage
+	}
+	for _, image := range resp.images {
+		switch image.Kind {
+		case models.StatusPageImageKindLogo:
+			data.LogoPath = statusPageImagePath(image)
+		case models.StatusPageImageKindFavicon:
+			data.FaviconPath = statusPageImagePath(image)
+		}
+	}
+
+	statuses := make([]models.ComponentStatus, 0, len(elements))
+	for _, element := range elements {
+		statuses = append(statuses, element.ComponentStatus)
+		component := statusPageHTMLComponent{
+			Name:   element.Name,
+			Status: element.ComponentStatus,
+			Label:  translated[string(element.ComponentStatus)],
+		}
+		if element.UptimeSLI90 != 0 {
+			component.Uptime = fillPlaceholders(translated["uptime_90_days"], "uptime", fmt.Sprintf("%.2f", element.UptimeSLI90))
+		}
+		data.Components = append(data.Components, component)
+	}
+
+	switch overall := groupComponentStatus(models.ComponentStatusOverride{}, statuses, time.Now()); overall {
+	case models.ComponentStatusUnderMaintenance:
+		data.Overall = statusPageHTMLBanner{Class: "maintenance", Text: translated["maintenance_in_progress"]}
+	case models.ComponentStatusDegradedPerformance:
+		data.Overall = statusPageHTMLBanner{Class: "degraded", Text: translated[string(overall)]}
+	case models.ComponentStatusPartialOutage:
+		data.Overall = statusPageHTMLBanner{Class: "partial", Text: translated[string(overall)]}
+	case models.ComponentStatusMajorOutage:
+		data.Overall = statusPageHTMLBanner{Class: "down", Text: translated[string(overall)]}
+	default:
+		data.Overall = statusPageHTMLBanner{Class: "up", Text: translated["all_systems_operational"]}
+	}
+
+	// Incidents repeat once per affected monitor
+	seen := make(map[int64]struct{})
+	for _, incident := range resp.Incidents {
+		if incident.Status == models.IncidentStatusResolved {
+			continue
+		}
+		if _, ok := seen[incident.ID]; ok {
+			continue
+		}
+		seen[incident.ID] = struct{}{}
+		data.Incidents = append(data.Incidents, statusPageHTMLIncident{
+			Title: fmt.Sprintf("%s (%s, %s)", translated["incident"], incident.Severity, incident.Status),
+			Since: fillPlaceholders(translated["since"], "time", formatStatusPageTime(incident.StartedAt, location)),
+		})
+	}
+
+	for _, maintenance := range resp.Maintenances {
+		data.Maintenances = append(data.Maintenances, statusPageHTMLMaintenance{
+			Title:      maintenance.Title,
+			Message:    maintenance.Message,
+			InProgress: maintenance.InProgress,
+			Window: fillPlaceholders(translated["window"],
+				"start", formatStatusPageTime(maintenance.StartsAt, location),
+				"end", formatStatusPageTime(maintenance.EndsAt, location)),
+		})
+	}
+
+	return data
+}
+
+// statusPageLocation is the time zone of the page, or UTC when it cannot be loaded
+func statusPageLocation(timezone string) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+	return location
+}
+
+func formatStatusPageTime(t time.Time, location *time.Location) string {
+	return t.In(location).Format("2006-01-02 15:04 MST")
+}
+
+func colorOr(color *string, fallback string) string {
+	if color == nil {
+		return fallback
+	}
+	return *color
+}
+
+// markdownParagraphs splits markdown into its paragraphs of text
+func markdownParagraphs(markdown string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,75 @@
+package statuspage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestStatusPageHTML(t *testing.T) {
+	resp := publicStatusPageResponse{
+		StatusPage: models.StatusPage{Title: "Acme <Corp>"},
+		Elements: []publicStatusPageElement{
+			{Name: "Website", SortOrder: 2, Status: "up", ComponentStatus: models.ComponentStatusOperational},
+			{Name: "API", SortOrder: 1, Status: "down", ComponentStatus: models.ComponentStatusMajorOutage, UptimeSLI90: 99.5},
+		},
+		Maintenances: []publicMaintenanceResponse{
+			{Title: "Database upgrade", StartsAt: time.Now().Add(time.Hour), EndsAt: time.Now().Add(2 * time.Hour)},
+		},
+		Incidents: []publicIncidentResponse{
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusInvestigating, Severity: models.IncidentSeverityMajor}, MonitorID: "42"},
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusInvestigating, Severity: models.IncidentSeverityMajor}, MonitorID: "43"},
+			{Incident: models.Incident{ID: 8, Status: models.IncidentStatusResolved}, MonitorID: "42"},
+		},
+	}
+
+	data := buildStatusPageHTMLData(resp)
+	require.Equal(t, "partial", data.Overall.Class)
+	require.Equal(t, "API", data.Components[0].Name)
+	require.Len(t, data.Incidents, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, statusPageHTML.Execute(&buf, data))
+	require.Contains(t, buf.String(), "Acme &lt;Corp&gt;")
+	require.Contains(t, buf.String(), "99.50% uptime over 90 days")
+	require.Contains(t, buf.String(), "Major outage")
+	require.Contains(t, buf.String(), "Database upgrade")
+	require.Contains(t, buf.String(), `<html lang="en">`)
+}
+
+func TestStatusPageHTML_Branding(t *testing.T) {
+	brand := "#1c7ed6"
+	startedAt := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	resp := publicStatusPageResponse{
+		StatusPage: models.StatusPage{Title: "Acme", StatusPageBranding: models.StatusPageBranding{
+			BrandColor:    &brand,
+			HeaderLinks:   []models.StatusPageLink{{Label: "Docs", URL: "https://docs.acme.com"}},
+			AboutMarkdown: "We make <things>.\n\nSecond paragraph",
+			Timezone:      "Europe/Berlin",
+			Language:      "de",
+		}},
+		Elements: []publicStatusPageElement{{Name: "API", ComponentStatus: models.ComponentStatusMajorOutage}},
+		Incidents: []publicIncidentResponse{
+			{Incident: models.Incident{ID: 7, Status: models.IncidentStatusInvestigating, Severity: models.IncidentSeverityMajor, StartedAt: startedAt}},
+		},
+	}
+	applyPublicBranding(&resp, []models.StatusPageImage{{Kind: models.StatusPageImageKindLogo, UpdatedAt: time.Unix(1700000000, 0)}})
+
+	data := buildStatusPageHTMLData(resp)
+	require.Equal(t, "Schwerer Ausfall", data.Overall.Text)
+	require.Equal(t, []string{"We make <things>.", "Second paragraph"}, data.About)
+
+	var buf bytes.Buffer
+	require.NoError(t, statusPageHTML.Execute(&buf, data))
+	page := buf.String()
+	require.Contains(t, page, `<html lang="de">`)
+	require.Contains(t, page, `<img src="images/logo?v=1700000000" alt="Acme">`)
+	require.Contains(t, page, `<a href="https://docs.acme.com" rel="noopener">Docs</a>`)
+	require.Contains(t, page, "color:#1c7ed6")
+	require.Contains(t, page, "seit 2026-01-15 13:00 CET")
+	require.Contains(t, page, "We make &lt;things&gt;.")
+	require.NotContains(t, page, `rel="icon"`)
+}
@@ -0,0 +1,387 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	notificationcore "github.com/yorukot/knocker/core/notification"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// +----------------------------------------------+
+// | Public subscriptions                         |
+// +----------------------------------------------+
+
+// subscriptionTokenLength is the length of the random token in the confirmation email
+const subscriptionTokenLength = 48
+
+// subscribeEmailMessage is returned whether or not the address is already subscribed, so the endpoint cannot be used to probe subscribers
+const subscribeEmailMessage = "Check your inbox for a link to confirm the subscription"
+
+type subscribeRequest struct {
+	Type       models.StatusPageSubscriberType `json:"type" validate:"required,oneof=email webhook"`
+	Email      string                          `json:"email" validate:"required_if=Type email,omitempty,email,max=255" example:"user@example.com"`
+	URL        string                          `json:"url" validate:"required_if=Type webhook,omitempty,url,max=2048" example:"https://example.com/hooks/status"`
+	MonitorIDs utils.IDList                    `json:"monitor_ids" validate:"max=100"`
+}
+
+type subscriptionTokenRequest struct {
+	Token string `json:"token" validate:"required,max=512"`
+}
+
+type webhookSubscriptionResponse struct {
+	models.StatusPageSubscriber
+	UnsubscribeToken string `json:"unsubscribe_token"`
+}
+
+// Subscribe godoc
+// @Summary Subscribe to a status page
+// @Description Subscribes an email address or webhook to the public incidents of a status page, optionally limited to some components. Email subscriptions must be confirmed from the emailed link
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Param request body subscribeRequest true "Subscription payload"
+// @Success 200 {object} response.SuccessResponse "Confirmation email sent"
+// @Success 201 {object} response.SuccessResponse "Webhook subscribed"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body"
+// @Failure 401 {object} response.ErrorResponse "Password or sign in required"
+// @Failure 403 {object} response.ErrorResponse "Not available from this network"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 409 {object} response.ErrorResponse "Webhook already subscribed"
+// @Failure 429 {object} response.ErrorResponse "Too many requests"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/subscribers [post]
+func (h *Handler) Subscribe(c echo.Context) error {
+	var req subscribeRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Type == models.StatusPageSubscriberWebhook {
+		if err := notificationcore.ValidateWebhookURL(req.URL); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, c.Param("slug"))
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	if err := h.authorizeStatusPage(c, tx, *page); err != nil {
+		return err
+	}
+
+	monitorIDs, err := h.pageMonitorIDs(ctx, tx, page.ID, req.MonitorIDs.Int64s())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	if req.Type == models.StatusPageSubscriberWebhook {
+		return h.subscribeWebhook(c, tx, *page, req.URL, monitorIDs, now)
+	}
+
+	existing, err := h.Repo.GetStatusPageSubscriberByTarget(ctx, tx, page.ID, models.StatusPageSubscriberEmail, req.Email)
+	if err != nil {
+		zap.L().Error("Failed to get status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page subscriber")
+	}
+
+	// A confirmed address stays as it is; the visitor gets the same answer either way.
+	if existing != nil && existing.ConfirmedAt != nil {
+		return c.JSON(http.StatusOK, response.SuccessMessage(subscribeEmailMessage))
+	}
+
+	token, err := encrypt.GenerateRandomString(subscriptionTokenLength)
+	if err != nil {
+		zap.L().Error("Failed to generate subscription token", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create subscription")
+	}
+	tokenHash := encrypt.HashToken(token)
+	expiresAt := now.Add(models.StatusPageSubscriptionConfirmTTL)
+
+	if existing != nil {
+		err = h.Repo.RenewStatusPageSubscriberConfirmation(ctx, tx, existing.ID, monitorIDs, tokenHash, expiresAt, now)
+	} else {
+		var subscriberID int64
+		subscriberID, err = id.GetID()
+		if err == nil {
+			err = h.Repo.CreateStatusPageSubscriber(ctx, tx, models.StatusPageSubscriber{
+				ID:                    subscriberID,
+				StatusPageID:          page.ID,
+				Type:                  models.StatusPageSubscriberEmail,
+				Target:                req.Email,
+				MonitorIDs:            monitorIDs,
+				ConfirmationTokenHash: &tokenHash,
+				ConfirmationExpiresAt: &expiresAt,
+				UpdatedAt:             now,
+				CreatedAt:             now,
+			})
+		}
+	}
+	if err != nil {
+		zap.L().Error("Failed to save status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create subscription")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	h.enqueueEmail(subscriptionConfirmEmail(req.Email, *page, token))
+
+	return c.JSON(http.StatusOK, response.SuccessMessage(subscribeEmailMessage))
+}
+
+// subscribeWebhook adds a webhook subscriber. Webhooks are confirmed straight away: the caller
+// gets the unsubscribe token in the response instead of an email.
+func (h *Handler) subscribeWebhook(c echo.Context, tx pgx.Tx, page models.StatusPage, url string, monitorIDs []int64, now time.Time) error {
+	ctx := c.Request().Context()
+
+	existing, err := h.Repo.GetStatusPageSubscriberByTarget(ctx, tx, page.ID, models.StatusPageSubscriberWebhook, url)
+	if err != nil {
+		zap.L().Error("Failed to get status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page subscriber")
+	}
+
+	if existing != nil {
+		return echo.NewHTTPError(http.StatusConflict, "This webhook is already subscribed to the status page")
+	}
+
+	subscriberID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate subscriber ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create subscription")
+	}
+
+	subscriber := models.StatusPageSubscriber{
+		ID:           subscriberID,
+		StatusPageID: page.ID,
+		Type:         models.StatusPageSubscriberWebhook,
+		Target:       url,
+		MonitorIDs:   monitorIDs,
+		ConfirmedAt:  &now,
+		UpdatedAt:    now,
+		CreatedAt:    now,
+	}
+
+	if err := h.Repo.CreateStatusPageSubscriber(ctx, tx, subscriber); err != nil {
+		zap.L().Error("Failed to create status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create subscription")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusCreated, response.Success("Webhook subscribed successfully", webhookSubscriptionResponse{
+		StatusPageSubscriber: subscriber,
+		UnsubscribeToken:     statuspagecore.UnsubscribeToken(subscriber.ID),
+	}))
+}
+
+// ConfirmSubscription godoc
+// @Summary Confirm a status page subscription
+// @Description Confirms an email subscription with the token from the confirmation email
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Param request body subscriptionTokenRequest true "Confirmation token"
+// @Success 200 {object} response.SuccessResponse "Subscription confirmed"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body"
+// @Failure 404 {object} response.ErrorResponse "Status page not found or link expired"
+// @Failure 429 {object} response.ErrorResponse "Too many requests"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/subscribers/confirm [post]
+func (h *Handler) ConfirmSubscription(c echo.Context) error {
+	var req subscriptionTokenRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, c.Param("slug"))
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	subscriber, err := h.Repo.ConfirmStatusPageSubscriber(ctx, tx, page.ID, encrypt.HashToken(req.Token), time.Now().UTC())
+	if err != nil {
+		zap.L().Error("Failed to confirm status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to confirm subscription")
+	}
+	if subscriber == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "The confirmation link is invalid or has expired")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Subscription confirmed successfully"))
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from a status page
+// @Description Ends a subscription with the token from an update's unsubscribe link. Unsubscribing twice succeeds
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param slug path string true "Status Page Slug"
+// @Param request body subscriptionTokenRequest true "Unsubscribe token"
+// @Success 200 {object} response.SuccessResponse "Unsubscribed"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or token"
+// @Failure 404 {object} response.ErrorResponse "Status page not found"
+// @Failure 429 {object} response.ErrorResponse "Too many requests"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /status-pages/{slug}/subscribers/unsubscribe [post]
+func (h *Handler) Unsubscribe(c echo.Context) error {
+	var req subscriptionTokenRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	subscriberID, ok := statuspagecore.ParseUnsubscribeToken(req.Token)
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid unsubscribe token")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.Repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, ctx)
+
+	page, err := h.Repo.GetStatusPageBySlug(ctx, tx, c.Param("slug"))
+	if err != nil {
+		zap.L().Error("Failed to get status page by slug", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status page")
+	}
+	if page == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
+	}
+
+	// An already removed subscriber is fine: the link in an old email should still say it worked.
+	if err := h.Repo.DeleteStatusPageSubscriber(ctx, tx, page.ID, subscriberID); err != nil && err != pgx.ErrNoRows {
+		zap.L().Error("Failed to delete status page subscriber", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to unsubscribe")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, ctx); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Unsubscribed successfully"))
+}
+
+// pageMonitorIDs checks that the requested components are shown on the status page and drops duplicates.
+func (h *Handler) pageMonitorIDs(ctx context.Context, tx pgx.Tx, statusPageID int64, requested []int64) ([]int64, error) {
+	if len(requested) == 0 {
+		return []int64{}, nil
+	}
+
+	monitors, err := h.Repo.ListStatusPageMonitorsByStatusPageID(ctx, tx, statusPageID)
+	if err != nil {
+		zap.L().Error("Failed to list status page monitors", zap.Error(err), zap.Int64("status_page_id", statusPageID))
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page monitors")
+	}
+
+	onPage := make(map[int64]struct{}, len(monitors))
+	for _, m := range monitors {
+		onPage[m.MonitorID] = struct{}{}
+	}
+
+	monitorIDs := make([]int64, 0, len(requested))
+	seen := make(map[int64]struct{}, len(requested))
+	for _, monitorID := range requested {
+		if _, ok := onPage[monitorID]; !ok {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Component not found on this status page")
+		}
+		if _, ok := seen[monitorID]; ok {
+			continue
+		}
+		seen[monitorID] = struct{}{}
+		monitorIDs = append(monitorIDs, monitorID)
+	}
+
+	return monitorIDs, nil
+}
+
+// enqueueEmail queues an email for the worker. Failures are logged, not returned:
+// the subscription is already saved and the visitor can subscribe again for a new link.
+func (h *Handler) enqueueEmail(payload tasks.SendEmailPayload) {
+	task, err := tasks.NewSendEmail(payload)
+	if err == nil {
+		_, err = h.AsynqClient.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue email", zap.String("subject", payload.Subject), zap.Error(err))
+	}
+}
+
+func subscriptionConfirmEmail(to string, page models.StatusPage, token string) tasks.SendEmailPayload {
+	return tasks.SendEmailPayload{
+		To:      to,
+		Subject: fmt.Sprintf("Confirm your subscription to %s", page.Title),
+		Text: fmt.Sprintf("Someone asked to send incident updates from %s to this address.\n\n"+
+			"Open the link below to confirm. It expires in %d hours.\n\n%s\n\n"+
+			"If you didn't ask for this, you can ignore this email.",
+			page.Title, int(models.StatusPageSubscriptionConfirmTTL.Hours()), statuspagecore.ConfirmURL(page.Slug, token)),
+	}
+}
@@ -0,0 +1,167 @@
+package statuspage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+func newSubscriptionContext(path, body string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/api/status-pages/acme"+path, strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("slug")
+	c.SetParamValues("acme")
+	return c, rec
+}
+
+func newPublicPageRepo() *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageBySlug", mock.Anything, mock.Anything, "acme").
+		Return(&models.StatusPage{ID: 5, TeamID: 10, Title: "Acme", Slug: "acme"}, nil)
+	mockRepo.On("ListStatusPageMonitorsByStatusPageID", mock.Anything, mock.Anything, int64(5)).
+		Return([]models.StatusPageMonitor{{MonitorID: 42}, {MonitorID: 43}}, nil)
+	return mockRepo
+}
+
+func TestSubscribe_EmailCreatesPendingSubscriber(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newPublicPageRepo()
+	mockRepo.On("GetStatusPageSubscriberByTarget", mock.Anything, mock.Anything, int64(5), models.StatusPageSubscriberEmail, "user@example.com").
+		Return(nil, nil)
+	mockRepo.On("CreateStatusPageSubscriber", mock.Anything, mock.Anything, mock.MatchedBy(func(s models.StatusPageSubscriber) bool {
+		return s.StatusPageID == 5 &&
+			s.Target == "user@example.com" &&
+			s.ConfirmedAt == nil &&
+			s.ConfirmationTokenHash != nil && len(*s.ConfirmationTokenHash) == 64 &&
+			s.ConfirmationExpiresAt.Sub(s.CreatedAt) == models.StatusPageSubscriptionConfirmTTL &&
+			len(s.MonitorIDs) == 1 && s.MonitorIDs[0] == 42
+	})).Return(nil)
+
+	enqueuer := &testutil.RecordingEnqueuer{}
+	h := &Handler{Repo: mockRepo, AsynqClient: enqueuer}
+	c, rec := newSubscriptionContext("/subscribers", `{"type":"email","email":" User@Example.com ","monitor_ids":["42","42"]}`)
+
+	require.NoError(t, h.Subscribe(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), subscribeEmailMessage)
+	mockRepo.AssertExpectations(t)
+
+	var email tasks.SendEmailPayload
+	require.Equal(t, tasks.TypeSendEmail, enqueuer.OnlyTask(t, &email).Type())
+	require.Equal(t, "user@example.com", email.To)
+	require.Contains(t, email.Subject, "Acme")
+}
+
+func TestSubscribe_ConfirmedEmailLooksTheSame(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	confirmedAt := time.Now()
+	mockRepo := newPublicPageRepo()
+	mockRepo.On("GetStatusPageSubscriberByTarget", mock.Anything, mock.Anything, int64(5), models.StatusPageSubscriberEmail, "user@example.com").
+		Return(&models.StatusPageSubscriber{ID: 9, ConfirmedAt: &confirmedAt}, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, rec := newSubscriptionContext("/subscribers", `{"type":"email","email":"user@example.com"}`)
+
+	require.NoError(t, h.Subscribe(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), subscribeEmailMessage)
+	mockRepo.AssertNotCalled(t, "CreateStatusPageSubscriber", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "RenewStatusPageSubscriberConfirmation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubscribe_UnknownComponent(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &Handler{Repo: newPublicPageRepo()}
+	c, _ := newSubscriptionContext("/subscribers", `{"type":"email","email":"user@example.com","monitor_ids":["99"]}`)
+
+	err := h.Subscribe(c)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+}
+
+func TestSubscribe_WebhookIsConfirmedImmediately(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newPublicPageRepo()
+	mockRepo.On("GetStatusPageSubscriberByTarget", mock.Anything, mock.Anything, int64(5), models.StatusPageSubscriberWebhook, "https://example.com/hook").
+		Return(nil, nil)
+	mockRepo.On("CreateStatusPageSubscriber", mock.Anything, mock.Anything, mock.MatchedBy(func(s models.StatusPageSubscriber) bool {
+		return s.Type == models.StatusPageSubscriberWebhook && s.ConfirmedAt != nil && s.ConfirmationTokenHash == nil
+	})).Return(nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, rec := newSubscriptionContext("/subscribers", `{"type":"webhook","url":"https://example.com/hook"}`)
+
+	require.NoError(t, h.Subscribe(c))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Contains(t, rec.Body.String(), "unsubscribe_token")
+}
+
+func TestSubscribe_WebhookRejectsPrivateAddress(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	h := &Handler{Repo: mockRepo}
+	c, _ := newSubscriptionContext("/subscribers", `{"type":"webhook","url":"http://169.254.169.254/latest/meta-data"}`)
+
+	err := h.Subscribe(c)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	mockRepo.AssertNotCalled(t, "StartTransaction", mock.Anything)
+}
+
+func TestConfirmSubscription_ExpiredToken(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newPublicPageRepo()
+	mockRepo.On("ConfirmStatusPageSubscriber", mock.Anything, mock.Anything, int64(5), mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil, nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, _ := newSubscriptionContext("/subscribers/confirm", `{"token":"stale"}`)
+
+	err := h.ConfirmSubscription(c)
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := newPublicPageRepo()
+	mockRepo.On("DeleteStatusPageSubscriber", mock.Anything, mock.Anything, int64(5), int64(9)).Return(nil)
+
+	h := &Handler{Repo: mockRepo}
+	c, rec := newSubscriptionContext("/subscribers/unsubscribe", `{"token":"`+statuspagecore.UnsubscribeToken(9)+`"}`)
+
+	require.NoError(t, h.Unsubscribe(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertCalled(t, "DeleteStatusPageSubscriber", mock.Anything, mock.Anything, int64(5), int64(9))
+}
+
+func TestUnsubscribe_ForgedToken(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	h := &Handler{Repo: mockRepo}
+	c, _ := newSubscriptionContext("/subscribers/unsubscribe", `{"token":"9.forged"}`)
+
+	err := h.Unsubscribe(c)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	mockRepo.AssertNotCalled(t, "StartTransaction", mock.Anything)
+}
@@ -0,0 +1,148 @@
+package statuspage
+
+import "strings"
+
+// statusPageTranslations are the strings of a status page per language. Placeholders in braces are
+// filled in by the page. English is complete; other languages fall back to it for missing keys.
+var statusPageTranslations = map[string]map[string]string{
+	"en": {
+		"status":                  "Status",
+		"all_systems_operational": "All systems operational",
+		"maintenance_in_progress": "Maintenance in progress",
+		"operational":             "Operational",
+		"under_maintenance":       "Under maintenance",
+		"degraded_performance":    "Degraded performance",
+		"partial_outage":          "Partial outage",
+		"major_outage":            "Major outage",
+		"active_incidents":        "Active incidents",
+		"incident":                "Incident",
+		"since":                   "since {time}",
+		"scheduled_maintenance":   "Scheduled maintenance",
+		"in_progress":             "in progress",
+		"window":                  "{start} to {end}",
+		"components":              "Components",
+		"uptime_90_days":          "{uptime}% uptime over 90 days",
+		"about":                   "About",
+	},
+	"de": {
+		"status":                  "Status",
+		"all_systems_operational": "Alle Systeme betriebsbereit",
+		"maintenance_in_progress": "Wartung läuft",
+		"operational":             "Betriebsbereit",
+		"under_maintenance":       "In Wartung",
+		"degraded_performance":    "Eingeschränkte Leistung",
+		"partial_outage":          "Teilweiser Ausfall",
+		"major_outage":            "Schwerer Ausfall",
+		"active_incidents":        "Aktive Vorfälle",
+		"incident":                "Vorfall",
+		"since":                   "seit {time}",
+		"scheduled_maintenance":   "Geplante Wartungen",
+		"in_progress":             "läuft",
+		"window":                  "{start} bis {end}",
+		"components":              "Komponenten",
+		"uptime_90_days":          "{uptime} % Verfügbarkeit in den letzten 90 Tagen",
+		"about":                   "Über uns",
+	},
+	"es": {
+		"status":                  "Estado",
+		"all_systems_operational": "Todos los sistemas operativos",
+		"maintenance_in_progress": "Mantenimiento en curso",
+		"operational":             "Operativo",
+		"under_maintenance":       "En mantenimiento",
+		"degraded_performance":    "Rendimiento degradado",
+		"partial_outage":          "Interrupción parcial",
+		"major_outage":            "Interrupción grave",
+		"active_incidents":        "Incidentes activos",
+		"incident":                "Incidente",
+		"since":                   "desde {time}",
+		"scheduled_maintenance":   "Mantenimiento programado",
+		"in_progress":             "en curso",
+		"window":                  "de {start} a {end}",
+		"components":              "Componentes",
+		"uptime_90_days":          "{uptime} % de disponibilidad en los últimos 90 días",
+		"about":                   "Acerca de",
+	},
+	"fr": {
+		"status":                  "Statut",
+		"all_systems_operational": "Tous les systèmes sont opérationnels",
+		"maintenance_in_progress": "Maintenance en cours",
+		"operational":             "Opérationnel",
+		"under_maintenance":       "En maintenance",
+		"degraded_performance":    "Performances dégradées",
+		"partial_outage":          "Panne partielle",
+		"major_outage":            "Panne majeure",
+		"active_incidents":        "Incidents en cours",
+		"incident":                "Incident",
+		"since":                   "depuis le {time}",
+		"scheduled_maintenance":   "Maintenance planifiée",
+		"in_progress":             "en cours",
+		"window":                  "du {start} au {end}",
+		"components":              "Composants",
+		"uptime_90_days":          "{uptime} % de disponibilité sur 90 jours",
+		"about":                   "À propos",
+	},
+	"ja": {
+		"status":                  "ステータス",
+		"all_systems_operational": "すべてのシステムが正常に稼働しています",
+		"maintenance_in_progress": "メンテナンス中",
+		"operational":             "正常",
+		"under_maintenance":       "メンテナンス中",
+		"degraded_performance":    "パフォーマンス低下",
+		"partial_outage":          "一部障害",
+		"major_outage":            "重大な障害",
+		"active_incidents":        "発生中のインシデント",
+		"incident":                "インシデント",
+		"since":                   "{time} から",
+		"scheduled_maintenance":   "予定されているメンテナンス",
+		"in_progress":             "実施中",
+		"window":                  "{start} 〜 {end}",
+		"components":              "コンポーネント",
+		"uptime_90_days":          "過去 90 日間の稼働率 {uptime}%",
+		"about":                   "概要",
+	},
+	"zh-TW": {
+		"status":                  "狀態",
+		"all_systems_operational": "所有系統運作正常",
+		"maintenance_in_progress": "維護進行中",
+		"operational":             "正常運作",
+		"under_maintenance":       "維護中",
+		"degraded_performance":    "效能降低",
+		"partial_outage":          "部分中斷",
+		"major_outage":            "嚴重中斷",
+		"active_incidents":        "進行中的事件",
+		"incident":                "事件",
+		"since":                   "自 {time} 起",
+		"scheduled_maintenance":   "排定的維護",
+		"in_progress":             "進行中",
+		"window":                  "{start} 至 {end}",
+		"components":              "元件",
+		"uptime_90_days":          "過去 90 天可用率 {uptime}%",
+		"about":                   "關於",
+	},
+}
+
+func supportedStatusPageLanguage(language string) bool {
+	_, ok := statusPageTranslations[language]
+	return ok
+}
+
+// statusPageStrings returns the strings of the language, completed with English ones.
+func statusPageStrings(language string) map[string]string {
+	translated := make(map[string]string, len(statusPageTranslations[defaultStatusPageLanguage]))
+	for key, value := range statusPageTranslations[defaultStatusPageLanguage] {
+		translated[key] = value
+	}
+	for key, value := range statusPageTranslations[language] {
+		translated[key] = value
+	}
+	return translated
+}
+
+// fillPlaceholders replaces {name} placeholders of a translated string, given as name/value pairs.
+func fillPlaceholders(text string, pairs ...string) string {
+	replacements := make([]string, 0, len(pairs))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		replacements = append(replacements, "{"+pairs[i]+"}", pairs[i+1])
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
@@ -16,7 +18,7 @@ import (
 
 // UpdateStatusPage godoc
 // @Summary Update a status page
-// @Description Updates slug/icon/groups/monitors for the given status page (owner/admin only)
+// @Description Updates title/slug/branding/visibility/groups/monitors for the given status page (owner/admin only)
 // @Tags status_pages
 // @Accept json
 // @Produce json
@@ -69,6 +71,10 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceStatusPage, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -76,20 +82,6 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
-	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
-	}
-
-	if member == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
-	}
-
-	if member.Role != models.MemberRoleOwner && member.Role != models.MemberRoleAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to update status pages for this team")
-	}
-
 	existing, err := h.Repo.GetStatusPageByID(c.Request().Context(), tx, teamID, statusPageID)
 	if err != nil {
 		zap.L().Error("Failed to get status page", zap.Error(err))
@@ -100,11 +92,17 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Status page not found")
 	}
 
+	before, err := h.loadStatusPageAuditSnapshot(c.Request().Context(), tx, *existing)
+	if err != nil {
+		zap.L().Error("Failed to load status page elements", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load status page elements")
+	}
+
 	// slug uniqueness (allow same slug for same record)
-	if slugOwner, err := h.Repo.GetStatusPageBySlug(c.Request().Context(), tx, normalizedReq.Slug); err != nil {
+	if slugOwner, err := h.Repo.GetStatusPageIDBySlug(c.Request().Context(), tx, normalizedReq.Slug); err != nil {
 		zap.L().Error("Failed to check slug uniqueness", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check slug uniqueness")
-	} else if slugOwner != nil && slugOwner.ID != existing.ID {
+	} else if slugOwner != nil && *slugOwner != existing.ID {
 		return echo.NewHTTPError(http.StatusBadRequest, "Slug already exists")
 	}
 
@@ -114,11 +112,18 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		TeamID:    existing.TeamID,
 		Title:     normalizedReq.Title,
 		Slug:      normalizedReq.Slug,
-		Icon:      normalizedReq.Icon,
 		CreatedAt: existing.CreatedAt,
 		UpdatedAt: now,
 	}
 
+	if err := applyStatusPageBranding(&updatedPage, normalizedReq, existing); err != nil {
+		return err
+	}
+
+	if err := applyStatusPageVisibility(&updatedPage, normalizedReq, existing); err != nil {
+		return err
+	}
+
 	page, err := h.Repo.UpdateStatusPage(c.Request().Context(), tx, updatedPage)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -128,10 +133,30 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update status page")
 	}
 
-	groups, monitors, err := buildStatusPageElements(normalizedReq, page.ID)
+	previousMonitors, err := h.Repo.ListStatusPageMonitorsByStatusPageID(c.Request().Context(), tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page monitors", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page monitors")
+	}
+
+	previousGroups, err := h.Repo.ListStatusPageGroupsByStatusPageID(c.Request().Context(), tx, page.ID)
+	if err != nil {
+		zap.L().Error("Failed to list status page groups", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list status page groups")
+	}
+
+	badgeKeys := make(map[int64]string)
+	for _, monitor := range previousMonitors {
+		if monitor.BadgeKey != nil {
+			badgeKeys[monitor.MonitorID] = *monitor.BadgeKey
+		}
+	}
+
+	groups, monitors, err := buildStatusPageElements(normalizedReq, page.ID, badgeKeys)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	carryStatusOverrides(normalizedReq, groups, monitors, previousGroups, previousMonitors)
 
 	if err := h.Repo.DeleteStatusPageMonitorsByStatusPageID(c.Request().Context(), tx, page.ID); err != nil {
 		zap.L().Error("Failed to clear status page monitors", zap.Error(err))
@@ -153,10 +178,6 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create status page monitors")
 	}
 
-	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
-	}
-
 	elements := buildStatusPageElementResponses(groups, monitors)
 
 	resp := statusPageResponse{
@@ -164,5 +185,23 @@ func (h *Handler) UpdateStatusPage(c echo.Context) error {
 		Elements:   elements,
 	}
 
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceStatusPage,
+		ResourceID:   page.ID,
+		Before:       *before,
+		After:        statusPageAuditSnapshot{StatusPage: *page, Elements: elements},
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	statuspagecore.InvalidateTeamPages(c.Request().Context(), teamID)
+
 	return c.JSON(http.StatusOK, response.Success("Status page updated successfully", resp))
 }
@@ -0,0 +1,122 @@
+package team
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// AcceptInvite godoc
+// @Summary Accept a team invite
+// @Description Accepts a pending invite addressed to the current user and joins the team with the invited role
+// @Tags invites
+// @Produce json
+// @Param id path string true "Invite ID"
+// @Success 200 {object} response.SuccessResponse "Invite accepted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid invite ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Invite not found"
+// @Failure 409 {object} response.ErrorResponse "Already a member of the team"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /invites/{id}/accept [post]
+func (h *TeamHandler) AcceptInvite(c echo.Context) error {
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid invite ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	invite, err := h.Repo.GetPendingTeamInviteForUser(c.Request().Context(), tx, inviteID, *userID, now)
+	if err != nil {
+		zap.L().Error("Failed to get invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get invite")
+	}
+
+	if invite == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Invite not found")
+	}
+
+	existing, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, invite.TeamID, *userID)
+	if err != nil {
+		zap.L().Error("Failed to get team membership", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+	}
+
+	if existing != nil {
+		return echo.NewHTTPError(http.StatusConflict, "You are already a member of this team")
+	}
+
+	memberID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate member ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate member ID")
+	}
+
+	member := models.TeamMember{
+		ID:        memberID,
+		TeamID:    invite.TeamID,
+		UserID:    *userID,
+		Role:      invite.Role,
+		UpdatedAt: now,
+		CreatedAt: now,
+	}
+
+	if err := h.Repo.CreateTeamMember(c.Request().Context(), tx, member); err != nil {
+		zap.L().Error("Failed to create team member", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create team member")
+	}
+
+	accepted := *invite
+	accepted.Status = models.InviteStatusAccepted
+	accepted.InvitedTo = userID
+	accepted.UpdatedAt = now
+
+	if err := h.Repo.UpdateTeamInviteStatus(c.Request().Context(), tx, invite.ID, accepted.Status, userID, now); err != nil {
+		zap.L().Error("Failed to accept invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to accept invite")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       invite.TeamID,
+		Action:       models.AuditActionAccept,
+		ResourceType: models.AuditResourceTeamInvite,
+		ResourceID:   invite.ID,
+		Before:       *invite,
+		After:        accepted,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Invite accepted successfully", member))
+}
@@ -0,0 +1,89 @@
+package team
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestAcceptInvite_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetPendingTeamInviteForUser", mock.Anything, mock.Anything, int64(7), int64(123), mock.AnythingOfType("time.Time")).
+		Return(&models.TeamInvite{ID: 7, TeamID: 10, Role: models.MemberRoleAdmin, Status: models.InviteStatusPending}, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return((*models.TeamMember)(nil), nil)
+	mockRepo.On("CreateTeamMember", mock.Anything, mock.Anything, mock.MatchedBy(func(member models.TeamMember) bool {
+		return member.TeamID == 10 && member.UserID == 123 && member.Role == models.MemberRoleAdmin
+	})).Return(nil)
+	mockRepo.On("UpdateTeamInviteStatus", mock.Anything, mock.Anything, int64(7), models.InviteStatusAccepted, mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/invites/7/accept", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+
+	err := h.AcceptInvite(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertCalled(t, "CreateTeamMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptInvite_NotAddressedToUser(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetPendingTeamInviteForUser", mock.Anything, mock.Anything, int64(7), int64(123), mock.AnythingOfType("time.Time")).
+		Return((*models.TeamInvite)(nil), nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/invites/7/accept", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+
+	err := h.AcceptInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestAcceptInvite_AlreadyMember(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetPendingTeamInviteForUser", mock.Anything, mock.Anything, int64(7), int64(123), mock.AnythingOfType("time.Time")).
+		Return(&models.TeamInvite{ID: 7, TeamID: 10, Role: models.MemberRoleMember}, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return(&models.TeamMember{UserID: 123, Role: models.MemberRoleViewer}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/invites/7/accept", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+
+	err := h.AcceptInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
@@ -0,0 +1,179 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+type createInviteRequest struct {
+	Email string            `json:"email" validate:"required,email,max=255"`
+	Role  models.MemberRole `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+// CreateInvite godoc
+// @Summary Invite someone to a team
+// @Description Invites an email address to join a team (owner or admin only) and emails it a link to accept. The address does not need to belong to an existing account; the invite shows up once the recipient signs up with it.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body createInviteRequest true "Team invite request"
+// @Success 201 {object} response.SuccessResponse "Invite created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Already a member or already invited"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/invites [post]
+func (h *TeamHandler) CreateInvite(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req createInviteRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	member := authutil.GetTeamMemberFromContext(c)
+	if member == nil || !member.Role.Can(models.PermissionResourceTeamInvite, models.PermissionActionCreate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to invite members")
+	}
+
+	if req.Role == models.MemberRoleOwner && member.Role != models.MemberRoleOwner {
+		return echo.NewHTTPError(http.StatusForbidden, "Only owners can invite new owners")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	// Link the invite to the account straight away when the address is already registered and verified.
+	// An unverified account only sees the invite once it proves it owns the address.
+	var invitedTo *int64
+	account, err := h.Repo.GetVerifiedAccountByEmail(c.Request().Context(), tx, email)
+	if err != nil {
+		zap.L().Error("Failed to get account by email", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get account")
+	}
+
+	if account != nil {
+		existing, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, account.UserID)
+		if err != nil {
+			zap.L().Error("Failed to get team membership", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		}
+
+		if existing != nil {
+			return echo.NewHTTPError(http.StatusConflict, "This user is already a member of the team")
+		}
+
+		invitedTo = &account.UserID
+	}
+
+	pending, err := h.Repo.GetPendingTeamInviteByEmail(c.Request().Context(), tx, teamID, email)
+	if err != nil {
+		zap.L().Error("Failed to get pending invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get pending invite")
+	}
+
+	if pending != nil {
+		if pending.ExpiresAt.After(now) {
+			return echo.NewHTTPError(http.StatusConflict, "This email already has a pending invite")
+		}
+
+		// An expired invite still holds the pending slot for the address; retire it first.
+		if err := h.Repo.UpdateTeamInviteStatus(c.Request().Context(), tx, pending.ID, models.InviteStatusRevoked, nil, now); err != nil {
+			zap.L().Error("Failed to revoke expired invite", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke expired invite")
+		}
+	}
+
+	team, err := h.Repo.GetTeamForUser(c.Request().Context(), tx, teamID, *userID)
+	if err != nil {
+		zap.L().Error("Failed to get team", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team")
+	}
+
+	if team == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
+
+	inviteID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate invite ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate invite ID")
+	}
+
+	invite := models.TeamInvite{
+		ID:        inviteID,
+		TeamID:    teamID,
+		InvitedBy: *userID,
+		InvitedTo: invitedTo,
+		Email:     email,
+		Role:      req.Role,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: now.Add(models.TeamInviteTTL),
+		UpdatedAt: now,
+		CreatedAt: now,
+	}
+
+	if err := h.Repo.CreateTeamInvite(c.Request().Context(), tx, invite); err != nil {
+		zap.L().Error("Failed to create invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create invite")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceTeamInvite,
+		ResourceID:   invite.ID,
+		After:        invite,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	h.enqueueEmail(teamInviteEmail(invite, team.Name))
+
+	return c.JSON(http.StatusCreated, response.Success("Invite created successfully", invite))
+}
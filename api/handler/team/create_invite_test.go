@@ -0,0 +1,164 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+func newCreateInviteContext(t *testing.T, body string) (echo.Context, *httptest.ResponseRecorder, *TeamHandler, *repository.MockRepository) {
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/teams/10/invites", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+
+	return c, rec, &TeamHandler{Repo: mockRepo, AsynqClient: &testutil.RecordingEnqueuer{}}, mockRepo
+}
+
+func TestCreateInvite_NewEmail(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, rec, h, mockRepo := newCreateInviteContext(t, `{"email":"New@Example.com","role":"member"}`)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+	mockRepo.On("GetVerifiedAccountByEmail", mock.Anything, mock.Anything, "new@example.com").
+		Return((*models.Account)(nil), nil)
+	mockRepo.On("GetPendingTeamInviteByEmail", mock.Anything, mock.Anything, int64(10), "new@example.com").
+		Return((*models.TeamInvite)(nil), nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 10, Name: "Ops"}}, nil)
+	mockRepo.On("CreateTeamInvite", mock.Anything, mock.Anything, mock.MatchedBy(func(invite models.TeamInvite) bool {
+		return invite.TeamID == 10 &&
+			invite.InvitedBy == 123 &&
+			invite.InvitedTo == nil &&
+			invite.Email == "new@example.com" &&
+			invite.Role == models.MemberRoleMember &&
+			invite.Status == models.InviteStatusPending
+	})).Return(nil)
+
+	err := h.CreateInvite(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "Invite created successfully", resp["message"])
+
+	var email tasks.SendEmailPayload
+	enqueuer := h.AsynqClient.(*testutil.RecordingEnqueuer)
+	require.Equal(t, tasks.TypeSendEmail, enqueuer.OnlyTask(t, &email).Type())
+	require.Equal(t, "new@example.com", email.To)
+	require.Contains(t, email.Subject, "Ops")
+	require.Contains(t, email.Text, "/invites/")
+}
+
+func TestCreateInvite_ExistingAccountLinked(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, rec, h, mockRepo := newCreateInviteContext(t, `{"email":"user@example.com","role":"viewer"}`)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetVerifiedAccountByEmail", mock.Anything, mock.Anything, "user@example.com").
+		Return(&models.Account{UserID: 456, Email: "user@example.com"}, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return((*models.TeamMember)(nil), nil)
+	mockRepo.On("GetPendingTeamInviteByEmail", mock.Anything, mock.Anything, int64(10), "user@example.com").
+		Return((*models.TeamInvite)(nil), nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 10, Name: "Ops"}}, nil)
+	mockRepo.On("CreateTeamInvite", mock.Anything, mock.Anything, mock.MatchedBy(func(invite models.TeamInvite) bool {
+		return invite.InvitedTo != nil && *invite.InvitedTo == 456
+	})).Return(nil)
+
+	err := h.CreateInvite(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateInvite_AlreadyMember(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, _, h, mockRepo := newCreateInviteContext(t, `{"email":"user@example.com","role":"member"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetVerifiedAccountByEmail", mock.Anything, mock.Anything, "user@example.com").
+		Return(&models.Account{UserID: 456}, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleMember}, nil)
+
+	err := h.CreateInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestCreateInvite_PendingInviteExists(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, _, h, mockRepo := newCreateInviteContext(t, `{"email":"new@example.com","role":"member"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetVerifiedAccountByEmail", mock.Anything, mock.Anything, "new@example.com").
+		Return((*models.Account)(nil), nil)
+	mockRepo.On("GetPendingTeamInviteByEmail", mock.Anything, mock.Anything, int64(10), "new@example.com").
+		Return(&models.TeamInvite{ID: 7, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	err := h.CreateInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestCreateInvite_AdminCannotInviteOwner(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, _, h, mockRepo := newCreateInviteContext(t, `{"email":"new@example.com","role":"owner"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+
+	err := h.CreateInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "CreateTeamInvite", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateInvite_InvalidEmail(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, _, h, _ := newCreateInviteContext(t, `{"email":"not-an-email","role":"member"}`)
+
+	err := h.CreateInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestTeamInviteEmail_LinksToInvite(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	payload := teamInviteEmail(models.TeamInvite{ID: 42, Email: "new@example.com", Role: models.MemberRoleMember}, "Ops")
+	require.Equal(t, "new@example.com", payload.To)
+	require.Contains(t, payload.Subject, "Ops")
+	require.Contains(t, payload.Text, "/invites/42")
+	require.Contains(t, payload.Text, "as member")
+}
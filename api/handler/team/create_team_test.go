@@ -23,6 +23,7 @@ func TestCreateTeam_Success(t *testing.T) {
 	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
 	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
 	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mockRepo.On("CreateTeam", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		capturedTeam = args.Get(2).(models.Team)
 	})
@@ -0,0 +1,90 @@
+package team
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// DeclineInvite godoc
+// @Summary Decline a team invite
+// @Description Declines a pending invite addressed to the current user
+// @Tags invites
+// @Produce json
+// @Param id path string true "Invite ID"
+// @Success 200 {object} response.SuccessResponse "Invite declined successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid invite ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Invite not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /invites/{id}/decline [post]
+func (h *TeamHandler) DeclineInvite(c echo.Context) error {
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid invite ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	invite, err := h.Repo.GetPendingTeamInviteForUser(c.Request().Context(), tx, inviteID, *userID, now)
+	if err != nil {
+		zap.L().Error("Failed to get invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get invite")
+	}
+
+	if invite == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Invite not found")
+	}
+
+	declined := *invite
+	declined.Status = models.InviteStatusDeclined
+	declined.InvitedTo = userID
+	declined.UpdatedAt = now
+
+	if err := h.Repo.UpdateTeamInviteStatus(c.Request().Context(), tx, invite.ID, declined.Status, userID, now); err != nil {
+		zap.L().Error("Failed to decline invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to decline invite")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       invite.TeamID,
+		Action:       models.AuditActionDecline,
+		ResourceType: models.AuditResourceTeamInvite,
+		ResourceID:   invite.ID,
+		Before:       *invite,
+		After:        declined,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Invite declined successfully"))
+}
@@ -0,0 +1,37 @@
+package team
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestDeclineInvite_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetPendingTeamInviteForUser", mock.Anything, mock.Anything, int64(7), int64(123), mock.AnythingOfType("time.Time")).
+		Return(&models.TeamInvite{ID: 7, TeamID: 10}, nil)
+	mockRepo.On("UpdateTeamInviteStatus", mock.Anything, mock.Anything, int64(7), models.InviteStatusDeclined, mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/invites/7/decline", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+
+	err := h.DeclineInvite(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertNotCalled(t, "CreateTeamMember", mock.Anything, mock.Anything, mock.Anything)
+}
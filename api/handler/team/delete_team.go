@@ -3,22 +3,36 @@ package team
 import (
 	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	escalationcore "github.com/yorukot/knocker/core/escalation"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
 	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/config"
 	"github.com/yorukot/knocker/utils/response"
 	"go.uber.org/zap"
 )
 
+// teamDeletionResponse is a deleted team along with the time it will be purged.
+type teamDeletionResponse struct {
+	models.Team
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// teamPurgeAt returns when a team deleted at deletedAt is purged for good.
+func teamPurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(time.Duration(config.Env().TeamDeletionGrace) * time.Second)
+}
+
 // DeleteTeam godoc
 // @Summary Delete a team
-// @Description Deletes a team (owner only)
+// @Description Deletes a team (owner only). The team is locked and its monitors stop being checked and pending escalations are cancelled, but it can be restored until the grace period ends and it is purged.
 // @Tags teams
 // @Produce json
 // @Param id path string true "Team ID"
-// @Success 200 {object} response.SuccessResponse "Team deleted successfully"
+// @Success 200 {object} response.SuccessResponse{data=teamDeletionResponse} "Team scheduled for deletion"
 // @Failure 400 {object} response.ErrorResponse "Invalid team ID"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized"
 // @Failure 403 {object} response.ErrorResponse "Forbidden"
@@ -41,6 +55,10 @@ func (h *TeamHandler) DeleteTeam(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
+	if !authutil.HasPermission(c, models.PermissionResourceTeam, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this team")
+	}
+
 	tx, err := h.Repo.StartTransaction(c.Request().Context())
 	if err != nil {
 		zap.L().Error("Failed to begin transaction", zap.Error(err))
@@ -48,32 +66,53 @@ func (h *TeamHandler) DeleteTeam(c echo.Context) error {
 	}
 	defer h.Repo.DeferRollback(tx, c.Request().Context())
 
-	member, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, *userID)
+	existing, err := h.Repo.GetTeamForUser(c.Request().Context(), tx, teamID, *userID)
 	if err != nil {
-		zap.L().Error("Failed to get team membership", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+		zap.L().Error("Failed to get team", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team")
 	}
 
-	if member == nil {
+	if existing == nil {
 		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
 	}
 
-	if member.Role != models.MemberRoleOwner {
-		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to delete this team")
+	deleted, err := h.Repo.SoftDeleteTeam(c.Request().Context(), tx, teamID, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to delete team", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete team")
 	}
 
-	if err := h.Repo.DeleteTeam(c.Request().Context(), tx, teamID); err != nil {
-		if err == pgx.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "Team not found")
-		}
+	if deleted == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
 
-		zap.L().Error("Failed to delete team", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete team")
+	// Nobody can acknowledge the locked team's incidents, so stop paging for them
+	cancelled, err := h.Repo.CancelPendingTeamIncidentEscalations(c.Request().Context(), tx, teamID, *deleted.DeletedAt)
+	if err != nil {
+		zap.L().Error("Failed to cancel escalations", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to cancel escalations")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceTeam,
+		ResourceID:   teamID,
+		Before:       existing.Team,
+		After:        *deleted,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
 	}
 
 	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
-	return c.JSON(http.StatusOK, response.SuccessMessage("Team deleted successfully"))
+	escalationcore.Cancel(h.Inspector, cancelled)
+
+	return c.JSON(http.StatusOK, response.Success("Team scheduled for deletion", teamDeletionResponse{
+		Team:    *deleted,
+		PurgeAt: teamPurgeAt(*deleted.DeletedAt),
+	}))
 }
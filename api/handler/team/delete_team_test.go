@@ -3,10 +3,12 @@ package team
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
 	"github.com/yorukot/knocker/internal/testutil"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/repository"
@@ -15,23 +17,59 @@ import (
 func TestDeleteTeam_Success(t *testing.T) {
 	testutil.InitTestEnv(t)
 
+	deletedAt := time.Now()
 	mockRepo := &repository.MockRepository{}
 	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
 	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
 	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
-	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(7), int64(123)).
-		Return(&models.TeamMember{Role: models.MemberRoleOwner}, nil)
-	mockRepo.On("DeleteTeam", mock.Anything, mock.Anything, int64(7)).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(7), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 7, Name: "Old"}, Role: models.MemberRoleOwner}, nil)
+	mockRepo.On("SoftDeleteTeam", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(&models.Team{ID: 7, Name: "Old", DeletedAt: &deletedAt}, nil)
+	mockRepo.On("CancelPendingTeamIncidentEscalations", mock.Anything, mock.Anything, int64(7), deletedAt).
+		Return([]models.IncidentEscalation{}, nil)
 
 	h := &TeamHandler{Repo: mockRepo}
 	c, rec := testutil.NewEchoContext(http.MethodDelete, "/teams/7", nil)
 	c.SetParamNames("id")
 	c.SetParamValues("7")
 	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleOwner})
 
 	err := h.DeleteTeam(c)
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"purge_at"`)
+	mockRepo.AssertNotCalled(t, "PurgeDeletedTeams", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteTeam_CancelsPendingEscalations(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	deletedAt := time.Now()
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(7), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 7, Name: "Old"}, Role: models.MemberRoleOwner}, nil)
+	mockRepo.On("SoftDeleteTeam", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(&models.Team{ID: 7, Name: "Old", DeletedAt: &deletedAt}, nil)
+	mockRepo.On("CancelPendingTeamIncidentEscalations", mock.Anything, mock.Anything, int64(7), deletedAt).
+		Return([]models.IncidentEscalation{{ID: 1, IncidentID: 11, TaskID: "escalation:11:1", Status: models.EscalationStatusCancelled}}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodDelete, "/teams/7", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleOwner})
+
+	require.NoError(t, h.DeleteTeam(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
 }
 
 func TestDeleteTeam_Forbidden(t *testing.T) {
@@ -40,14 +78,13 @@ func TestDeleteTeam_Forbidden(t *testing.T) {
 	mockRepo := &repository.MockRepository{}
 	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
 	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
-	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(&models.TeamMember{Role: models.MemberRoleMember}, nil)
 
 	h := &TeamHandler{Repo: mockRepo}
 	c, _ := testutil.NewEchoContext(http.MethodDelete, "/teams/7", nil)
 	c.SetParamNames("id")
 	c.SetParamValues("7")
 	testutil.Authenticate(c, 555)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleMember})
 
 	err := h.DeleteTeam(c)
 	require.Error(t, err)
@@ -67,11 +104,13 @@ func TestDeleteTeam_NotFound(t *testing.T) {
 
 	h := &TeamHandler{Repo: mockRepo}
 	c, _ := testutil.NewEchoContext(http.MethodDelete, "/teams/7", nil)
+	c.SetPath("/teams/:id")
 	c.SetParamNames("id")
 	c.SetParamValues("7")
 	testutil.Authenticate(c, 555)
 
-	err := h.DeleteTeam(c)
+	// Non-members are turned away by the membership middleware before the handler runs.
+	err := middleware.TeamMemberMiddleware(mockRepo)(h.DeleteTeam)(c)
 	require.Error(t, err)
 	httpErr, ok := err.(*echo.HTTPError)
 	require.True(t, ok)
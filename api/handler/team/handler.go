@@ -1,7 +1,13 @@
 package team
 
-import "github.com/yorukot/knocker/repository"
+import (
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
 
 type TeamHandler struct {
-	Repo repository.Repository
+	Repo        repository.Repository
+	AsynqClient tasks.Enqueuer
+	Inspector   *asynq.Inspector
 }
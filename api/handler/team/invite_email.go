@@ -0,0 +1,41 @@
+package team
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// inviteLink points at the frontend page where the recipient signs in and accepts the invite
+func inviteLink(inviteID int64) string {
+	return strings.TrimRight(config.Env().FrontendURL, "/") + "/invites/" + strconv.FormatInt(inviteID, 10)
+}
+
+func teamInviteEmail(invite models.TeamInvite, teamName string) tasks.SendEmailPayload {
+	return tasks.SendEmailPayload{
+		To:      invite.Email,
+		Subject: fmt.Sprintf("You're invited to join %s on Knocker", teamName),
+		Text: fmt.Sprintf("You have been invited to join the team %s on Knocker as %s.\n\n"+
+			"Open the link below and sign in, or sign up, with this email address to accept. "+
+			"The invite expires in %d days.\n\n%s\n\n"+
+			"If you weren't expecting this, you can ignore this email.",
+			teamName, invite.Role, int(models.TeamInviteTTL.Hours()/24), inviteLink(invite.ID)),
+	}
+}
+
+// enqueueEmail queues an email for the worker. Failures are logged, not returned:
+// the invite is already saved and still shows up for the recipient once they sign in.
+func (h *TeamHandler) enqueueEmail(payload tasks.SendEmailPayload) {
+	task, err := tasks.NewSendEmail(payload)
+	if err == nil {
+		_, err = h.AsynqClient.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("Failed to enqueue email", zap.String("subject", payload.Subject), zap.Error(err))
+	}
+}
@@ -0,0 +1,64 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestListInvites_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListPendingTeamInvitesByTeamID", mock.Anything, mock.Anything, int64(10)).Return([]models.TeamInvite{
+		{ID: 1, TeamID: 10, Email: "new@example.com", Role: models.MemberRoleMember, Status: models.InviteStatusPending},
+	}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/teams/10/invites", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleOwner})
+
+	err := h.ListInvites(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	data, ok := resp["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, data, 1)
+}
+
+func TestListInvites_Forbidden(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/teams/10/invites", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleMember})
+
+	err := h.ListInvites(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+}
@@ -0,0 +1,69 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestListMembers_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListTeamMembers", mock.Anything, mock.Anything, int64(10)).Return([]models.TeamMemberWithUser{
+		{TeamMember: models.TeamMember{UserID: 123, Role: models.MemberRoleViewer}, DisplayName: "Viewer"},
+		{TeamMember: models.TeamMember{UserID: 456, Role: models.MemberRoleOwner}, DisplayName: "Owner"},
+	}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/teams/10/members", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleViewer})
+
+	err := h.ListMembers(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	data, ok := resp["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, data, 2)
+}
+
+func TestListMembers_NotMember(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return((*models.TeamMember)(nil), nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/teams/10/members", nil)
+	c.SetPath("/teams/:id/members")
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+
+	// Non-members are turned away by the membership middleware before the handler runs.
+	err := middleware.TeamMemberMiddleware(mockRepo)(h.ListMembers)(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+}
@@ -0,0 +1,51 @@
+package team
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// ListMyInvites godoc
+// @Summary List my team invites
+// @Description Lists pending team invites addressed to the current user or any of their account emails
+// @Tags invites
+// @Produce json
+// @Success 200 {object} response.SuccessResponse "Invites retrieved successfully"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /invites [get]
+func (h *TeamHandler) ListMyInvites(c echo.Context) error {
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	invites, err := h.Repo.ListPendingTeamInvitesForUser(c.Request().Context(), tx, *userID, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to list invites", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list invites")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Invites retrieved successfully", invites))
+}
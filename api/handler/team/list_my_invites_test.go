@@ -0,0 +1,55 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestListMyInvites_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListPendingTeamInvitesForUser", mock.Anything, mock.Anything, int64(123), mock.AnythingOfType("time.Time")).
+		Return([]models.TeamInviteWithTeam{
+			{TeamInvite: models.TeamInvite{ID: 7, TeamID: 10}, TeamName: "Ops"},
+		}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/invites", nil)
+	testutil.Authenticate(c, 123)
+
+	err := h.ListMyInvites(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	data, ok := resp["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, data, 1)
+	require.Equal(t, "Ops", data[0].(map[string]any)["team_name"])
+}
+
+func TestListMyInvites_Unauthorized(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	h := &TeamHandler{Repo: &repository.MockRepository{}}
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/invites", nil)
+
+	err := h.ListMyInvites(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
@@ -0,0 +1,123 @@
+package team
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// RemoveMember godoc
+// @Summary Remove a team member
+// @Description Removes a member from a team (owner or admin only). Any member may remove themselves to leave the team. The last owner cannot be removed.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param userID path string true "User ID of the member"
+// @Success 200 {object} response.SuccessResponse "Member removed successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 409 {object} response.ErrorResponse "Team must keep at least one owner"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/members/{userID} [delete]
+func (h *TeamHandler) RemoveMember(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	targetUserID, err := strconv.ParseInt(c.Param("userID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	member := authutil.GetTeamMemberFromContext(c)
+	if member == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	target := member
+	if targetUserID != *userID {
+		if !member.Role.Can(models.PermissionResourceTeamMember, models.PermissionActionDelete) {
+			return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to manage team members")
+		}
+
+		target, err = h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, targetUserID)
+		if err != nil {
+			zap.L().Error("Failed to get team member", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team member")
+		}
+
+		if target == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+		}
+
+		if target.Role == models.MemberRoleOwner && member.Role != models.MemberRoleOwner {
+			return echo.NewHTTPError(http.StatusForbidden, "Only owners can remove an owner")
+		}
+	}
+
+	if target.Role == models.MemberRoleOwner {
+		owners, err := h.Repo.CountTeamOwners(c.Request().Context(), tx, teamID)
+		if err != nil {
+			zap.L().Error("Failed to count team owners", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to count team owners")
+		}
+
+		if owners <= 1 {
+			return echo.NewHTTPError(http.StatusConflict, "Team must keep at least one owner")
+		}
+	}
+
+	if err := h.Repo.DeleteTeamMember(c.Request().Context(), tx, teamID, targetUserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+		}
+
+		zap.L().Error("Failed to remove team member", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove team member")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionDelete,
+		ResourceType: models.AuditResourceTeamMember,
+		ResourceID:   target.ID,
+		Before:       *target,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Member removed successfully"))
+}
@@ -0,0 +1,102 @@
+package team
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestRemoveMember_AdminRemovesMember(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleMember}, nil)
+	mockRepo.On("DeleteTeamMember", mock.Anything, mock.Anything, int64(10), int64(456)).Return(nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodDelete, "/teams/10/members/456", nil)
+	c.SetParamNames("id", "userID")
+	c.SetParamValues("10", "456")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+
+	err := h.RemoveMember(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRemoveMember_MemberLeaves(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("DeleteTeamMember", mock.Anything, mock.Anything, int64(10), int64(123)).Return(nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodDelete, "/teams/10/members/123", nil)
+	c.SetParamNames("id", "userID")
+	c.SetParamValues("10", "123")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleViewer})
+
+	err := h.RemoveMember(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRemoveMember_ViewerCannotRemoveOthers(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodDelete, "/teams/10/members/456", nil)
+	c.SetParamNames("id", "userID")
+	c.SetParamValues("10", "456")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleViewer})
+
+	err := h.RemoveMember(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+}
+
+func TestRemoveMember_LastOwnerCannotLeave(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CountTeamOwners", mock.Anything, mock.Anything, int64(10)).Return(1, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodDelete, "/teams/10/members/123", nil)
+	c.SetParamNames("id", "userID")
+	c.SetParamValues("10", "123")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+
+	err := h.RemoveMember(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
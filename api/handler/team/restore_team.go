@@ -0,0 +1,99 @@
+package team
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// RestoreTeam godoc
+// @Summary Restore a deleted team
+// @Description Restores a deleted team before it is purged (owner only). Its monitors resume being checked.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID"
+// @Success 200 {object} response.SuccessResponse "Team restored successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team is not deleted"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/restore [post]
+func (h *TeamHandler) RestoreTeam(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	// Deleted teams are hidden from the membership middleware, so look the team up directly.
+	existing, err := h.Repo.GetTeamForUser(c.Request().Context(), tx, teamID, *userID)
+	if err != nil {
+		zap.L().Error("Failed to get team", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team")
+	}
+
+	if existing == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
+
+	if !existing.Role.Can(models.PermissionResourceTeam, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to restore this team")
+	}
+
+	if existing.DeletedAt == nil {
+		return echo.NewHTTPError(http.StatusConflict, "Team is not deleted")
+	}
+
+	restored, err := h.Repo.RestoreTeam(c.Request().Context(), tx, teamID, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to restore team", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to restore team")
+	}
+
+	if restored == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionRestore,
+		ResourceType: models.AuditResourceTeam,
+		ResourceID:   teamID,
+		Before:       existing.Team,
+		After:        *restored,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Team restored successfully", restored))
+}
@@ -0,0 +1,74 @@
+package team
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newRestoreTeamContext() (echo.Context, *TeamHandler, *repository.MockRepository) {
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/teams/7/restore", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	testutil.Authenticate(c, 123)
+
+	return c, &TeamHandler{Repo: mockRepo}, mockRepo
+}
+
+func TestRestoreTeam_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	deletedAt := time.Now().Add(-time.Hour)
+	c, h, mockRepo := newRestoreTeamContext()
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(7), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 7, Name: "Ops", DeletedAt: &deletedAt}, Role: models.MemberRoleOwner}, nil)
+	mockRepo.On("RestoreTeam", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).
+		Return(&models.Team{ID: 7, Name: "Ops"}, nil)
+
+	err := h.RestoreTeam(c)
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "RestoreTeam", mock.Anything, mock.Anything, int64(7), mock.Anything)
+}
+
+func TestRestoreTeam_NotDeleted(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newRestoreTeamContext()
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(7), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 7, Name: "Ops"}, Role: models.MemberRoleOwner}, nil)
+
+	err := h.RestoreTeam(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestRestoreTeam_AdminForbidden(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	deletedAt := time.Now()
+	c, h, mockRepo := newRestoreTeamContext()
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(7), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 7, Name: "Ops", DeletedAt: &deletedAt}, Role: models.MemberRoleAdmin}, nil)
+
+	err := h.RestoreTeam(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "RestoreTeam", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
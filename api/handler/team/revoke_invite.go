@@ -0,0 +1,103 @@
+package team
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// RevokeInvite godoc
+// @Summary Revoke a team invite
+// @Description Revokes a pending team invite (owner or admin only)
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param inviteID path string true "Invite ID"
+// @Success 200 {object} response.SuccessResponse "Invite revoked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or invite not found"
+// @Failure 409 {object} response.ErrorResponse "Invite is no longer pending"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/invites/{inviteID} [delete]
+func (h *TeamHandler) RevokeInvite(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	inviteID, err := strconv.ParseInt(c.Param("inviteID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid invite ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !authutil.HasPermission(c, models.PermissionResourceTeamInvite, models.PermissionActionDelete) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to revoke team invites")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	invite, err := h.Repo.GetTeamInviteByID(c.Request().Context(), tx, teamID, inviteID)
+	if err != nil {
+		zap.L().Error("Failed to get invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get invite")
+	}
+
+	if invite == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Invite not found")
+	}
+
+	if invite.Status != models.InviteStatusPending {
+		return echo.NewHTTPError(http.StatusConflict, "Invite is no longer pending")
+	}
+
+	revoked := *invite
+	revoked.Status = models.InviteStatusRevoked
+	revoked.UpdatedAt = time.Now()
+
+	if err := h.Repo.UpdateTeamInviteStatus(c.Request().Context(), tx, invite.ID, revoked.Status, nil, revoked.UpdatedAt); err != nil {
+		zap.L().Error("Failed to revoke invite", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke invite")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionRevoke,
+		ResourceType: models.AuditResourceTeamInvite,
+		ResourceID:   invite.ID,
+		Before:       *invite,
+		After:        revoked,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Invite revoked successfully"))
+}
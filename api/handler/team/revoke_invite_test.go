@@ -0,0 +1,61 @@
+package team
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestRevokeInvite_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamInviteByID", mock.Anything, mock.Anything, int64(10), int64(7)).
+		Return(&models.TeamInvite{ID: 7, TeamID: 10, Status: models.InviteStatusPending}, nil)
+	mockRepo.On("UpdateTeamInviteStatus", mock.Anything, mock.Anything, int64(7), models.InviteStatusRevoked, (*int64)(nil), mock.AnythingOfType("time.Time")).
+		Return(nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodDelete, "/teams/10/invites/7", nil)
+	c.SetParamNames("id", "inviteID")
+	c.SetParamValues("10", "7")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleAdmin})
+
+	err := h.RevokeInvite(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRevokeInvite_NotPending(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetTeamInviteByID", mock.Anything, mock.Anything, int64(10), int64(7)).
+		Return(&models.TeamInvite{ID: 7, TeamID: 10, Status: models.InviteStatusAccepted}, nil)
+
+	h := &TeamHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodDelete, "/teams/10/invites/7", nil)
+	c.SetParamNames("id", "inviteID")
+	c.SetParamValues("10", "7")
+	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleOwner})
+
+	err := h.RevokeInvite(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
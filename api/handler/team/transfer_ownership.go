@@ -0,0 +1,138 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+type transferOwnershipRequest struct {
+	UserID string `json:"user_id" validate:"required,numeric"`
+}
+
+// TransferOwnership godoc
+// @Summary Transfer team ownership
+// @Description Makes another member an owner of the team and steps the caller down to admin (owner only). The new owner must already be a member.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body transferOwnershipRequest true "Ownership transfer request"
+// @Success 200 {object} response.SuccessResponse "Ownership transferred successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/transfer-ownership [post]
+func (h *TeamHandler) TransferOwnership(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req transferOwnershipRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	targetUserID, err := strconv.ParseInt(req.UserID, 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	member := authutil.GetTeamMemberFromContext(c)
+	if member == nil || !member.Role.Can(models.PermissionResourceTeam, models.PermissionActionTransfer) {
+		return echo.NewHTTPError(http.StatusForbidden, "Only owners can transfer team ownership")
+	}
+
+	if targetUserID == *userID {
+		return echo.NewHTTPError(http.StatusBadRequest, "You already own this team")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	target, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, targetUserID)
+	if err != nil {
+		zap.L().Error("Failed to get team member", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team member")
+	}
+
+	if target == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+	}
+
+	now := time.Now()
+
+	// Promote first so the team always keeps an owner.
+	promoted, err := h.Repo.UpdateTeamMemberRole(c.Request().Context(), tx, teamID, targetUserID, models.MemberRoleOwner, now)
+	if err != nil {
+		zap.L().Error("Failed to update member role", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update member role")
+	}
+
+	if promoted == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+	}
+
+	demoted, err := h.Repo.UpdateTeamMemberRole(c.Request().Context(), tx, teamID, *userID, models.MemberRoleAdmin, now)
+	if err != nil {
+		zap.L().Error("Failed to update member role", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update member role")
+	}
+
+	if demoted == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+	}
+
+	for _, change := range []struct{ before, after models.TeamMember }{
+		{before: *target, after: *promoted},
+		{before: *member, after: *demoted},
+	} {
+		if err := audit.Record(c, h.Repo, tx, audit.Entry{
+			TeamID:       teamID,
+			Action:       models.AuditActionTransfer,
+			ResourceType: models.AuditResourceTeamMember,
+			ResourceID:   change.after.ID,
+			Before:       change.before,
+			After:        change.after,
+		}); err != nil {
+			zap.L().Error("Failed to record audit event", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+		}
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Ownership transferred successfully"))
+}
@@ -0,0 +1,90 @@
+package team
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newTransferOwnershipContext(body string) (echo.Context, *TeamHandler, *repository.MockRepository) {
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	c, _ := testutil.NewEchoContext(http.MethodPost, "/teams/10/transfer-ownership", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+
+	return c, &TeamHandler{Repo: mockRepo}, mockRepo
+}
+
+func TestTransferOwnership_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newTransferOwnershipContext(`{"user_id":"456"}`)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	testutil.SetTeamMember(c, &models.TeamMember{ID: 1, UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{ID: 2, UserID: 456, Role: models.MemberRoleMember}, nil)
+	mockRepo.On("UpdateTeamMemberRole", mock.Anything, mock.Anything, int64(10), int64(456), models.MemberRoleOwner, mock.AnythingOfType("time.Time")).
+		Return(&models.TeamMember{ID: 2, UserID: 456, Role: models.MemberRoleOwner}, nil)
+	mockRepo.On("UpdateTeamMemberRole", mock.Anything, mock.Anything, int64(10), int64(123), models.MemberRoleAdmin, mock.AnythingOfType("time.Time")).
+		Return(&models.TeamMember{ID: 1, UserID: 123, Role: models.MemberRoleAdmin}, nil)
+
+	err := h.TransferOwnership(c)
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "CreateAuditEvent", 2)
+}
+
+func TestTransferOwnership_TargetNotMember(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newTransferOwnershipContext(`{"user_id":"456"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return((*models.TeamMember)(nil), nil)
+
+	err := h.TransferOwnership(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "UpdateTeamMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferOwnership_AdminForbidden(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newTransferOwnershipContext(`{"user_id":"456"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+
+	err := h.TransferOwnership(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "StartTransaction", mock.Anything)
+}
+
+func TestTransferOwnership_ToSelf(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, _ := newTransferOwnershipContext(`{"user_id":"123"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+
+	err := h.TransferOwnership(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
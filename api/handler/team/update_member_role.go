@@ -0,0 +1,135 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/audit"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+type updateMemberRoleRequest struct {
+	Role models.MemberRole `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+// UpdateMemberRole godoc
+// @Summary Change a member's role
+// @Description Changes the role of a team member (owner or admin only). Only owners can grant or revoke the owner role, and the last owner cannot be demoted.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param userID path string true "User ID of the member"
+// @Param request body updateMemberRoleRequest true "Member role update request"
+// @Success 200 {object} response.SuccessResponse "Member role updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 409 {object} response.ErrorResponse "Team must keep at least one owner"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{id}/members/{userID} [put]
+func (h *TeamHandler) UpdateMemberRole(c echo.Context) error {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+	}
+
+	targetUserID, err := strconv.ParseInt(c.Param("userID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req updateMemberRoleRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	member := authutil.GetTeamMemberFromContext(c)
+	if member == nil || !member.Role.Can(models.PermissionResourceTeamMember, models.PermissionActionUpdate) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to manage team members")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	target, err := h.Repo.GetTeamMemberByUserID(c.Request().Context(), tx, teamID, targetUserID)
+	if err != nil {
+		zap.L().Error("Failed to get team member", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team member")
+	}
+
+	if target == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+	}
+
+	// Admins manage everyone below owner; only owners touch the owner role.
+	if member.Role != models.MemberRoleOwner && (target.Role == models.MemberRoleOwner || req.Role == models.MemberRoleOwner) {
+		return echo.NewHTTPError(http.StatusForbidden, "Only owners can grant or revoke the owner role")
+	}
+
+	if target.Role == models.MemberRoleOwner && req.Role != models.MemberRoleOwner {
+		owners, err := h.Repo.CountTeamOwners(c.Request().Context(), tx, teamID)
+		if err != nil {
+			zap.L().Error("Failed to count team owners", zap.Error(err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to count team owners")
+		}
+
+		if owners <= 1 {
+			return echo.NewHTTPError(http.StatusConflict, "Team must keep at least one owner")
+		}
+	}
+
+	updated, err := h.Repo.UpdateTeamMemberRole(c.Request().Context(), tx, teamID, targetUserID, req.Role, time.Now())
+	if err != nil {
+		zap.L().Error("Failed to update member role", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update member role")
+	}
+
+	if updated == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+	}
+
+	if err := audit.Record(c, h.Repo, tx, audit.Entry{
+		TeamID:       teamID,
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.AuditResourceTeamMember,
+		ResourceID:   target.ID,
+		Before:       *target,
+		After:        *updated,
+	}); err != nil {
+		zap.L().Error("Failed to record audit event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record audit event")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Member role updated successfully", updated))
+}
@@ -0,0 +1,88 @@
+package team
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newUpdateMemberRoleContext(body string) (echo.Context, *TeamHandler, *repository.MockRepository) {
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+
+	c, _ := testutil.NewEchoContext(http.MethodPut, "/teams/10/members/456", strings.NewReader(body))
+	testutil.SetJSONHeader(c)
+	c.SetParamNames("id", "userID")
+	c.SetParamValues("10", "456")
+	testutil.Authenticate(c, 123)
+
+	return c, &TeamHandler{Repo: mockRepo}, mockRepo
+}
+
+func TestUpdateMemberRole_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newUpdateMemberRoleContext(`{"role":"admin"}`)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleMember}, nil)
+	mockRepo.On("UpdateTeamMemberRole", mock.Anything, mock.Anything, int64(10), int64(456), models.MemberRoleAdmin, mock.AnythingOfType("time.Time")).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleAdmin}, nil)
+
+	err := h.UpdateMemberRole(c)
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "UpdateTeamMemberRole", mock.Anything, mock.Anything, int64(10), int64(456), models.MemberRoleAdmin, mock.Anything)
+}
+
+func TestUpdateMemberRole_AdminCannotGrantOwner(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newUpdateMemberRoleContext(`{"role":"owner"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleAdmin})
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleMember}, nil)
+
+	err := h.UpdateMemberRole(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, httpErr.Code)
+}
+
+func TestUpdateMemberRole_LastOwner(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, mockRepo := newUpdateMemberRoleContext(`{"role":"member"}`)
+	testutil.SetTeamMember(c, &models.TeamMember{UserID: 123, Role: models.MemberRoleOwner})
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(456)).
+		Return(&models.TeamMember{UserID: 456, Role: models.MemberRoleOwner}, nil)
+	mockRepo.On("CountTeamOwners", mock.Anything, mock.Anything, int64(10)).Return(1, nil)
+
+	err := h.UpdateMemberRole(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestUpdateMemberRole_InvalidRole(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	c, h, _ := newUpdateMemberRoleContext(`{"role":"superuser"}`)
+
+	err := h.UpdateMemberRole(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
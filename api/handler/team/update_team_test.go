@@ -9,6 +9,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
 	"github.com/yorukot/knocker/internal/testutil"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/repository"
@@ -21,8 +22,9 @@ func TestUpdateTeam_Success(t *testing.T) {
 	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
 	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
 	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
-	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(123)).
-		Return(&models.TeamMember{Role: models.MemberRoleAdmin}, nil)
+	mockRepo.On("CreateAuditEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetTeamForUser", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return(&models.TeamWithRole{Team: models.Team{ID: 10, Name: "OldName"}, Role: models.MemberRoleAdmin}, nil)
 	mockRepo.On("UpdateTeamName", mock.Anything, mock.Anything, int64(10), "NewName", mock.AnythingOfType("time.Time")).
 		Return(&models.Team{ID: 10, Name: "NewName"}, nil)
 
@@ -32,6 +34,7 @@ func TestUpdateTeam_Success(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("10")
 	testutil.Authenticate(c, 123)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleAdmin})
 
 	err := h.UpdateTeam(c)
 	require.NoError(t, err)
@@ -40,6 +43,13 @@ func TestUpdateTeam_Success(t *testing.T) {
 	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
 	require.Equal(t, "Team updated successfully", resp["message"])
+
+	mockRepo.AssertCalled(t, "CreateAuditEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.AuditEvent) bool {
+		return event.Action == models.AuditActionUpdate &&
+			event.ResourceType == models.AuditResourceTeam &&
+			string(event.Before) == `{"name":"OldName"}` &&
+			string(event.After) == `{"name":"NewName"}`
+	}))
 }
 
 func TestUpdateTeam_Forbidden(t *testing.T) {
@@ -48,8 +58,6 @@ func TestUpdateTeam_Forbidden(t *testing.T) {
 	mockRepo := &repository.MockRepository{}
 	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
 	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
-	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(&models.TeamMember{Role: models.MemberRoleViewer}, nil)
 
 	h := &TeamHandler{Repo: mockRepo}
 	c, _ := testutil.NewEchoContext(http.MethodPut, "/teams/11", strings.NewReader(`{"name":"NewName"}`))
@@ -57,6 +65,7 @@ func TestUpdateTeam_Forbidden(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("11")
 	testutil.Authenticate(c, 999)
+	testutil.SetTeamMember(c, &models.TeamMember{Role: models.MemberRoleViewer})
 
 	err := h.UpdateTeam(c)
 	require.Error(t, err)
@@ -77,11 +86,13 @@ func TestUpdateTeam_NotFound(t *testing.T) {
 	h := &TeamHandler{Repo: mockRepo}
 	c, _ := testutil.NewEchoContext(http.MethodPut, "/teams/11", strings.NewReader(`{"name":"NewName"}`))
 	testutil.SetJSONHeader(c)
+	c.SetPath("/teams/:id")
 	c.SetParamNames("id")
 	c.SetParamValues("11")
 	testutil.Authenticate(c, 999)
 
-	err := h.UpdateTeam(c)
+	// Non-members are turned away by the membership middleware before the handler runs.
+	err := middleware.TeamMemberMiddleware(mockRepo)(h.UpdateTeam)(c)
 	require.Error(t, err)
 	httpErr, ok := err.(*echo.HTTPError)
 	require.True(t, ok)
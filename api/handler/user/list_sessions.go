@@ -0,0 +1,54 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// ListSessions godoc
+// @Summary List my sessions
+// @Description Lists the authenticated user's active sessions with the device and IP of their latest use
+// @Tags users
+// @Produce json
+// @Success 200 {object} response.SuccessResponse "Sessions retrieved successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/me/sessions [get]
+func (h *UserHandler) ListSessions(c echo.Context) error {
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	issuedAfter := time.Now().Add(-time.Duration(config.Env().RefreshTokenExpiresAt) * time.Second)
+	sessions, err := h.Repo.ListSessionsByUserID(c.Request().Context(), tx, *userID, issuedAfter)
+	if err != nil {
+		zap.L().Error("Failed to list sessions", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list sessions")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	return c.JSON(http.StatusOK, response.Success("Sessions retrieved successfully", sessions))
+}
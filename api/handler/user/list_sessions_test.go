@@ -0,0 +1,49 @@
+package user
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestListSessions_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	userAgent := "Mozilla/5.0"
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListSessionsByUserID", mock.Anything, mock.Anything, int64(123), mock.AnythingOfType("time.Time")).
+		Return([]models.Session{
+			{ID: 55, UserAgent: &userAgent, IP: net.ParseIP("192.0.2.1"), LastActiveAt: now, CreatedAt: now.Add(-time.Hour)},
+		}, nil)
+
+	h := &UserHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/users/me/sessions", nil)
+	testutil.Authenticate(c, 123)
+
+	err := h.ListSessions(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	data, ok := resp["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, data, 1)
+
+	session := data[0].(map[string]any)
+	require.Equal(t, "55", session["id"])
+	require.Equal(t, "192.0.2.1", session["ip"])
+	require.Equal(t, "Mozilla/5.0", session["user_agent"])
+}
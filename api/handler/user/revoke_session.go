@@ -0,0 +1,104 @@
+package user
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/denylist"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/utils/response"
+	"go.uber.org/zap"
+)
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Signs the authenticated user out of one of their sessions; its refresh and access tokens stop working immediately
+// @Tags users
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.SuccessResponse "Session revoked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid session ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Session not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid session ID")
+	}
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		zap.L().Error("Failed to parse user ID from context", zap.Error(err))
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	tx, err := h.Repo.StartTransaction(c.Request().Context())
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to begin transaction")
+	}
+	defer h.Repo.DeferRollback(tx, c.Request().Context())
+
+	now := time.Now()
+
+	revoked, err := h.Repo.RevokeRefreshTokenFamily(c.Request().Context(), tx, *userID, sessionID, now)
+	if err != nil {
+		zap.L().Error("Failed to revoke session", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke session")
+	}
+
+	if revoked == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Session not found")
+	}
+
+	eventID, err := id.GetID()
+	if err != nil {
+		zap.L().Error("Failed to generate security event ID", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate security event ID")
+	}
+
+	userAgent := c.Request().UserAgent()
+	if err := h.Repo.CreateSecurityEvent(c.Request().Context(), tx, models.SecurityEvent{
+		ID:        eventID,
+		UserID:    *userID,
+		Type:      models.SecurityEventSessionRevoked,
+		SessionID: &sessionID,
+		IP:        net.ParseIP(c.RealIP()),
+		UserAgent: &userAgent,
+		CreatedAt: now,
+	}); err != nil {
+		zap.L().Error("Failed to create security event", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create security event")
+	}
+
+	// Access tokens of the session would otherwise stay valid until they expire
+	accessTokenLifetime := time.Duration(config.Env().AccessTokenExpiresAt) * time.Second
+	accessTokens, err := h.Repo.ListIssuedAccessTokens(c.Request().Context(), tx, *userID, &sessionID, now.Add(-accessTokenLifetime))
+	if err != nil {
+		zap.L().Error("Failed to list session access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list session access tokens")
+	}
+
+	if err := h.Repo.CommitTransaction(tx, c.Request().Context()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit transaction")
+	}
+
+	if err := denylist.AddIssued(c.Request().Context(), accessTokens, accessTokenLifetime); err != nil {
+		zap.L().Error("Failed to denylist session access tokens", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke session access tokens")
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessMessage("Session revoked successfully"))
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestRevokeSession_Success(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, int64(123), int64(55), mock.AnythingOfType("time.Time")).
+		Return(int64(1), nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.SecurityEvent) bool {
+		return event.UserID == 123 && event.Type == models.SecurityEventSessionRevoked && *event.SessionID == 55
+	})).Return(nil)
+	mockRepo.On("ListIssuedAccessTokens", mock.Anything, mock.Anything, int64(123), mock.MatchedBy(func(familyID *int64) bool {
+		return familyID != nil && *familyID == 55
+	}), mock.AnythingOfType("time.Time")).Return([]models.IssuedAccessToken{}, nil)
+
+	h := &UserHandler{Repo: mockRepo}
+	c, rec := testutil.NewEchoContext(http.MethodDelete, "/users/me/sessions/55", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("55")
+	testutil.Authenticate(c, 123)
+
+	err := h.RevokeSession(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, int64(123), int64(55), mock.AnythingOfType("time.Time")).
+		Return(int64(0), nil)
+
+	h := &UserHandler{Repo: mockRepo}
+	c, _ := testutil.NewEchoContext(http.MethodDelete, "/users/me/sessions/55", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("55")
+	testutil.Authenticate(c, 123)
+
+	err := h.RevokeSession(c)
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, httpErr.Code)
+	mockRepo.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything, mock.Anything)
+}
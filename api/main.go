@@ -1,16 +1,21 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
 
 	scalar "github.com/MarceloPetrucio/go-scalar-api-reference"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"github.com/yorukot/knocker/api/middleware"
 	"github.com/yorukot/knocker/api/router"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	swaggerDocs "github.com/yorukot/knocker/docs"
 	"github.com/yorukot/knocker/repository"
 	"github.com/yorukot/knocker/utils/config"
@@ -27,22 +32,56 @@ func Run(db *pgxpool.Pool) {
 	e.Use(echoMiddleware.Recover())
 
 	env := config.Env()
+	repo := repository.New(db)
+
+	if len(env.TrustedProxies) > 0 {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(trustedProxyOptions(env.TrustedProxies)...)
+	}
+
+	// Custom domains are resolved before routing so their requests reach the public status page routes
+	if env.StatusPageDomainTarget != "" {
+		e.Pre(middleware.CustomDomainMiddleware(repo, ownHosts(env)))
+	}
 	e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
 		AllowOrigins:     frontendOrigins(env.FrontendDomain),
 		AllowMethods:     []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
 		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		ExposeHeaders:    []string{middleware.HeaderRateLimitLimit, middleware.HeaderRateLimitRemaining, middleware.HeaderRateLimitReset, echo.HeaderRetryAfter},
 		AllowCredentials: true,
 	}))
 
 	// Setup routes
-	repo := repository.New(db)
-	routes(e, repo)
+	asynqClient := asynq.NewClient(config.AsynqRedisOpt())
+	defer asynqClient.Close()
+	inspector := asynq.NewInspector(config.AsynqRedisOpt())
+	defer inspector.Close()
+
+	routes(e, repo, asynqClient, inspector)
+
+	if env.TLSMode == config.TLSModeACME {
+		certificates := statuspagecore.NewACMECertificateManager(statuspagecore.ACMEOptions{
+			Email:        env.ACMEEmail,
+			DirectoryURL: env.ACMEDirectoryURL,
+			CacheDir:     env.ACMECacheDir,
+			HostPolicy:   customDomainHostPolicy(repo, ownHosts(env)),
+		})
+
+		// Plain HTTP keeps serving the API and answers the ACME HTTP-01 challenges
+		go func() {
+			e.Logger.Fatal(http.ListenAndServe(":"+env.AppPort, certificates.HTTPHandler(e)))
+		}()
+
+		e.Logger.Infof("Starting TLS server on port %s in %s mode", env.TLSPort, env.AppEnv)
+		e.Logger.Fatal(e.StartServer(&http.Server{Addr: ":" + env.TLSPort, TLSConfig: certificates.TLSConfig()}))
+		return
+	}
+
 	e.Logger.Infof("Starting server on port %s in %s mode", env.AppPort, env.AppEnv)
 	e.Logger.Fatal(e.Start(":" + env.AppPort))
 }
 
 // routes sets up the API routes
-func routes(e *echo.Echo, repo repository.Repository) {
+func routes(e *echo.Echo, repo repository.Repository, asynqClient *asynq.Client, inspector *asynq.Inspector) {
 	// Development-only routes
 	if config.Env().AppEnv == config.AppEnvDev {
 		// Swagger documentation route
@@ -53,15 +92,20 @@ func routes(e *echo.Echo, repo repository.Repository) {
 
 	// User routes
 	api := e.Group("/api")
-	router.AuthRouter(api, repo)
+	router.AuthRouter(api, repo, asynqClient)
 	router.UserRouter(api, repo)
-	router.TeamRouter(api, repo)
+	router.TeamRouter(api, repo, asynqClient, inspector)
+	router.APIKeyRouter(api, repo)
+	router.AuditLogRouter(api, repo)
 	router.RegionRouter(api, repo)
 	router.NotificationRouter(api, repo)
+	router.NotificationRouteRouter(api, repo)
 	router.MonitorRouter(api, repo)
-	router.IncidentRouter(api, repo)
+	router.IncidentRouter(api, repo, asynqClient, inspector)
+	router.EscalationPolicyRouter(api, repo)
+	router.OnCallScheduleRouter(api, repo)
 	router.StatusPageRouter(api, repo)
-	router.PublicStatusPageRouter(api, repo)
+	router.PublicStatusPageRouter(api, repo, asynqClient)
 }
 
 func scalarDocsHandler() echo.HandlerFunc {
@@ -83,6 +127,68 @@ func scalarDocsHandler() echo.HandlerFunc {
 	}
 }
 
+// ownHosts lists the hosts Knocker itself answers on, which are never looked up as custom domains.
+func ownHosts(env *config.EnvConfig) []string {
+	hosts := []string{"localhost", env.StatusPageDomainTarget}
+	if domain := strings.TrimSpace(env.FrontendDomain); domain != "" {
+		domain = strings.TrimPrefix(strings.TrimPrefix(domain, "https://"), "http://")
+		hosts = append(hosts, strings.Split(domain, ":")[0])
+	}
+	return hosts
+}
+
+// customDomainHostPolicy only lets certificates be issued for Knocker's own hosts and verified custom domains,
+// so a stray DNS record cannot make the server request certificates for any name.
+func customDomainHostPolicy(repo repository.Repository, own []string) statuspagecore.HostPolicy {
+	return func(ctx context.Context, host string) error {
+		for _, h := range own {
+			if h != "" && strings.EqualFold(h, host) {
+				return nil
+			}
+		}
+
+		tx, err := repo.StartTransaction(ctx)
+		if err != nil {
+			return err
+		}
+		defer repo.DeferRollback(tx, ctx)
+
+		page, err := repo.GetStatusPageByCustomDomain(ctx, tx, strings.ToLower(host))
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			return errors.New("host is not a verified status page domain")
+		}
+
+		return repo.CommitTransaction(tx, ctx)
+	}
+}
+
+// trustedProxyOptions trusts only the configured proxy ranges, not the loopback and private defaults
+func trustedProxyOptions(ranges []string) []echo.TrustOption {
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, raw := range ranges {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if !strings.Contains(raw, "/") {
+			if strings.Contains(raw, ":") {
+				raw += "/128"
+			} else {
+				raw += "/32"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(raw)
+		if err != nil {
+			zap.L().Fatal("Invalid TRUSTED_PROXIES range", zap.String("range", raw), zap.Error(err))
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return options
+}
+
 // frontendOrigins builds allowed origins for CORS from the configured frontend domain.
 func frontendOrigins(domain string) []string {
 	trimmed := strings.TrimSpace(domain)
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/encrypt"
+	"go.uber.org/zap"
+)
+
+// authenticateAPIKey resolves an API key to its team and role and stores them on the context.
+// The key's creator is used as the acting user, and its membership is stored for the permission checks.
+func authenticateAPIKey(c echo.Context, repo repository.Repository, token string) error {
+	ctx := c.Request().Context()
+	tx, err := repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to begin transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+	defer repo.DeferRollback(tx, ctx)
+
+	key, err := repo.GetAPIKeyByTokenHash(ctx, tx, encrypt.HashToken(token))
+	if err != nil {
+		zap.L().Error("Failed to get API key", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if key == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "API key has expired")
+	}
+
+	teamID := routeTeamID(c)
+	if teamID == "" {
+		return echo.NewHTTPError(http.StatusForbidden, "API keys can only access team resources")
+	}
+
+	if teamID != strconv.FormatInt(key.TeamID, 10) {
+		return echo.NewHTTPError(http.StatusForbidden, "API key does not belong to this team")
+	}
+
+	if key.Scope == models.APIKeyScopeRead && !isSafeMethod(c.Request().Method) {
+		return echo.NewHTTPError(http.StatusForbidden, "API key is read-only")
+	}
+
+	// A key stops working once its creator leaves the team.
+	member, err := repo.GetTeamMemberByUserID(ctx, tx, key.TeamID, key.CreatedBy)
+	if err != nil {
+		zap.L().Error("Failed to get team membership", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if member == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+	}
+
+	// The key acts as its creator, except that read-only keys never get more than viewer rights.
+	if key.Scope == models.APIKeyScopeRead {
+		member.Role = models.MemberRoleViewer
+	}
+
+	if err := repo.TouchAPIKeyLastUsed(ctx, tx, key.ID, now); err != nil {
+		zap.L().Error("Failed to update API key last used time", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if err := repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("Failed to commit transaction", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	c.Set(string(UserIDKey), strconv.FormatInt(key.CreatedBy, 10))
+	c.Set(string(APIKeyIDKey), strconv.FormatInt(key.ID, 10))
+	c.Set(string(APIKeyTeamIDKey), strconv.FormatInt(key.TeamID, 10))
+	c.Set(string(TeamMemberKey), member)
+	return nil
+}
+
+// routeTeamID returns the team ID path parameter of the matched route, if it has one.
+func routeTeamID(c echo.Context) string {
+	if teamID := c.Param("teamID"); teamID != "" {
+		return teamID
+	}
+
+	if strings.Contains(c.Path(), "/teams/:id") {
+		return c.Param("id")
+	}
+
+	return ""
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
@@ -0,0 +1,128 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/encrypt"
+)
+
+const testAPIKey = "kn_test-token"
+
+func newAPIKeyContext(method string) echo.Context {
+	c, _ := testutil.NewEchoContext(method, "/api/teams/10/monitors", nil)
+	c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+testAPIKey)
+	c.SetPath("/api/teams/:teamID/monitors")
+	c.SetParamNames("teamID")
+	c.SetParamValues("10")
+	return c
+}
+
+func newAPIKeyRepo(key *models.APIKey) *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetAPIKeyByTokenHash", mock.Anything, mock.Anything, encrypt.HashToken(testAPIKey)).Return(key, nil)
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(123)).
+		Return(&models.TeamMember{TeamID: 10, UserID: 123, Role: models.MemberRoleAdmin}, nil)
+	mockRepo.On("TouchAPIKeyLastUsed", mock.Anything, mock.Anything, int64(7), mock.AnythingOfType("time.Time")).Return(nil)
+	return mockRepo
+}
+
+func runAuth(t *testing.T, repo repository.Repository, c echo.Context) (bool, error) {
+	t.Helper()
+
+	called := false
+	handler := middleware.AuthRequiredMiddleware(repo)(func(c echo.Context) error {
+		called = true
+		return nil
+	})
+
+	return called, handler(c)
+}
+
+func requireHTTPStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	require.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, status, httpErr.Code)
+}
+
+func TestAPIKey_WriteKeyActsAsCreator(t *testing.T) {
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 10, CreatedBy: 123, Scope: models.APIKeyScopeWrite})
+	c := newAPIKeyContext(http.MethodPost)
+
+	called, err := runAuth(t, mockRepo, c)
+	require.NoError(t, err)
+	require.True(t, called)
+
+	userID, err := authutil.GetUserIDFromContext(c)
+	require.NoError(t, err)
+	require.Equal(t, int64(123), *userID)
+	require.True(t, authutil.IsAPIKeyRequest(c))
+	require.Equal(t, models.MemberRoleAdmin, authutil.GetTeamMemberFromContext(c).Role)
+	mockRepo.AssertCalled(t, "TouchAPIKeyLastUsed", mock.Anything, mock.Anything, int64(7), mock.Anything)
+}
+
+func TestAPIKey_ReadKeyResolvesToViewer(t *testing.T) {
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 10, CreatedBy: 123, Scope: models.APIKeyScopeRead})
+	c := newAPIKeyContext(http.MethodGet)
+
+	called, err := runAuth(t, mockRepo, c)
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Equal(t, models.MemberRoleViewer, authutil.GetTeamMemberFromContext(c).Role)
+}
+
+func TestAPIKey_ReadKeyRejectsWrites(t *testing.T) {
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 10, CreatedBy: 123, Scope: models.APIKeyScopeRead})
+
+	called, err := runAuth(t, mockRepo, newAPIKeyContext(http.MethodDelete))
+	requireHTTPStatus(t, err, http.StatusForbidden)
+	require.False(t, called)
+}
+
+func TestAPIKey_OtherTeam(t *testing.T) {
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 99, CreatedBy: 123, Scope: models.APIKeyScopeWrite})
+
+	called, err := runAuth(t, mockRepo, newAPIKeyContext(http.MethodGet))
+	requireHTTPStatus(t, err, http.StatusForbidden)
+	require.False(t, called)
+}
+
+func TestAPIKey_NonTeamRoute(t *testing.T) {
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 10, CreatedBy: 123, Scope: models.APIKeyScopeWrite})
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/users/me", nil)
+	c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+testAPIKey)
+	c.SetPath("/api/users/me")
+
+	called, err := runAuth(t, mockRepo, c)
+	requireHTTPStatus(t, err, http.StatusForbidden)
+	require.False(t, called)
+}
+
+func TestAPIKey_Expired(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	mockRepo := newAPIKeyRepo(&models.APIKey{ID: 7, TeamID: 10, CreatedBy: 123, Scope: models.APIKeyScopeWrite, ExpiresAt: &expired})
+
+	called, err := runAuth(t, mockRepo, newAPIKeyContext(http.MethodGet))
+	requireHTTPStatus(t, err, http.StatusUnauthorized)
+	require.False(t, called)
+}
+
+func TestAPIKey_Unknown(t *testing.T) {
+	mockRepo := newAPIKeyRepo(nil)
+
+	called, err := runAuth(t, mockRepo, newAPIKeyContext(http.MethodGet))
+	requireHTTPStatus(t, err, http.StatusUnauthorized)
+	require.False(t, called)
+}
@@ -2,16 +2,20 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
 	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/denylist"
 	"github.com/yorukot/knocker/utils/encrypt"
 	"go.uber.org/zap"
 )
 
 // authMiddlewareLogic is the logic for the auth middleware
-func authMiddlewareLogic(token string) (*encrypt.AccessTokenClaims, error) {
+func authMiddlewareLogic(c echo.Context, token string) (*encrypt.AccessTokenClaims, error) {
 	JWTSecret := encrypt.JWTSecret{
 		Secret: config.Env().JWTSecretKey,
 	}
@@ -26,36 +30,87 @@ func authMiddlewareLogic(token string) (*encrypt.AccessTokenClaims, error) {
 		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
 	}
 
+	// Tokens revoked by logout or a session sign-out stay denylisted until they expire
+	if claims.ID != "" {
+		revoked, err := denylist.Contains(c.Request().Context(), claims.ID)
+		if err != nil {
+			zap.L().Error("Failed to check access token denylist", zap.Error(err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		}
+
+		if revoked {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, "Token has been revoked")
+		}
+	}
+
 	return &claims, nil
 }
 
-// AuthRequiredMiddleware is the middleware for the auth required
-func AuthRequiredMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		accessCookie, err := c.Cookie(models.CookieNameAccessToken)
-		if err != nil || accessCookie.Value == "" {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
-		}
+// bearerToken returns the token from an "Authorization: Bearer <token>" header.
+func bearerToken(c echo.Context) (string, bool) {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
 
-		claims, err := authMiddlewareLogic(accessCookie.Value)
-		if err != nil {
-			return err
-		}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
 
-		c.Set(string(UserIDKey), claims.Subject)
-		return next(c)
+// accessToken returns the JWT access token from the Authorization header, falling back to the cookie.
+func accessToken(c echo.Context) string {
+	if token, ok := bearerToken(c); ok {
+		return token
+	}
+
+	accessCookie, err := c.Cookie(models.CookieNameAccessToken)
+	if err != nil {
+		return ""
+	}
+
+	return accessCookie.Value
+}
+
+// AuthRequiredMiddleware is the middleware for the auth required.
+// It accepts the access token cookie, a Bearer access token, or a Bearer API key.
+func AuthRequiredMiddleware(repo repository.Repository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if token, ok := bearerToken(c); ok && encrypt.IsAPIKey(token) {
+				if err := authenticateAPIKey(c, repo, token); err != nil {
+					return err
+				}
+				return next(c)
+			}
+
+			token := accessToken(c)
+			if token == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			claims, err := authMiddlewareLogic(c, token)
+			if err != nil {
+				return err
+			}
+
+			c.Set(string(UserIDKey), claims.Subject)
+			c.Set(string(AccessTokenIDKey), claims.ID)
+			c.Set(string(AccessTokenExpiresAtKey), time.Unix(claims.ExpiresAt, 0))
+			return next(c)
+		}
 	}
 }
 
 // AuthOptionalMiddleware is the middleware for the auth optional
 func AuthOptionalMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		accessCookie, err := c.Cookie(models.CookieNameAccessToken)
-		if err != nil || accessCookie.Value == "" {
+		token := accessToken(c)
+		if token == "" || encrypt.IsAPIKey(token) {
 			return next(c)
 		}
 
-		claims, err := authMiddlewareLogic(accessCookie.Value)
+		claims, err := authMiddlewareLogic(c, token)
 		if err != nil {
 			// For optional auth, continue even if token is invalid
 			return next(c)
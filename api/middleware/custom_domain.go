@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/repository"
+	"go.uber.org/zap"
+)
+
+// CustomDomainMiddleware serves status pages on their verified custom domains. It must run before
+// routing (echo.Pre): a request for status.customer.com/feed.rss is rewritten to
+// /api/status-pages/<slug>/feed.rss, and the root of the domain to the page itself, as HTML for
+// browsers and JSON otherwise. Nothing but the public status page routes is reachable that way.
+// Requests for ownHosts, IP addresses and unknown domains are passed through untouched.
+// Lookups are cached in memory for customDomainCacheTTL, unknown domains included, so a domain
+// change can take that long to show.
+func CustomDomainMiddleware(repo repository.Repository, ownHosts []string) echo.MiddlewareFunc {
+	cache := newDomainCache(customDomainCacheTTL, customDomainCacheSize)
+	own := make(map[string]struct{}, len(ownHosts))
+	for _, host := range ownHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			own[host] = struct{}{}
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			host := requestHost(c.Request())
+			if host == "" || net.ParseIP(host) != nil {
+				return next(c)
+			}
+			if _, ok := own[host]; ok {
+				return next(c)
+			}
+
+			now := time.Now()
+			slug, ok := cache.get(host, now)
+			if !ok {
+				var err error
+				if slug, err = lookupCustomDomain(c.Request().Context(), repo, host); err != nil {
+					zap.L().Error("Failed to get status page by custom domain", zap.String("host", host), zap.Error(err))
+					return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+				}
+				cache.set(host, slug, now)
+			}
+
+			if slug == "" {
+				return next(c)
+			}
+
+			rewriteToStatusPage(c.Request(), slug)
+			return next(c)
+		}
+	}
+}
+
+// lookupCustomDomain returns the slug of the page verified for the domain, or "" when there is none
+func lookupCustomDomain(ctx context.Context, repo repository.Repository, domain string) (string, error) {
+	tx, err := repo.StartTransaction(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer repo.DeferRollback(tx, ctx)
+
+	page, err := repo.GetStatusPageByCustomDomain(ctx, tx, domain)
+	if err != nil {
+		return "", err
+	}
+
+	if err := repo.CommitTransaction(tx, ctx); err != nil {
+		return "", err
+	}
+
+	if page == nil {
+		return "", nil
+	}
+	return page.Slug, nil
+}
+
+const (
+	// customDomainCacheTTL bounds how long a domain change takes to show
+	customDomainCacheTTL = 30 * time.Second
+	// customDomainCacheSize caps the cache, since anyone can send arbitrary Host headers
+	customDomainCacheSize = 10000
+)
+
+// domainCache remembers which slug a host resolved to, "" for hosts that serve no page
+type domainCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]domainCacheEntry
+}
+
+type domainCacheEntry struct {
+	slug      string
+	expiresAt time.Time
+}
+
+func newDomainCache(ttl time.Duration, size int) *domainCache {
+	return &domainCache{ttl: ttl, size: size, entries: make(map[string]domainCacheEntry)}
+}
+
+func (d *domainCache) get(host string, now time.Time) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.entries[host]
+	if !ok || !now.Before(entry.expiresAt) {
+		return "", false
+	}
+	return entry.slug, true
+}
+
+func (d *domainCache) set(host, slug string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.entries) >= d.size {
+		for key, entry := range d.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(d.entries, key)
+			}
+		}
+		// Still full of live entries: start over rather than grow without bound.
+		if len(d.entries) >= d.size {
+			clear(d.entries)
+		}
+	}
+
+	d.entries[host] = domainCacheEntry{slug: slug, expiresAt: now.Add(d.ttl)}
+}
+
+// rewriteToStatusPage points the request at the public routes of the status page
+func rewriteToStatusPage(r *http.Request, slug string) {
+	path := r.URL.Path
+	if path == "" || path == "/" {
+		path = ""
+		if strings.Contains(r.Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+			path = "/index.html"
+		}
+	}
+
+	r.URL.Path = "/api/status-pages/" + url.PathEscape(slug) + path
+	r.URL.RawPath = ""
+}
+
+// requestHost returns the lowercased host of the request without its port
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func newCustomDomainRepo() *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetStatusPageByCustomDomain", mock.Anything, mock.Anything, "status.acme.com").
+		Return(&models.StatusPage{ID: 5, Slug: "acme"}, nil)
+	mockRepo.On("GetStatusPageByCustomDomain", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil)
+	return mockRepo
+}
+
+func newCustomDomainServer(repo repository.Repository) *echo.Echo {
+	e := echo.New()
+	e.Pre(middleware.CustomDomainMiddleware(repo, []string{"knocker.example.com"}))
+	e.Any("/*", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Request().URL.Path)
+	})
+	return e
+}
+
+// serveCustomDomain runs a request through the middleware and returns the path it was routed to
+func serveCustomDomain(t *testing.T, repo repository.Repository, host, path, accept string) string {
+	t.Helper()
+	return serveCustomDomainWith(t, newCustomDomainServer(repo), host, path, accept)
+}
+
+func serveCustomDomainWith(t *testing.T, e *echo.Echo, host, path, accept string) string {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Host = host
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestCustomDomain_RewritesToStatusPage(t *testing.T) {
+	mockRepo := newCustomDomainRepo()
+
+	require.Equal(t, "/api/status-pages/acme", serveCustomDomain(t, mockRepo, "status.acme.com", "/", "application/json"))
+	require.Equal(t, "/api/status-pages/acme/index.html", serveCustomDomain(t, mockRepo, "Status.Acme.com:443", "/", "text/html,application/xhtml+xml"))
+	require.Equal(t, "/api/status-pages/acme/feed.rss", serveCustomDomain(t, mockRepo, "status.acme.com", "/feed.rss", ""))
+	require.Equal(t, "/api/status-pages/acme/api/teams", serveCustomDomain(t, mockRepo, "status.acme.com", "/api/teams", ""))
+	require.Equal(t, "/api/status-pages/acme/api/v2/summary.json", serveCustomDomain(t, mockRepo, "status.acme.com", "/api/v2/summary.json", ""))
+}
+
+func TestCustomDomain_PassesThroughOtherHosts(t *testing.T) {
+	mockRepo := newCustomDomainRepo()
+
+	require.Equal(t, "/api/teams", serveCustomDomain(t, mockRepo, "knocker.example.com", "/api/teams", ""))
+	require.Equal(t, "/api/teams", serveCustomDomain(t, mockRepo, "10.0.0.5:8000", "/api/teams", ""))
+	require.Equal(t, "/api/teams", serveCustomDomain(t, mockRepo, "unknown.example.org", "/api/teams", ""))
+	mockRepo.AssertNotCalled(t, "GetStatusPageByCustomDomain", mock.Anything, mock.Anything, "knocker.example.com")
+}
+
+func TestCustomDomain_CachesLookups(t *testing.T) {
+	mockRepo := newCustomDomainRepo()
+	e := newCustomDomainServer(mockRepo)
+
+	for range 3 {
+		require.Equal(t, "/api/status-pages/acme/feed.rss", serveCustomDomainWith(t, e, "status.acme.com", "/feed.rss", ""))
+		require.Equal(t, "/api/teams", serveCustomDomainWith(t, e, "unknown.example.org", "/api/teams", ""))
+	}
+
+	// One lookup per host, unknown hosts included
+	mockRepo.AssertNumberOfCalls(t, "GetStatusPageByCustomDomain", 2)
+	mockRepo.AssertNumberOfCalls(t, "StartTransaction", 2)
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/utils/ratelimit"
+	"go.uber.org/zap"
+)
+
+// Rate limit response headers (IETF draft-ietf-httpapi-ratelimit-headers)
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimitMiddleware limits requests per key, where key picks what is counted (an IP, a slug...).
+// Requests are let through when Redis is unavailable so an outage of the limiter is not an outage of the API.
+func RateLimitMiddleware(limit ratelimit.Limit, key func(c echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := key(c)
+			if id == "" {
+				return next(c)
+			}
+
+			result, err := limit.Allow(c.Request().Context(), id)
+			if err != nil {
+				zap.L().Error("Failed to check rate limit", zap.String("limit", limit.Name), zap.Error(err))
+				return next(c)
+			}
+
+			SetRateLimitHeaders(c, result.Limit, result.Remaining, result.Reset)
+
+			if !result.Allowed {
+				zap.L().Warn("Rate limit exceeded",
+					zap.String("limit", limit.Name),
+					zap.String("key", id),
+					zap.String("ip", c.RealIP()),
+					zap.String("path", c.Path()))
+				return TooManyRequests(c, result.Reset)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RateLimitByIP limits requests per client IP
+func RateLimitByIP(limit ratelimit.Limit) echo.MiddlewareFunc {
+	return RateLimitMiddleware(limit, func(c echo.Context) string {
+		return c.RealIP()
+	})
+}
+
+// RateLimitByParam limits requests per value of a route parameter, shared by every client
+func RateLimitByParam(limit ratelimit.Limit, param string) echo.MiddlewareFunc {
+	return RateLimitMiddleware(limit, func(c echo.Context) string {
+		return c.Param(param)
+	})
+}
+
+// SetRateLimitHeaders sets the RateLimit-* headers on the response
+func SetRateLimitHeaders(c echo.Context, limit, remaining int64, reset time.Duration) {
+	header := c.Response().Header()
+	header.Set(HeaderRateLimitLimit, strconv.FormatInt(limit, 10))
+	header.Set(HeaderRateLimitRemaining, strconv.FormatInt(remaining, 10))
+	header.Set(HeaderRateLimitReset, strconv.Itoa(seconds(reset)))
+}
+
+// TooManyRequests returns a 429 telling the client when to retry
+func TooManyRequests(c echo.Context, retryAfter time.Duration) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds(retryAfter)))
+	return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests, try again later")
+}
+
+// seconds rounds up so clients never retry before the window ends
+func seconds(d time.Duration) int {
+	return int(math.Ceil(max(d, 0).Seconds()))
+}
@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/utils/ratelimit"
+)
+
+func TestRateLimitMiddleware_FailsOpenWithoutRedis(t *testing.T) {
+	testutil.InitTestEnv(t)
+	ratelimit.Init(nil)
+
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/api/auth/login", nil)
+	limit := ratelimit.Limit{Name: "test", Limit: 1, Window: time.Minute}
+
+	called := false
+	handler := middleware.RateLimitByIP(limit)(func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusOK)
+	})
+
+	require.NoError(t, handler(c))
+	require.True(t, called)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get(middleware.HeaderRateLimitLimit))
+}
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	c, rec := testutil.NewEchoContext(http.MethodGet, "/api/status-pages/demo", nil)
+
+	middleware.SetRateLimitHeaders(c, 120, 0, 1500*time.Millisecond)
+
+	require.Equal(t, "120", rec.Header().Get(middleware.HeaderRateLimitLimit))
+	require.Equal(t, "0", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+	require.Equal(t, "2", rec.Header().Get(middleware.HeaderRateLimitReset))
+}
+
+func TestTooManyRequests(t *testing.T) {
+	c, rec := testutil.NewEchoContext(http.MethodPost, "/api/auth/login", nil)
+
+	err := middleware.TooManyRequests(c, 30*time.Second)
+
+	httpErr, ok := err.(*echo.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusTooManyRequests, httpErr.Code)
+	require.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+}
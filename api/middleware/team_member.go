@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"go.uber.org/zap"
+)
+
+// TeamMemberMiddleware loads the caller's membership of the route's team once per request
+// so handlers can check permissions without querying it again. Callers outside the team get a 404.
+// Routes without a team ID are passed through untouched.
+func TeamMemberMiddleware(repo repository.Repository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			teamIDParam := routeTeamID(c)
+			if teamIDParam == "" {
+				return next(c)
+			}
+
+			// API key requests already resolved the membership they act with.
+			if member, ok := c.Get(string(TeamMemberKey)).(*models.TeamMember); ok && member != nil {
+				return next(c)
+			}
+
+			teamID, err := strconv.ParseInt(teamIDParam, 10, 64)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid team ID")
+			}
+
+			userIDStr, _ := c.Get(string(UserIDKey)).(string)
+			userID, err := strconv.ParseInt(userIDStr, 10, 64)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			ctx := c.Request().Context()
+			tx, err := repo.StartTransaction(ctx)
+			if err != nil {
+				zap.L().Error("Failed to begin transaction", zap.Error(err))
+				return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+			defer repo.DeferRollback(tx, ctx)
+
+			member, err := repo.GetTeamMemberByUserID(ctx, tx, teamID, userID)
+			if err != nil {
+				zap.L().Error("Failed to get team membership", zap.Error(err))
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get team membership")
+			}
+
+			if member == nil {
+				return echo.NewHTTPError(http.StatusNotFound, "Team not found")
+			}
+
+			if err := repo.CommitTransaction(tx, ctx); err != nil {
+				zap.L().Error("Failed to commit transaction", zap.Error(err))
+				return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+
+			c.Set(string(TeamMemberKey), member)
+			return next(c)
+		}
+	}
+}
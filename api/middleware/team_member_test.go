@@ -0,0 +1,109 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	authutil "github.com/yorukot/knocker/utils/auth"
+)
+
+func newTeamRouteContext() echo.Context {
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/teams/10/incidents", nil)
+	c.SetPath("/api/teams/:teamID/incidents")
+	c.SetParamNames("teamID")
+	c.SetParamValues("10")
+	testutil.Authenticate(c, 123)
+	return c
+}
+
+func newMembershipRepo(member *models.TeamMember) *repository.MockRepository {
+	mockRepo := testutil.NewMockRepo()
+	mockRepo.On("GetTeamMemberByUserID", mock.Anything, mock.Anything, int64(10), int64(123)).Return(member, nil)
+	return mockRepo
+}
+
+func runTeamMember(repo repository.Repository, c echo.Context) (bool, error) {
+	called := false
+	handler := middleware.TeamMemberMiddleware(repo)(func(c echo.Context) error {
+		called = true
+		return nil
+	})
+
+	return called, handler(c)
+}
+
+func TestTeamMember_LoadsMembership(t *testing.T) {
+	mockRepo := newMembershipRepo(&models.TeamMember{TeamID: 10, UserID: 123, Role: models.MemberRoleMember})
+	c := newTeamRouteContext()
+
+	called, err := runTeamMember(mockRepo, c)
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Equal(t, models.MemberRoleMember, authutil.GetTeamMemberFromContext(c).Role)
+	require.True(t, authutil.HasPermission(c, models.PermissionResourceIncident, models.PermissionActionUpdate))
+	require.False(t, authutil.HasPermission(c, models.PermissionResourceMonitor, models.PermissionActionUpdate))
+}
+
+func TestTeamMember_NotMember(t *testing.T) {
+	mockRepo := newMembershipRepo(nil)
+
+	called, err := runTeamMember(mockRepo, newTeamRouteContext())
+	requireHTTPStatus(t, err, http.StatusNotFound)
+	require.False(t, called)
+}
+
+func TestTeamMember_ReusesAPIKeyMembership(t *testing.T) {
+	mockRepo := &repository.MockRepository{}
+	c := newTeamRouteContext()
+	testutil.SetTeamMember(c, &models.TeamMember{TeamID: 10, UserID: 123, Role: models.MemberRoleViewer})
+
+	called, err := runTeamMember(mockRepo, c)
+	require.NoError(t, err)
+	require.True(t, called)
+	mockRepo.AssertNotCalled(t, "GetTeamMemberByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamMember_NonTeamRoute(t *testing.T) {
+	c, _ := testutil.NewEchoContext(http.MethodGet, "/api/invites", nil)
+	c.SetPath("/api/invites")
+	testutil.Authenticate(c, 123)
+
+	called, err := runTeamMember(&repository.MockRepository{}, c)
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Nil(t, authutil.GetTeamMemberFromContext(c))
+}
+
+func TestHasPermission_Matrix(t *testing.T) {
+	cases := []struct {
+		role     models.MemberRole
+		resource models.PermissionResource
+		action   models.PermissionAction
+		allowed  bool
+	}{
+		{models.MemberRoleViewer, models.PermissionResourceIncident, models.PermissionActionView, true},
+		{models.MemberRoleViewer, models.PermissionResourceIncident, models.PermissionActionUpdate, false},
+		{models.MemberRoleMember, models.PermissionResourceIncident, models.PermissionActionCreate, true},
+		{models.MemberRoleMember, models.PermissionResourceMonitor, models.PermissionActionCreate, false},
+		{models.MemberRoleMember, models.PermissionResourceAPIKey, models.PermissionActionView, false},
+		{models.MemberRoleAdmin, models.PermissionResourceMonitor, models.PermissionActionDelete, true},
+		{models.MemberRoleAdmin, models.PermissionResourceTeam, models.PermissionActionDelete, false},
+		{models.MemberRoleOwner, models.PermissionResourceTeam, models.PermissionActionDelete, true},
+		{models.MemberRoleOwner, models.PermissionResourceAuditLog, models.PermissionActionDelete, false},
+	}
+
+	for _, tc := range cases {
+		c := newTeamRouteContext()
+		testutil.SetTeamMember(c, &models.TeamMember{Role: tc.role})
+		require.Equal(t, tc.allowed, authutil.HasPermission(c, tc.resource, tc.action), "%s %s %s", tc.role, tc.action, tc.resource)
+	}
+
+	require.False(t, authutil.HasPermission(newTeamRouteContext(), models.PermissionResourceIncident, models.PermissionActionView))
+}
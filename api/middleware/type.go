@@ -6,4 +6,15 @@ type ContextKey string
 // ContextKey constants
 const (
 	UserIDKey ContextKey = "userID"
+
+	// Set only when the request was authenticated with an access token.
+	AccessTokenIDKey        ContextKey = "accessTokenID"
+	AccessTokenExpiresAtKey ContextKey = "accessTokenExpiresAt"
+
+	// Set only when the request was authenticated with an API key.
+	APIKeyIDKey     ContextKey = "apiKeyID"
+	APIKeyTeamIDKey ContextKey = "apiKeyTeamID"
+
+	// Set on team routes to the caller's *models.TeamMember.
+	TeamMemberKey ContextKey = "teamMember"
 )
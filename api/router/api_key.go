@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/handler/apikey"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/repository"
+)
+
+// APIKeyRouter handles team API key routes.
+func APIKeyRouter(api *echo.Group, repo repository.Repository) {
+	apiKeyHandler := &apikey.APIKeyHandler{
+		Repo: repo,
+	}
+	r := api.Group("/teams/:teamID/api-keys", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
+
+	r.POST("", apiKeyHandler.CreateAPIKey)
+	r.GET("", apiKeyHandler.ListAPIKeys)
+	r.GET("/:id", apiKeyHandler.GetAPIKey)
+	r.PUT("/:id", apiKeyHandler.UpdateAPIKey)
+	r.DELETE("/:id", apiKeyHandler.DeleteAPIKey)
+}
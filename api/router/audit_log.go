@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/handler/auditlog"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/repository"
+)
+
+// AuditLogRouter handles team audit log routes.
+func AuditLogRouter(api *echo.Group, repo repository.Repository) {
+	auditLogHandler := &auditlog.AuditLogHandler{
+		Repo: repo,
+	}
+	r := api.Group("/teams/:teamID/audit-log", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
+
+	r.GET("", auditLogHandler.ListAuditEvents)
+}
@@ -1,15 +1,31 @@
 package router
 
 import (
+	"time"
+
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/handler/auth"
 	"github.com/yorukot/knocker/api/middleware"
 	"github.com/yorukot/knocker/repository"
 	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/ratelimit"
+)
+
+// Per-IP limits on the unauthenticated auth endpoints. Failed logins are also locked out per account (see auth.Login).
+var (
+	loginLimit    = ratelimit.Limit{Name: "auth:login", Limit: 30, Window: time.Minute}
+	loginMFALimit = ratelimit.Limit{Name: "auth:login-mfa", Limit: 30, Window: time.Minute}
+	registerLimit = ratelimit.Limit{Name: "auth:register", Limit: 10, Window: time.Hour}
+	refreshLimit  = ratelimit.Limit{Name: "auth:refresh", Limit: 60, Window: time.Minute}
+	// Endpoints that send an email
+	emailLimit = ratelimit.Limit{Name: "auth:email", Limit: 5, Window: 15 * time.Minute}
+	// Endpoints that redeem an emailed token
+	accountTokenLimit = ratelimit.Limit{Name: "auth:account-token", Limit: 20, Window: time.Minute}
 )
 
 // Auth router going to route register signin etc
-func AuthRouter(api *echo.Group, repo repository.Repository) {
+func AuthRouter(api *echo.Group, repo repository.Repository, asynqClient *asynq.Client) {
 	oauthConfig, err := config.GetOAuthConfig()
 	if err != nil {
 		panic("Failed to initialize OAuth config: " + err.Error())
@@ -18,14 +34,30 @@ func AuthRouter(api *echo.Group, repo repository.Repository) {
 	authHandler := &auth.AuthHandler{
 		Repo:        repo,
 		OAuthConfig: oauthConfig,
+		AsynqClient: asynqClient,
 	}
 	r := api.Group("/auth")
 
+	r.GET("/providers", authHandler.ListProviders)
 	r.GET("/oauth/:provider", authHandler.OAuthEntry, middleware.AuthOptionalMiddleware)
 	r.GET("/oauth/:provider/callback", authHandler.OAuthCallback)
 
-	r.GET("/status", authHandler.Status, middleware.AuthRequiredMiddleware)
-	r.POST("/register", authHandler.Register)
-	r.POST("/login", authHandler.Login)
-	r.POST("/refresh", authHandler.RefreshToken)
+	r.GET("/status", authHandler.Status, middleware.AuthRequiredMiddleware(repo))
+	r.POST("/register", authHandler.Register, middleware.RateLimitByIP(registerLimit))
+	r.POST("/login", authHandler.Login, middleware.RateLimitByIP(loginLimit))
+	r.POST("/login/mfa", authHandler.LoginMFA, middleware.RateLimitByIP(loginMFALimit))
+	r.POST("/refresh", authHandler.RefreshToken, middleware.RateLimitByIP(refreshLimit))
+	r.POST("/logout", authHandler.Logout, middleware.AuthRequiredMiddleware(repo))
+	r.POST("/logout-all", authHandler.LogoutAll, middleware.AuthRequiredMiddleware(repo))
+	r.POST("/password/forgot", authHandler.ForgotPassword, middleware.RateLimitByIP(emailLimit))
+	r.POST("/password/reset", authHandler.ResetPassword, middleware.RateLimitByIP(accountTokenLimit))
+	r.POST("/verify", authHandler.VerifyEmail, middleware.RateLimitByIP(accountTokenLimit))
+	r.POST("/verify/resend", authHandler.ResendVerification, middleware.AuthRequiredMiddleware(repo), middleware.RateLimitByIP(emailLimit))
+
+	mfa := r.Group("/mfa", middleware.AuthRequiredMiddleware(repo))
+	mfa.GET("", authHandler.GetMFAStatus)
+	mfa.POST("/totp", authHandler.EnrollTOTP)
+	mfa.POST("/totp/confirm", authHandler.ConfirmTOTP)
+	mfa.DELETE("/totp", authHandler.DisableTOTP)
+	mfa.POST("/recovery-codes", authHandler.RegenerateRecoveryCodes)
 }
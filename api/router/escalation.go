@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/handler/escalation"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/repository"
+)
+
+// EscalationPolicyRouter handles escalation policy routes.
+func EscalationPolicyRouter(api *echo.Group, repo repository.Repository) {
+	escalationHandler := &escalation.EscalationHandler{
+		Repo: repo,
+	}
+	r := api.Group("/teams/:teamID/escalation-policies", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
+
+	r.POST("", escalationHandler.CreateEscalationPolicy)
+	r.GET("", escalationHandler.ListEscalationPolicies)
+	r.GET("/:id", escalationHandler.GetEscalationPolicy)
+	r.PUT("/:id", escalationHandler.UpdateEscalationPolicy)
+	r.DELETE("/:id", escalationHandler.DeleteEscalationPolicy)
+}
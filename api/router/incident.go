@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/handler/incident"
 	"github.com/yorukot/knocker/api/middleware"
@@ -8,13 +9,15 @@ import (
 )
 
 // IncidentRouter handles incident-related routes.
-func IncidentRouter(api *echo.Group, repo repository.Repository) {
+func IncidentRouter(api *echo.Group, repo repository.Repository, asynqClient *asynq.Client, inspector *asynq.Inspector) {
 	incidentHandler := &incident.IncidentHandler{
-		Repo: repo,
+		Repo:        repo,
+		AsynqClient: asynqClient,
+		Inspector:   inspector,
 	}
 
 	// Monitor-scoped read/update for backwards compatibility
-	r := api.Group("/teams/:teamID/incidents", middleware.AuthRequiredMiddleware)
+	r := api.Group("/teams/:teamID/incidents", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
 	r.POST("", incidentHandler.CreateIncident)
 	r.GET("", incidentHandler.ListIncidents)
 	r.GET("/:incidentID", incidentHandler.GetIncident)
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/handler/notificationroute"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/repository"
+)
+
+// NotificationRouteRouter handles notification routing rule routes.
+func NotificationRouteRouter(api *echo.Group, repo repository.Repository) {
+	routeHandler := &notificationroute.NotificationRouteHandler{
+		Repo: repo,
+	}
+	r := api.Group("/teams/:teamID/notification-routes", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
+
+	r.POST("", routeHandler.CreateNotificationRoute)
+	r.GET("", routeHandler.ListNotificationRoutes)
+	r.GET("/:id", routeHandler.GetNotificationRoute)
+	r.PUT("/:id", routeHandler.UpdateNotificationRoute)
+	r.DELETE("/:id", routeHandler.DeleteNotificationRoute)
+}
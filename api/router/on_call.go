@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/api/handler/oncall"
+	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/repository"
+)
+
+// OnCallScheduleRouter handles on-call schedule and override routes.
+func OnCallScheduleRouter(api *echo.Group, repo repository.Repository) {
+	onCallHandler := &oncall.OnCallHandler{
+		Repo: repo,
+	}
+	r := api.Group("/teams/:teamID/on-call-schedules", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
+
+	r.POST("", onCallHandler.CreateSchedule)
+	r.GET("", onCallHandler.ListSchedules)
+	r.GET("/:id", onCallHandler.GetSchedule)
+	r.PUT("/:id", onCallHandler.UpdateSchedule)
+	r.DELETE("/:id", onCallHandler.DeleteSchedule)
+	r.POST("/:id/overrides", onCallHandler.CreateOverride)
+	r.DELETE("/:id/overrides/:overrideID", onCallHandler.DeleteOverride)
+}
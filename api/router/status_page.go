@@ -1,26 +1,101 @@
 package router
 
 import (
+	"time"
+
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/handler/statuspage"
 	"github.com/yorukot/knocker/api/middleware"
 	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/ratelimit"
+)
+
+// Limits on the public status page: per client, and per page so a botnet cannot hammer one page's queries
+var (
+	publicStatusPageIPLimit   = ratelimit.Limit{Name: "status-page:ip", Limit: 120, Window: time.Minute}
+	publicStatusPageSlugLimit = ratelimit.Limit{Name: "status-page:slug", Limit: 1200, Window: time.Minute}
+	// Subscribing sends email, so it gets a much tighter budget than reading the page
+	statusPageSubscribeIPLimit = ratelimit.Limit{Name: "status-page-subscribe:ip", Limit: 10, Window: time.Hour}
+	// Badges are fetched through shared image proxies such as GitHub's camo, so they are limited per badge, not per IP
+	statusPageBadgeLimit = ratelimit.Limit{Name: "status-page-badge:slug", Limit: 600, Window: time.Minute}
+	monitorBadgeLimit    = ratelimit.Limit{Name: "monitor-badge:key", Limit: 600, Window: time.Minute}
+	// Password guesses are limited per client and per page
+	statusPageUnlockIPLimit   = ratelimit.Limit{Name: "status-page-unlock:ip", Limit: 10, Window: 15 * time.Minute}
+	statusPageUnlockSlugLimit = ratelimit.Limit{Name: "status-page-unlock:slug", Limit: 100, Window: 15 * time.Minute}
 )
 
 // StatusPageRouter handles status page routes.
 func StatusPageRouter(api *echo.Group, repo repository.Repository) {
 	handler := &statuspage.Handler{Repo: repo}
 
-	r := api.Group("/teams/:teamID/status-pages", middleware.AuthRequiredMiddleware)
+	r := api.Group("/teams/:teamID/status-pages", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
 	r.POST("", handler.CreateStatusPage)
 	r.GET("", handler.ListStatusPages)
 	r.GET("/:id", handler.GetStatusPage)
 	r.PUT("/:id", handler.UpdateStatusPage)
 	r.DELETE("/:id", handler.DeleteStatusPage)
+	r.GET("/:id/subscribers", handler.ListStatusPageSubscribers)
+	r.DELETE("/:id/subscribers/:subscriberID", handler.DeleteStatusPageSubscriber)
+	r.GET("/:id/domain", handler.GetStatusPageDomain)
+	r.PUT("/:id/domain", handler.SetStatusPageDomain)
+	r.DELETE("/:id/domain", handler.DeleteStatusPageDomain)
+	r.POST("/:id/domain/verify", handler.VerifyStatusPageDomain)
+	r.PUT("/:id/elements/:elementID/status", handler.SetStatusPageElementStatus)
+	r.DELETE("/:id/elements/:elementID/status", handler.ClearStatusPageElementStatus)
+	r.GET("/:id/maintenances", handler.ListStatusPageMaintenances)
+	r.POST("/:id/maintenances", handler.CreateStatusPageMaintenance)
+	r.PUT("/:id/maintenances/:maintenanceID", handler.UpdateStatusPageMaintenance)
+	r.DELETE("/:id/maintenances/:maintenanceID", handler.DeleteStatusPageMaintenance)
+	r.PUT("/:id/images/:kind", handler.UploadStatusPageImage)
+	r.DELETE("/:id/images/:kind", handler.DeleteStatusPageImage)
 }
 
 // PublicStatusPageRouter handles public status page routes.
-func PublicStatusPageRouter(api *echo.Group, repo repository.Repository) {
-	handler := &statuspage.Handler{Repo: repo}
-	api.GET("/status-pages/:slug", handler.GetPublicStatusPage)
+func PublicStatusPageRouter(api *echo.Group, repo repository.Repository, asynqClient *asynq.Client) {
+	handler := &statuspage.Handler{Repo: repo, AsynqClient: asynqClient}
+	// Team-only pages read the signed in user when there is one
+	api.GET("/status-pages/:slug", handler.GetPublicStatusPage,
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	api.GET("/status-pages/:slug/index.html", handler.GetPublicStatusPageHTML,
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	api.GET("/status-pages/:slug/feed.rss", handler.GetStatusPageRSS,
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	api.GET("/status-pages/:slug/feed.atom", handler.GetStatusPageAtom,
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	api.GET("/status-pages/:slug/images/:kind", handler.GetPublicStatusPageImage,
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	// Statuspage.io compatible API; on a custom domain it answers at /api/v2 like Statuspage does
+	compat := api.Group("/status-pages/:slug/api/v2",
+		middleware.RateLimitByIP(publicStatusPageIPLimit),
+		middleware.RateLimitByParam(publicStatusPageSlugLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	compat.GET("/summary.json", handler.GetStatusPageCompatSummary)
+	compat.GET("/status.json", handler.GetStatusPageCompatStatus)
+	compat.GET("/components.json", handler.GetStatusPageCompatComponents)
+	compat.GET("/incidents.json", handler.GetStatusPageCompatIncidents)
+	compat.GET("/scheduled-maintenances.json", handler.GetStatusPageCompatScheduledMaintenances)
+	api.POST("/status-pages/:slug/unlock", handler.UnlockStatusPage,
+		middleware.RateLimitByIP(statusPageUnlockIPLimit),
+		middleware.RateLimitByParam(statusPageUnlockSlugLimit, "slug"))
+
+	api.GET("/badges/:slug/:badge", handler.GetStatusPageBadge,
+		middleware.RateLimitByParam(statusPageBadgeLimit, "slug"),
+		middleware.AuthOptionalMiddleware)
+	api.GET("/badges/monitor/:key/:badge", handler.GetMonitorBadge, middleware.RateLimitByParam(monitorBadgeLimit, "key"))
+
+	r := api.Group("/status-pages/:slug/subscribers", middleware.RateLimitByIP(publicStatusPageIPLimit))
+	r.POST("", handler.Subscribe, middleware.RateLimitByIP(statusPageSubscribeIPLimit), middleware.AuthOptionalMiddleware)
+	r.POST("/confirm", handler.ConfirmSubscription)
+	r.POST("/unsubscribe", handler.Unsubscribe)
 }
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/handler/team"
 	"github.com/yorukot/knocker/api/middleware"
@@ -8,15 +9,35 @@ import (
 )
 
 // TeamRouter handles team-related routes
-func TeamRouter(api *echo.Group, repo repository.Repository) {
+func TeamRouter(api *echo.Group, repo repository.Repository, asynqClient *asynq.Client, inspector *asynq.Inspector) {
 	teamHandler := &team.TeamHandler{
-		Repo: repo,
+		Repo:        repo,
+		AsynqClient: asynqClient,
+		Inspector:   inspector,
 	}
 
-	r := api.Group("/teams", middleware.AuthRequiredMiddleware)
+	r := api.Group("/teams", middleware.AuthRequiredMiddleware(repo), middleware.TeamMemberMiddleware(repo))
 	r.GET("", teamHandler.ListTeams)
 	r.POST("", teamHandler.CreateTeam)
 	r.GET("/:id", teamHandler.GetTeam)
 	r.PUT("/:id", teamHandler.UpdateTeam)
 	r.DELETE("/:id", teamHandler.DeleteTeam)
+	r.POST("/:id/transfer-ownership", teamHandler.TransferOwnership)
+
+	r.GET("/:id/members", teamHandler.ListMembers)
+	r.PUT("/:id/members/:userID", teamHandler.UpdateMemberRole)
+	r.DELETE("/:id/members/:userID", teamHandler.RemoveMember)
+
+	r.GET("/:id/invites", teamHandler.ListInvites)
+	r.POST("/:id/invites", teamHandler.CreateInvite)
+	r.DELETE("/:id/invites/:inviteID", teamHandler.RevokeInvite)
+
+	// Deleted teams are locked behind the membership middleware, so restoring one skips it.
+	deleted := api.Group("/teams", middleware.AuthRequiredMiddleware(repo))
+	deleted.POST("/:id/restore", teamHandler.RestoreTeam)
+
+	invites := api.Group("/invites", middleware.AuthRequiredMiddleware(repo))
+	invites.GET("", teamHandler.ListMyInvites)
+	invites.POST("/:id/accept", teamHandler.AcceptInvite)
+	invites.POST("/:id/decline", teamHandler.DeclineInvite)
 }
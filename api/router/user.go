@@ -13,6 +13,8 @@ func UserRouter(api *echo.Group, repo repository.Repository) {
 		Repo: repo,
 	}
 
-	r := api.Group("/users", middleware.AuthRequiredMiddleware)
+	r := api.Group("/users", middleware.AuthRequiredMiddleware(repo))
 	r.GET("/me", userHandler.GetMe)
+	r.GET("/me/sessions", userHandler.ListSessions)
+	r.DELETE("/me/sessions/:id", userHandler.RevokeSession)
 }
@@ -7,11 +7,15 @@ import (
 
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/yorukot/knocker/api"
+	"github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/db"
 	"github.com/yorukot/knocker/schedular"
 	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/denylist"
 	"github.com/yorukot/knocker/utils/id"
 	"github.com/yorukot/knocker/utils/logger"
+	"github.com/yorukot/knocker/utils/mailer"
+	"github.com/yorukot/knocker/utils/ratelimit"
 	"github.com/yorukot/knocker/worker"
 	"go.uber.org/zap"
 )
@@ -35,7 +39,7 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-// @description Type "Bearer" followed by a space and JWT token.
+// @description Type "Bearer" followed by a space and a JWT access token or a team API key (kn_...).
 func main() {
 	// initialize logger
 	logger.InitLogger()
@@ -59,6 +63,21 @@ func main() {
 		zap.L().Fatal("Error initializing Postgres", zap.Error(err))
 	}
 	defer pgsql.Close()
+
+	rdb, err := db.InitRedis()
+	if err != nil {
+		zap.L().Fatal("Error initializing Redis", zap.Error(err))
+	}
+	defer rdb.Close()
+
+	denylist.Init(rdb)
+	ratelimit.Init(rdb)
+	statuspage.InitCache(rdb)
+
+	err = mailer.InitFromEnv()
+	if err != nil {
+		zap.L().Fatal("Error initializing mailer", zap.Error(err))
+	}
 	
 	_, err = config.InitRegionConfig(pgsql)
 	if err != nil {
@@ -0,0 +1,39 @@
+package escalation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/models"
+	"go.uber.org/zap"
+)
+
+// Queue is the asynq queue escalation step tasks are enqueued on.
+const Queue = "default"
+
+// TaskID returns the asynq task ID for a recorded escalation step of an incident.
+// It is keyed by the escalation ID so an incident can be escalated again after it stops flapping.
+func TaskID(incidentID, escalationID int64) string {
+	return fmt.Sprintf("escalation:%d:%d", incidentID, escalationID)
+}
+
+// Cancel removes the queued tasks of cancelled escalations.
+// Tasks that already ran or were never enqueued are ignored.
+func Cancel(inspector *asynq.Inspector, escalations []models.IncidentEscalation) {
+	if inspector == nil {
+		return
+	}
+
+	for _, escalation := range escalations {
+		err := inspector.DeleteTask(Queue, escalation.TaskID)
+		if err == nil || errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			continue
+		}
+
+		zap.L().Warn("failed to delete escalation task",
+			zap.Int64("incident_id", escalation.IncidentID),
+			zap.String("task_id", escalation.TaskID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,95 @@
+package escalation
+
+import (
+	"time"
+	// Schedules are computed in their own time zone without relying on the host's zoneinfo
+	_ "time/tzdata"
+
+	"github.com/yorukot/knocker/models"
+)
+
+// OnCallUserIDs resolves who is on call for a schedule at the given time.
+// Active overrides win over layers; otherwise the highest layer with someone on rotation is used.
+// Rotations are computed in the schedule's time zone, or UTC when it cannot be loaded.
+func OnCallUserIDs(schedule models.OnCallSchedule, at time.Time) []int64 {
+	var overridden []int64
+	for _, override := range schedule.Overrides {
+		if !at.Before(override.StartsAt) && at.Before(override.EndsAt) {
+			overridden = appendUnique(overridden, override.UserID)
+		}
+	}
+	if len(overridden) > 0 {
+		return overridden
+	}
+
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	var (
+		userID   int64
+		position int16
+		found    bool
+	)
+	for _, layer := range schedule.Layers {
+		current, ok := LayerUserID(layer, at, location)
+		if !ok {
+			continue
+		}
+		if !found || layer.Position > position {
+			userID, position, found = current, layer.Position, true
+		}
+	}
+
+	if !found {
+		return []int64{}
+	}
+
+	return []int64{userID}
+}
+
+// LayerUserID returns the user on rotation for a layer at the given time. Rotations of whole days
+// hand off at the wall-clock time of StartsAt in location, so they stay put across DST changes;
+// shorter rotations are a fixed duration.
+func LayerUserID(layer models.OnCallLayer, at time.Time, location *time.Location) (int64, bool) {
+	if len(layer.UserIDs) == 0 || layer.RotationSeconds <= 0 || at.Before(layer.StartsAt) {
+		return 0, false
+	}
+
+	rotation := time.Duration(layer.RotationSeconds) * time.Second
+
+	var turn int64
+	if rotation%(24*time.Hour) == 0 {
+		turn = int64(elapsedDays(layer.StartsAt.In(location), at) / int(rotation/(24*time.Hour)))
+	} else {
+		turn = int64(at.Sub(layer.StartsAt) / rotation)
+	}
+
+	return layer.UserIDs[turn%int64(len(layer.UserIDs))], true
+}
+
+// elapsedDays counts the daily handoffs at start's wall-clock time that have passed by at.
+func elapsedDays(start time.Time, at time.Time) int {
+	handoff := func(days int) time.Time {
+		return time.Date(start.Year(), start.Month(), start.Day()+days, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	days := int(at.Sub(start) / (24 * time.Hour))
+	for !handoff(days + 1).After(at) {
+		days++
+	}
+	for days > 0 && handoff(days).After(at) {
+		days--
+	}
+	return days
+}
+
+func appendUnique(ids []int64, id int64) []int64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
@@ -0,0 +1,81 @@
+package escalation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestLayerUserIDRotates(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	layer := models.OnCallLayer{
+		RotationSeconds: int((24 * time.Hour).Seconds()),
+		StartsAt:        start,
+		UserIDs:         []int64{1, 2, 3},
+	}
+
+	_, ok := LayerUserID(layer, start.Add(-time.Minute), time.UTC)
+	require.False(t, ok)
+
+	userID, ok := LayerUserID(layer, start, time.UTC)
+	require.True(t, ok)
+	require.Equal(t, int64(1), userID)
+
+	userID, _ = LayerUserID(layer, start.Add(25*time.Hour), time.UTC)
+	require.Equal(t, int64(2), userID)
+
+	userID, _ = LayerUserID(layer, start.Add(72*time.Hour), time.UTC)
+	require.Equal(t, int64(1), userID)
+}
+
+func TestOnCallUserIDsPrefersHigherLayer(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	schedule := models.OnCallSchedule{
+		Layers: []models.OnCallLayer{
+			{Position: 1, RotationSeconds: 3600, StartsAt: start, UserIDs: []int64{10}},
+			{Position: 2, RotationSeconds: 3600, StartsAt: start.Add(2 * time.Hour), UserIDs: []int64{20}},
+		},
+	}
+
+	require.Equal(t, []int64{10}, OnCallUserIDs(schedule, start.Add(time.Hour)))
+	require.Equal(t, []int64{20}, OnCallUserIDs(schedule, start.Add(3*time.Hour)))
+	require.Empty(t, OnCallUserIDs(schedule, start.Add(-time.Hour)))
+}
+
+func TestOnCallUserIDsOverrideWins(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	schedule := models.OnCallSchedule{
+		Layers: []models.OnCallLayer{
+			{Position: 1, RotationSeconds: 3600, StartsAt: start, UserIDs: []int64{10}},
+		},
+		Overrides: []models.OnCallOverride{
+			{UserID: 99, StartsAt: start.Add(time.Hour), EndsAt: start.Add(2 * time.Hour)},
+		},
+	}
+
+	require.Equal(t, []int64{99}, OnCallUserIDs(schedule, start.Add(90*time.Minute)))
+	require.Equal(t, []int64{10}, OnCallUserIDs(schedule, start.Add(2*time.Hour)))
+}
+
+func TestOnCallUserIDsFollowsScheduleTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Daily handoff at 09:00 Berlin time, across the switch to summer time on 2025-03-30
+	start := time.Date(2025, 3, 28, 9, 0, 0, 0, berlin)
+	schedule := models.OnCallSchedule{
+		Timezone: "Europe/Berlin",
+		Layers: []models.OnCallLayer{
+			{Position: 1, RotationSeconds: int((24 * time.Hour).Seconds()), StartsAt: start, UserIDs: []int64{1, 2, 3, 4}},
+		},
+	}
+
+	require.Equal(t, []int64{4}, OnCallUserIDs(schedule, time.Date(2025, 3, 31, 9, 0, 0, 0, berlin)))
+	require.Equal(t, []int64{3}, OnCallUserIDs(schedule, time.Date(2025, 3, 31, 8, 30, 0, 0, berlin)))
+
+	// Computed in UTC the handoff would drift to 10:00 local time after the switch
+	schedule.Timezone = "UTC"
+	require.Equal(t, []int64{3}, OnCallUserIDs(schedule, time.Date(2025, 3, 31, 9, 30, 0, 0, berlin)))
+}
@@ -0,0 +1,34 @@
+package monitor
+
+import "github.com/yorukot/knocker/models"
+
+// CreatesDependencyCycle reports whether giving monitorID the parents parentIDs would make
+// the monitor (transitively) depend on itself. Existing edges of monitorID are ignored
+// since they are about to be replaced.
+func CreatesDependencyCycle(edges []models.MonitorDependency, monitorID int64, parentIDs []int64) bool {
+	parents := make(map[int64][]int64, len(edges))
+	for _, edge := range edges {
+		if edge.MonitorID == monitorID {
+			continue
+		}
+		parents[edge.MonitorID] = append(parents[edge.MonitorID], edge.ParentID)
+	}
+
+	visited := make(map[int64]struct{})
+	stack := append([]int64(nil), parentIDs...)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == monitorID {
+			return true
+		}
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+		stack = append(stack, parents[current]...)
+	}
+
+	return false
+}
@@ -0,0 +1,24 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestCreatesDependencyCycle(t *testing.T) {
+	// 3 depends on 2, 2 depends on 1.
+	edges := []models.MonitorDependency{
+		{MonitorID: 3, ParentID: 2},
+		{MonitorID: 2, ParentID: 1},
+	}
+
+	require.False(t, CreatesDependencyCycle(edges, 4, []int64{3}))
+	require.True(t, CreatesDependencyCycle(edges, 1, []int64{3}))
+	require.True(t, CreatesDependencyCycle(edges, 1, []int64{1}))
+
+	// 2's existing edges are replaced, but depending on its own child is still a cycle.
+	require.False(t, CreatesDependencyCycle(edges, 2, []int64{}))
+	require.True(t, CreatesDependencyCycle(edges, 2, []int64{3}))
+}
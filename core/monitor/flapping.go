@@ -0,0 +1,50 @@
+package monitor
+
+import "github.com/yorukot/knocker/models"
+
+// FlapPercent returns the weighted percentage of state changes across pings (newest first),
+// following Nagios: recent transitions weigh 1.25 and the oldest 0.75.
+func FlapPercent(pings []models.Ping) float64 {
+	transitions := len(pings) - 1
+	if transitions < 1 {
+		return 0
+	}
+
+	var weighted float64
+	for i := 0; i < transitions; i++ {
+		// i walks transitions from oldest to newest.
+		older := pings[transitions-i]
+		newer := pings[transitions-i-1]
+		if (older.Status == models.PingStatusSuccessful) == (newer.Status == models.PingStatusSuccessful) {
+			continue
+		}
+
+		weight := 1.0
+		if transitions > 1 {
+			weight = 0.75 + 0.5*float64(i)/float64(transitions-1)
+		}
+		weighted += weight
+	}
+
+	return weighted / float64(transitions) * 100
+}
+
+// DetectFlapping evaluates the monitor's flap thresholds against pings (newest first).
+// A monitor starts flapping at FlapStartThreshold and keeps flapping until it drops
+// below FlapStopThreshold. Without a full window the previous state is kept.
+func DetectFlapping(monitor models.Monitor, pings []models.Ping, wasFlapping bool) (bool, float64) {
+	if monitor.FlapStartThreshold <= 0 || monitor.FlapWindow < 2 {
+		return false, 0
+	}
+
+	window := int(monitor.FlapWindow)
+	if len(pings) < window {
+		return wasFlapping, FlapPercent(pings)
+	}
+
+	percent := FlapPercent(pings[:window])
+	if wasFlapping {
+		return percent >= float64(monitor.FlapStopThreshold), percent
+	}
+	return percent >= float64(monitor.FlapStartThreshold), percent
+}
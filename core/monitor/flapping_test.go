@@ -0,0 +1,55 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func pingsFromPattern(pattern string) []models.Ping {
+	pings := make([]models.Ping, len(pattern))
+	for i, c := range pattern {
+		status := models.PingStatusSuccessful
+		if c == 'F' {
+			status = models.PingStatusFailed
+		}
+		pings[i] = models.Ping{Status: status}
+	}
+	return pings
+}
+
+func TestFlapPercent(t *testing.T) {
+	require.Zero(t, FlapPercent(pingsFromPattern("SSSSS")))
+	require.InDelta(t, 100, FlapPercent(pingsFromPattern("SFSFS")), 0.001)
+
+	// A change among the newest pings weighs more than one among the oldest.
+	recent := FlapPercent(pingsFromPattern("FSSSS"))
+	old := FlapPercent(pingsFromPattern("SSSSF"))
+	require.Greater(t, recent, old)
+}
+
+func TestDetectFlappingHysteresis(t *testing.T) {
+	monitor := models.Monitor{FlapWindow: 5, FlapStartThreshold: 50, FlapStopThreshold: 20}
+
+	flapping, _ := DetectFlapping(monitor, pingsFromPattern("SFSFS"), false)
+	require.True(t, flapping)
+
+	// One recent change is below the start threshold but above the stop threshold.
+	pings := pingsFromPattern("FSSSS")
+	flapping, _ = DetectFlapping(monitor, pings, false)
+	require.False(t, flapping)
+	flapping, _ = DetectFlapping(monitor, pings, true)
+	require.True(t, flapping)
+
+	flapping, _ = DetectFlapping(monitor, pingsFromPattern("SSSSS"), true)
+	require.False(t, flapping)
+
+	// Not enough history keeps the current state.
+	flapping, _ = DetectFlapping(monitor, pingsFromPattern("SF"), true)
+	require.True(t, flapping)
+
+	monitor.FlapStartThreshold = 0
+	flapping, _ = DetectFlapping(monitor, pingsFromPattern("SFSFS"), true)
+	require.False(t, flapping)
+}
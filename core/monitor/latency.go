@@ -0,0 +1,20 @@
+package monitor
+
+import "github.com/yorukot/knocker/models"
+
+// PingHealth returns the monitor status a single ping points to. Failed pings are down and
+// successful pings slower than the warn or critical latency threshold are degraded.
+func PingHealth(monitor models.Monitor, ping models.Ping) models.MonitorStatus {
+	if ping.Status != models.PingStatusSuccessful {
+		return models.MonitorStatusDown
+	}
+
+	if monitor.LatencyWarnMs != nil && ping.Latency > *monitor.LatencyWarnMs {
+		return models.MonitorStatusDegraded
+	}
+	if monitor.LatencyCriticalMs != nil && ping.Latency > *monitor.LatencyCriticalMs {
+		return models.MonitorStatusDegraded
+	}
+
+	return models.MonitorStatusUp
+}
@@ -0,0 +1,23 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestPingHealth(t *testing.T) {
+	warn, critical := 300, 1000
+	monitor := models.Monitor{LatencyWarnMs: &warn, LatencyCriticalMs: &critical}
+
+	require.Equal(t, models.MonitorStatusUp, PingHealth(monitor, models.Ping{Status: models.PingStatusSuccessful, Latency: 300}))
+	require.Equal(t, models.MonitorStatusDegraded, PingHealth(monitor, models.Ping{Status: models.PingStatusSuccessful, Latency: 301}))
+	require.Equal(t, models.MonitorStatusDegraded, PingHealth(monitor, models.Ping{Status: models.PingStatusSuccessful, Latency: 1500}))
+	require.Equal(t, models.MonitorStatusDown, PingHealth(monitor, models.Ping{Status: models.PingStatusTimeout, Latency: 10}))
+
+	// Without a warn threshold only the critical one degrades the monitor.
+	monitor.LatencyWarnMs = nil
+	require.Equal(t, models.MonitorStatusUp, PingHealth(monitor, models.Ping{Status: models.PingStatusSuccessful, Latency: 900}))
+	require.Equal(t, models.MonitorStatusDegraded, PingHealth(monitor, models.Ping{Status: models.PingStatusSuccessful, Latency: 1001}))
+}
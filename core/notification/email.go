@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/mailer"
+)
+
+func sendEmail(ctx context.Context, notification models.Notification, title, description string) error {
+	var cfg models.EmailNotificationConfig
+	if err := json.Unmarshal(notification.Config, &cfg); err != nil {
+		return fmt.Errorf("decode email config: %w", err)
+	}
+
+	if cfg.Email == "" {
+		return errors.New("email address is required")
+	}
+
+	return mailer.Send(ctx, mailer.Message{
+		To:      cfg.Email,
+		Subject: title,
+		Text:    description,
+	})
+}
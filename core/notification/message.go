@@ -17,6 +17,7 @@ type MessageInput struct {
 	LatencyMs         int
 	CheckedAt         time.Time
 	Detail            string
+	Event             models.NotificationEventKind
 }
 
 // FormatMessage generates a title and description for a notification.
@@ -27,6 +28,12 @@ func FormatMessage(input MessageInput) (string, string) {
 	}
 
 	title := fmt.Sprintf("%s is %s", input.MonitorName, strings.ToUpper(string(input.Status)))
+	switch input.Event {
+	case models.NotificationEventFlappingStarted:
+		title = fmt.Sprintf("%s is FLAPPING", input.MonitorName)
+	case models.NotificationEventFlappingEnded:
+		title = fmt.Sprintf("%s stopped flapping and is %s", input.MonitorName, strings.ToUpper(string(input.Status)))
+	}
 
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("Monitor: %s\n", input.MonitorName))
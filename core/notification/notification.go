@@ -30,7 +30,7 @@ func SendWithClient(ctx context.Context, client *http.Client, notification model
 	case models.NotificationTypeTelegram:
 		return sendTelegram(ctx, client, notification, title, description, status)
 	case models.NotificationTypeEmail:
-		return fmt.Errorf("email notification not implemented")
+		return sendEmail(ctx, notification, title, description)
 	default:
 		return fmt.Errorf("unsupported notification type %q", notification.Type)
 	}
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yorukot/knocker/models"
+)
+
+// RouteEvent carries the attributes notification routes match against.
+type RouteEvent struct {
+	Kind        models.NotificationEventKind
+	Severity    models.IncidentSeverity
+	RegionID    int64
+	MonitorType models.MonitorType
+	Tags        []string
+}
+
+// MatchRoute returns the first route (by position) whose conditions match the event, or nil.
+func MatchRoute(routes []models.NotificationRoute, event RouteEvent) *models.NotificationRoute {
+	ordered := slices.Clone(routes)
+	slices.SortStableFunc(ordered, func(a, b models.NotificationRoute) int {
+		return int(a.Position) - int(b.Position)
+	})
+
+	for i := range ordered {
+		if routeMatches(ordered[i], event) {
+			return &ordered[i]
+		}
+	}
+
+	return nil
+}
+
+func routeMatches(route models.NotificationRoute, event RouteEvent) bool {
+	if len(route.EventKinds) > 0 && !slices.Contains(route.EventKinds, event.Kind) {
+		return false
+	}
+	if len(route.Severities) > 0 && !slices.Contains(route.Severities, event.Severity) {
+		return false
+	}
+	if len(route.RegionIDs) > 0 && !slices.Contains(route.RegionIDs, event.RegionID) {
+		return false
+	}
+	if len(route.MonitorTypes) > 0 && !slices.Contains(route.MonitorTypes, event.MonitorType) {
+		return false
+	}
+	if len(route.Tags) > 0 && !slices.ContainsFunc(route.Tags, func(tag string) bool {
+		return slices.Contains(event.Tags, tag)
+	}) {
+		return false
+	}
+	return true
+}
+
+// InQuietHours reports whether at falls inside the route's quiet hours.
+// Routes without quiet hours, or with an unknown timezone, are never quiet.
+func InQuietHours(route models.NotificationRoute, at time.Time) bool {
+	if route.QuietHoursStart == nil || route.QuietHoursEnd == nil {
+		return false
+	}
+
+	start, err := ParseClock(*route.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(*route.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	loc, err := time.LoadLocation(route.Timezone)
+	if err != nil {
+		return false
+	}
+
+	local := at.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	// The window wraps past midnight, e.g. 18:00-09:00.
+	return minute >= start || minute < end
+}
+
+// ParseClock parses an "HH:MM" clock time into minutes since midnight.
+func ParseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/models"
+)
+
+func TestMatchRouteFirstMatchByPosition(t *testing.T) {
+	routes := []models.NotificationRoute{
+		{ID: 2, Position: 2, NotificationIDs: []int64{20}},
+		{ID: 1, Position: 1, Severities: []models.IncidentSeverity{models.IncidentSeverityMinor}, NotificationIDs: []int64{10}},
+	}
+
+	route := MatchRoute(routes, RouteEvent{Kind: models.NotificationEventOpened, Severity: models.IncidentSeverityMinor})
+	require.NotNil(t, route)
+	require.Equal(t, int64(1), route.ID)
+
+	route = MatchRoute(routes, RouteEvent{Kind: models.NotificationEventOpened, Severity: models.IncidentSeverityCritical})
+	require.NotNil(t, route)
+	require.Equal(t, int64(2), route.ID)
+}
+
+func TestMatchRouteConditions(t *testing.T) {
+	routes := []models.NotificationRoute{{
+		EventKinds:   []models.NotificationEventKind{models.NotificationEventOpened},
+		RegionIDs:    []int64{7},
+		MonitorTypes: []models.MonitorType{models.MonitorTypeHTTP},
+		Tags:         []string{"db", "api"},
+	}}
+
+	event := RouteEvent{Kind: models.NotificationEventOpened, RegionID: 7, MonitorType: models.MonitorTypeHTTP, Tags: []string{"api"}}
+	require.NotNil(t, MatchRoute(routes, event))
+
+	resolved := event
+	resolved.Kind = models.NotificationEventResolved
+	require.Nil(t, MatchRoute(routes, resolved))
+
+	otherRegion := event
+	otherRegion.RegionID = 8
+	require.Nil(t, MatchRoute(routes, otherRegion))
+
+	untagged := event
+	untagged.Tags = nil
+	require.Nil(t, MatchRoute(routes, untagged))
+}
+
+func TestInQuietHours(t *testing.T) {
+	start, end := "18:00", "09:00"
+	route := models.NotificationRoute{Timezone: "Asia/Taipei", QuietHoursStart: &start, QuietHoursEnd: &end}
+
+	// 02:00 UTC is 10:00 in Taipei.
+	require.False(t, InQuietHours(route, time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC)))
+	// 12:00 UTC is 20:00 in Taipei.
+	require.True(t, InQuietHours(route, time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)))
+	// 23:30 UTC is 07:30 in Taipei.
+	require.True(t, InQuietHours(route, time.Date(2025, 1, 6, 23, 30, 0, 0, time.UTC)))
+
+	require.False(t, InQuietHours(models.NotificationRoute{Timezone: "UTC"}, time.Now()))
+}
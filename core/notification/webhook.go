@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which net.IP does not treat as private
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// webhookClient only connects to public addresses. Webhook URLs are given by anonymous
+// status page visitors and must not reach the internal network.
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: dialPublicOnly,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+// SendWebhook posts payload as JSON to a subscriber's webhook URL
+func SendWebhook(ctx context.Context, webhookURL string, payload any) error {
+	return postJSON(ctx, webhookClient, webhookURL, payload)
+}
+
+// ValidateWebhookURL checks that a webhook URL is an absolute http(s) URL that does not point at a
+// non-public address. Host names are only resolved when the webhook is sent.
+func ValidateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return errors.New("webhook URL must be an absolute http or https URL")
+	}
+
+	if ip := net.ParseIP(parsed.Hostname()); ip != nil && !IsPublicIP(ip) {
+		return errors.New("webhook URL must not point at a private address")
+	}
+
+	return nil
+}
+
+// IsPublicIP reports whether ip is routable on the public internet
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsUnspecified() ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// dialPublicOnly runs after DNS resolution, so it also catches host names that resolve to internal addresses
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("refusing to connect to non-public address %s", host)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "::1", "fd00::1", "0.0.0.0"} {
+		require.False(t, IsPublicIP(net.ParseIP(addr)), addr)
+	}
+
+	for _, addr := range []string{"1.1.1.1", "8.8.8.8", "2606:4700:4700::1111"} {
+		require.True(t, IsPublicIP(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	require.NoError(t, ValidateWebhookURL("https://hooks.example.com/status"))
+	require.Error(t, ValidateWebhookURL("ftp://hooks.example.com/status"))
+	require.Error(t, ValidateWebhookURL("/relative"))
+	require.Error(t, ValidateWebhookURL("http://169.254.169.254/latest/meta-data"))
+	require.Error(t, ValidateWebhookURL("http://[::1]:8080/"))
+}
+
+func TestSendWebhook_RefusesLoopback(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	err := SendWebhook(context.Background(), server.URL, map[string]string{"hello": "world"})
+	require.Error(t, err)
+	require.False(t, called)
+}
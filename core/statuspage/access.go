@@ -0,0 +1,92 @@
+package statuspage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// accessPurpose scopes the signature of status page access cookies
+const accessPurpose = "status-page-access"
+
+// AccessTokenTTL is how long a visitor stays in after unlocking a password protected page
+const AccessTokenTTL = 24 * time.Hour
+
+// accessPurposeFor binds a token to the current password: changing it signs everyone out
+func accessPurposeFor(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return accessPurpose + ":" + hex.EncodeToString(sum[:8])
+}
+
+// AccessToken returns the value of the cookie that lets a visitor in to a password protected page
+func AccessToken(statusPageID int64, passwordHash string, expiresAt time.Time) string {
+	value := strconv.FormatInt(statusPageID, 10) + "-" + strconv.FormatInt(expiresAt.Unix(), 10)
+	return signer().SignValue(accessPurposeFor(passwordHash), value)
+}
+
+// VerifyAccessToken reports whether a token made by AccessToken still opens the page
+func VerifyAccessToken(token string, statusPageID int64, passwordHash string, now time.Time) bool {
+	value, ok := signer().VerifySignedValue(accessPurposeFor(passwordHash), token)
+	if !ok {
+		return false
+	}
+
+	pageID, expires, found := strings.Cut(value, "-")
+	if !found || pageID != strconv.FormatInt(statusPageID, 10) {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return now.Unix() < expiresAt
+}
+
+// NormalizeIPRanges parses CIDR ranges or single addresses into canonical CIDR ranges
+func NormalizeIPRanges(ranges []string) ([]string, error) {
+	normalized := make([]string, 0, len(ranges))
+	for _, raw := range ranges {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(raw)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid IP range %q", raw)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+
+		normalized = append(normalized, prefix.Masked().String())
+	}
+	return normalized, nil
+}
+
+// IPAllowed reports whether the address is inside one of the CIDR ranges
+func IPAllowed(ip string, ranges []string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, raw := range ranges {
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			continue
+		}
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package statuspage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+)
+
+func TestAccessToken(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	now := time.Now()
+	token := AccessToken(5, "hash-a", now.Add(time.Hour))
+
+	require.True(t, VerifyAccessToken(token, 5, "hash-a", now))
+	require.False(t, VerifyAccessToken(token, 6, "hash-a", now), "token of another page")
+	require.False(t, VerifyAccessToken(token, 5, "hash-b", now), "password changed")
+	require.False(t, VerifyAccessToken(token, 5, "hash-a", now.Add(2*time.Hour)), "expired")
+	require.False(t, VerifyAccessToken(token+"x", 5, "hash-a", now), "tampered")
+}
+
+func TestNormalizeIPRanges(t *testing.T) {
+	ranges, err := NormalizeIPRanges([]string{" 10.1.2.3/8 ", "192.168.1.10", "", "2001:db8::1/32"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8", "192.168.1.10/32", "2001:db8::/32"}, ranges)
+
+	_, err = NormalizeIPRanges([]string{"not-an-ip"})
+	require.Error(t, err)
+}
+
+func TestIPAllowed(t *testing.T) {
+	ranges := []string{"10.0.0.0/8", "2001:db8::/32"}
+
+	require.True(t, IPAllowed("10.20.30.40", ranges))
+	require.True(t, IPAllowed("::ffff:10.0.0.1", ranges))
+	require.True(t, IPAllowed("2001:db8::5", ranges))
+	require.False(t, IPAllowed("192.168.0.1", ranges))
+	require.False(t, IPAllowed("", ranges))
+	require.False(t, IPAllowed("10.0.0.1", nil))
+}
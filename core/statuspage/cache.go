@@ -0,0 +1,162 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yorukot/knocker/models"
+	"go.uber.org/zap"
+)
+
+// Public status pages are cached in Redis. Every page key carries the generation of its team, so
+// bumping the generation when a monitor, incident or page of the team changes drops all of them
+// at once without looking up which pages show what. The cache is best effort: failures are
+// logged and read as misses, and nothing is cached until InitCache is called.
+
+const (
+	cacheKeyPrefix = "status-page:"
+
+	// PageCacheTTL bounds how stale a page can get when an invalidation is missed
+	PageCacheTTL = 30 * time.Second
+	// TimelineCacheTTL outlives the precompute interval, so visitors rarely run the 90-day aggregate
+	TimelineCacheTTL = 15 * time.Minute
+	// TimelinePrecomputeInterval is how often the scheduler refreshes the daily timelines
+	TimelinePrecomputeInterval = 5 * time.Minute
+)
+
+var cacheClient *redis.Client
+
+// InitCache sets the Redis client used to cache public status pages.
+func InitCache(redisClient *redis.Client) {
+	cacheClient = redisClient
+}
+
+func generationKey(teamID int64) string {
+	return cacheKeyPrefix + "generation:" + strconv.FormatInt(teamID, 10)
+}
+
+func pageKey(pageID, generation int64) string {
+	return cacheKeyPrefix + "page:" + strconv.FormatInt(pageID, 10) + ":" + strconv.FormatInt(generation, 10)
+}
+
+func timelineKey(pageID int64, start time.Time) string {
+	return cacheKeyPrefix + "timeline:" + strconv.FormatInt(pageID, 10) + ":" + start.UTC().Format("2006-01-02")
+}
+
+// CachedPage returns the cached body of a page and the team generation it was looked up under.
+// The generation must be handed back to CachePage, so a body computed while the team changed
+// is stored under a generation nobody reads anymore.
+func CachedPage(ctx context.Context, teamID, pageID int64) ([]byte, int64, bool) {
+	if cacheClient == nil {
+		return nil, 0, false
+	}
+
+	generation, err := cacheClient.Get(ctx, generationKey(teamID)).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		zap.L().Warn("Failed to read status page cache generation", zap.Int64("team_id", teamID), zap.Error(err))
+		return nil, 0, false
+	}
+
+	body, err := cacheClient.Get(ctx, pageKey(pageID, generation)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Warn("Failed to read cached status page", zap.Int64("status_page_id", pageID), zap.Error(err))
+		}
+		return nil, generation, false
+	}
+
+	return body, generation, true
+}
+
+// CachePage stores the computed body of a page under the generation returned by CachedPage.
+func CachePage(ctx context.Context, pageID, generation int64, body []byte) {
+	if cacheClient == nil {
+		return
+	}
+
+	if err := cacheClient.Set(ctx, pageKey(pageID, generation), body, PageCacheTTL).Err(); err != nil {
+		zap.L().Warn("Failed to cache status page", zap.Int64("status_page_id", pageID), zap.Error(err))
+	}
+}
+
+// InvalidateTeamPages drops the cached pages of a team. Call it after the change is committed.
+func InvalidateTeamPages(ctx context.Context, teamID int64) {
+	if cacheClient == nil {
+		return
+	}
+
+	pipe := cacheClient.TxPipeline()
+	pipe.Incr(ctx, generationKey(teamID))
+	// The generation only has to outlive the pages cached under it
+	pipe.Expire(ctx, generationKey(teamID), 24*time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		zap.L().Warn("Failed to invalidate status page cache", zap.Int64("team_id", teamID), zap.Error(err))
+	}
+}
+
+// TimelineWindow returns the 90 UTC days shown on status pages, ending with the current day.
+func TimelineWindow(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(24 * time.Hour)
+	start := end.AddDate(0, 0, -90)
+	return start, end
+}
+
+// cachedTimeline is the daily summaries of a page, with the monitors they were computed for
+type cachedTimeline struct {
+	MonitorIDs []int64                      `json:"monitor_ids"`
+	Summaries  []models.MonitorDailySummary `json:"summaries"`
+}
+
+// CachedTimeline returns the precomputed daily summaries of a page for the window starting at start.
+// It misses when the page shows other monitors than the summaries were computed for.
+func CachedTimeline(ctx context.Context, pageID int64, monitorIDs []int64, start time.Time) ([]models.MonitorDailySummary, bool) {
+	if cacheClient == nil {
+		return nil, false
+	}
+
+	body, err := cacheClient.Get(ctx, timelineKey(pageID, start)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Warn("Failed to read cached status page timeline", zap.Int64("status_page_id", pageID), zap.Error(err))
+		}
+		return nil, false
+	}
+
+	var cached cachedTimeline
+	if err := json.Unmarshal(body, &cached); err != nil {
+		return nil, false
+	}
+	if !slices.Equal(cached.MonitorIDs, sortedIDs(monitorIDs)) {
+		return nil, false
+	}
+
+	return cached.Summaries, true
+}
+
+// CacheTimeline stores the daily summaries of a page for the window starting at start.
+func CacheTimeline(ctx context.Context, pageID int64, monitorIDs []int64, start time.Time, summaries []models.MonitorDailySummary) {
+	if cacheClient == nil {
+		return
+	}
+
+	body, err := json.Marshal(cachedTimeline{MonitorIDs: sortedIDs(monitorIDs), Summaries: summaries})
+	if err != nil {
+		return
+	}
+
+	if err := cacheClient.Set(ctx, timelineKey(pageID, start), body, TimelineCacheTTL).Err(); err != nil {
+		zap.L().Warn("Failed to cache status page timeline", zap.Int64("status_page_id", pageID), zap.Error(err))
+	}
+}
+
+func sortedIDs(ids []int64) []int64 {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
@@ -0,0 +1,36 @@
+package statuspage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineWindow(t *testing.T) {
+	start, end := TimelineWindow(time.Date(2026, 3, 10, 23, 30, 0, 0, time.FixedZone("UTC+8", 8*3600)))
+
+	require.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), end)
+	require.Equal(t, time.Date(2025, 12, 11, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestSortedIDs(t *testing.T) {
+	ids := []int64{43, 42, 43, 7}
+
+	require.Equal(t, []int64{7, 42, 43}, sortedIDs(ids))
+	require.Equal(t, []int64{43, 42, 43, 7}, ids, "input is left untouched")
+}
+
+func TestCacheDisabledWithoutClient(t *testing.T) {
+	ctx := context.Background()
+
+	CachePage(ctx, 5, 0, []byte("{}"))
+	InvalidateTeamPages(ctx, 9)
+	_, _, ok := CachedPage(ctx, 9, 5)
+	require.False(t, ok)
+
+	CacheTimeline(ctx, 5, []int64{42}, time.Now(), nil)
+	_, ok = CachedTimeline(ctx, 5, []int64{42}, time.Now())
+	require.False(t, ok)
+}
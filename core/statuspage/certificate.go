@@ -0,0 +1,45 @@
+package statuspage
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// CertificateManager provides TLS certificates for status page custom domains.
+// ACME is built in; another issuer only has to implement this interface.
+type CertificateManager interface {
+	// TLSConfig returns the configuration of the HTTPS listener
+	TLSConfig() *tls.Config
+	// HTTPHandler wraps the plain HTTP handler so the issuer can answer its own challenges
+	HTTPHandler(fallback http.Handler) http.Handler
+}
+
+// HostPolicy decides whether a certificate may be issued for a host. It returns an error to refuse.
+type HostPolicy func(ctx context.Context, host string) error
+
+// ACMEOptions configures the built-in ACME certificate manager
+type ACMEOptions struct {
+	Email        string
+	DirectoryURL string // Let's Encrypt when empty
+	CacheDir     string
+	HostPolicy   HostPolicy
+}
+
+// NewACMECertificateManager returns a certificate manager that issues certificates on the first
+// TLS handshake of each allowed host and keeps them in CacheDir.
+func NewACMECertificateManager(opts ACMEOptions) CertificateManager {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Email:      opts.Email,
+		Cache:      autocert.DirCache(opts.CacheDir),
+		HostPolicy: autocert.HostPolicy(opts.HostPolicy),
+	}
+	if opts.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: opts.DirectoryURL}
+	}
+	return manager
+}
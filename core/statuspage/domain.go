@@ -0,0 +1,87 @@
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/yorukot/knocker/utils/config"
+)
+
+// verificationRecordPrefix is the label the ownership TXT record lives under
+const verificationRecordPrefix = "_knocker-challenge."
+
+// verificationValuePrefix starts the value of the ownership TXT record
+const verificationValuePrefix = "knocker-verification="
+
+var domainLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ErrInvalidDomain is returned for something that is not a hostname a status page can be served on
+var ErrInvalidDomain = errors.New("invalid custom domain")
+
+// TXTResolver looks up DNS TXT records. *net.Resolver satisfies it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NormalizeDomain lowercases a hostname and checks it can be used as a custom domain.
+// IP addresses, single labels and the hosts Knocker itself runs on are refused.
+func NormalizeDomain(raw string) (string, error) {
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+	if domain == "" || len(domain) > 253 || net.ParseIP(domain) != nil {
+		return "", ErrInvalidDomain
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", ErrInvalidDomain
+	}
+	for _, label := range labels {
+		if !domainLabelPattern.MatchString(label) {
+			return "", ErrInvalidDomain
+		}
+	}
+
+	for _, reserved := range []string{config.Env().StatusPageDomainTarget, config.Env().FrontendDomain} {
+		reserved = strings.ToLower(strings.TrimSpace(reserved))
+		if reserved != "" && (domain == reserved || strings.HasSuffix(domain, "."+reserved)) {
+			return "", ErrInvalidDomain
+		}
+	}
+
+	return domain, nil
+}
+
+// VerificationRecordName returns the name of the TXT record that proves ownership of the domain
+func VerificationRecordName(domain string) string {
+	return verificationRecordPrefix + domain
+}
+
+// VerificationRecordValue returns the value the ownership TXT record must hold
+func VerificationRecordValue(token string) string {
+	return verificationValuePrefix + token
+}
+
+// VerifyDomainOwnership reports whether the domain publishes the TXT record for the token.
+// A record that does not exist yet is not an error, the domain is just not verified.
+func VerifyDomainOwnership(ctx context.Context, resolver TXTResolver, domain, token string) (bool, error) {
+	records, err := resolver.LookupTXT(ctx, VerificationRecordName(domain))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	want := VerificationRecordValue(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == want {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
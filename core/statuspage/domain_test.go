@@ -0,0 +1,51 @@
+package statuspage
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+)
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := f[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	domain, err := NormalizeDomain("  Status.Example.COM. ")
+	require.NoError(t, err)
+	require.Equal(t, "status.example.com", domain)
+
+	for _, invalid := range []string{"", "localhost", "127.0.0.1", "status.example.com:443", "-bad.example.com", "https://status.example.com", "status.localhost"} {
+		_, err := NormalizeDomain(invalid)
+		require.ErrorIs(t, err, ErrInvalidDomain, invalid)
+	}
+}
+
+func TestVerifyDomainOwnership(t *testing.T) {
+	resolver := fakeResolver{
+		"_knocker-challenge.status.example.com": {"other", "knocker-verification=tok3n"},
+	}
+
+	verified, err := VerifyDomainOwnership(context.Background(), resolver, "status.example.com", "tok3n")
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	verified, err = VerifyDomainOwnership(context.Background(), resolver, "status.example.com", "wrong")
+	require.NoError(t, err)
+	require.False(t, verified)
+
+	verified, err = VerifyDomainOwnership(context.Background(), resolver, "missing.example.com", "tok3n")
+	require.NoError(t, err)
+	require.False(t, verified)
+}
@@ -0,0 +1,53 @@
+package statuspage
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yorukot/knocker/utils/config"
+	"github.com/yorukot/knocker/utils/encrypt"
+)
+
+// unsubscribePurpose scopes the signature of unsubscribe links to that one use
+const unsubscribePurpose = "status-page-unsubscribe"
+
+func signer() *encrypt.JWTSecret {
+	return &encrypt.JWTSecret{Secret: config.Env().JWTSecretKey}
+}
+
+// UnsubscribeToken returns the token of a subscriber's unsubscribe link. It is signed rather than
+// stored, so every update can carry it.
+func UnsubscribeToken(subscriberID int64) string {
+	return signer().SignValue(unsubscribePurpose, strconv.FormatInt(subscriberID, 10))
+}
+
+// ParseUnsubscribeToken returns the subscriber ID of a token made by UnsubscribeToken
+func ParseUnsubscribeToken(token string) (int64, bool) {
+	value, ok := signer().VerifySignedValue(unsubscribePurpose, token)
+	if !ok {
+		return 0, false
+	}
+
+	subscriberID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return subscriberID, true
+}
+
+// PageURL returns the public address of a status page on the frontend
+func PageURL(slug string) string {
+	return strings.TrimRight(config.Env().FrontendURL, "/") + "/s/" + url.PathEscape(slug)
+}
+
+// ConfirmURL returns the link that confirms an email subscription
+func ConfirmURL(slug, token string) string {
+	return PageURL(slug) + "/subscription/confirm?token=" + url.QueryEscape(token)
+}
+
+// UnsubscribeURL returns the link that ends a subscription
+func UnsubscribeURL(slug string, subscriberID int64) string {
+	return PageURL(slug) + "/subscription/unsubscribe?token=" + url.QueryEscape(UnsubscribeToken(subscriberID))
+}
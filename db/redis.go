@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yorukot/knocker/utils/config"
+	"go.uber.org/zap"
+)
+
+// InitRedis initialize the Redis/Dragonfly client shared by caches and denylists
+func InitRedis() (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", config.Env().RedisHost, config.Env().RedisPort),
+		Password: config.Env().RedisPassword,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	zap.L().Info("Redis initialized")
+
+	return client, nil
+}
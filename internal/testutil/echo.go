@@ -7,6 +7,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/models"
 )
 
 // NewEchoContext creates a fresh Echo context and recorder.
@@ -22,6 +23,11 @@ func Authenticate(c echo.Context, userID int64) {
 	c.Set(string(middleware.UserIDKey), strconv.FormatInt(userID, 10))
 }
 
+// SetTeamMember sets the caller's team membership on the context to mimic middleware.
+func SetTeamMember(c echo.Context, member *models.TeamMember) {
+	c.Set(string(middleware.TeamMemberKey), member)
+}
+
 // SetJSONHeader ensures the request content-type is JSON.
 func SetJSONHeader(c echo.Context) {
 	c.Request().Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
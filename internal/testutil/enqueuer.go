@@ -0,0 +1,28 @@
+package testutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+// RecordingEnqueuer captures queued tasks instead of sending them to Redis.
+type RecordingEnqueuer struct {
+	Tasks []*asynq.Task
+}
+
+func (r *RecordingEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.Tasks = append(r.Tasks, task)
+	return &asynq.TaskInfo{}, nil
+}
+
+// OnlyTask returns the single task queued and decodes its payload into out.
+func (r *RecordingEnqueuer) OnlyTask(t *testing.T, out any) *asynq.Task {
+	t.Helper()
+
+	require.Len(t, r.Tasks, 1)
+	require.NoError(t, json.Unmarshal(r.Tasks[0].Payload(), out))
+	return r.Tasks[0]
+}
@@ -0,0 +1,15 @@
+package testutil
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/yorukot/knocker/repository"
+)
+
+// NewMockRepo creates a mock repository whose transactions always start and commit.
+func NewMockRepo() *repository.MockRepository {
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	return mockRepo
+}
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Each refresh token records the jti of the access token issued alongside it,
+-- so signing a session out can also denylist its still-valid access token.
+ALTER TABLE "public"."refresh_tokens" ADD COLUMN "access_token_id" text;
+
+CREATE INDEX "idx_refresh_tokens_access_token_id" ON "public"."refresh_tokens" ("access_token_id");
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Login providers are configured at runtime (any OIDC issuer, GitHub, GitLab),
+-- so the provider column holds the configured provider key instead of an enum.
+ALTER TABLE "public"."accounts" ALTER COLUMN "provider" TYPE text USING "provider"::text;
+ALTER TABLE "public"."oauth_tokens" ALTER COLUMN "provider" TYPE text USING "provider"::text;
+
+DROP TYPE "public"."auth_provider";
+
+COMMIT;
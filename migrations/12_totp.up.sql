@@ -0,0 +1,34 @@
+BEGIN;
+
+-- A TOTP secret is pending until the user confirms a code (enabled_at set).
+-- last_used_step blocks replaying a code; failed_attempts throttles guessing at login.
+CREATE TABLE "public"."user_totp" (
+    "user_id" bigint NOT NULL,
+    "secret" text NOT NULL,
+    "enabled_at" timestamp,
+    "last_used_step" bigint,
+    "failed_attempts" integer NOT NULL DEFAULT 0,
+    "last_failed_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT "pk_user_totp_user_id" PRIMARY KEY ("user_id")
+);
+
+-- Foreign key constraints
+ALTER TABLE "public"."user_totp" ADD CONSTRAINT "fk_user_totp_user_id_users_id" FOREIGN KEY("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE;
+
+CREATE TABLE "public"."recovery_codes" (
+    "id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "code_hash" text NOT NULL,
+    "used_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_recovery_codes_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE INDEX "idx_recovery_codes_user_id" ON "public"."recovery_codes" ("user_id");
+
+-- Foreign key constraints
+ALTER TABLE "public"."recovery_codes" ADD CONSTRAINT "fk_recovery_codes_user_id_users_id" FOREIGN KEY("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE;
+
+COMMIT;
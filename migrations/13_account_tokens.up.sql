@@ -0,0 +1,30 @@
+BEGIN;
+
+-- Email ownership. OAuth providers only hand out verified addresses, so existing
+-- OAuth accounts count as verified; email/password accounts must confirm theirs.
+ALTER TABLE "public"."accounts" ADD COLUMN "email_verified_at" timestamp;
+UPDATE "public"."accounts" SET "email_verified_at" = "created_at" WHERE "provider" <> 'email';
+
+CREATE TYPE "public"."account_token_purpose" AS ENUM ('password_reset', 'email_verification');
+
+-- Single-use tokens mailed to an account. Only the SHA-256 hash is stored.
+CREATE TABLE "public"."account_tokens" (
+    "id" bigint NOT NULL,
+    "account_id" bigint NOT NULL,
+    "purpose" account_token_purpose NOT NULL,
+    "token_hash" text NOT NULL,
+    "expires_at" timestamp NOT NULL,
+    "used_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_account_tokens_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_account_tokens_token_hash" ON "public"."account_tokens" ("token_hash");
+CREATE INDEX "idx_account_tokens_account_id_purpose" ON "public"."account_tokens" ("account_id", "purpose");
+
+-- Foreign key constraints
+ALTER TABLE "public"."account_tokens" ADD CONSTRAINT "fk_account_tokens_account_id_accounts_id" FOREIGN KEY("account_id") REFERENCES "public"."accounts"("id") ON DELETE CASCADE;
+
+ALTER TYPE "public"."security_event_type" ADD VALUE 'password_reset';
+
+COMMIT;
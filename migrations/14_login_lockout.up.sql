@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Recorded when repeated failed logins lock an account
+ALTER TYPE "public"."security_event_type" ADD VALUE 'login_locked';
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+-- Append-only record of configuration changes in a team. There are no foreign keys
+-- on purpose: events must outlive the team, actor and resource they describe.
+CREATE TABLE "public"."audit_events" (
+    "id" bigint NOT NULL,
+    "team_id" bigint NOT NULL,
+    "actor_user_id" bigint,
+    "actor_api_key_id" bigint,
+    "action" text NOT NULL,
+    "resource_type" text NOT NULL,
+    "resource_id" bigint,
+    "before" jsonb,
+    "after" jsonb,
+    "ip" inet,
+    "user_agent" text,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_audit_events_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE INDEX "idx_audit_events_team_id_id" ON "public"."audit_events" ("team_id", "id" DESC);
+CREATE INDEX "idx_audit_events_team_id_resource" ON "public"."audit_events" ("team_id", "resource_type", "resource_id");
+
+-- Reject any change to recorded events
+CREATE FUNCTION "public"."audit_events_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "trg_audit_events_append_only"
+    BEFORE UPDATE OR DELETE ON "public"."audit_events"
+    FOR EACH ROW EXECUTE FUNCTION "public"."audit_events_append_only"();
+
+CREATE TRIGGER "trg_audit_events_no_truncate"
+    BEFORE TRUNCATE ON "public"."audit_events"
+    FOR EACH STATEMENT EXECUTE FUNCTION "public"."audit_events_append_only"();
+
+COMMIT;
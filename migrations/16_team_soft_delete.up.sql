@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Deleted teams are kept until the grace period ends so they can be restored before being purged
+ALTER TABLE "public"."teams" ADD COLUMN "deleted_at" timestamp;
+-- Indexes
+CREATE INDEX "idx_teams_deleted_at" ON "public"."teams" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+COMMIT;
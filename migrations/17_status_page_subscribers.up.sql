@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TYPE "public"."status_page_subscriber_type" AS ENUM ('email', 'webhook');
+
+-- Visitors following a status page. Email subscribers only get updates once they confirm
+-- the address; only the SHA-256 hash of the confirmation token is stored.
+CREATE TABLE "public"."status_page_subscribers" (
+    "id" bigint NOT NULL,
+    "status_page_id" bigint NOT NULL,
+    "type" status_page_subscriber_type NOT NULL,
+    "target" text NOT NULL,
+    "monitor_ids" bigint[] NOT NULL DEFAULT '{}',
+    "confirmation_token_hash" text,
+    "confirmation_expires_at" timestamp,
+    "confirmed_at" timestamp,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_status_page_subscribers_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_status_page_subscribers_status_page_id_type_target" ON "public"."status_page_subscribers" ("status_page_id", "type", "target");
+CREATE UNIQUE INDEX "uq_status_page_subscribers_confirmation_token_hash" ON "public"."status_page_subscribers" ("confirmation_token_hash");
+
+-- Foreign key constraints
+ALTER TABLE "public"."status_page_subscribers" ADD CONSTRAINT "fk_status_page_subscribers_status_page_id_status_pages_id" FOREIGN KEY("status_page_id") REFERENCES "public"."status_pages"("id") ON DELETE CASCADE;
+
+COMMIT;
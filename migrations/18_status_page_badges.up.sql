@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Badges are opt-in per status page monitor; the key is the public part of the badge URL
+ALTER TABLE "public"."status_page_monitors" ADD COLUMN "badge_key" text;
+-- Indexes
+CREATE UNIQUE INDEX "uq_status_page_monitors_badge_key" ON "public"."status_page_monitors" ("badge_key") WHERE "badge_key" IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- A status page can be served on a customer's own domain once a DNS TXT record proves they own it.
+-- Several pages may claim a domain while unverified; only one can verify it.
+ALTER TABLE "public"."status_pages" ADD COLUMN "custom_domain" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "domain_verification_token" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "domain_verified_at" timestamp;
+-- Indexes
+CREATE UNIQUE INDEX "uq_status_pages_custom_domain_verified" ON "public"."status_pages" ("custom_domain") WHERE "domain_verified_at" IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TYPE "public"."status_page_visibility" AS ENUM ('public', 'password', 'ip_allowlist', 'team');
+
+-- Who may see a status page. Password pages store an Argon2id hash; IP allowlists hold CIDR ranges.
+ALTER TABLE "public"."status_pages" ADD COLUMN "visibility" status_page_visibility NOT NULL DEFAULT 'public';
+ALTER TABLE "public"."status_pages" ADD COLUMN "password_hash" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "allowed_ip_ranges" text[] NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TYPE "public"."status_page_component_status" AS ENUM ('operational', 'degraded_performance', 'partial_outage', 'major_outage', 'under_maintenance');
+
+-- Manual status of a status page element, shown instead of the computed one until it expires
+ALTER TABLE "public"."status_page_groups" ADD COLUMN "status_override" status_page_component_status;
+ALTER TABLE "public"."status_page_groups" ADD COLUMN "status_override_expires_at" timestamp;
+ALTER TABLE "public"."status_page_monitors" ADD COLUMN "status_override" status_page_component_status;
+ALTER TABLE "public"."status_page_monitors" ADD COLUMN "status_override_expires_at" timestamp;
+
+-- Scheduled maintenance announced on a status page. Empty monitor_ids means the whole page.
+CREATE TABLE "public"."status_page_maintenances" (
+    "id" bigint NOT NULL,
+    "status_page_id" bigint NOT NULL,
+    "title" character varying(255) NOT NULL,
+    "message" text NOT NULL DEFAULT '',
+    "monitor_ids" bigint[] NOT NULL DEFAULT '{}',
+    "starts_at" timestamp NOT NULL,
+    "ends_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_status_page_maintenances_id" PRIMARY KEY ("id"),
+    CONSTRAINT "chk_status_page_maintenances_window" CHECK ("ends_at" > "starts_at")
+);
+-- Indexes
+CREATE INDEX "idx_status_page_maintenances_status_page_id_ends_at" ON "public"."status_page_maintenances" ("status_page_id", "ends_at");
+
+-- Foreign key constraints
+ALTER TABLE "public"."status_page_maintenances" ADD CONSTRAINT "fk_status_page_maintenances_status_page_id_status_pages_id" FOREIGN KEY("status_page_id") REFERENCES "public"."status_pages"("id") ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,47 @@
+BEGIN;
+
+ALTER TYPE "monitor_status" ADD VALUE IF NOT EXISTS 'degraded';
+
+-- Successful pings slower than latency_warn_ms or latency_critical_ms degrade the monitor.
+-- Both are NULL (disabled) until set, so existing monitors don't start degrading; pings over latency_critical_ms also stop counting as good.
+ALTER TABLE "public"."monitors" ADD COLUMN "latency_warn_ms" integer;
+ALTER TABLE "public"."monitors" ADD COLUMN "latency_critical_ms" integer;
+ALTER TABLE "public"."monitors" ADD CONSTRAINT "chk_monitors_latency_thresholds" CHECK (
+    ("latency_critical_ms" IS NULL OR "latency_critical_ms" > 0)
+    AND ("latency_warn_ms" IS NULL OR "latency_warn_ms" > 0)
+    AND ("latency_warn_ms" IS NULL OR "latency_critical_ms" IS NULL OR "latency_warn_ms" < "latency_critical_ms")
+);
+
+-- The critical threshold of the monitor when the ping was recorded; NULL when it had none.
+ALTER TABLE "public"."pings" ADD COLUMN "latency_critical_ms" integer;
+
+-- monitor_30min_summary and its history stay as they are. This aggregate counts the pings that were
+-- slower than their threshold, and uptime queries take good_count from it wherever it has the bucket,
+-- falling back to the fixed 5000 ms good_count for buckets older than the ping retention.
+CREATE MATERIALIZED VIEW monitor_30min_latency_summary
+WITH (timescaledb.continuous) AS
+SELECT
+    monitor_id,
+    region_id,
+    time_bucket('30 minutes', time) AS bucket,
+    count(*) FILTER (WHERE status = 'successful') AS successful_count,
+    count(*) FILTER (
+        WHERE status = 'successful' AND latency_critical_ms IS NOT NULL AND latency > latency_critical_ms
+    ) AS slow_count
+FROM pings
+GROUP BY monitor_id, region_id, bucket
+WITH NO DATA;
+
+-- The refresh window spans the whole ping retention so the first run covers every ping still stored;
+-- later runs only recompute invalidated buckets.
+SELECT add_continuous_aggregate_policy(
+    'monitor_30min_latency_summary',
+    start_offset => INTERVAL '90 days',
+    end_offset   => INTERVAL '30 minutes',
+    schedule_interval => INTERVAL '15 minutes'
+);
+
+ALTER MATERIALIZED VIEW monitor_30min_latency_summary
+SET (timescaledb.materialized_only = false);
+
+COMMIT;
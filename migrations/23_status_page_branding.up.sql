@@ -0,0 +1,47 @@
+BEGIN;
+
+CREATE TYPE "public"."status_page_image_kind" AS ENUM ('logo', 'favicon');
+
+-- Uploaded logo and favicon of a status page, kept apart so reading a page does not load them
+CREATE TABLE "public"."status_page_images" (
+    "status_page_id" bigint NOT NULL,
+    "kind" status_page_image_kind NOT NULL,
+    "content_type" text NOT NULL,
+    "data" bytea NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT "pk_status_page_images" PRIMARY KEY ("status_page_id", "kind")
+);
+
+-- The old icon becomes the logo when its bytes are an image format uploads accept; anything else is dropped.
+INSERT INTO "public"."status_page_images" ("status_page_id", "kind", "content_type", "data", "updated_at")
+SELECT "id", 'logo', "content_type", "icon", "updated_at"
+FROM (
+    SELECT "id", "icon", "updated_at",
+        CASE
+            WHEN substring("icon" FROM 1 FOR 8) = '\x89504e470d0a1a0a'::bytea THEN 'image/png'
+            WHEN substring("icon" FROM 1 FOR 3) = '\xffd8ff'::bytea THEN 'image/jpeg'
+            WHEN substring("icon" FROM 1 FOR 4) = '\x47494638'::bytea THEN 'image/gif'
+            WHEN substring("icon" FROM 1 FOR 4) = '\x52494646'::bytea AND substring("icon" FROM 9 FOR 4) = '\x57454250'::bytea THEN 'image/webp'
+        END AS "content_type"
+    FROM "public"."status_pages"
+    WHERE "icon" IS NOT NULL
+) AS "icons"
+WHERE "content_type" IS NOT NULL;
+
+ALTER TABLE "public"."status_pages" DROP COLUMN "icon";
+
+-- Branding; colors are CSS hex colors and header_links is a JSON array of {label, url}
+ALTER TABLE "public"."status_pages" ADD COLUMN "brand_color" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "background_color" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "text_color" text;
+ALTER TABLE "public"."status_pages" ADD COLUMN "header_links" jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE "public"."status_pages" ADD COLUMN "footer_markdown" text NOT NULL DEFAULT '';
+ALTER TABLE "public"."status_pages" ADD COLUMN "about_markdown" text NOT NULL DEFAULT '';
+-- IANA time zone dates are shown in, and the language of the page's own strings
+ALTER TABLE "public"."status_pages" ADD COLUMN "timezone" text NOT NULL DEFAULT 'UTC';
+ALTER TABLE "public"."status_pages" ADD COLUMN "language" text NOT NULL DEFAULT 'en';
+
+-- Foreign key constraints
+ALTER TABLE "public"."status_page_images" ADD CONSTRAINT "fk_status_page_images_status_page_id_status_pages_id" FOREIGN KEY("status_page_id") REFERENCES "public"."status_pages"("id") ON DELETE CASCADE;
+
+COMMIT;
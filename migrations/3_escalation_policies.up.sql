@@ -0,0 +1,111 @@
+BEGIN;
+
+CREATE TYPE "escalation_target_type" AS ENUM ('notification', 'user', 'schedule');
+CREATE TYPE "escalation_status" AS ENUM ('pending', 'notified', 'cancelled');
+
+CREATE TABLE "public"."escalation_policies" (
+    "id" bigint NOT NULL,
+    "team_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_escalation_policies_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE INDEX "idx_escalation_policies_team_id" ON "public"."escalation_policies" ("team_id");
+
+CREATE TABLE "public"."escalation_policy_steps" (
+    "id" bigint NOT NULL,
+    "policy_id" bigint NOT NULL,
+    "position" smallint NOT NULL,
+    "delay_seconds" integer NOT NULL DEFAULT 0 CHECK ("delay_seconds" >= 0),
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_escalation_policy_steps_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_escalation_policy_steps_policy_id_position" ON "public"."escalation_policy_steps" ("policy_id", "position");
+
+CREATE TABLE "public"."escalation_step_targets" (
+    "id" bigint NOT NULL,
+    "step_id" bigint NOT NULL,
+    "target_type" escalation_target_type NOT NULL,
+    "target_id" bigint NOT NULL,
+    CONSTRAINT "pk_escalation_step_targets_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_escalation_step_targets_step_id_target" ON "public"."escalation_step_targets" ("step_id", "target_type", "target_id");
+
+CREATE TABLE "public"."on_call_schedules" (
+    "id" bigint NOT NULL,
+    "team_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "timezone" text NOT NULL DEFAULT 'UTC',
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_on_call_schedules_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE INDEX "idx_on_call_schedules_team_id" ON "public"."on_call_schedules" ("team_id");
+
+CREATE TABLE "public"."on_call_schedule_layers" (
+    "id" bigint NOT NULL,
+    "schedule_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "position" smallint NOT NULL,
+    "rotation_seconds" integer NOT NULL CHECK ("rotation_seconds" > 0),
+    "starts_at" timestamp NOT NULL,
+    "user_ids" bigint[] NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_on_call_schedule_layers_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_on_call_schedule_layers_schedule_id_position" ON "public"."on_call_schedule_layers" ("schedule_id", "position");
+
+CREATE TABLE "public"."on_call_schedule_overrides" (
+    "id" bigint NOT NULL,
+    "schedule_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "starts_at" timestamp NOT NULL,
+    "ends_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_on_call_schedule_overrides_id" PRIMARY KEY ("id"),
+    CONSTRAINT "chk_on_call_schedule_overrides_range" CHECK ("ends_at" > "starts_at")
+);
+-- Indexes
+CREATE INDEX "idx_on_call_schedule_overrides_schedule_id_ends_at" ON "public"."on_call_schedule_overrides" ("schedule_id", "ends_at");
+
+CREATE TABLE "public"."incident_escalations" (
+    "id" bigint NOT NULL,
+    "incident_id" bigint NOT NULL,
+    "policy_id" bigint,
+    "position" smallint NOT NULL,
+    "task_id" text NOT NULL,
+    "status" escalation_status NOT NULL DEFAULT 'pending',
+    "scheduled_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_incident_escalations_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_incident_escalations_task_id" ON "public"."incident_escalations" ("task_id");
+CREATE INDEX "idx_incident_escalations_incident_id_status" ON "public"."incident_escalations" ("incident_id", "status");
+
+ALTER TABLE "public"."monitors" ADD COLUMN "escalation_policy_id" bigint;
+
+-- Foreign key constraints
+ALTER TABLE "public"."escalation_policies" ADD CONSTRAINT "fk_escalation_policies_team_id_teams_id" FOREIGN KEY("team_id") REFERENCES "public"."teams"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."escalation_policy_steps" ADD CONSTRAINT "fk_escalation_policy_steps_policy_id_escalation_policies_id" FOREIGN KEY("policy_id") REFERENCES "public"."escalation_policies"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."escalation_step_targets" ADD CONSTRAINT "fk_escalation_step_targets_step_id_escalation_policy_steps_id" FOREIGN KEY("step_id") REFERENCES "public"."escalation_policy_steps"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."on_call_schedules" ADD CONSTRAINT "fk_on_call_schedules_team_id_teams_id" FOREIGN KEY("team_id") REFERENCES "public"."teams"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."on_call_schedule_layers" ADD CONSTRAINT "fk_on_call_schedule_layers_schedule_id_on_call_schedules_id" FOREIGN KEY("schedule_id") REFERENCES "public"."on_call_schedules"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."on_call_schedule_overrides" ADD CONSTRAINT "fk_on_call_schedule_overrides_schedule_id_on_call_schedules_id" FOREIGN KEY("schedule_id") REFERENCES "public"."on_call_schedules"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."on_call_schedule_overrides" ADD CONSTRAINT "fk_on_call_schedule_overrides_user_id_users_id" FOREIGN KEY("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."incident_escalations" ADD CONSTRAINT "fk_incident_escalations_incident_id_incidents_id" FOREIGN KEY("incident_id") REFERENCES "public"."incidents"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."incident_escalations" ADD CONSTRAINT "fk_incident_escalations_policy_id_escalation_policies_id" FOREIGN KEY("policy_id") REFERENCES "public"."escalation_policies"("id") ON DELETE SET NULL;
+ALTER TABLE "public"."monitors" ADD CONSTRAINT "fk_monitors_escalation_policy_id_escalation_policies_id" FOREIGN KEY("escalation_policy_id") REFERENCES "public"."escalation_policies"("id") ON DELETE SET NULL;
+
+COMMIT;
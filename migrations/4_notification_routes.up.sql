@@ -0,0 +1,30 @@
+BEGIN;
+
+ALTER TABLE "public"."monitors" ADD COLUMN "tags" text[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE "public"."notification_routes" (
+    "id" bigint NOT NULL,
+    "team_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "position" smallint NOT NULL DEFAULT 0,
+    "event_kinds" text[] NOT NULL DEFAULT '{}',
+    "severities" text[] NOT NULL DEFAULT '{}',
+    "region_ids" bigint[] NOT NULL DEFAULT '{}',
+    "monitor_types" text[] NOT NULL DEFAULT '{}',
+    "tags" text[] NOT NULL DEFAULT '{}',
+    "notification_ids" bigint[] NOT NULL DEFAULT '{}',
+    "timezone" text NOT NULL DEFAULT 'UTC',
+    "quiet_hours_start" text,
+    "quiet_hours_end" text,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_notification_routes_id" PRIMARY KEY ("id"),
+    CONSTRAINT "chk_notification_routes_quiet_hours" CHECK (("quiet_hours_start" IS NULL) = ("quiet_hours_end" IS NULL))
+);
+-- Indexes
+CREATE INDEX "idx_notification_routes_team_id_position" ON "public"."notification_routes" ("team_id", "position");
+
+-- Foreign key constraints
+ALTER TABLE "public"."notification_routes" ADD CONSTRAINT "fk_notification_routes_team_id_teams_id" FOREIGN KEY("team_id") REFERENCES "public"."teams"("id") ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TYPE "incident_status" ADD VALUE IF NOT EXISTS 'flapping';
+ALTER TYPE "event_type" ADD VALUE IF NOT EXISTS 'flapping_started';
+ALTER TYPE "event_type" ADD VALUE IF NOT EXISTS 'flapping_ended';
+
+-- Flap thresholds are percentages of weighted state changes over the last flap_window pings.
+-- A flap_start_threshold of 0 disables flap detection, so existing monitors keep alerting as before until it is turned on.
+ALTER TABLE "public"."monitors" ADD COLUMN "flap_window" smallint NOT NULL DEFAULT 21;
+ALTER TABLE "public"."monitors" ADD COLUMN "flap_start_threshold" smallint NOT NULL DEFAULT 0;
+ALTER TABLE "public"."monitors" ADD COLUMN "flap_stop_threshold" smallint NOT NULL DEFAULT 0;
+
+COMMIT;
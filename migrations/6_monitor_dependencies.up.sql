@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE "public"."monitor_dependencies" (
+    "id" bigint NOT NULL,
+    "monitor_id" bigint NOT NULL,
+    "parent_id" bigint NOT NULL,
+    CONSTRAINT "pk_monitor_dependencies_id" PRIMARY KEY ("id"),
+    CONSTRAINT "chk_monitor_dependencies_not_self" CHECK ("monitor_id" <> "parent_id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_monitor_dependencies_monitor_id_parent_id" ON "public"."monitor_dependencies" ("monitor_id", "parent_id");
+CREATE INDEX "idx_monitor_dependencies_parent_id" ON "public"."monitor_dependencies" ("parent_id");
+
+-- Downstream rows link a failing child monitor to its parent's incident.
+ALTER TABLE "public"."incident_monitors" ADD COLUMN "downstream" boolean NOT NULL DEFAULT false;
+
+-- Foreign key constraints
+ALTER TABLE "public"."monitor_dependencies" ADD CONSTRAINT "fk_monitor_dependencies_monitor_id_monitors_id" FOREIGN KEY("monitor_id") REFERENCES "public"."monitors"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."monitor_dependencies" ADD CONSTRAINT "fk_monitor_dependencies_parent_id_monitors_id" FOREIGN KEY("parent_id") REFERENCES "public"."monitors"("id") ON DELETE CASCADE;
+
+COMMIT;
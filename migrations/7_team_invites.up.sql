@@ -0,0 +1,46 @@
+BEGIN;
+
+CREATE TYPE "public"."invite_status" AS ENUM ('pending', 'accepted', 'declined', 'revoked');
+
+-- Invites are addressed by email so people without an account can be invited;
+-- invited_to is filled in when the address already belongs to a user.
+ALTER TABLE "public"."team_invites" ALTER COLUMN "invited_to" DROP NOT NULL;
+ALTER TABLE "public"."team_invites" ADD COLUMN "email" text;
+ALTER TABLE "public"."team_invites" ADD COLUMN "role" member_role NOT NULL DEFAULT 'member';
+ALTER TABLE "public"."team_invites" ADD COLUMN "status" invite_status NOT NULL DEFAULT 'pending';
+ALTER TABLE "public"."team_invites" ADD COLUMN "expires_at" timestamp;
+
+-- Existing invites are addressed to the invited user's email, preferring their password login,
+-- and expire a week after they were sent like new ones.
+UPDATE "public"."team_invites" ti
+SET "email" = (
+        SELECT lower(a."email")
+        FROM "public"."accounts" a
+        WHERE a."user_id" = ti."invited_to"
+        ORDER BY (a."provider" = 'email') DESC, a."created_at" ASC
+        LIMIT 1
+    ),
+    "expires_at" = ti."created_at" + INTERVAL '7 days';
+
+-- An invite to a user without any account has no address left to reach.
+DELETE FROM "public"."team_invites" WHERE "email" IS NULL;
+
+-- Only the newest invite per team and address stays pending.
+UPDATE "public"."team_invites" SET "status" = 'revoked'
+WHERE "id" IN (
+    SELECT "id" FROM (
+        SELECT "id", row_number() OVER (PARTITION BY "team_id", "email" ORDER BY "created_at" DESC, "id" DESC) AS "rank"
+        FROM "public"."team_invites"
+    ) ranked
+    WHERE "rank" > 1
+);
+
+ALTER TABLE "public"."team_invites" ALTER COLUMN "email" SET NOT NULL;
+ALTER TABLE "public"."team_invites" ALTER COLUMN "expires_at" SET NOT NULL;
+
+-- Indexes
+CREATE UNIQUE INDEX "uq_team_invites_team_id_email_pending" ON "public"."team_invites" ("team_id", lower("email")) WHERE "status" = 'pending';
+CREATE INDEX "idx_team_invites_email" ON "public"."team_invites" (lower("email"));
+CREATE INDEX "idx_team_invites_invited_to" ON "public"."team_invites" ("invited_to");
+
+COMMIT;
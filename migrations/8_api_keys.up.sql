@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TYPE "public"."api_key_scope" AS ENUM ('read', 'write');
+
+CREATE TABLE "public"."api_keys" (
+    "id" bigint NOT NULL,
+    "team_id" bigint NOT NULL,
+    "created_by" bigint NOT NULL,
+    "name" text NOT NULL,
+    "scope" api_key_scope NOT NULL,
+    "token_prefix" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "expires_at" timestamp,
+    "last_used_at" timestamp,
+    "updated_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_api_keys_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE UNIQUE INDEX "uq_api_keys_token_hash" ON "public"."api_keys" ("token_hash");
+CREATE INDEX "idx_api_keys_team_id" ON "public"."api_keys" ("team_id");
+
+-- Foreign key constraints
+ALTER TABLE "public"."api_keys" ADD CONSTRAINT "fk_api_keys_team_id_teams_id" FOREIGN KEY("team_id") REFERENCES "public"."teams"("id") ON DELETE CASCADE;
+ALTER TABLE "public"."api_keys" ADD CONSTRAINT "fk_api_keys_created_by_users_id" FOREIGN KEY("created_by") REFERENCES "public"."users"("id") ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+-- Every refresh token belongs to a family that starts at login; each rotation
+-- adds a child. A family is what users see as a "session".
+ALTER TABLE "public"."refresh_tokens" ADD COLUMN "family_id" bigint;
+UPDATE "public"."refresh_tokens" SET "family_id" = "id";
+ALTER TABLE "public"."refresh_tokens" ALTER COLUMN "family_id" SET NOT NULL;
+ALTER TABLE "public"."refresh_tokens" ADD COLUMN "parent_id" bigint;
+ALTER TABLE "public"."refresh_tokens" ADD COLUMN "revoked_at" timestamp;
+
+CREATE INDEX "idx_refresh_tokens_family_id" ON "public"."refresh_tokens" ("family_id");
+
+CREATE TYPE "public"."security_event_type" AS ENUM ('refresh_token_reuse', 'session_revoked');
+
+CREATE TABLE "public"."security_events" (
+    "id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "type" security_event_type NOT NULL,
+    "session_id" bigint,
+    "ip" inet,
+    "user_agent" text,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT "pk_security_events_id" PRIMARY KEY ("id")
+);
+-- Indexes
+CREATE INDEX "idx_security_events_user_id_created_at" ON "public"."security_events" ("user_id", "created_at");
+
+-- Foreign key constraints
+ALTER TABLE "public"."security_events" ADD CONSTRAINT "fk_security_events_user_id_users_id" FOREIGN KEY("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// AccountTokenPurpose is what a mailed account token may be used for
+type AccountTokenPurpose string
+
+// AccountTokenPurpose constants
+const (
+	AccountTokenPasswordReset     AccountTokenPurpose = "password_reset"
+	AccountTokenEmailVerification AccountTokenPurpose = "email_verification"
+)
+
+// Account token lifetimes
+const (
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 48 * time.Hour
+)
+
+// AccountToken is a single-use, expiring token mailed to an account's address.
+// Only the SHA-256 hash of the token is stored; the plaintext only exists in the email.
+type AccountToken struct {
+	ID        int64               `json:"id,string" db:"id"`
+	AccountID int64               `json:"account_id,string" db:"account_id"`
+	Purpose   AccountTokenPurpose `json:"purpose" db:"purpose"`
+	TokenHash string              `json:"-" db:"token_hash"`
+	ExpiresAt time.Time           `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time          `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+}
+
+// AccountTokenWithAccount is an account token joined with the account it was sent to
+type AccountTokenWithAccount struct {
+	AccountToken
+	UserID int64  `json:"user_id,string" db:"user_id"`
+	Email  string `json:"email" db:"email"`
+}
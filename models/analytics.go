@@ -17,9 +17,11 @@ type MonitorAnalyticsBucket struct {
 }
 
 // MonitorDailySummary represents a daily aggregation for a monitor.
+// P50Ms is the mean of the half-hourly medians weighted by their check counts.
 type MonitorDailySummary struct {
 	MonitorID  int64     `json:"monitor_id,string" db:"monitor_id"`
 	Day        time.Time `json:"day" db:"day"`
 	TotalCount int64     `json:"total_count" db:"total_count"`
 	GoodCount  int64     `json:"good_count" db:"good_count"`
+	P50Ms      float64   `json:"p50_ms" db:"p50_ms"`
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// APIKeyScope limits what an API key may do within its team.
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead only allows safe (GET/HEAD) requests.
+	APIKeyScopeRead APIKeyScope = "read"
+	// APIKeyScopeWrite acts with the role of the member who created the key.
+	APIKeyScopeWrite APIKeyScope = "write"
+)
+
+// APIKey is a team-scoped token used by scripts and CI pipelines.
+// Only the SHA-256 hash of the token is stored; the plaintext is shown once on creation.
+type APIKey struct {
+	ID          int64       `json:"id,string" db:"id"`
+	TeamID      int64       `json:"team_id,string" db:"team_id"`
+	CreatedBy   int64       `json:"created_by,string" db:"created_by"`
+	Name        string      `json:"name" db:"name"`
+	Scope       APIKeyScope `json:"scope" db:"scope"`
+	TokenPrefix string      `json:"token_prefix" db:"token_prefix"`
+	TokenHash   string      `json:"-" db:"token_hash"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt  *time.Time  `json:"last_used_at,omitempty" db:"last_used_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
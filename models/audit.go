@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// AuditAction is what was done to a resource
+type AuditAction string
+
+// AuditAction constants
+const (
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionAccept   AuditAction = "accept"   // An invite was accepted
+	AuditActionDecline  AuditAction = "decline"  // An invite was declined
+	AuditActionRevoke   AuditAction = "revoke"   // An invite was revoked
+	AuditActionRestore  AuditAction = "restore"  // A deleted team was restored
+	AuditActionTransfer AuditAction = "transfer" // Team ownership was handed to another member
+)
+
+// AuditResourceType is the kind of resource an audit event is about
+type AuditResourceType string
+
+// AuditResourceType constants
+const (
+	AuditResourceTeam                  AuditResourceType = "team"
+	AuditResourceTeamMember            AuditResourceType = "team_member"
+	AuditResourceTeamInvite            AuditResourceType = "team_invite"
+	AuditResourceAPIKey                AuditResourceType = "api_key"
+	AuditResourceMonitor               AuditResourceType = "monitor"
+	AuditResourceNotification          AuditResourceType = "notification"
+	AuditResourceNotificationRoute     AuditResourceType = "notification_route"
+	AuditResourceEscalationPolicy      AuditResourceType = "escalation_policy"
+	AuditResourceOnCallSchedule        AuditResourceType = "on_call_schedule"
+	AuditResourceOnCallOverride        AuditResourceType = "on_call_override"
+	AuditResourceStatusPage            AuditResourceType = "status_page"
+	AuditResourceStatusPageSubscriber  AuditResourceType = "status_page_subscriber"
+	AuditResourceStatusPageMaintenance AuditResourceType = "status_page_maintenance"
+	AuditResourceIncident              AuditResourceType = "incident"
+	AuditResourceIncidentEvent         AuditResourceType = "incident_event"
+)
+
+// AuditEvent records who changed what in a team. Before and After only hold the fields that changed.
+type AuditEvent struct {
+	ID            int64             `json:"id,string" db:"id"`
+	TeamID        int64             `json:"team_id,string" db:"team_id"`
+	ActorUserID   *int64            `json:"actor_user_id,string,omitempty" db:"actor_user_id"`
+	ActorAPIKeyID *int64            `json:"actor_api_key_id,string,omitempty" db:"actor_api_key_id"`
+	Action        AuditAction       `json:"action" db:"action" example:"update"`
+	ResourceType  AuditResourceType `json:"resource_type" db:"resource_type" example:"monitor"`
+	ResourceID    *int64            `json:"resource_id,string,omitempty" db:"resource_id"`
+	Before        json.RawMessage   `json:"before,omitempty" db:"before"`
+	After         json.RawMessage   `json:"after,omitempty" db:"after"`
+	IP            net.IP            `json:"ip,omitempty" db:"ip" example:"192.168.1.100"`
+	UserAgent     *string           `json:"user_agent,omitempty" db:"user_agent"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
+
+// AuditEventFilter narrows a team's audit log. Zero values do not filter.
+type AuditEventFilter struct {
+	Action        AuditAction
+	ResourceType  AuditResourceType
+	ResourceID    *int64
+	ActorUserID   *int64
+	ActorAPIKeyID *int64
+	From          *time.Time
+	To            *time.Time
+	// BeforeID pages backwards: only events older than this ID are returned
+	BeforeID *int64
+	Limit    int
+}
@@ -10,6 +10,9 @@ const (
 	CookieNameOAuthSession = "oauth_session"
 	CookieNameRefreshToken = "refresh_token"
 	CookieNameAccessToken  = "access_token"
+
+	// CookieNameStatusPageAccess is followed by the status page ID, one cookie per unlocked page
+	CookieNameStatusPageAccess = "status_page_access_"
 )
 
 type RefreshToken struct {
@@ -19,15 +22,57 @@ type RefreshToken struct {
 	UserAgent *string    `json:"user_agent" db:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"`
 	IP        net.IP     `json:"ip" db:"ip" example:"192.168.1.100"`
 	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at" example:"2023-01-01T12:00:00Z"`
-	CreatedAt time.Time  `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"`
+	FamilyID  int64      `json:"family_id,string" db:"family_id" example:"175928847299117063"`
+	ParentID  *int64     `json:"parent_id,string,omitempty" db:"parent_id" example:"175928847299117062"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at" example:"2023-01-01T12:00:00Z"`
+	// AccessTokenID is the jti of the access token issued together with this refresh token
+	AccessTokenID *string   `json:"-" db:"access_token_id"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"`
+}
+
+// IssuedAccessToken identifies an access token that may still be valid
+type IssuedAccessToken struct {
+	ID       string    `db:"access_token_id"`
+	IssuedAt time.Time `db:"created_at"`
+}
+
+// Session is a login session: the refresh token family started at sign-in.
+// Its ID is the family ID and the user agent/IP come from the latest rotation.
+type Session struct {
+	ID           int64     `json:"id,string" db:"id" example:"175928847299117063"`
+	UserAgent    *string   `json:"user_agent" db:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"`
+	IP           net.IP    `json:"ip" db:"ip" example:"192.168.1.100"`
+	LastActiveAt time.Time `json:"last_active_at" db:"last_active_at" example:"2023-01-02T12:00:00Z"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"`
+}
+
+// SecurityEventType represents the kind of security-relevant event recorded for a user
+type SecurityEventType string
+
+// SecurityEventType constants
+const (
+	SecurityEventRefreshTokenReuse SecurityEventType = "refresh_token_reuse"
+	SecurityEventSessionRevoked    SecurityEventType = "session_revoked"
+	SecurityEventPasswordReset     SecurityEventType = "password_reset"
+	SecurityEventLoginLocked       SecurityEventType = "login_locked"
+)
+
+type SecurityEvent struct {
+	ID        int64             `json:"id,string" db:"id" example:"175928847299117063"`
+	UserID    int64             `json:"user_id,string" db:"user_id" example:"175928847299117063"`
+	Type      SecurityEventType `json:"type" db:"type" example:"refresh_token_reuse"`
+	SessionID *int64            `json:"session_id,string,omitempty" db:"session_id" example:"175928847299117063"`
+	IP        net.IP            `json:"ip" db:"ip" example:"192.168.1.100"`
+	UserAgent *string           `json:"user_agent" db:"user_agent" example:"Mozilla/5.0"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"`
 }
 
-// Provider represents the authentication provider type
+// Provider represents the authentication provider type.
+// OAuth providers use the key they are configured under in OAUTH_PROVIDERS.
 type Provider string
 
 // Provider constants
 const (
 	ProviderEmail  Provider = "email"  // Email/password authentication
-	ProviderGoogle Provider = "google" // Google OAuth authentication
-	// You can add more providers here
+	ProviderGoogle Provider = "google" // Google OAuth authentication (legacy GOOGLE_* settings)
 )
@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+type EscalationTargetType string
+
+const (
+	EscalationTargetTypeNotification EscalationTargetType = "notification"
+	EscalationTargetTypeUser         EscalationTargetType = "user"
+	EscalationTargetTypeSchedule     EscalationTargetType = "schedule"
+)
+
+type EscalationStatus string
+
+const (
+	EscalationStatusPending   EscalationStatus = "pending"
+	EscalationStatusNotified  EscalationStatus = "notified"
+	EscalationStatusCancelled EscalationStatus = "cancelled"
+)
+
+// EscalationPolicy is an ordered list of steps used to page people when an incident opens.
+type EscalationPolicy struct {
+	ID          int64            `json:"id,string" db:"id"`
+	TeamID      int64            `json:"team_id,string" db:"team_id"`
+	Name        string           `json:"name" db:"name"`
+	Description *string          `json:"description,omitempty" db:"description"`
+	Steps       []EscalationStep `json:"steps" db:"-"`
+	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+}
+
+// EscalationStep is a single step of a policy. DelaySeconds is measured from the previous step.
+type EscalationStep struct {
+	ID           int64              `json:"id,string" db:"id"`
+	PolicyID     int64              `json:"policy_id,string" db:"policy_id"`
+	Position     int16              `json:"position" db:"position"`
+	DelaySeconds int                `json:"delay_seconds" db:"delay_seconds"`
+	Targets      []EscalationTarget `json:"targets" db:"-"`
+	UpdatedAt    time.Time          `json:"updated_at" db:"updated_at"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+}
+
+// EscalationTarget points a step at a notification channel, a team member or an on-call schedule.
+type EscalationTarget struct {
+	ID       int64                `json:"id,string" db:"id"`
+	StepID   int64                `json:"step_id,string" db:"step_id"`
+	Type     EscalationTargetType `json:"type" db:"target_type"`
+	TargetID int64                `json:"target_id,string" db:"target_id"`
+}
+
+// IncidentEscalation tracks a delayed escalation task enqueued for an incident.
+type IncidentEscalation struct {
+	ID          int64            `json:"id,string" db:"id"`
+	IncidentID  int64            `json:"incident_id,string" db:"incident_id"`
+	PolicyID    *int64           `json:"policy_id,string,omitempty" db:"policy_id"`
+	Position    int16            `json:"position" db:"position"`
+	TaskID      string           `json:"task_id" db:"task_id"`
+	Status      EscalationStatus `json:"status" db:"status"`
+	ScheduledAt time.Time        `json:"scheduled_at" db:"scheduled_at"`
+	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+}
+
+// OnCallSchedule is a rotating schedule made of layers, with overrides taking precedence.
+type OnCallSchedule struct {
+	ID        int64            `json:"id,string" db:"id"`
+	TeamID    int64            `json:"team_id,string" db:"team_id"`
+	Name      string           `json:"name" db:"name"`
+	Timezone  string           `json:"timezone" db:"timezone"`
+	Layers    []OnCallLayer    `json:"layers" db:"-"`
+	Overrides []OnCallOverride `json:"overrides" db:"-"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
+
+// OnCallLayer rotates through UserIDs every RotationSeconds starting at StartsAt.
+// Layers with a higher position take precedence over lower ones.
+type OnCallLayer struct {
+	ID              int64     `json:"id,string" db:"id"`
+	ScheduleID      int64     `json:"schedule_id,string" db:"schedule_id"`
+	Name            string    `json:"name" db:"name"`
+	Position        int16     `json:"position" db:"position"`
+	RotationSeconds int       `json:"rotation_seconds" db:"rotation_seconds"`
+	StartsAt        time.Time `json:"starts_at" db:"starts_at"`
+	UserIDs         []int64   `json:"user_ids" db:"user_ids"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// OnCallOverride replaces whoever is on call between StartsAt and EndsAt.
+type OnCallOverride struct {
+	ID         int64     `json:"id,string" db:"id"`
+	ScheduleID int64     `json:"schedule_id,string" db:"schedule_id"`
+	UserID     int64     `json:"user_id,string" db:"user_id"`
+	StartsAt   time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt     time.Time `json:"ends_at" db:"ends_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -10,6 +10,7 @@ const (
 	IncidentStatusIdentified    IncidentStatus = "identified"
 	IncidentStatusMonitoring    IncidentStatus = "monitoring"
 	IncidentStatusResolved      IncidentStatus = "resolved"
+	IncidentStatusFlapping      IncidentStatus = "flapping"
 )
 
 type IncidentSeverity string
@@ -35,8 +36,26 @@ const (
 	IncidentEventTypeIdentified       EventType = "identified"
 	IncidentEventTypeUpdate           EventType = "update"
 	IncidentEventTypeMonitoring       EventType = "monitoring"
+	IncidentEventTypeFlappingStarted  EventType = "flapping_started"
+	IncidentEventTypeFlappingEnded    EventType = "flapping_ended"
 )
 
+// IsPublic reports whether events of this type belong on the public timeline of a status page.
+// Detection, paging and publishing are internal bookkeeping.
+func (t EventType) IsPublic() bool {
+	switch t {
+	case IncidentEventTypeInvestigating,
+		IncidentEventTypeIdentified,
+		IncidentEventTypeUpdate,
+		IncidentEventTypeMonitoring,
+		IncidentEventTypeManuallyResolved,
+		IncidentEventTypeAutoResolved:
+		return true
+	default:
+		return false
+	}
+}
+
 // Incident represents an incident record in the database
 type Incident struct {
 	ID          int64            `json:"id,string" db:"id"`
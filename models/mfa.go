@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// MFA settings
+const (
+	RecoveryCodeCount    = 10
+	MFAChallengeTTL      = 5 * time.Minute
+	MFAMaxFailedAttempts = 5
+	MFAFailedAttemptsTTL = 15 * time.Minute
+)
+
+// UserTOTP is a user's authenticator app enrollment.
+// It is pending until the user confirms a code, which sets EnabledAt.
+type UserTOTP struct {
+	UserID         int64      `json:"user_id,string" db:"user_id"`
+	Secret         string     `json:"-" db:"secret"`
+	EnabledAt      *time.Time `json:"enabled_at,omitempty" db:"enabled_at"`
+	LastUsedStep   *int64     `json:"-" db:"last_used_step"`
+	FailedAttempts int        `json:"-" db:"failed_attempts"`
+	LastFailedAt   *time.Time `json:"-" db:"last_failed_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Enabled reports whether the enrollment has been confirmed
+func (t *UserTOTP) Enabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// Locked reports whether too many wrong codes were entered recently
+func (t *UserTOTP) Locked(now time.Time) bool {
+	return t.FailedAttempts >= MFAMaxFailedAttempts &&
+		t.LastFailedAt != nil && now.Sub(*t.LastFailedAt) < MFAFailedAttemptsTTL
+}
+
+// RecoveryCode is a one-time code that replaces a TOTP code when the authenticator is lost.
+// Only the argon2id hash is stored; the plaintext is shown once.
+type RecoveryCode struct {
+	ID        int64      `json:"id,string" db:"id"`
+	UserID    int64      `json:"user_id,string" db:"user_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
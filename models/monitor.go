@@ -16,11 +16,16 @@ const (
 	MonitorTypePing MonitorType = "ping"
 )
 
+// DefaultFlapWindow is applied when a monitor request leaves it unset.
+// Flap detection itself is opt-in and stays off until a start threshold is set.
+const DefaultFlapWindow int16 = 21
+
 type MonitorStatus string
 
 const (
-	MonitorStatusUp   MonitorStatus = "up"
-	MonitorStatusDown MonitorStatus = "down"
+	MonitorStatusUp       MonitorStatus = "up"
+	MonitorStatusDegraded MonitorStatus = "degraded"
+	MonitorStatusDown     MonitorStatus = "down"
 )
 
 type NotificationType string
@@ -44,6 +49,7 @@ type Monitor struct {
 	Config   json.RawMessage `json:"config" db:"config"`
 	Interval int             `json:"interval" db:"interval"`
 	Status   MonitorStatus   `json:"status" db:"status"`
+	Tags     []string        `json:"tags" db:"tags"`
 
 	// Scheduling
 	LastChecked time.Time `json:"last_checked" db:"last_checked"`
@@ -53,11 +59,24 @@ type Monitor struct {
 	FailureThreshold  int16 `json:"failure_threshold" db:"failure_threshold"`
 	RecoveryThreshold int16 `json:"recovery_threshold" db:"recovery_threshold"`
 
+	// Flap detection; thresholds are percentages and a zero start threshold disables it
+	FlapWindow         int16 `json:"flap_window" db:"flap_window"`
+	FlapStartThreshold int16 `json:"flap_start_threshold" db:"flap_start_threshold"`
+	FlapStopThreshold  int16 `json:"flap_stop_threshold" db:"flap_stop_threshold"`
+
+	// Latency thresholds in milliseconds; slower successful pings degrade the monitor and a nil threshold is disabled
+	LatencyWarnMs     *int `json:"latency_warn_ms,omitempty" db:"latency_warn_ms"`
+	LatencyCriticalMs *int `json:"latency_critical_ms,omitempty" db:"latency_critical_ms"`
+
 	// Regions
 	RegionIDs []int64 `json:"regions" db:"region_ids"`
 
+	// Dependencies; failures while a parent has an open incident attach to it
+	ParentIDs []int64 `json:"parents" db:"parent_ids"`
+
 	// Notifications
-	NotificationIDs []int64 `json:"notification" db:"notification_ids"`
+	NotificationIDs    []int64 `json:"notification" db:"notification_ids"`
+	EscalationPolicyID *int64  `json:"escalation_policy_id,string,omitempty" db:"escalation_policy_id"`
 
 	// Metadata
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
@@ -70,6 +89,13 @@ type MonitorWithIncidents struct {
 	Incidents []Incident `json:"incidents,omitempty" db:"incidents"`
 }
 
+// MonitorDependency records that a monitor depends on a parent monitor.
+type MonitorDependency struct {
+	ID        int64 `json:"id,string" db:"id"`
+	MonitorID int64 `json:"monitor_id,string" db:"monitor_id"`
+	ParentID  int64 `json:"parent_id,string" db:"parent_id"`
+}
+
 // HTTPConfig decodes the monitor config into an HTTPMonitorConfig.
 func (m Monitor) HTTPConfig() (*monitorm.HTTPMonitorConfig, error) {
 	if m.Type != MonitorTypeHTTP {
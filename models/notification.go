@@ -26,6 +26,11 @@ type TelegramNotificationConfig struct {
 	ChatID   string `json:"chat_id"`
 }
 
+// EmailNotificationConfig describes the stored config for an email notification channel.
+type EmailNotificationConfig struct {
+	Email string `json:"email"`
+}
+
 type MonitorNotification struct {
 	ID             int64 `json:"id,string" db:"id"`
 	MonitorID      int64 `json:"monitor_id,string" db:"monitor_id"`
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// NotificationEventKind describes why a notification is being sent.
+type NotificationEventKind string
+
+const (
+	NotificationEventOpened     NotificationEventKind = "opened"
+	NotificationEventResolved   NotificationEventKind = "resolved"
+	NotificationEventReminder   NotificationEventKind = "reminder"
+	NotificationEventCertExpiry NotificationEventKind = "cert_expiry"
+
+	NotificationEventFlappingStarted NotificationEventKind = "flapping_started"
+	NotificationEventFlappingEnded   NotificationEventKind = "flapping_ended"
+)
+
+// NotificationRoute is a team-level rule choosing which channels receive an event.
+// Empty match lists match everything. Routes are evaluated by ascending position
+// and the first match wins; during quiet hours a matching route delivers nothing.
+type NotificationRoute struct {
+	ID       int64  `json:"id,string" db:"id"`
+	TeamID   int64  `json:"team_id,string" db:"team_id"`
+	Name     string `json:"name" db:"name"`
+	Position int16  `json:"position" db:"position"`
+
+	// Match conditions
+	EventKinds   []NotificationEventKind `json:"event_kinds" db:"event_kinds"`
+	Severities   []IncidentSeverity      `json:"severities" db:"severities"`
+	RegionIDs    []int64                 `json:"region_ids" db:"region_ids"`
+	MonitorTypes []MonitorType           `json:"monitor_types" db:"monitor_types"`
+	Tags         []string                `json:"tags" db:"tags"`
+
+	// Delivery
+	NotificationIDs []int64 `json:"notification_ids" db:"notification_ids"`
+
+	// Quiet hours are "HH:MM" clock times in Timezone; the window may wrap past midnight.
+	Timezone        string  `json:"timezone" db:"timezone"`
+	QuietHoursStart *string `json:"quiet_hours_start,omitempty" db:"quiet_hours_start"`
+	QuietHoursEnd   *string `json:"quiet_hours_end,omitempty" db:"quiet_hours_end"`
+
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
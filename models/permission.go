@@ -0,0 +1,102 @@
+package models
+
+// PermissionAction is something a team member does to a resource.
+type PermissionAction string
+
+const (
+	PermissionActionView     PermissionAction = "view"
+	PermissionActionCreate   PermissionAction = "create"
+	PermissionActionUpdate   PermissionAction = "update"
+	PermissionActionDelete   PermissionAction = "delete"
+	PermissionActionTransfer PermissionAction = "transfer" // Handing the resource to someone else, e.g. team ownership
+)
+
+// PermissionResource is a kind of team resource guarded by the permission matrix.
+type PermissionResource string
+
+const (
+	PermissionResourceTeam              PermissionResource = "team"
+	PermissionResourceTeamMember        PermissionResource = "team_member"
+	PermissionResourceTeamInvite        PermissionResource = "team_invite"
+	PermissionResourceAPIKey            PermissionResource = "api_key"
+	PermissionResourceAuditLog          PermissionResource = "audit_log"
+	PermissionResourceMonitor           PermissionResource = "monitor"
+	PermissionResourceNotification      PermissionResource = "notification"
+	PermissionResourceNotificationRoute PermissionResource = "notification_route"
+	PermissionResourceEscalationPolicy  PermissionResource = "escalation_policy"
+	PermissionResourceOnCallSchedule    PermissionResource = "on_call_schedule"
+	PermissionResourceOnCallOverride    PermissionResource = "on_call_override"
+	PermissionResourceStatusPage        PermissionResource = "status_page"
+	PermissionResourceIncident          PermissionResource = "incident"
+)
+
+var (
+	everyRole      = []MemberRole{MemberRoleOwner, MemberRoleAdmin, MemberRoleMember, MemberRoleViewer}
+	responderRoles = []MemberRole{MemberRoleOwner, MemberRoleAdmin, MemberRoleMember}
+	managerRoles   = []MemberRole{MemberRoleOwner, MemberRoleAdmin}
+	ownerRoles     = []MemberRole{MemberRoleOwner}
+)
+
+// managedByAdmins grants read access to the whole team and leaves changes to owners and admins.
+func managedByAdmins() map[PermissionAction][]MemberRole {
+	return map[PermissionAction][]MemberRole{
+		PermissionActionView:   everyRole,
+		PermissionActionCreate: managerRoles,
+		PermissionActionUpdate: managerRoles,
+		PermissionActionDelete: managerRoles,
+	}
+}
+
+// permissionMatrix lists the roles allowed to perform each action on each resource.
+// Anything missing is denied, so a new resource stays locked until it is added here.
+var permissionMatrix = map[PermissionResource]map[PermissionAction][]MemberRole{
+	PermissionResourceTeam: {
+		PermissionActionView:     everyRole,
+		PermissionActionUpdate:   managerRoles,
+		PermissionActionDelete:   ownerRoles,
+		PermissionActionTransfer: ownerRoles,
+	},
+	PermissionResourceTeamMember: {
+		PermissionActionView:   everyRole,
+		PermissionActionUpdate: managerRoles,
+		PermissionActionDelete: managerRoles,
+	},
+	PermissionResourceTeamInvite: {
+		PermissionActionView:   managerRoles,
+		PermissionActionCreate: managerRoles,
+		PermissionActionDelete: managerRoles,
+	},
+	PermissionResourceAPIKey: {
+		PermissionActionView:   managerRoles,
+		PermissionActionCreate: managerRoles,
+		PermissionActionUpdate: managerRoles,
+		PermissionActionDelete: managerRoles,
+	},
+	PermissionResourceAuditLog: {
+		PermissionActionView: managerRoles,
+	},
+	PermissionResourceMonitor:           managedByAdmins(),
+	PermissionResourceNotification:      managedByAdmins(),
+	PermissionResourceNotificationRoute: managedByAdmins(),
+	PermissionResourceEscalationPolicy:  managedByAdmins(),
+	PermissionResourceOnCallSchedule:    managedByAdmins(),
+	PermissionResourceOnCallOverride:    managedByAdmins(),
+	PermissionResourceStatusPage:        managedByAdmins(),
+	// Members respond to incidents without being able to change what is monitored.
+	PermissionResourceIncident: {
+		PermissionActionView:   everyRole,
+		PermissionActionCreate: responderRoles,
+		PermissionActionUpdate: responderRoles,
+	},
+}
+
+// Can reports whether the role may perform action on resource.
+func (r MemberRole) Can(resource PermissionResource, action PermissionAction) bool {
+	for _, role := range permissionMatrix[resource][action] {
+		if role == r {
+			return true
+		}
+	}
+
+	return false
+}
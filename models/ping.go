@@ -19,4 +19,7 @@ type Ping struct {
 	RegionID  int64      `json:"region_id,string" db:"region_id"`
 	Latency   int        `json:"latency" db:"latency"`
 	Status    PingStatus `json:"status" db:"status"`
+
+	// LatencyCriticalMs is the monitor's critical latency threshold when the ping was recorded
+	LatencyCriticalMs *int `json:"latency_critical_ms,omitempty" db:"latency_critical_ms"`
 }
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type StatusPageElementType string
 
@@ -9,15 +12,103 @@ const (
 	StatusPageElementTypeCurrentStatusIndicator StatusPageElementType = "current_status_indicator"
 )
 
+// ComponentStatus is the status a status page shows for one of its elements
+type ComponentStatus string
+
+// ComponentStatus constants, from best to worst
+const (
+	ComponentStatusOperational         ComponentStatus = "operational"
+	ComponentStatusUnderMaintenance    ComponentStatus = "under_maintenance"
+	ComponentStatusDegradedPerformance ComponentStatus = "degraded_performance"
+	ComponentStatusPartialOutage       ComponentStatus = "partial_outage"
+	ComponentStatusMajorOutage         ComponentStatus = "major_outage"
+)
+
+// ComponentStatusOverride is a status an admin set by hand on a status page element.
+// It replaces the status computed from monitors until it expires; no expiry keeps it until cleared.
+type ComponentStatusOverride struct {
+	StatusOverride          *ComponentStatus `json:"status_override,omitempty" db:"status_override"`
+	StatusOverrideExpiresAt *time.Time       `json:"status_override_expires_at,omitempty" db:"status_override_expires_at"`
+}
+
+// ActiveOverride returns the overridden status, or nil when none is set or it expired.
+func (o ComponentStatusOverride) ActiveOverride(now time.Time) *ComponentStatus {
+	if o.StatusOverride == nil {
+		return nil
+	}
+	if o.StatusOverrideExpiresAt != nil && !now.Before(*o.StatusOverrideExpiresAt) {
+		return nil
+	}
+	return o.StatusOverride
+}
+
+// StatusPageVisibility is who may see a status page
+type StatusPageVisibility string
+
+// StatusPageVisibility constants
+const (
+	StatusPageVisibilityPublic      StatusPageVisibility = "public"
+	StatusPageVisibilityPassword    StatusPageVisibility = "password"     // Visitors unlock the page with a shared password
+	StatusPageVisibilityIPAllowlist StatusPageVisibility = "ip_allowlist" // Only clients in AllowedIPRanges
+	StatusPageVisibilityTeam        StatusPageVisibility = "team"         // Only signed-in members of the team
+)
+
 // StatusPage represents a public status page for a team.
 type StatusPage struct {
 	ID        int64     `json:"id,string" db:"id"`
 	TeamID    int64     `json:"team_id,string" db:"team_id"`
 	Title     string    `json:"title" db:"title"`
 	Slug      string    `json:"slug" db:"slug"`
-	Icon      []byte    `json:"icon,omitempty" db:"icon"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Custom domain the page is also served on, only routed once DomainVerifiedAt is set
+	CustomDomain            *string    `json:"custom_domain,omitempty" db:"custom_domain"`
+	DomainVerificationToken *string    `json:"-" db:"domain_verification_token"`
+	DomainVerifiedAt        *time.Time `json:"domain_verified_at,omitempty" db:"domain_verified_at"`
+
+	Visibility      StatusPageVisibility `json:"visibility" db:"visibility"`
+	PasswordHash    *string              `json:"-" db:"password_hash"`
+	AllowedIPRanges []string             `json:"allowed_ip_ranges" db:"allowed_ip_ranges"` // CIDR ranges
+
+	StatusPageBranding `json:"branding"`
+}
+
+// StatusPageBranding is how a status page looks and reads to its visitors. Its logo and favicon
+// are StatusPageImages.
+type StatusPageBranding struct {
+	BrandColor      *string          `json:"brand_color,omitempty" db:"brand_color"` // CSS hex colors
+	BackgroundColor *string          `json:"background_color,omitempty" db:"background_color"`
+	TextColor       *string          `json:"text_color,omitempty" db:"text_color"`
+	HeaderLinks     []StatusPageLink `json:"header_links" db:"header_links"`
+	FooterMarkdown  string           `json:"footer_markdown" db:"footer_markdown"`
+	AboutMarkdown   string           `json:"about_markdown" db:"about_markdown"`
+	Timezone        string           `json:"timezone" db:"timezone"` // IANA name dates are shown in
+	Language        string           `json:"language" db:"language"`
+}
+
+// StatusPageLink is a link in the header of a status page
+type StatusPageLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// StatusPageImageKind is what an uploaded status page image is used for
+type StatusPageImageKind string
+
+// StatusPageImageKind constants
+const (
+	StatusPageImageKindLogo    StatusPageImageKind = "logo"
+	StatusPageImageKindFavicon StatusPageImageKind = "favicon"
+)
+
+// StatusPageImage is a logo or favicon uploaded for a status page
+type StatusPageImage struct {
+	StatusPageID int64               `json:"status_page_id,string" db:"status_page_id"`
+	Kind         StatusPageImageKind `json:"kind" db:"kind"`
+	ContentType  string              `json:"content_type" db:"content_type"`
+	Data         []byte              `json:"-" db:"data"`
+	UpdatedAt    time.Time           `json:"updated_at" db:"updated_at"`
 }
 
 // StatusPageGroup groups monitors or elements within a status page.
@@ -27,6 +118,7 @@ type StatusPageGroup struct {
 	Name         string                `json:"name" db:"name"`
 	Type         StatusPageElementType `json:"type" db:"type"`
 	SortOrder    int                   `json:"sort_order" db:"sort_order"`
+	ComponentStatusOverride
 }
 
 // StatusPageMonitor defines how a monitor appears on a status page.
@@ -38,4 +130,37 @@ type StatusPageMonitor struct {
 	Name         string                `json:"name" db:"name"`
 	Type         StatusPageElementType `json:"type" db:"type"`
 	SortOrder    int                   `json:"sort_order" db:"sort_order"`
+	BadgeKey     *string               `json:"badge_key,omitempty" db:"badge_key"` // Set when the monitor's badges are public
+	ComponentStatusOverride
+}
+
+// StatusPageBadgeMonitor is a status page monitor looked up by its badge key, with the team that owns it.
+type StatusPageBadgeMonitor struct {
+	StatusPageMonitor
+	TeamID     int64                `json:"team_id,string" db:"team_id"`
+	Visibility StatusPageVisibility `json:"visibility" db:"visibility"`
+}
+
+// StatusPageMaintenance is scheduled maintenance announced on a status page. The affected
+// components show as under maintenance during the window. Empty MonitorIDs means the whole page.
+type StatusPageMaintenance struct {
+	ID           int64     `json:"id,string" db:"id"`
+	StatusPageID int64     `json:"status_page_id,string" db:"status_page_id"`
+	Title        string    `json:"title" db:"title"`
+	Message      string    `json:"message" db:"message"`
+	MonitorIDs   []int64   `json:"monitor_ids" db:"monitor_ids"`
+	StartsAt     time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt       time.Time `json:"ends_at" db:"ends_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// InProgress reports whether the maintenance window is open at now.
+func (m StatusPageMaintenance) InProgress(now time.Time) bool {
+	return !now.Before(m.StartsAt) && now.Before(m.EndsAt)
+}
+
+// Affects reports whether the maintenance covers the monitor.
+func (m StatusPageMaintenance) Affects(monitorID int64) bool {
+	return len(m.MonitorIDs) == 0 || slices.Contains(m.MonitorIDs, monitorID)
 }
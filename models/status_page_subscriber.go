@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// StatusPageSubscriberType is how a subscriber receives status page updates
+type StatusPageSubscriberType string
+
+// StatusPageSubscriberType constants
+const (
+	StatusPageSubscriberEmail   StatusPageSubscriberType = "email"
+	StatusPageSubscriberWebhook StatusPageSubscriberType = "webhook"
+)
+
+// StatusPageSubscriptionConfirmTTL is how long an email subscriber has to confirm the address
+const StatusPageSubscriptionConfirmTTL = 48 * time.Hour
+
+// StatusPageSubscriber follows the public incidents of a status page.
+// MonitorIDs limits updates to incidents affecting those components; empty means every component.
+type StatusPageSubscriber struct {
+	ID                    int64                    `json:"id,string" db:"id"`
+	StatusPageID          int64                    `json:"status_page_id,string" db:"status_page_id"`
+	Type                  StatusPageSubscriberType `json:"type" db:"type"`
+	Target                string                   `json:"target" db:"target"` // Email address or webhook URL
+	MonitorIDs            []int64                  `json:"monitor_ids" db:"monitor_ids"`
+	ConfirmationTokenHash *string                  `json:"-" db:"confirmation_token_hash"`
+	ConfirmationExpiresAt *time.Time               `json:"-" db:"confirmation_expires_at"`
+	ConfirmedAt           *time.Time               `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	UpdatedAt             time.Time                `json:"updated_at" db:"updated_at"`
+	CreatedAt             time.Time                `json:"created_at" db:"created_at"`
+}
+
+// StatusPageSubscriberWithPage is a subscriber along with the status page it follows
+type StatusPageSubscriberWithPage struct {
+	StatusPageSubscriber
+	StatusPageSlug  string `json:"status_page_slug" db:"status_page_slug"`
+	StatusPageTitle string `json:"status_page_title" db:"status_page_title"`
+}
+
+// StatusPageUpdateKind is what happened to a public incident that subscribers hear about
+type StatusPageUpdateKind string
+
+// StatusPageUpdateKind constants
+const (
+	StatusPageUpdateOpened   StatusPageUpdateKind = "opened"
+	StatusPageUpdateUpdated  StatusPageUpdateKind = "updated"
+	StatusPageUpdateResolved StatusPageUpdateKind = "resolved"
+)
@@ -12,10 +12,11 @@ const (
 )
 
 type Team struct {
-	ID        int64     `json:"id,string" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int64      `json:"id,string" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // Set while the team waits to be purged
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 }
 
 type TeamMember struct {
@@ -27,13 +28,43 @@ type TeamMember struct {
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 }
 
+type InviteStatus string
+
+const (
+	InviteStatusPending  InviteStatus = "pending"
+	InviteStatusAccepted InviteStatus = "accepted"
+	InviteStatusDeclined InviteStatus = "declined"
+	InviteStatusRevoked  InviteStatus = "revoked"
+)
+
+// TeamInviteTTL is how long an invite stays valid after it is sent.
+const TeamInviteTTL = 7 * 24 * time.Hour
+
 type TeamInvite struct {
-	ID        int64     `json:"id,string" db:"id"`
-	TeamID    int64     `json:"team_id,string" db:"team_id"`
-	InvitedBy int64     `json:"invited_by,string" db:"invited_by"`
-	InvitedTo int64     `json:"invited_to,string" db:"invited_to"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int64        `json:"id,string" db:"id"`
+	TeamID    int64        `json:"team_id,string" db:"team_id"`
+	InvitedBy int64        `json:"invited_by,string" db:"invited_by"`
+	InvitedTo *int64       `json:"invited_to,string,omitempty" db:"invited_to"`
+	Email     string       `json:"email" db:"email"`
+	Role      MemberRole   `json:"role" db:"role"`
+	Status    InviteStatus `json:"status" db:"status"`
+	ExpiresAt time.Time    `json:"expires_at" db:"expires_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// TeamInviteWithTeam represents an invite along with the name of the team it is for.
+type TeamInviteWithTeam struct {
+	TeamInvite
+	TeamName string `json:"team_name" db:"team_name"`
+}
+
+// TeamMemberWithUser represents a membership along with the member's profile.
+type TeamMemberWithUser struct {
+	TeamMember
+	DisplayName string  `json:"display_name" db:"display_name"`
+	Avatar      *string `json:"avatar,omitempty" db:"avatar"`
+	Email       string  `json:"email" db:"email"`
 }
 
 // TeamWithRole represents a team along with the current member's role.
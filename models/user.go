@@ -14,13 +14,14 @@ type User struct {
 
 // Account represents how a user can login to the system
 type Account struct {
-	ID             int64     `json:"id,string" db:"id" example:"175928847299117063"`            // Unique identifier for the account
-	Provider       Provider  `json:"provider" db:"provider" example:"email"`                    // Authentication provider type
-	ProviderUserID string    `json:"provider_user_id" db:"provider_user_id" example:"user123"`  // User ID from the provider
-	UserID         int64     `json:"user_id,string" db:"user_id" example:"175928847299117063"`  // Associated user ID
-	Email          string    `json:"email" db:"email" example:"user@example.com"`               // User's email address
-	CreatedAt      time.Time `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"` // Timestamp when the account was created
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at" example:"2023-01-01T12:00:00Z"` // Timestamp when the account was last updated
+	ID              int64      `json:"id,string" db:"id" example:"175928847299117063"`                                    // Unique identifier for the account
+	Provider        Provider   `json:"provider" db:"provider" example:"email"`                                            // Authentication provider type
+	ProviderUserID  string     `json:"provider_user_id" db:"provider_user_id" example:"user123"`                          // User ID from the provider
+	UserID          int64      `json:"user_id,string" db:"user_id" example:"175928847299117063"`                          // Associated user ID
+	Email           string     `json:"email" db:"email" example:"user@example.com"`                                       // User's email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at" example:"2023-01-01T12:00:00Z"` // When ownership of the email was proven
+	CreatedAt       time.Time  `json:"created_at" db:"created_at" example:"2023-01-01T12:00:00Z"`                         // Timestamp when the account was created
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at" example:"2023-01-01T12:00:00Z"`                         // Timestamp when the account was last updated
 }
 
 // OAuthToken represents OAuth tokens for external providers
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// GetAccountByProviderEmail retrieves the account of a provider registered with an email address
+func (r *PGRepository) GetAccountByProviderEmail(ctx context.Context, tx pgx.Tx, provider models.Provider, email string) (*models.Account, error) {
+	query := `
+		SELECT id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at
+		FROM accounts
+		WHERE provider = $1 AND lower(email) = lower($2)
+		LIMIT 1
+	`
+
+	var account models.Account
+	if err := pgxscan.Get(ctx, tx, &account, query, provider, email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// GetAccountByUserIDAndProvider retrieves a user's account for one provider
+func (r *PGRepository) GetAccountByUserIDAndProvider(ctx context.Context, tx pgx.Tx, userID int64, provider models.Provider) (*models.Account, error) {
+	query := `
+		SELECT id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at
+		FROM accounts
+		WHERE user_id = $1 AND provider = $2
+		LIMIT 1
+	`
+
+	var account models.Account
+	if err := pgxscan.Get(ctx, tx, &account, query, userID, provider); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// GetVerifiedAccountByEmail retrieves an account whose ownership of the email address has been proven
+func (r *PGRepository) GetVerifiedAccountByEmail(ctx context.Context, tx pgx.Tx, email string) (*models.Account, error) {
+	query := `
+		SELECT id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at
+		FROM accounts
+		WHERE lower(email) = lower($1) AND email_verified_at IS NOT NULL
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+
+	var account models.Account
+	if err := pgxscan.Get(ctx, tx, &account, query, email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// MarkAccountEmailVerified records that the account proved ownership of its email address
+func (r *PGRepository) MarkAccountEmailVerified(ctx context.Context, tx pgx.Tx, accountID int64, verifiedAt time.Time) error {
+	query := `
+		UPDATE accounts
+		SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1
+		WHERE id = $2
+	`
+
+	_, err := tx.Exec(ctx, query, verifiedAt, accountID)
+	return err
+}
+
+// UpdateUserPassword replaces a user's password hash
+func (r *PGRepository) UpdateUserPassword(ctx context.Context, tx pgx.Tx, userID int64, passwordHash string, updatedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET password_hash = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err := tx.Exec(ctx, query, passwordHash, updatedAt, userID)
+	return err
+}
+
+// CreateAccountToken stores a new account token
+func (r *PGRepository) CreateAccountToken(ctx context.Context, tx pgx.Tx, token models.AccountToken) error {
+	query := `
+		INSERT INTO account_tokens (id, account_id, purpose, token_hash, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		token.ID,
+		token.AccountID,
+		token.Purpose,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+// GetUsableAccountToken retrieves an unused, unexpired token by hash and purpose and locks it for the transaction
+func (r *PGRepository) GetUsableAccountToken(ctx context.Context, tx pgx.Tx, tokenHash string, purpose models.AccountTokenPurpose, now time.Time) (*models.AccountTokenWithAccount, error) {
+	query := `
+		SELECT t.id, t.account_id, t.purpose, t.token_hash, t.expires_at, t.used_at, t.created_at,
+			a.user_id, a.email
+		FROM account_tokens t
+		INNER JOIN accounts a ON a.id = t.account_id
+		WHERE t.token_hash = $1
+			AND t.purpose = $2
+			AND t.used_at IS NULL
+			AND t.expires_at > $3
+		LIMIT 1
+		FOR UPDATE OF t
+	`
+
+	var token models.AccountTokenWithAccount
+	if err := pgxscan.Get(ctx, tx, &token, query, tokenHash, purpose, now); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// UseAccountTokens marks every unused token of an account for a purpose as used,
+// so redeeming or reissuing one token invalidates its siblings.
+func (r *PGRepository) UseAccountTokens(ctx context.Context, tx pgx.Tx, accountID int64, purpose models.AccountTokenPurpose, usedAt time.Time) error {
+	query := `
+		UPDATE account_tokens
+		SET used_at = $1
+		WHERE account_id = $2 AND purpose = $3 AND used_at IS NULL
+	`
+
+	_, err := tx.Exec(ctx, query, usedAt, accountID, purpose)
+	return err
+}
@@ -11,33 +11,36 @@ import (
 )
 
 // GetMonitorAnalytics retrieves aggregated uptime/latency buckets for a monitor over a time window.
-// Data comes from the Timescale continuous aggregate monitor_30min_summary.
+// Data comes from the Timescale continuous aggregate monitor_30min_summary, with good_count following
+// the latency thresholds from monitor_30min_latency_summary where it covers the bucket.
 func (r *PGRepository) GetMonitorAnalytics(ctx context.Context, tx pgx.Tx, monitorID int64, start time.Time, end time.Time, regionID *int64) ([]models.MonitorAnalyticsBucket, error) {
 	query := strings.Builder{}
 	query.WriteString(`
 		SELECT
-			bucket,
-			region_id,
-			total_count,
-			good_count,
-			p50_ms,
-			p75_ms,
-			p90_ms,
-			p95_ms,
-			p99_ms
-		FROM monitor_30min_summary
-		WHERE monitor_id = $1
-		  AND bucket >= $2
-		  AND bucket < $3
+			s.bucket,
+			s.region_id,
+			s.total_count,
+			COALESCE(l.successful_count - l.slow_count, s.good_count) AS good_count,
+			s.p50_ms,
+			s.p75_ms,
+			s.p90_ms,
+			s.p95_ms,
+			s.p99_ms
+		FROM monitor_30min_summary s
+		LEFT JOIN monitor_30min_latency_summary l
+		  ON l.monitor_id = s.monitor_id AND l.region_id = s.region_id AND l.bucket = s.bucket
+		WHERE s.monitor_id = $1
+		  AND s.bucket >= $2
+		  AND s.bucket < $3
 	`)
 
 	args := []any{monitorID, start, end}
 	if regionID != nil {
-		query.WriteString(" AND region_id = $4")
+		query.WriteString(" AND s.region_id = $4")
 		args = append(args, *regionID)
 	}
 
-	query.WriteString(" ORDER BY bucket, region_id")
+	query.WriteString(" ORDER BY s.bucket, s.region_id")
 
 	var buckets []models.MonitorAnalyticsBucket
 	if err := pgxscan.Select(ctx, tx, &buckets, query.String(), args...); err != nil {
@@ -48,6 +51,7 @@ func (r *PGRepository) GetMonitorAnalytics(ctx context.Context, tx pgx.Tx, monit
 }
 
 // ListMonitorDailySummaryByMonitorIDs returns daily totals for monitors within a window.
+// good_count comes from the same sources as GetMonitorAnalytics.
 func (r *PGRepository) ListMonitorDailySummaryByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64, start time.Time, end time.Time) ([]models.MonitorDailySummary, error) {
 	if len(monitorIDs) == 0 {
 		return []models.MonitorDailySummary{}, nil
@@ -55,16 +59,19 @@ func (r *PGRepository) ListMonitorDailySummaryByMonitorIDs(ctx context.Context,
 
 	const query = `
 		SELECT
-			monitor_id,
-			time_bucket('1 day', bucket) AS day,
-			SUM(total_count) AS total_count,
-			SUM(good_count) AS good_count
-		FROM monitor_30min_summary
-		WHERE monitor_id = ANY($1)
-		  AND bucket >= $2
-		  AND bucket < $3
-		GROUP BY monitor_id, day
-		ORDER BY monitor_id, day
+			s.monitor_id,
+			time_bucket('1 day', s.bucket) AS day,
+			SUM(s.total_count) AS total_count,
+			SUM(COALESCE(l.successful_count - l.slow_count, s.good_count)) AS good_count,
+			COALESCE(SUM(s.p50_ms * s.total_count) / NULLIF(SUM(s.total_count) FILTER (WHERE s.p50_ms IS NOT NULL), 0), 0) AS p50_ms
+		FROM monitor_30min_summary s
+		LEFT JOIN monitor_30min_latency_summary l
+		  ON l.monitor_id = s.monitor_id AND l.region_id = s.region_id AND l.bucket = s.bucket
+		WHERE s.monitor_id = ANY($1)
+		  AND s.bucket >= $2
+		  AND s.bucket < $3
+		GROUP BY s.monitor_id, day
+		ORDER BY s.monitor_id, day
 	`
 
 	var summaries []models.MonitorDailySummary
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// CreateAPIKey inserts a new API key.
+func (r *PGRepository) CreateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, team_id, created_by, name, scope, token_prefix, token_hash, expires_at, last_used_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		key.ID,
+		key.TeamID,
+		key.CreatedBy,
+		key.Name,
+		key.Scope,
+		key.TokenPrefix,
+		key.TokenHash,
+		key.ExpiresAt,
+		key.LastUsedAt,
+		key.UpdatedAt,
+		key.CreatedAt,
+	)
+	return err
+}
+
+// ListAPIKeysByTeamID returns all API keys of a team, newest first.
+func (r *PGRepository) ListAPIKeysByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.APIKey, error) {
+	query := `
+		SELECT id, team_id, created_by, name, scope, token_prefix, token_hash, expires_at, last_used_at, updated_at, created_at
+		FROM api_keys
+		WHERE team_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var keys []models.APIKey
+	if err := pgxscan.Select(ctx, tx, &keys, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// GetAPIKeyByID returns an API key that belongs to the given team.
+func (r *PGRepository) GetAPIKeyByID(ctx context.Context, tx pgx.Tx, teamID, keyID int64) (*models.APIKey, error) {
+	query := `
+		SELECT id, team_id, created_by, name, scope, token_prefix, token_hash, expires_at, last_used_at, updated_at, created_at
+		FROM api_keys
+		WHERE id = $1 AND team_id = $2
+		LIMIT 1
+	`
+
+	var key models.APIKey
+	if err := pgxscan.Get(ctx, tx, &key, query, keyID, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// GetAPIKeyByTokenHash looks up an API key by the hash of its token.
+func (r *PGRepository) GetAPIKeyByTokenHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*models.APIKey, error) {
+	query := `
+		SELECT id, team_id, created_by, name, scope, token_prefix, token_hash, expires_at, last_used_at, updated_at, created_at
+		FROM api_keys
+		WHERE token_hash = $1
+		LIMIT 1
+	`
+
+	var key models.APIKey
+	if err := pgxscan.Get(ctx, tx, &key, query, tokenHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// UpdateAPIKey updates the editable fields of an API key.
+func (r *PGRepository) UpdateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) (*models.APIKey, error) {
+	query := `
+		UPDATE api_keys
+		SET name = $1, scope = $2, expires_at = $3, updated_at = $4
+		WHERE id = $5 AND team_id = $6
+		RETURNING id, team_id, created_by, name, scope, token_prefix, token_hash, expires_at, last_used_at, updated_at, created_at
+	`
+
+	var updated models.APIKey
+	if err := pgxscan.Get(ctx, tx, &updated, query,
+		key.Name,
+		key.Scope,
+		key.ExpiresAt,
+		key.UpdatedAt,
+		key.ID,
+		key.TeamID,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteAPIKey removes an API key from a team.
+func (r *PGRepository) DeleteAPIKey(ctx context.Context, tx pgx.Tx, teamID, keyID int64) error {
+	cmd, err := tx.Exec(ctx, `DELETE FROM api_keys WHERE id = $1 AND team_id = $2`, keyID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// TouchAPIKeyLastUsed records that an API key was used. Writes are throttled to
+// one per minute so busy automation does not update the row on every request.
+func (r *PGRepository) TouchAPIKeyLastUsed(ctx context.Context, tx pgx.Tx, keyID int64, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $1
+		WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $1 - interval '1 minute')
+	`
+
+	_, err := tx.Exec(ctx, query, usedAt, keyID)
+	return err
+}
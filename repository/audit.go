@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// CreateAuditEvent appends an event to the audit log.
+func (r *PGRepository) CreateAuditEvent(ctx context.Context, tx pgx.Tx, event models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, team_id, actor_user_id, actor_api_key_id, action, resource_type, resource_id,
+			before, after, ip, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		event.ID,
+		event.TeamID,
+		event.ActorUserID,
+		event.ActorAPIKeyID,
+		event.Action,
+		event.ResourceType,
+		event.ResourceID,
+		event.Before,
+		event.After,
+		event.IP,
+		event.UserAgent,
+		event.CreatedAt,
+	)
+	return err
+}
+
+// ListAuditEvents returns a team's audit events matching the filter, newest first.
+func (r *PGRepository) ListAuditEvents(ctx context.Context, tx pgx.Tx, teamID int64, filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	query := `
+		SELECT id, team_id, actor_user_id, actor_api_key_id, action, resource_type, resource_id,
+			before, after, ip, user_agent, created_at
+		FROM audit_events
+		WHERE team_id = $1
+			AND (NULLIF($2, '') IS NULL OR action = $2)
+			AND (NULLIF($3, '') IS NULL OR resource_type = $3)
+			AND ($4::bigint IS NULL OR resource_id = $4)
+			AND ($5::bigint IS NULL OR actor_user_id = $5)
+			AND ($6::bigint IS NULL OR actor_api_key_id = $6)
+			AND ($7::timestamp IS NULL OR created_at >= $7)
+			AND ($8::timestamp IS NULL OR created_at < $8)
+			AND ($9::bigint IS NULL OR id < $9)
+		ORDER BY id DESC
+		LIMIT $10
+	`
+
+	var events []models.AuditEvent
+	if err := pgxscan.Select(ctx, tx, &events, query,
+		teamID,
+		string(filter.Action),
+		string(filter.ResourceType),
+		filter.ResourceID,
+		filter.ActorUserID,
+		filter.ActorAPIKeyID,
+		filter.From,
+		filter.To,
+		filter.BeforeID,
+		filter.Limit,
+	); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
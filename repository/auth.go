@@ -41,7 +41,7 @@ func (r *PGRepository) GetUserByEmail(ctx context.Context, tx pgx.Tx, email stri
 
 // GetAccountByEmail retrieves an account by email address
 func (r *PGRepository) GetAccountByEmail(ctx context.Context, tx pgx.Tx, email string) (*models.Account, error) {
-	query := `SELECT id, provider, provider_user_id, user_id, email, created_at, updated_at
+	query := `SELECT id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at
 	          FROM accounts
 	          WHERE email = $1
 	          LIMIT 1`
@@ -53,6 +53,7 @@ func (r *PGRepository) GetAccountByEmail(ctx context.Context, tx pgx.Tx, email s
 		&account.ProviderUserID,
 		&account.UserID,
 		&account.Email,
+		&account.EmailVerifiedAt,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -97,7 +98,7 @@ func (r *PGRepository) GetAccountWithUserByProviderUserID(ctx context.Context, d
 
 // GetRefreshTokenByToken retrieves a refresh token by its token value
 func (r *PGRepository) GetRefreshTokenByToken(ctx context.Context, tx pgx.Tx, token string) (*models.RefreshToken, error) {
-	query := `SELECT id, user_id, token, user_agent, ip, used_at, created_at
+	query := `SELECT id, user_id, token, user_agent, ip, used_at, family_id, parent_id, revoked_at, access_token_id, created_at
 	          FROM refresh_tokens
 	          WHERE token = $1
 	          LIMIT 1`
@@ -110,6 +111,10 @@ func (r *PGRepository) GetRefreshTokenByToken(ctx context.Context, tx pgx.Tx, to
 		&refreshToken.UserAgent,
 		&refreshToken.IP,
 		&refreshToken.UsedAt,
+		&refreshToken.FamilyID,
+		&refreshToken.ParentID,
+		&refreshToken.RevokedAt,
+		&refreshToken.AccessTokenID,
 		&refreshToken.CreatedAt,
 	)
 
@@ -126,8 +131,8 @@ func (r *PGRepository) GetRefreshTokenByToken(ctx context.Context, tx pgx.Tx, to
 
 // CreateAccount creates a new account
 func (r *PGRepository) CreateAccount(ctx context.Context, tx pgx.Tx, account models.Account) error {
-	query := `INSERT INTO accounts (id, provider, provider_user_id, user_id, email, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	query := `INSERT INTO accounts (id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	_, err := tx.Exec(ctx, query,
 		account.ID,
@@ -135,6 +140,7 @@ func (r *PGRepository) CreateAccount(ctx context.Context, tx pgx.Tx, account mod
 		account.ProviderUserID,
 		account.UserID,
 		account.Email,
+		account.EmailVerifiedAt,
 		account.CreatedAt,
 		account.UpdatedAt,
 	)
@@ -161,8 +167,8 @@ func (r *PGRepository) CreateUserAndAccount(ctx context.Context, tx pgx.Tx, user
 	}
 
 	// Insert account
-	accountQuery := `INSERT INTO accounts (id, provider, provider_user_id, user_id, email, created_at, updated_at)
-	                 VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	accountQuery := `INSERT INTO accounts (id, provider, provider_user_id, user_id, email, email_verified_at, created_at, updated_at)
+	                 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	_, err = tx.Exec(ctx, accountQuery,
 		account.ID,
@@ -170,6 +176,7 @@ func (r *PGRepository) CreateUserAndAccount(ctx context.Context, tx pgx.Tx, user
 		account.ProviderUserID,
 		account.UserID,
 		account.Email,
+		account.EmailVerifiedAt,
 		account.CreatedAt,
 		account.UpdatedAt,
 	)
@@ -219,8 +226,8 @@ func (r *PGRepository) CreateOAuthToken(ctx context.Context, db pgx.Tx, oauthTok
 
 // CreateRefreshToken creates a new refresh token in the database
 func (r *PGRepository) CreateRefreshToken(ctx context.Context, tx pgx.Tx, token models.RefreshToken) error {
-	query := `INSERT INTO refresh_tokens (id, user_id, token, user_agent, ip, used_at, created_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	query := `INSERT INTO refresh_tokens (id, user_id, token, user_agent, ip, used_at, family_id, parent_id, revoked_at, access_token_id, created_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	_, err := tx.Exec(ctx, query,
 		token.ID,
@@ -229,18 +236,27 @@ func (r *PGRepository) CreateRefreshToken(ctx context.Context, tx pgx.Tx, token
 		token.UserAgent,
 		token.IP,
 		token.UsedAt,
+		token.FamilyID,
+		token.ParentID,
+		token.RevokedAt,
+		token.AccessTokenID,
 		token.CreatedAt,
 	)
 
 	return err
 }
 
-// UpdateRefreshTokenUsedAt updates the used_at timestamp for a refresh token
-func (r *PGRepository) UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) error {
+// UpdateRefreshTokenUsedAt sets the used_at timestamp of a refresh token that is still unused.
+// It reports false when another refresh used the token first, which then waits on the row lock.
+func (r *PGRepository) UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) (bool, error) {
 	query := `UPDATE refresh_tokens
 	          SET used_at = $1
-	          WHERE id = $2`
+	          WHERE id = $2 AND used_at IS NULL`
 
-	_, err := tx.Exec(ctx, query, token.UsedAt, token.ID)
-	return err
+	result, err := tx.Exec(ctx, query, token.UsedAt, token.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
 }
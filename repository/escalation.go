@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/id"
+)
+
+// CreateEscalationPolicy inserts an escalation policy record.
+func (r *PGRepository) CreateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) error {
+	query := `
+		INSERT INTO escalation_policies (id, team_id, name, description, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		policy.ID,
+		policy.TeamID,
+		policy.Name,
+		policy.Description,
+		policy.UpdatedAt,
+		policy.CreatedAt,
+	)
+	return err
+}
+
+// ListEscalationPoliciesByTeamID returns escalation policies belonging to a team.
+func (r *PGRepository) ListEscalationPoliciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.EscalationPolicy, error) {
+	query := `
+		SELECT id, team_id, name, description, updated_at, created_at
+		FROM escalation_policies
+		WHERE team_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var policies []models.EscalationPolicy
+	if err := pgxscan.Select(ctx, tx, &policies, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GetEscalationPolicyByID fetches an escalation policy ensuring it belongs to the provided team.
+func (r *PGRepository) GetEscalationPolicyByID(ctx context.Context, tx pgx.Tx, teamID, policyID int64) (*models.EscalationPolicy, error) {
+	query := `
+		SELECT id, team_id, name, description, updated_at, created_at
+		FROM escalation_policies
+		WHERE id = $1 AND team_id = $2
+	`
+
+	var policy models.EscalationPolicy
+	if err := pgxscan.Get(ctx, tx, &policy, query, policyID, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// UpdateEscalationPolicy updates an escalation policy and returns the persisted record.
+func (r *PGRepository) UpdateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) (*models.EscalationPolicy, error) {
+	query := `
+		UPDATE escalation_policies
+		SET name = $1, description = $2, updated_at = $3
+		WHERE id = $4 AND team_id = $5
+		RETURNING id, team_id, name, description, updated_at, created_at
+	`
+
+	var updated models.EscalationPolicy
+	if err := pgxscan.Get(ctx, tx, &updated, query,
+		policy.Name,
+		policy.Description,
+		policy.UpdatedAt,
+		policy.ID,
+		policy.TeamID,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteEscalationPolicy removes an escalation policy belonging to a team.
+func (r *PGRepository) DeleteEscalationPolicy(ctx context.Context, tx pgx.Tx, teamID, policyID int64) error {
+	result, err := tx.Exec(ctx, `DELETE FROM escalation_policies WHERE id = $1 AND team_id = $2`, policyID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateEscalationSteps inserts policy steps together with their targets.
+func (r *PGRepository) CreateEscalationSteps(ctx context.Context, tx pgx.Tx, steps []models.EscalationStep) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	stepQuery := `
+		INSERT INTO escalation_policy_steps (id, policy_id, position, delay_seconds, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	targetQuery := `
+		INSERT INTO escalation_step_targets (id, step_id, target_type, target_id)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, step := range steps {
+		if _, err := tx.Exec(ctx, stepQuery,
+			step.ID,
+			step.PolicyID,
+			step.Position,
+			step.DelaySeconds,
+			step.UpdatedAt,
+			step.CreatedAt,
+		); err != nil {
+			return err
+		}
+
+		for _, target := range step.Targets {
+			targetID, err := id.GetID()
+			if err != nil {
+				return fmt.Errorf("failed to generate escalation target ID: %w", err)
+			}
+
+			if _, err := tx.Exec(ctx, targetQuery, targetID, step.ID, target.Type, target.TargetID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteEscalationStepsByPolicyID removes all steps (and their targets) of a policy.
+func (r *PGRepository) DeleteEscalationStepsByPolicyID(ctx context.Context, tx pgx.Tx, policyID int64) error {
+	_, err := tx.Exec(ctx, `DELETE FROM escalation_policy_steps WHERE policy_id = $1`, policyID)
+	return err
+}
+
+// ListEscalationStepsByPolicyIDs returns the steps of the given policies ordered by position, with targets loaded.
+func (r *PGRepository) ListEscalationStepsByPolicyIDs(ctx context.Context, tx pgx.Tx, policyIDs []int64) ([]models.EscalationStep, error) {
+	if len(policyIDs) == 0 {
+		return []models.EscalationStep{}, nil
+	}
+
+	stepQuery := `
+		SELECT id, policy_id, position, delay_seconds, updated_at, created_at
+		FROM escalation_policy_steps
+		WHERE policy_id = ANY($1)
+		ORDER BY policy_id, position ASC
+	`
+
+	var steps []models.EscalationStep
+	if err := pgxscan.Select(ctx, tx, &steps, stepQuery, policyIDs); err != nil {
+		return nil, err
+	}
+
+	if len(steps) == 0 {
+		return steps, nil
+	}
+
+	stepIDs := make([]int64, len(steps))
+	for i, step := range steps {
+		stepIDs[i] = step.ID
+	}
+
+	targetQuery := `
+		SELECT id, step_id, target_type, target_id
+		FROM escalation_step_targets
+		WHERE step_id = ANY($1)
+		ORDER BY id
+	`
+
+	var targets []models.EscalationTarget
+	if err := pgxscan.Select(ctx, tx, &targets, targetQuery, stepIDs); err != nil {
+		return nil, err
+	}
+
+	targetsByStep := make(map[int64][]models.EscalationTarget, len(steps))
+	for _, target := range targets {
+		targetsByStep[target.StepID] = append(targetsByStep[target.StepID], target)
+	}
+
+	for i := range steps {
+		steps[i].Targets = targetsByStep[steps[i].ID]
+		if steps[i].Targets == nil {
+			steps[i].Targets = []models.EscalationTarget{}
+		}
+	}
+
+	return steps, nil
+}
+
+// CreateIncidentEscalations records the escalation tasks scheduled for an incident.
+func (r *PGRepository) CreateIncidentEscalations(ctx context.Context, tx pgx.Tx, escalations []models.IncidentEscalation) error {
+	if len(escalations) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO incident_escalations (id, incident_id, policy_id, position, task_id, status, scheduled_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, escalation := range escalations {
+		if _, err := tx.Exec(ctx, query,
+			escalation.ID,
+			escalation.IncidentID,
+			escalation.PolicyID,
+			escalation.Position,
+			escalation.TaskID,
+			escalation.Status,
+			escalation.ScheduledAt,
+			escalation.UpdatedAt,
+			escalation.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListIncidentEscalationsByIncidentID returns every escalation recorded for an incident ordered by position.
+func (r *PGRepository) ListIncidentEscalationsByIncidentID(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.IncidentEscalation, error) {
+	query := `
+		SELECT id, incident_id, policy_id, position, task_id, status, scheduled_at, updated_at, created_at
+		FROM incident_escalations
+		WHERE incident_id = $1
+		ORDER BY position ASC
+	`
+
+	var escalations []models.IncidentEscalation
+	if err := pgxscan.Select(ctx, tx, &escalations, query, incidentID); err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
+
+// MarkIncidentEscalationNotified flags a pending escalation as delivered.
+// It returns false when the escalation is no longer pending (e.g. it was cancelled).
+func (r *PGRepository) MarkIncidentEscalationNotified(ctx context.Context, tx pgx.Tx, taskID string, updatedAt time.Time) (bool, error) {
+	result, err := tx.Exec(ctx, `
+		UPDATE incident_escalations
+		SET status = $1, updated_at = $2
+		WHERE task_id = $3 AND status = $4
+	`, models.EscalationStatusNotified, updatedAt, taskID, models.EscalationStatusPending)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// CancelPendingIncidentEscalations marks all pending escalations of an incident as cancelled and returns them.
+func (r *PGRepository) CancelPendingIncidentEscalations(ctx context.Context, tx pgx.Tx, incidentID int64, updatedAt time.Time) ([]models.IncidentEscalation, error) {
+	query := `
+		UPDATE incident_escalations
+		SET status = $1, updated_at = $2
+		WHERE incident_id = $3 AND status = $4
+		RETURNING id, incident_id, policy_id, position, task_id, status, scheduled_at, updated_at, created_at
+	`
+
+	var escalations []models.IncidentEscalation
+	if err := pgxscan.Select(ctx, tx, &escalations, query,
+		models.EscalationStatusCancelled,
+		updatedAt,
+		incidentID,
+		models.EscalationStatusPending,
+	); err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
+
+// CancelPendingTeamIncidentEscalations marks the pending escalations of every incident of a team's monitors as cancelled and returns them.
+func (r *PGRepository) CancelPendingTeamIncidentEscalations(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) ([]models.IncidentEscalation, error) {
+	query := `
+		UPDATE incident_escalations
+		SET status = $1, updated_at = $2
+		WHERE status = $4 AND incident_id IN (
+			SELECT im.incident_id
+			FROM incident_monitors im
+			INNER JOIN monitors m ON m.id = im.monitor_id
+			WHERE m.team_id = $3
+		)
+		RETURNING id, incident_id, policy_id, position, task_id, status, scheduled_at, updated_at, created_at
+	`
+
+	var escalations []models.IncidentEscalation
+	if err := pgxscan.Select(ctx, tx, &escalations, query,
+		models.EscalationStatusCancelled,
+		updatedAt,
+		teamID,
+		models.EscalationStatusPending,
+	); err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
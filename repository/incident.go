@@ -34,6 +34,52 @@ func (r *PGRepository) GetOpenIncidentByMonitorID(ctx context.Context, tx pgx.Tx
 	return &incident, nil
 }
 
+// GetOpenIncidentByMonitorIDs fetches the latest non-resolved incident affecting any of the monitors.
+func (r *PGRepository) GetOpenIncidentByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64) (*models.Incident, error) {
+	if len(monitorIDs) == 0 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT i.id, i.status, i.severity, i.is_public, i.auto_resolve, i.started_at, i.resolved_at, i.created_at, i.updated_at
+		FROM incidents i
+		INNER JOIN incident_monitors im ON im.incident_id = i.id
+		WHERE im.monitor_id = ANY($1)
+		  AND i.status <> 'resolved'
+		ORDER BY i.started_at ASC, i.id ASC
+		LIMIT 1
+	`
+
+	var incident models.Incident
+	if err := pgxscan.Get(ctx, tx, &incident, query, monitorIDs); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+// IsDownstreamIncidentMonitor reports whether a monitor is attached to an incident only because a parent failed.
+func (r *PGRepository) IsDownstreamIncidentMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) (bool, error) {
+	const query = `
+		SELECT downstream
+		FROM incident_monitors
+		WHERE incident_id = $1 AND monitor_id = $2
+	`
+
+	var downstream bool
+	if err := tx.QueryRow(ctx, query, incidentID, monitorID).Scan(&downstream); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return downstream, nil
+}
+
 // ListPublicIncidentsByMonitorIDs returns public incidents for the provided monitors.
 func (r *PGRepository) ListPublicIncidentsByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64) ([]models.IncidentWithMonitorID, error) {
 	if len(monitorIDs) == 0 {
@@ -115,6 +161,23 @@ func (r *PGRepository) CreateIncidentMonitor(ctx context.Context, tx pgx.Tx, inc
 	return err
 }
 
+// AttachDownstreamMonitor links a failing child monitor to its parent's incident.
+func (r *PGRepository) AttachDownstreamMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) error {
+	const query = `
+		INSERT INTO incident_monitors (id, incident_id, monitor_id, downstream)
+		VALUES ($1, $2, $3, true)
+		ON CONFLICT (incident_id, monitor_id) DO NOTHING
+	`
+
+	junctionID, err := id.GetID()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, junctionID, incidentID, monitorID)
+	return err
+}
+
 // MarkIncidentResolved closes an incident.
 func (r *PGRepository) MarkIncidentResolved(ctx context.Context, tx pgx.Tx, incidentID int64, resolvedAt, updatedAt time.Time) error {
 	const query = `
@@ -237,13 +300,15 @@ func (r *PGRepository) ListIncidentsByTeamID(ctx context.Context, tx pgx.Tx, tea
 }
 
 // GetIncidentByIDForTeam fetches an incident ensuring it belongs to the team via monitor association.
+// Incidents of soft-deleted teams are not found, so their queued escalations stop paging.
 func (r *PGRepository) GetIncidentByIDForTeam(ctx context.Context, tx pgx.Tx, teamID, incidentID int64) (*models.Incident, error) {
 	const query = `
 		SELECT i.id, i.status, i.severity, i.is_public, i.auto_resolve, i.started_at, i.resolved_at, i.created_at, i.updated_at
 		FROM incidents i
 		INNER JOIN incident_monitors im ON im.incident_id = i.id
 		INNER JOIN monitors m ON m.id = im.monitor_id
-		WHERE i.id = $1 AND m.team_id = $2
+		INNER JOIN teams t ON t.id = m.team_id
+		WHERE i.id = $1 AND m.team_id = $2 AND t.deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -274,6 +339,27 @@ func (r *PGRepository) ListEventTimelinesByIncidentID(ctx context.Context, tx pg
 	return events, nil
 }
 
+// ListEventTimelinesByIncidentIDs returns the timeline events of several incidents, oldest first.
+func (r *PGRepository) ListEventTimelinesByIncidentIDs(ctx context.Context, tx pgx.Tx, incidentIDs []int64) ([]models.EventTimeline, error) {
+	if len(incidentIDs) == 0 {
+		return []models.EventTimeline{}, nil
+	}
+
+	const query = `
+		SELECT id, event_id, created_by, message, event_type, created_at, updated_at
+		FROM event_timelines
+		WHERE event_id = ANY($1)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	var events []models.EventTimeline
+	if err := pgxscan.Select(ctx, tx, &events, query, incidentIDs); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 // UpdateIncidentStatus updates the status (and optional resolved time) for an incident and returns the updated row.
 func (r *PGRepository) UpdateIncidentStatus(ctx context.Context, tx pgx.Tx, incidentID int64, status models.IncidentStatus, resolvedAt *time.Time, updatedAt time.Time) (*models.Incident, error) {
 	const query = `
@@ -296,6 +382,27 @@ func (r *PGRepository) UpdateIncidentStatus(ctx context.Context, tx pgx.Tx, inci
 	return &incident, nil
 }
 
+// UpdateIncidentSeverity changes the severity of an incident.
+func (r *PGRepository) UpdateIncidentSeverity(ctx context.Context, tx pgx.Tx, incidentID int64, severity models.IncidentSeverity, updatedAt time.Time) (*models.Incident, error) {
+	const query = `
+		UPDATE incidents
+		SET severity = $2,
+		    updated_at = $3
+		WHERE id = $1
+		RETURNING id, status, severity, is_public, auto_resolve, started_at, resolved_at, created_at, updated_at
+	`
+
+	var incident models.Incident
+	if err := pgxscan.Get(ctx, tx, &incident, query, incidentID, severity, updatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
 // UpdateIncidentSettings updates visibility/auto-resolve flags for an incident.
 func (r *PGRepository) UpdateIncidentSettings(ctx context.Context, tx pgx.Tx, incidentID int64, isPublic bool, autoResolve bool, updatedAt time.Time) (*models.Incident, error) {
 	const query = `
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// GetUserTOTP retrieves a user's TOTP enrollment and locks it for the transaction
+func (r *PGRepository) GetUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) (*models.UserTOTP, error) {
+	query := `
+		SELECT user_id, secret, enabled_at, last_used_step, failed_attempts, last_failed_at, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+		FOR UPDATE
+	`
+
+	var totp models.UserTOTP
+	if err := pgxscan.Get(ctx, tx, &totp, query, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &totp, nil
+}
+
+// UpsertUserTOTP creates a user's TOTP enrollment or replaces a pending one
+func (r *PGRepository) UpsertUserTOTP(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, enabled_at, last_used_step, failed_attempts, last_failed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			secret = EXCLUDED.secret,
+			enabled_at = EXCLUDED.enabled_at,
+			last_used_step = EXCLUDED.last_used_step,
+			failed_attempts = EXCLUDED.failed_attempts,
+			last_failed_at = EXCLUDED.last_failed_at,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := tx.Exec(ctx, query,
+		totp.UserID,
+		totp.Secret,
+		totp.EnabledAt,
+		totp.LastUsedStep,
+		totp.FailedAttempts,
+		totp.LastFailedAt,
+		totp.CreatedAt,
+		totp.UpdatedAt,
+	)
+	return err
+}
+
+// UpdateUserTOTPState updates the confirmation, replay and throttling state of a TOTP enrollment
+func (r *PGRepository) UpdateUserTOTPState(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error {
+	query := `
+		UPDATE user_totp
+		SET enabled_at = $1, last_used_step = $2, failed_attempts = $3, last_failed_at = $4, updated_at = $5
+		WHERE user_id = $6
+	`
+
+	_, err := tx.Exec(ctx, query,
+		totp.EnabledAt,
+		totp.LastUsedStep,
+		totp.FailedAttempts,
+		totp.LastFailedAt,
+		totp.UpdatedAt,
+		totp.UserID,
+	)
+	return err
+}
+
+// DeleteUserTOTP removes a user's TOTP enrollment together with their recovery codes
+func (r *PGRepository) DeleteUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID)
+	return err
+}
+
+// ListUnusedRecoveryCodes returns the recovery codes a user can still redeem
+func (r *PGRepository) ListUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64) ([]models.RecoveryCode, error) {
+	query := `
+		SELECT id, user_id, code_hash, used_at, created_at
+		FROM recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+		ORDER BY id
+	`
+
+	var codes []models.RecoveryCode
+	if err := pgxscan.Select(ctx, tx, &codes, query, userID); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// ReplaceRecoveryCodes discards every recovery code of a user and stores a new set
+func (r *PGRepository) ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, codes []models.RecoveryCode) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	rows := make([][]any, 0, len(codes))
+	for _, code := range codes {
+		rows = append(rows, []any{code.ID, code.UserID, code.CodeHash, code.UsedAt, code.CreatedAt})
+	}
+
+	_, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"recovery_codes"},
+		[]string{"id", "user_id", "code_hash", "used_at", "created_at"},
+		pgx.CopyFromRows(rows),
+	)
+	return err
+}
+
+// MarkRecoveryCodeUsed redeems a recovery code; it returns pgx.ErrNoRows if it was already used
+func (r *PGRepository) MarkRecoveryCodeUsed(ctx context.Context, tx pgx.Tx, codeID int64, usedAt time.Time) error {
+	query := `
+		UPDATE recovery_codes
+		SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+
+	cmd, err := tx.Exec(ctx, query, usedAt, codeID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
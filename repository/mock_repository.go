@@ -52,6 +52,23 @@ func (m *MockRepository) GetStatusPageBySlug(ctx context.Context, tx pgx.Tx, slu
 	return page, args.Error(1)
 }
 
+func (m *MockRepository) GetStatusPageIDBySlug(ctx context.Context, tx pgx.Tx, slug string) (*int64, error) {
+	args := m.Called(ctx, tx, slug)
+	statusPageID, _ := args.Get(0).(*int64)
+	return statusPageID, args.Error(1)
+}
+
+func (m *MockRepository) GetStatusPageByCustomDomain(ctx context.Context, tx pgx.Tx, domain string) (*models.StatusPage, error) {
+	args := m.Called(ctx, tx, domain)
+	page, _ := args.Get(0).(*models.StatusPage)
+	return page, args.Error(1)
+}
+
+func (m *MockRepository) UpdateStatusPageCustomDomain(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) error {
+	args := m.Called(ctx, tx, statusPage)
+	return args.Error(0)
+}
+
 func (m *MockRepository) ListStatusPagesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.StatusPage, error) {
 	args := m.Called(ctx, tx, teamID)
 	pages, _ := args.Get(0).([]models.StatusPage)
@@ -70,6 +87,12 @@ func (m *MockRepository) ListStatusPageMonitorsByStatusPageID(ctx context.Contex
 	return monitors, args.Error(1)
 }
 
+func (m *MockRepository) ListAllStatusPageMonitors(ctx context.Context, tx pgx.Tx) ([]models.StatusPageMonitor, error) {
+	args := m.Called(ctx, tx)
+	monitors, _ := args.Get(0).([]models.StatusPageMonitor)
+	return monitors, args.Error(1)
+}
+
 func (m *MockRepository) CreateStatusPageGroups(ctx context.Context, tx pgx.Tx, groups []models.StatusPageGroup) error {
 	args := m.Called(ctx, tx, groups)
 	return args.Error(0)
@@ -90,6 +113,12 @@ func (m *MockRepository) DeleteStatusPageMonitorsByStatusPageID(ctx context.Cont
 	return args.Error(0)
 }
 
+func (m *MockRepository) GetStatusPageMonitorByBadgeKey(ctx context.Context, tx pgx.Tx, badgeKey string) (*models.StatusPageBadgeMonitor, error) {
+	args := m.Called(ctx, tx, badgeKey)
+	monitor, _ := args.Get(0).(*models.StatusPageBadgeMonitor)
+	return monitor, args.Error(1)
+}
+
 func (m *MockRepository) DeleteStatusPageGroupsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) error {
 	args := m.Called(ctx, tx, statusPageID)
 	return args.Error(0)
@@ -146,9 +175,10 @@ func (m *MockRepository) CreateRefreshToken(ctx context.Context, tx pgx.Tx, toke
 	return args.Error(0)
 }
 
-func (m *MockRepository) UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) error {
+func (m *MockRepository) UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) (bool, error) {
 	args := m.Called(ctx, tx, token)
-	return args.Error(0)
+	ok, _ := args.Get(0).(bool)
+	return ok, args.Error(1)
 }
 
 func (m *MockRepository) ListTeamsByUserID(ctx context.Context, tx pgx.Tx, userID int64) ([]models.TeamWithRole, error) {
@@ -185,9 +215,22 @@ func (m *MockRepository) UpdateTeamName(ctx context.Context, tx pgx.Tx, teamID i
 	return team, args.Error(1)
 }
 
-func (m *MockRepository) DeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64) error {
-	args := m.Called(ctx, tx, teamID)
-	return args.Error(0)
+func (m *MockRepository) SoftDeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64, deletedAt time.Time) (*models.Team, error) {
+	args := m.Called(ctx, tx, teamID, deletedAt)
+	team, _ := args.Get(0).(*models.Team)
+	return team, args.Error(1)
+}
+
+func (m *MockRepository) RestoreTeam(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) (*models.Team, error) {
+	args := m.Called(ctx, tx, teamID, updatedAt)
+	team, _ := args.Get(0).(*models.Team)
+	return team, args.Error(1)
+}
+
+func (m *MockRepository) PurgeDeletedTeams(ctx context.Context, tx pgx.Tx, deletedBefore time.Time) ([]int64, error) {
+	args := m.Called(ctx, tx, deletedBefore)
+	teamIDs, _ := args.Get(0).([]int64)
+	return teamIDs, args.Error(1)
 }
 
 func (m *MockRepository) ListNotificationsByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.Notification, error) {
@@ -368,12 +411,24 @@ func (m *MockRepository) ListEventTimelinesByIncidentID(ctx context.Context, tx
 	return events, args.Error(1)
 }
 
+func (m *MockRepository) ListEventTimelinesByIncidentIDs(ctx context.Context, tx pgx.Tx, incidentIDs []int64) ([]models.EventTimeline, error) {
+	args := m.Called(ctx, tx, incidentIDs)
+	events, _ := args.Get(0).([]models.EventTimeline)
+	return events, args.Error(1)
+}
+
 func (m *MockRepository) UpdateIncidentStatus(ctx context.Context, tx pgx.Tx, incidentID int64, status models.IncidentStatus, resolvedAt *time.Time, updatedAt time.Time) (*models.Incident, error) {
 	args := m.Called(ctx, tx, incidentID, status, resolvedAt, updatedAt)
 	incident, _ := args.Get(0).(*models.Incident)
 	return incident, args.Error(1)
 }
 
+func (m *MockRepository) UpdateIncidentSeverity(ctx context.Context, tx pgx.Tx, incidentID int64, severity models.IncidentSeverity, updatedAt time.Time) (*models.Incident, error) {
+	args := m.Called(ctx, tx, incidentID, severity, updatedAt)
+	incident, _ := args.Get(0).(*models.Incident)
+	return incident, args.Error(1)
+}
+
 func (m *MockRepository) UpdateIncidentSettings(ctx context.Context, tx pgx.Tx, incidentID int64, isPublic bool, autoResolve bool, updatedAt time.Time) (*models.Incident, error) {
 	args := m.Called(ctx, tx, incidentID, isPublic, autoResolve, updatedAt)
 	incident, _ := args.Get(0).(*models.Incident)
@@ -414,3 +469,544 @@ func (m *MockRepository) ListIncidentsByMonitorIDWithinRange(ctx context.Context
 	incidents, _ := args.Get(0).([]models.Incident)
 	return incidents, args.Error(1)
 }
+
+func (m *MockRepository) GetUserEmailByID(ctx context.Context, tx pgx.Tx, userID int64) (string, error) {
+	args := m.Called(ctx, tx, userID)
+	value, _ := args.Get(0).(string)
+	return value, args.Error(1)
+}
+
+func (m *MockRepository) CreateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) error {
+	args := m.Called(ctx, tx, policy)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListEscalationPoliciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.EscalationPolicy, error) {
+	args := m.Called(ctx, tx, teamID)
+	escalationPolicies, _ := args.Get(0).([]models.EscalationPolicy)
+	return escalationPolicies, args.Error(1)
+}
+
+func (m *MockRepository) GetEscalationPolicyByID(ctx context.Context, tx pgx.Tx, teamID, policyID int64) (*models.EscalationPolicy, error) {
+	args := m.Called(ctx, tx, teamID, policyID)
+	escalationPolicy, _ := args.Get(0).(*models.EscalationPolicy)
+	return escalationPolicy, args.Error(1)
+}
+
+func (m *MockRepository) UpdateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) (*models.EscalationPolicy, error) {
+	args := m.Called(ctx, tx, policy)
+	escalationPolicy, _ := args.Get(0).(*models.EscalationPolicy)
+	return escalationPolicy, args.Error(1)
+}
+
+func (m *MockRepository) DeleteEscalationPolicy(ctx context.Context, tx pgx.Tx, teamID, policyID int64) error {
+	args := m.Called(ctx, tx, teamID, policyID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateEscalationSteps(ctx context.Context, tx pgx.Tx, steps []models.EscalationStep) error {
+	args := m.Called(ctx, tx, steps)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteEscalationStepsByPolicyID(ctx context.Context, tx pgx.Tx, policyID int64) error {
+	args := m.Called(ctx, tx, policyID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListEscalationStepsByPolicyIDs(ctx context.Context, tx pgx.Tx, policyIDs []int64) ([]models.EscalationStep, error) {
+	args := m.Called(ctx, tx, policyIDs)
+	escalationSteps, _ := args.Get(0).([]models.EscalationStep)
+	return escalationSteps, args.Error(1)
+}
+
+func (m *MockRepository) CreateIncidentEscalations(ctx context.Context, tx pgx.Tx, escalations []models.IncidentEscalation) error {
+	args := m.Called(ctx, tx, escalations)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListIncidentEscalationsByIncidentID(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.IncidentEscalation, error) {
+	args := m.Called(ctx, tx, incidentID)
+	incidentEscalations, _ := args.Get(0).([]models.IncidentEscalation)
+	return incidentEscalations, args.Error(1)
+}
+
+func (m *MockRepository) MarkIncidentEscalationNotified(ctx context.Context, tx pgx.Tx, taskID string, updatedAt time.Time) (bool, error) {
+	args := m.Called(ctx, tx, taskID, updatedAt)
+	ok, _ := args.Get(0).(bool)
+	return ok, args.Error(1)
+}
+
+func (m *MockRepository) CancelPendingIncidentEscalations(ctx context.Context, tx pgx.Tx, incidentID int64, updatedAt time.Time) ([]models.IncidentEscalation, error) {
+	args := m.Called(ctx, tx, incidentID, updatedAt)
+	incidentEscalations, _ := args.Get(0).([]models.IncidentEscalation)
+	return incidentEscalations, args.Error(1)
+}
+
+func (m *MockRepository) CancelPendingTeamIncidentEscalations(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) ([]models.IncidentEscalation, error) {
+	args := m.Called(ctx, tx, teamID, updatedAt)
+	incidentEscalations, _ := args.Get(0).([]models.IncidentEscalation)
+	return incidentEscalations, args.Error(1)
+}
+
+func (m *MockRepository) CreateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) error {
+	args := m.Called(ctx, tx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListOnCallSchedulesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.OnCallSchedule, error) {
+	args := m.Called(ctx, tx, teamID)
+	onCallSchedules, _ := args.Get(0).([]models.OnCallSchedule)
+	return onCallSchedules, args.Error(1)
+}
+
+func (m *MockRepository) GetOnCallScheduleByID(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) (*models.OnCallSchedule, error) {
+	args := m.Called(ctx, tx, teamID, scheduleID)
+	onCallSchedule, _ := args.Get(0).(*models.OnCallSchedule)
+	return onCallSchedule, args.Error(1)
+}
+
+func (m *MockRepository) UpdateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) (*models.OnCallSchedule, error) {
+	args := m.Called(ctx, tx, schedule)
+	onCallSchedule, _ := args.Get(0).(*models.OnCallSchedule)
+	return onCallSchedule, args.Error(1)
+}
+
+func (m *MockRepository) DeleteOnCallSchedule(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) error {
+	args := m.Called(ctx, tx, teamID, scheduleID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateOnCallLayers(ctx context.Context, tx pgx.Tx, layers []models.OnCallLayer) error {
+	args := m.Called(ctx, tx, layers)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteOnCallLayersByScheduleID(ctx context.Context, tx pgx.Tx, scheduleID int64) error {
+	args := m.Called(ctx, tx, scheduleID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListOnCallLayersByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64) ([]models.OnCallLayer, error) {
+	args := m.Called(ctx, tx, scheduleIDs)
+	onCallLayers, _ := args.Get(0).([]models.OnCallLayer)
+	return onCallLayers, args.Error(1)
+}
+
+func (m *MockRepository) CreateOnCallOverride(ctx context.Context, tx pgx.Tx, override models.OnCallOverride) error {
+	args := m.Called(ctx, tx, override)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteOnCallOverride(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) error {
+	args := m.Called(ctx, tx, scheduleID, overrideID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListOnCallOverridesByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64, since time.Time) ([]models.OnCallOverride, error) {
+	args := m.Called(ctx, tx, scheduleIDs, since)
+	onCallOverrides, _ := args.Get(0).([]models.OnCallOverride)
+	return onCallOverrides, args.Error(1)
+}
+
+func (m *MockRepository) CreateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) error {
+	args := m.Called(ctx, tx, route)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListNotificationRoutesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.NotificationRoute, error) {
+	args := m.Called(ctx, tx, teamID)
+	notificationRoutes, _ := args.Get(0).([]models.NotificationRoute)
+	return notificationRoutes, args.Error(1)
+}
+
+func (m *MockRepository) GetNotificationRouteByID(ctx context.Context, tx pgx.Tx, teamID, routeID int64) (*models.NotificationRoute, error) {
+	args := m.Called(ctx, tx, teamID, routeID)
+	notificationRoute, _ := args.Get(0).(*models.NotificationRoute)
+	return notificationRoute, args.Error(1)
+}
+
+func (m *MockRepository) UpdateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) (*models.NotificationRoute, error) {
+	args := m.Called(ctx, tx, route)
+	notificationRoute, _ := args.Get(0).(*models.NotificationRoute)
+	return notificationRoute, args.Error(1)
+}
+
+func (m *MockRepository) DeleteNotificationRoute(ctx context.Context, tx pgx.Tx, teamID, routeID int64) error {
+	args := m.Called(ctx, tx, teamID, routeID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64, parentIDs []int64) error {
+	args := m.Called(ctx, tx, monitorID, parentIDs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64) error {
+	args := m.Called(ctx, tx, monitorID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListMonitorDependenciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.MonitorDependency, error) {
+	args := m.Called(ctx, tx, teamID)
+	monitorDependencies, _ := args.Get(0).([]models.MonitorDependency)
+	return monitorDependencies, args.Error(1)
+}
+
+func (m *MockRepository) GetOpenIncidentByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64) (*models.Incident, error) {
+	args := m.Called(ctx, tx, monitorIDs)
+	incident, _ := args.Get(0).(*models.Incident)
+	return incident, args.Error(1)
+}
+
+func (m *MockRepository) IsDownstreamIncidentMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) (bool, error) {
+	args := m.Called(ctx, tx, incidentID, monitorID)
+	ok, _ := args.Get(0).(bool)
+	return ok, args.Error(1)
+}
+
+func (m *MockRepository) AttachDownstreamMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) error {
+	args := m.Called(ctx, tx, incidentID, monitorID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListTeamMembers(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamMemberWithUser, error) {
+	args := m.Called(ctx, tx, teamID)
+	teamMemberWithUsers, _ := args.Get(0).([]models.TeamMemberWithUser)
+	return teamMemberWithUsers, args.Error(1)
+}
+
+func (m *MockRepository) CountTeamOwners(ctx context.Context, tx pgx.Tx, teamID int64) (int, error) {
+	args := m.Called(ctx, tx, teamID)
+	count, _ := args.Get(0).(int)
+	return count, args.Error(1)
+}
+
+func (m *MockRepository) UpdateTeamMemberRole(ctx context.Context, tx pgx.Tx, teamID, userID int64, role models.MemberRole, updatedAt time.Time) (*models.TeamMember, error) {
+	args := m.Called(ctx, tx, teamID, userID, role, updatedAt)
+	teamMember, _ := args.Get(0).(*models.TeamMember)
+	return teamMember, args.Error(1)
+}
+
+func (m *MockRepository) DeleteTeamMember(ctx context.Context, tx pgx.Tx, teamID, userID int64) error {
+	args := m.Called(ctx, tx, teamID, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateTeamInvite(ctx context.Context, tx pgx.Tx, invite models.TeamInvite) error {
+	args := m.Called(ctx, tx, invite)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListPendingTeamInvitesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamInvite, error) {
+	args := m.Called(ctx, tx, teamID)
+	teamInvites, _ := args.Get(0).([]models.TeamInvite)
+	return teamInvites, args.Error(1)
+}
+
+func (m *MockRepository) GetTeamInviteByID(ctx context.Context, tx pgx.Tx, teamID, inviteID int64) (*models.TeamInvite, error) {
+	args := m.Called(ctx, tx, teamID, inviteID)
+	teamInvite, _ := args.Get(0).(*models.TeamInvite)
+	return teamInvite, args.Error(1)
+}
+
+func (m *MockRepository) GetPendingTeamInviteByEmail(ctx context.Context, tx pgx.Tx, teamID int64, email string) (*models.TeamInvite, error) {
+	args := m.Called(ctx, tx, teamID, email)
+	teamInvite, _ := args.Get(0).(*models.TeamInvite)
+	return teamInvite, args.Error(1)
+}
+
+func (m *MockRepository) ListPendingTeamInvitesForUser(ctx context.Context, tx pgx.Tx, userID int64, now time.Time) ([]models.TeamInviteWithTeam, error) {
+	args := m.Called(ctx, tx, userID, now)
+	teamInviteWithTeams, _ := args.Get(0).([]models.TeamInviteWithTeam)
+	return teamInviteWithTeams, args.Error(1)
+}
+
+func (m *MockRepository) GetPendingTeamInviteForUser(ctx context.Context, tx pgx.Tx, inviteID, userID int64, now time.Time) (*models.TeamInvite, error) {
+	args := m.Called(ctx, tx, inviteID, userID, now)
+	teamInvite, _ := args.Get(0).(*models.TeamInvite)
+	return teamInvite, args.Error(1)
+}
+
+func (m *MockRepository) UpdateTeamInviteStatus(ctx context.Context, tx pgx.Tx, inviteID int64, status models.InviteStatus, invitedTo *int64, updatedAt time.Time) error {
+	args := m.Called(ctx, tx, inviteID, status, invitedTo, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) error {
+	args := m.Called(ctx, tx, key)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListAPIKeysByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.APIKey, error) {
+	args := m.Called(ctx, tx, teamID)
+	aPIKeies, _ := args.Get(0).([]models.APIKey)
+	return aPIKeies, args.Error(1)
+}
+
+func (m *MockRepository) GetAPIKeyByID(ctx context.Context, tx pgx.Tx, teamID, keyID int64) (*models.APIKey, error) {
+	args := m.Called(ctx, tx, teamID, keyID)
+	aPIKey, _ := args.Get(0).(*models.APIKey)
+	return aPIKey, args.Error(1)
+}
+
+func (m *MockRepository) GetAPIKeyByTokenHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*models.APIKey, error) {
+	args := m.Called(ctx, tx, tokenHash)
+	aPIKey, _ := args.Get(0).(*models.APIKey)
+	return aPIKey, args.Error(1)
+}
+
+func (m *MockRepository) UpdateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) (*models.APIKey, error) {
+	args := m.Called(ctx, tx, key)
+	aPIKey, _ := args.Get(0).(*models.APIKey)
+	return aPIKey, args.Error(1)
+}
+
+func (m *MockRepository) DeleteAPIKey(ctx context.Context, tx pgx.Tx, teamID, keyID int64) error {
+	args := m.Called(ctx, tx, teamID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) TouchAPIKeyLastUsed(ctx context.Context, tx pgx.Tx, keyID int64, usedAt time.Time) error {
+	args := m.Called(ctx, tx, keyID, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListSessionsByUserID(ctx context.Context, tx pgx.Tx, userID int64, issuedAfter time.Time) ([]models.Session, error) {
+	args := m.Called(ctx, tx, userID, issuedAfter)
+	sessions, _ := args.Get(0).([]models.Session)
+	return sessions, args.Error(1)
+}
+
+func (m *MockRepository) RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, userID, familyID int64, revokedAt time.Time) (int64, error) {
+	args := m.Called(ctx, tx, userID, familyID, revokedAt)
+	value, _ := args.Get(0).(int64)
+	return value, args.Error(1)
+}
+
+func (m *MockRepository) CreateSecurityEvent(ctx context.Context, tx pgx.Tx, event models.SecurityEvent) error {
+	args := m.Called(ctx, tx, event)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RevokeAllRefreshTokensByUserID(ctx context.Context, tx pgx.Tx, userID int64, revokedAt time.Time) (int64, error) {
+	args := m.Called(ctx, tx, userID, revokedAt)
+	value, _ := args.Get(0).(int64)
+	return value, args.Error(1)
+}
+
+func (m *MockRepository) GetRefreshTokenByAccessTokenID(ctx context.Context, tx pgx.Tx, accessTokenID string) (*models.RefreshToken, error) {
+	args := m.Called(ctx, tx, accessTokenID)
+	refreshToken, _ := args.Get(0).(*models.RefreshToken)
+	return refreshToken, args.Error(1)
+}
+
+func (m *MockRepository) ListIssuedAccessTokens(ctx context.Context, tx pgx.Tx, userID int64, familyID *int64, issuedAfter time.Time) ([]models.IssuedAccessToken, error) {
+	args := m.Called(ctx, tx, userID, familyID, issuedAfter)
+	issuedAccessTokens, _ := args.Get(0).([]models.IssuedAccessToken)
+	return issuedAccessTokens, args.Error(1)
+}
+
+func (m *MockRepository) GetUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) (*models.UserTOTP, error) {
+	args := m.Called(ctx, tx, userID)
+	userTOTP, _ := args.Get(0).(*models.UserTOTP)
+	return userTOTP, args.Error(1)
+}
+
+func (m *MockRepository) UpsertUserTOTP(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error {
+	args := m.Called(ctx, tx, totp)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateUserTOTPState(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error {
+	args := m.Called(ctx, tx, totp)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) error {
+	args := m.Called(ctx, tx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64) ([]models.RecoveryCode, error) {
+	args := m.Called(ctx, tx, userID)
+	recoveryCodes, _ := args.Get(0).([]models.RecoveryCode)
+	return recoveryCodes, args.Error(1)
+}
+
+func (m *MockRepository) ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, codes []models.RecoveryCode) error {
+	args := m.Called(ctx, tx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkRecoveryCodeUsed(ctx context.Context, tx pgx.Tx, codeID int64, usedAt time.Time) error {
+	args := m.Called(ctx, tx, codeID, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetAccountByProviderEmail(ctx context.Context, tx pgx.Tx, provider models.Provider, email string) (*models.Account, error) {
+	args := m.Called(ctx, tx, provider, email)
+	account, _ := args.Get(0).(*models.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockRepository) GetAccountByUserIDAndProvider(ctx context.Context, tx pgx.Tx, userID int64, provider models.Provider) (*models.Account, error) {
+	args := m.Called(ctx, tx, userID, provider)
+	account, _ := args.Get(0).(*models.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockRepository) GetVerifiedAccountByEmail(ctx context.Context, tx pgx.Tx, email string) (*models.Account, error) {
+	args := m.Called(ctx, tx, email)
+	account, _ := args.Get(0).(*models.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockRepository) MarkAccountEmailVerified(ctx context.Context, tx pgx.Tx, accountID int64, verifiedAt time.Time) error {
+	args := m.Called(ctx, tx, accountID, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateUserPassword(ctx context.Context, tx pgx.Tx, userID int64, passwordHash string, updatedAt time.Time) error {
+	args := m.Called(ctx, tx, userID, passwordHash, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateAccountToken(ctx context.Context, tx pgx.Tx, token models.AccountToken) error {
+	args := m.Called(ctx, tx, token)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetUsableAccountToken(ctx context.Context, tx pgx.Tx, tokenHash string, purpose models.AccountTokenPurpose, now time.Time) (*models.AccountTokenWithAccount, error) {
+	args := m.Called(ctx, tx, tokenHash, purpose, now)
+	accountTokenWithAccount, _ := args.Get(0).(*models.AccountTokenWithAccount)
+	return accountTokenWithAccount, args.Error(1)
+}
+
+func (m *MockRepository) UseAccountTokens(ctx context.Context, tx pgx.Tx, accountID int64, purpose models.AccountTokenPurpose, usedAt time.Time) error {
+	args := m.Called(ctx, tx, accountID, purpose, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateAuditEvent(ctx context.Context, tx pgx.Tx, event models.AuditEvent) error {
+	args := m.Called(ctx, tx, event)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListAuditEvents(ctx context.Context, tx pgx.Tx, teamID int64, filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	args := m.Called(ctx, tx, teamID, filter)
+	auditEvents, _ := args.Get(0).([]models.AuditEvent)
+	return auditEvents, args.Error(1)
+}
+
+func (m *MockRepository) GetOnCallOverrideByID(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) (*models.OnCallOverride, error) {
+	args := m.Called(ctx, tx, scheduleID, overrideID)
+	onCallOverride, _ := args.Get(0).(*models.OnCallOverride)
+	return onCallOverride, args.Error(1)
+}
+
+func (m *MockRepository) CreateStatusPageSubscriber(ctx context.Context, tx pgx.Tx, subscriber models.StatusPageSubscriber) error {
+	args := m.Called(ctx, tx, subscriber)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetStatusPageSubscriberByID(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) (*models.StatusPageSubscriber, error) {
+	args := m.Called(ctx, tx, statusPageID, subscriberID)
+	subscriber, _ := args.Get(0).(*models.StatusPageSubscriber)
+	return subscriber, args.Error(1)
+}
+
+func (m *MockRepository) GetStatusPageSubscriberByTarget(ctx context.Context, tx pgx.Tx, statusPageID int64, subscriberType models.StatusPageSubscriberType, target string) (*models.StatusPageSubscriber, error) {
+	args := m.Called(ctx, tx, statusPageID, subscriberType, target)
+	subscriber, _ := args.Get(0).(*models.StatusPageSubscriber)
+	return subscriber, args.Error(1)
+}
+
+func (m *MockRepository) ListStatusPageSubscribers(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageSubscriber, error) {
+	args := m.Called(ctx, tx, statusPageID)
+	subscribers, _ := args.Get(0).([]models.StatusPageSubscriber)
+	return subscribers, args.Error(1)
+}
+
+func (m *MockRepository) RenewStatusPageSubscriberConfirmation(ctx context.Context, tx pgx.Tx, subscriberID int64, monitorIDs []int64, tokenHash string, expiresAt, updatedAt time.Time) error {
+	args := m.Called(ctx, tx, subscriberID, monitorIDs, tokenHash, expiresAt, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ConfirmStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID int64, tokenHash string, now time.Time) (*models.StatusPageSubscriber, error) {
+	args := m.Called(ctx, tx, statusPageID, tokenHash, now)
+	subscriber, _ := args.Get(0).(*models.StatusPageSubscriber)
+	return subscriber, args.Error(1)
+}
+
+func (m *MockRepository) DeleteStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) error {
+	args := m.Called(ctx, tx, statusPageID, subscriberID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateStatusPageElementStatusOverride(ctx context.Context, tx pgx.Tx, statusPageID, elementID int64, override models.ComponentStatusOverride) error {
+	args := m.Called(ctx, tx, statusPageID, elementID, override)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) error {
+	args := m.Called(ctx, tx, maintenance)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetStatusPageMaintenanceByID(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) (*models.StatusPageMaintenance, error) {
+	args := m.Called(ctx, tx, statusPageID, maintenanceID)
+	maintenance, _ := args.Get(0).(*models.StatusPageMaintenance)
+	return maintenance, args.Error(1)
+}
+
+func (m *MockRepository) ListStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageMaintenance, error) {
+	args := m.Called(ctx, tx, statusPageID)
+	maintenances, _ := args.Get(0).([]models.StatusPageMaintenance)
+	return maintenances, args.Error(1)
+}
+
+func (m *MockRepository) ListUpcomingStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64, now time.Time) ([]models.StatusPageMaintenance, error) {
+	args := m.Called(ctx, tx, statusPageID, now)
+	maintenances, _ := args.Get(0).([]models.StatusPageMaintenance)
+	return maintenances, args.Error(1)
+}
+
+func (m *MockRepository) UpdateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) (*models.StatusPageMaintenance, error) {
+	args := m.Called(ctx, tx, maintenance)
+	updated, _ := args.Get(0).(*models.StatusPageMaintenance)
+	return updated, args.Error(1)
+}
+
+func (m *MockRepository) DeleteStatusPageMaintenance(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) error {
+	args := m.Called(ctx, tx, statusPageID, maintenanceID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpsertStatusPageImage(ctx context.Context, tx pgx.Tx, image models.StatusPageImage) error {
+	args := m.Called(ctx, tx, image)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) (*models.StatusPageImage, error) {
+	args := m.Called(ctx, tx, statusPageID, kind)
+	image, _ := args.Get(0).(*models.StatusPageImage)
+	return image, args.Error(1)
+}
+
+func (m *MockRepository) ListStatusPageImages(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageImage, error) {
+	args := m.Called(ctx, tx, statusPageID)
+	images, _ := args.Get(0).([]models.StatusPageImage)
+	return images, args.Error(1)
+}
+
+func (m *MockRepository) DeleteStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) error {
+	args := m.Called(ctx, tx, statusPageID, kind)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListStatusPageSubscribersForIncident(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.StatusPageSubscriberWithPage, error) {
+	args := m.Called(ctx, tx, incidentID)
+	subscribers, _ := args.Get(0).([]models.StatusPageSubscriberWithPage)
+	return subscribers, args.Error(1)
+}
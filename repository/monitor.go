@@ -14,8 +14,8 @@ import (
 // CreateMonitor inserts a monitor record.
 func (r *PGRepository) CreateMonitor(ctx context.Context, tx pgx.Tx, monitor models.Monitor) error {
 	query := `
-		INSERT INTO monitors (id, team_id, name, type, interval, config, last_checked, next_check, status, failure_threshold, recovery_threshold, updated_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO monitors (id, team_id, name, type, interval, config, last_checked, next_check, status, failure_threshold, recovery_threshold, escalation_policy_id, tags, flap_window, flap_start_threshold, flap_stop_threshold, latency_warn_ms, latency_critical_ms, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 	`
 
 	_, err := tx.Exec(ctx, query,
@@ -30,6 +30,13 @@ func (r *PGRepository) CreateMonitor(ctx context.Context, tx pgx.Tx, monitor mod
 		monitor.Status,
 		monitor.FailureThreshold,
 		monitor.RecoveryThreshold,
+		monitor.EscalationPolicyID,
+		monitor.Tags,
+		monitor.FlapWindow,
+		monitor.FlapStartThreshold,
+		monitor.FlapStopThreshold,
+		monitor.LatencyWarnMs,
+		monitor.LatencyCriticalMs,
 		monitor.UpdatedAt,
 		monitor.CreatedAt,
 	)
@@ -51,6 +58,13 @@ func (r *PGRepository) ListMonitorsByTeamID(ctx context.Context, tx pgx.Tx, team
 			m.status,
 			m.failure_threshold,
 			m.recovery_threshold,
+			m.escalation_policy_id,
+			m.tags,
+			m.flap_window,
+			m.flap_start_threshold,
+			m.flap_stop_threshold,
+			m.latency_warn_ms,
+			m.latency_critical_ms,
 			m.updated_at,
 			m.created_at,
 			COALESCE((
@@ -62,7 +76,12 @@ func (r *PGRepository) ListMonitorsByTeamID(ctx context.Context, tx pgx.Tx, team
 				SELECT array_agg(mr.region_id ORDER BY mr.id)
 				FROM monitor_regions mr
 				WHERE mr.monitor_id = m.id
-			), '{}') AS region_ids
+			), '{}') AS region_ids,
+			COALESCE((
+				SELECT array_agg(md.parent_id ORDER BY md.id)
+				FROM monitor_dependencies md
+				WHERE md.monitor_id = m.id
+			), '{}') AS parent_ids
 		FROM monitors m
 		WHERE m.team_id = $1
 		ORDER BY m.created_at DESC
@@ -95,6 +114,13 @@ func (r *PGRepository) ListMonitorsByIDs(ctx context.Context, tx pgx.Tx, teamID
 			m.status,
 			m.failure_threshold,
 			m.recovery_threshold,
+			m.escalation_policy_id,
+			m.tags,
+			m.flap_window,
+			m.flap_start_threshold,
+			m.flap_stop_threshold,
+			m.latency_warn_ms,
+			m.latency_critical_ms,
 			m.updated_at,
 			m.created_at,
 			COALESCE((
@@ -106,7 +132,12 @@ func (r *PGRepository) ListMonitorsByIDs(ctx context.Context, tx pgx.Tx, teamID
 				SELECT array_agg(mr.region_id ORDER BY mr.id)
 				FROM monitor_regions mr
 				WHERE mr.monitor_id = m.id
-			), '{}') AS region_ids
+			), '{}') AS region_ids,
+			COALESCE((
+				SELECT array_agg(md.parent_id ORDER BY md.id)
+				FROM monitor_dependencies md
+				WHERE md.monitor_id = m.id
+			), '{}') AS parent_ids
 		FROM monitors m
 		WHERE m.team_id = $1
 		  AND m.id = ANY($2)
@@ -136,6 +167,13 @@ func (r *PGRepository) GetMonitorByID(ctx context.Context, tx pgx.Tx, teamID, mo
 			m.status,
 			m.failure_threshold,
 			m.recovery_threshold,
+			m.escalation_policy_id,
+			m.tags,
+			m.flap_window,
+			m.flap_start_threshold,
+			m.flap_stop_threshold,
+			m.latency_warn_ms,
+			m.latency_critical_ms,
 			m.updated_at,
 			m.created_at,
 			COALESCE((
@@ -147,7 +185,12 @@ func (r *PGRepository) GetMonitorByID(ctx context.Context, tx pgx.Tx, teamID, mo
 				SELECT array_agg(mr.region_id ORDER BY mr.id)
 				FROM monitor_regions mr
 				WHERE mr.monitor_id = m.id
-			), '{}') AS region_ids
+			), '{}') AS region_ids,
+			COALESCE((
+				SELECT array_agg(md.parent_id ORDER BY md.id)
+				FROM monitor_dependencies md
+				WHERE md.monitor_id = m.id
+			), '{}') AS parent_ids
 		FROM monitors m
 		WHERE m.id = $1 AND m.team_id = $2
 	`
@@ -167,9 +210,11 @@ func (r *PGRepository) GetMonitorByID(ctx context.Context, tx pgx.Tx, teamID, mo
 func (r *PGRepository) UpdateMonitor(ctx context.Context, tx pgx.Tx, monitor models.Monitor) (*models.Monitor, error) {
 	query := `
 		UPDATE monitors
-		SET name = $1, type = $2, interval = $3, config = $4, last_checked = $5, next_check = $6, status = $7, failure_threshold = $8, recovery_threshold = $9, updated_at = $10
-		WHERE id = $11 AND team_id = $12
-		RETURNING id, team_id, name, type, interval, config, last_checked, next_check, status, failure_threshold, recovery_threshold, updated_at, created_at
+		SET name = $1, type = $2, interval = $3, config = $4, last_checked = $5, next_check = $6, status = $7, failure_threshold = $8, recovery_threshold = $9, escalation_policy_id = $10, tags = $11,
+			flap_window = $12, flap_start_threshold = $13, flap_stop_threshold = $14, latency_warn_ms = $15, latency_critical_ms = $16, updated_at = $17
+		WHERE id = $18 AND team_id = $19
+		RETURNING id, team_id, name, type, interval, config, last_checked, next_check, status, failure_threshold, recovery_threshold, escalation_policy_id, tags,
+			flap_window, flap_start_threshold, flap_stop_threshold, latency_warn_ms, latency_critical_ms, updated_at, created_at
 	`
 
 	var updated models.Monitor
@@ -183,6 +228,13 @@ func (r *PGRepository) UpdateMonitor(ctx context.Context, tx pgx.Tx, monitor mod
 		monitor.Status,
 		monitor.FailureThreshold,
 		monitor.RecoveryThreshold,
+		monitor.EscalationPolicyID,
+		monitor.Tags,
+		monitor.FlapWindow,
+		monitor.FlapStartThreshold,
+		monitor.FlapStopThreshold,
+		monitor.LatencyWarnMs,
+		monitor.LatencyCriticalMs,
 		monitor.UpdatedAt,
 		monitor.ID,
 		monitor.TeamID,
@@ -198,6 +250,13 @@ func (r *PGRepository) UpdateMonitor(ctx context.Context, tx pgx.Tx, monitor mod
 		&updated.Status,
 		&updated.FailureThreshold,
 		&updated.RecoveryThreshold,
+		&updated.EscalationPolicyID,
+		&updated.Tags,
+		&updated.FlapWindow,
+		&updated.FlapStartThreshold,
+		&updated.FlapStopThreshold,
+		&updated.LatencyWarnMs,
+		&updated.LatencyCriticalMs,
 		&updated.UpdatedAt,
 		&updated.CreatedAt,
 	); err != nil {
@@ -230,7 +289,7 @@ func (r *PGRepository) UpdateMonitorStatus(ctx context.Context, tx pgx.Tx, monit
 	return err
 }
 
-// ListMonitorsDueForCheck fetches all monitors where next_check <= now
+// ListMonitorsDueForCheck fetches all monitors where next_check <= now, skipping monitors of deleted teams
 func (r *PGRepository) ListMonitorsDueForCheck(ctx context.Context, tx pgx.Tx) ([]models.Monitor, error) {
 	query := `
 		SELECT
@@ -245,15 +304,28 @@ func (r *PGRepository) ListMonitorsDueForCheck(ctx context.Context, tx pgx.Tx) (
 			m.status,
 			m.failure_threshold,
 			m.recovery_threshold,
+			m.escalation_policy_id,
+			m.tags,
+			m.flap_window,
+			m.flap_start_threshold,
+			m.flap_stop_threshold,
+			m.latency_warn_ms,
+			m.latency_critical_ms,
 			m.updated_at,
 			m.created_at,
 			COALESCE((
 				SELECT array_agg(mr.region_id ORDER BY mr.id)
 				FROM monitor_regions mr
 				WHERE mr.monitor_id = m.id
-			), '{}') AS region_ids
+			), '{}') AS region_ids,
+			COALESCE((
+				SELECT array_agg(md.parent_id ORDER BY md.id)
+				FROM monitor_dependencies md
+				WHERE md.monitor_id = m.id
+			), '{}') AS parent_ids
 		FROM monitors m
-		WHERE m.next_check <= NOW()
+		INNER JOIN teams t ON t.id = m.team_id
+		WHERE m.next_check <= NOW() AND t.deleted_at IS NULL
 		ORDER BY m.next_check ASC
 	`
 
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/id"
+)
+
+// CreateMonitorDependencies records the parent monitors a monitor depends on.
+func (r *PGRepository) CreateMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64, parentIDs []int64) error {
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO monitor_dependencies (id, monitor_id, parent_id)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, parentID := range parentIDs {
+		junctionID, err := id.GetID()
+		if err != nil {
+			return fmt.Errorf("failed to generate junction table ID: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, junctionID, monitorID, parentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMonitorDependencies removes all parent associations for a monitor.
+func (r *PGRepository) DeleteMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64) error {
+	_, err := tx.Exec(ctx, `DELETE FROM monitor_dependencies WHERE monitor_id = $1`, monitorID)
+	return err
+}
+
+// ListMonitorDependenciesByTeamID returns every dependency edge between a team's monitors.
+func (r *PGRepository) ListMonitorDependenciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.MonitorDependency, error) {
+	query := `
+		SELECT md.id, md.monitor_id, md.parent_id
+		FROM monitor_dependencies md
+		INNER JOIN monitors m ON m.id = md.monitor_id
+		WHERE m.team_id = $1
+	`
+
+	var dependencies []models.MonitorDependency
+	if err := pgxscan.Select(ctx, tx, &dependencies, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// CreateNotificationRoute inserts a notification routing rule.
+func (r *PGRepository) CreateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) error {
+	query := `
+		INSERT INTO notification_routes (id, team_id, name, position, event_kinds, severities, region_ids, monitor_types, tags, notification_ids, timezone, quiet_hours_start, quiet_hours_end, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		route.ID,
+		route.TeamID,
+		route.Name,
+		route.Position,
+		route.EventKinds,
+		route.Severities,
+		route.RegionIDs,
+		route.MonitorTypes,
+		route.Tags,
+		route.NotificationIDs,
+		route.Timezone,
+		route.QuietHoursStart,
+		route.QuietHoursEnd,
+		route.UpdatedAt,
+		route.CreatedAt,
+	)
+	return err
+}
+
+// ListNotificationRoutesByTeamID returns a team's routing rules in evaluation order.
+func (r *PGRepository) ListNotificationRoutesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.NotificationRoute, error) {
+	query := `
+		SELECT id, team_id, name, position, event_kinds, severities, region_ids, monitor_types, tags, notification_ids, timezone, quiet_hours_start, quiet_hours_end, updated_at, created_at
+		FROM notification_routes
+		WHERE team_id = $1
+		ORDER BY position ASC, created_at ASC
+	`
+
+	var routes []models.NotificationRoute
+	if err := pgxscan.Select(ctx, tx, &routes, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// GetNotificationRouteByID fetches a routing rule ensuring it belongs to the provided team.
+func (r *PGRepository) GetNotificationRouteByID(ctx context.Context, tx pgx.Tx, teamID, routeID int64) (*models.NotificationRoute, error) {
+	query := `
+		SELECT id, team_id, name, position, event_kinds, severities, region_ids, monitor_types, tags, notification_ids, timezone, quiet_hours_start, quiet_hours_end, updated_at, created_at
+		FROM notification_routes
+		WHERE id = $1 AND team_id = $2
+	`
+
+	var route models.NotificationRoute
+	if err := pgxscan.Get(ctx, tx, &route, query, routeID, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &route, nil
+}
+
+// UpdateNotificationRoute updates a routing rule and returns the persisted record.
+func (r *PGRepository) UpdateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) (*models.NotificationRoute, error) {
+	query := `
+		UPDATE notification_routes
+		SET name = $1, position = $2, event_kinds = $3, severities = $4, region_ids = $5, monitor_types = $6, tags = $7,
+			notification_ids = $8, timezone = $9, quiet_hours_start = $10, quiet_hours_end = $11, updated_at = $12
+		WHERE id = $13 AND team_id = $14
+		RETURNING id, team_id, name, position, event_kinds, severities, region_ids, monitor_types, tags, notification_ids, timezone, quiet_hours_start, quiet_hours_end, updated_at, created_at
+	`
+
+	var updated models.NotificationRoute
+	if err := pgxscan.Get(ctx, tx, &updated, query,
+		route.Name,
+		route.Position,
+		route.EventKinds,
+		route.Severities,
+		route.RegionIDs,
+		route.MonitorTypes,
+		route.Tags,
+		route.NotificationIDs,
+		route.Timezone,
+		route.QuietHoursStart,
+		route.QuietHoursEnd,
+		route.UpdatedAt,
+		route.ID,
+		route.TeamID,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteNotificationRoute removes a routing rule belonging to a team.
+func (r *PGRepository) DeleteNotificationRoute(ctx context.Context, tx pgx.Tx, teamID, routeID int64) error {
+	result, err := tx.Exec(ctx, `DELETE FROM notification_routes WHERE id = $1 AND team_id = $2`, routeID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// CreateOnCallSchedule inserts an on-call schedule record.
+func (r *PGRepository) CreateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) error {
+	query := `
+		INSERT INTO on_call_schedules (id, team_id, name, timezone, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		schedule.ID,
+		schedule.TeamID,
+		schedule.Name,
+		schedule.Timezone,
+		schedule.UpdatedAt,
+		schedule.CreatedAt,
+	)
+	return err
+}
+
+// ListOnCallSchedulesByTeamID returns on-call schedules belonging to a team.
+func (r *PGRepository) ListOnCallSchedulesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.OnCallSchedule, error) {
+	query := `
+		SELECT id, team_id, name, timezone, updated_at, created_at
+		FROM on_call_schedules
+		WHERE team_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var schedules []models.OnCallSchedule
+	if err := pgxscan.Select(ctx, tx, &schedules, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// GetOnCallScheduleByID fetches an on-call schedule ensuring it belongs to the provided team.
+func (r *PGRepository) GetOnCallScheduleByID(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) (*models.OnCallSchedule, error) {
+	query := `
+		SELECT id, team_id, name, timezone, updated_at, created_at
+		FROM on_call_schedules
+		WHERE id = $1 AND team_id = $2
+	`
+
+	var schedule models.OnCallSchedule
+	if err := pgxscan.Get(ctx, tx, &schedule, query, scheduleID, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// UpdateOnCallSchedule updates an on-call schedule and returns the persisted record.
+func (r *PGRepository) UpdateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) (*models.OnCallSchedule, error) {
+	query := `
+		UPDATE on_call_schedules
+		SET name = $1, timezone = $2, updated_at = $3
+		WHERE id = $4 AND team_id = $5
+		RETURNING id, team_id, name, timezone, updated_at, created_at
+	`
+
+	var updated models.OnCallSchedule
+	if err := pgxscan.Get(ctx, tx, &updated, query,
+		schedule.Name,
+		schedule.Timezone,
+		schedule.UpdatedAt,
+		schedule.ID,
+		schedule.TeamID,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteOnCallSchedule removes an on-call schedule belonging to a team.
+func (r *PGRepository) DeleteOnCallSchedule(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) error {
+	result, err := tx.Exec(ctx, `DELETE FROM on_call_schedules WHERE id = $1 AND team_id = $2`, scheduleID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateOnCallLayers inserts rotation layers for a schedule.
+func (r *PGRepository) CreateOnCallLayers(ctx context.Context, tx pgx.Tx, layers []models.OnCallLayer) error {
+	if len(layers) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO on_call_schedule_layers (id, schedule_id, name, position, rotation_seconds, starts_at, user_ids, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, layer := range layers {
+		if _, err := tx.Exec(ctx, query,
+			layer.ID,
+			layer.ScheduleID,
+			layer.Name,
+			layer.Position,
+			layer.RotationSeconds,
+			layer.StartsAt,
+			layer.UserIDs,
+			layer.UpdatedAt,
+			layer.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteOnCallLayersByScheduleID removes all rotation layers of a schedule.
+func (r *PGRepository) DeleteOnCallLayersByScheduleID(ctx context.Context, tx pgx.Tx, scheduleID int64) error {
+	_, err := tx.Exec(ctx, `DELETE FROM on_call_schedule_layers WHERE schedule_id = $1`, scheduleID)
+	return err
+}
+
+// ListOnCallLayersByScheduleIDs returns the rotation layers of the given schedules ordered by position.
+func (r *PGRepository) ListOnCallLayersByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64) ([]models.OnCallLayer, error) {
+	if len(scheduleIDs) == 0 {
+		return []models.OnCallLayer{}, nil
+	}
+
+	query := `
+		SELECT id, schedule_id, name, position, rotation_seconds, starts_at, user_ids, updated_at, created_at
+		FROM on_call_schedule_layers
+		WHERE schedule_id = ANY($1)
+		ORDER BY schedule_id, position ASC
+	`
+
+	var layers []models.OnCallLayer
+	if err := pgxscan.Select(ctx, tx, &layers, query, scheduleIDs); err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
+
+// CreateOnCallOverride inserts a schedule override.
+func (r *PGRepository) CreateOnCallOverride(ctx context.Context, tx pgx.Tx, override models.OnCallOverride) error {
+	query := `
+		INSERT INTO on_call_schedule_overrides (id, schedule_id, user_id, starts_at, ends_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		override.ID,
+		override.ScheduleID,
+		override.UserID,
+		override.StartsAt,
+		override.EndsAt,
+		override.UpdatedAt,
+		override.CreatedAt,
+	)
+	return err
+}
+
+// GetOnCallOverrideByID returns an override of the given schedule, or nil when it does not exist.
+func (r *PGRepository) GetOnCallOverrideByID(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) (*models.OnCallOverride, error) {
+	query := `
+		SELECT id, schedule_id, user_id, starts_at, ends_at, updated_at, created_at
+		FROM on_call_schedule_overrides
+		WHERE id = $1 AND schedule_id = $2
+	`
+
+	var override models.OnCallOverride
+	if err := pgxscan.Get(ctx, tx, &override, query, overrideID, scheduleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+// DeleteOnCallOverride removes an override from a schedule.
+func (r *PGRepository) DeleteOnCallOverride(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) error {
+	result, err := tx.Exec(ctx, `DELETE FROM on_call_schedule_overrides WHERE id = $1 AND schedule_id = $2`, overrideID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListOnCallOverridesByScheduleIDs returns overrides of the given schedules that have not ended before since.
+func (r *PGRepository) ListOnCallOverridesByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64, since time.Time) ([]models.OnCallOverride, error) {
+	if len(scheduleIDs) == 0 {
+		return []models.OnCallOverride{}, nil
+	}
+
+	query := `
+		SELECT id, schedule_id, user_id, starts_at, ends_at, updated_at, created_at
+		FROM on_call_schedule_overrides
+		WHERE schedule_id = ANY($1) AND ends_at > $2
+		ORDER BY starts_at ASC
+	`
+
+	var overrides []models.OnCallOverride
+	if err := pgxscan.Select(ctx, tx, &overrides, query, scheduleIDs, since); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
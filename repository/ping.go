@@ -24,6 +24,7 @@ func (r *PGRepository) BatchInsertPings(ctx context.Context, tx pgx.Tx, pings []
 			ping.RegionID,
 			ping.Latency,
 			ping.Status,
+			ping.LatencyCriticalMs,
 		})
 	}
 
@@ -31,7 +32,7 @@ func (r *PGRepository) BatchInsertPings(ctx context.Context, tx pgx.Tx, pings []
 	copied, err := tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"pings"},
-		[]string{"time", "monitor_id", "region_id", "latency", "status"},
+		[]string{"time", "monitor_id", "region_id", "latency", "status", "latency_critical_ms"},
 		pgx.CopyFromRows(rows),
 	)
 	if err != nil {
@@ -21,15 +21,43 @@ type Repository interface {
 	UpdateStatusPage(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) (*models.StatusPage, error)
 	GetStatusPageByID(ctx context.Context, tx pgx.Tx, teamID, statusPageID int64) (*models.StatusPage, error)
 	GetStatusPageBySlug(ctx context.Context, tx pgx.Tx, slug string) (*models.StatusPage, error)
+	GetStatusPageIDBySlug(ctx context.Context, tx pgx.Tx, slug string) (*int64, error)
+	GetStatusPageByCustomDomain(ctx context.Context, tx pgx.Tx, domain string) (*models.StatusPage, error)
+	UpdateStatusPageCustomDomain(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) error
 	ListStatusPagesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.StatusPage, error)
 	ListStatusPageGroupsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageGroup, error)
 	ListStatusPageMonitorsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageMonitor, error)
+	ListAllStatusPageMonitors(ctx context.Context, tx pgx.Tx) ([]models.StatusPageMonitor, error)
 	ListMonitorsByIDs(ctx context.Context, tx pgx.Tx, teamID int64, monitorIDs []int64) ([]models.Monitor, error)
 	CreateStatusPageGroups(ctx context.Context, tx pgx.Tx, groups []models.StatusPageGroup) error
 	CreateStatusPageMonitors(ctx context.Context, tx pgx.Tx, monitors []models.StatusPageMonitor) error
 	DeleteStatusPage(ctx context.Context, tx pgx.Tx, teamID, statusPageID int64) error
 	DeleteStatusPageMonitorsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) error
+	GetStatusPageMonitorByBadgeKey(ctx context.Context, tx pgx.Tx, badgeKey string) (*models.StatusPageBadgeMonitor, error)
 	DeleteStatusPageGroupsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) error
+	UpdateStatusPageElementStatusOverride(ctx context.Context, tx pgx.Tx, statusPageID, elementID int64, override models.ComponentStatusOverride) error
+
+	// Status page maintenance
+	CreateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) error
+	GetStatusPageMaintenanceByID(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) (*models.StatusPageMaintenance, error)
+	ListStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageMaintenance, error)
+	ListUpcomingStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64, now time.Time) ([]models.StatusPageMaintenance, error)
+	UpdateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) (*models.StatusPageMaintenance, error)
+	DeleteStatusPageMaintenance(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) error
+	UpsertStatusPageImage(ctx context.Context, tx pgx.Tx, image models.StatusPageImage) error
+	GetStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) (*models.StatusPageImage, error)
+	ListStatusPageImages(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageImage, error)
+	DeleteStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) error
+
+	// Status page subscribers
+	CreateStatusPageSubscriber(ctx context.Context, tx pgx.Tx, subscriber models.StatusPageSubscriber) error
+	GetStatusPageSubscriberByID(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) (*models.StatusPageSubscriber, error)
+	GetStatusPageSubscriberByTarget(ctx context.Context, tx pgx.Tx, statusPageID int64, subscriberType models.StatusPageSubscriberType, target string) (*models.StatusPageSubscriber, error)
+	ListStatusPageSubscribers(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageSubscriber, error)
+	RenewStatusPageSubscriberConfirmation(ctx context.Context, tx pgx.Tx, subscriberID int64, monitorIDs []int64, tokenHash string, expiresAt, updatedAt time.Time) error
+	ConfirmStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID int64, tokenHash string, now time.Time) (*models.StatusPageSubscriber, error)
+	DeleteStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) error
+	ListStatusPageSubscribersForIncident(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.StatusPageSubscriberWithPage, error)
 
 	// Auth
 	GetUserByEmail(ctx context.Context, tx pgx.Tx, email string) (*models.User, error)
@@ -40,10 +68,42 @@ type Repository interface {
 	CreateUserAndAccount(ctx context.Context, tx pgx.Tx, user models.User, account models.Account) error
 	CreateOAuthToken(ctx context.Context, tx pgx.Tx, oauthToken models.OAuthToken) error
 	CreateRefreshToken(ctx context.Context, tx pgx.Tx, token models.RefreshToken) error
-	UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) error
+	UpdateRefreshTokenUsedAt(ctx context.Context, tx pgx.Tx, token models.RefreshToken) (bool, error)
+
+	// Sessions
+	ListSessionsByUserID(ctx context.Context, tx pgx.Tx, userID int64, issuedAfter time.Time) ([]models.Session, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, userID, familyID int64, revokedAt time.Time) (int64, error)
+	RevokeAllRefreshTokensByUserID(ctx context.Context, tx pgx.Tx, userID int64, revokedAt time.Time) (int64, error)
+	GetRefreshTokenByAccessTokenID(ctx context.Context, tx pgx.Tx, accessTokenID string) (*models.RefreshToken, error)
+	ListIssuedAccessTokens(ctx context.Context, tx pgx.Tx, userID int64, familyID *int64, issuedAfter time.Time) ([]models.IssuedAccessToken, error)
+	CreateSecurityEvent(ctx context.Context, tx pgx.Tx, event models.SecurityEvent) error
+
+	// Multi-factor authentication
+	GetUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) (*models.UserTOTP, error)
+	UpsertUserTOTP(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error
+	UpdateUserTOTPState(ctx context.Context, tx pgx.Tx, totp models.UserTOTP) error
+	DeleteUserTOTP(ctx context.Context, tx pgx.Tx, userID int64) error
+	ListUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64) ([]models.RecoveryCode, error)
+	ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, codes []models.RecoveryCode) error
+	MarkRecoveryCodeUsed(ctx context.Context, tx pgx.Tx, codeID int64, usedAt time.Time) error
+
+	// Password reset and email verification
+	GetAccountByProviderEmail(ctx context.Context, tx pgx.Tx, provider models.Provider, email string) (*models.Account, error)
+	GetAccountByUserIDAndProvider(ctx context.Context, tx pgx.Tx, userID int64, provider models.Provider) (*models.Account, error)
+	GetVerifiedAccountByEmail(ctx context.Context, tx pgx.Tx, email string) (*models.Account, error)
+	MarkAccountEmailVerified(ctx context.Context, tx pgx.Tx, accountID int64, verifiedAt time.Time) error
+	UpdateUserPassword(ctx context.Context, tx pgx.Tx, userID int64, passwordHash string, updatedAt time.Time) error
+	CreateAccountToken(ctx context.Context, tx pgx.Tx, token models.AccountToken) error
+	GetUsableAccountToken(ctx context.Context, tx pgx.Tx, tokenHash string, purpose models.AccountTokenPurpose, now time.Time) (*models.AccountTokenWithAccount, error)
+	UseAccountTokens(ctx context.Context, tx pgx.Tx, accountID int64, purpose models.AccountTokenPurpose, usedAt time.Time) error
+
+	// Audit log
+	CreateAuditEvent(ctx context.Context, tx pgx.Tx, event models.AuditEvent) error
+	ListAuditEvents(ctx context.Context, tx pgx.Tx, teamID int64, filter models.AuditEventFilter) ([]models.AuditEvent, error)
 
 	// Users
 	GetUserByID(ctx context.Context, tx pgx.Tx, userID int64) (*models.User, error)
+	GetUserEmailByID(ctx context.Context, tx pgx.Tx, userID int64) (string, error)
 
 	// Teams
 	ListTeamsByUserID(ctx context.Context, tx pgx.Tx, userID int64) ([]models.TeamWithRole, error)
@@ -52,7 +112,31 @@ type Repository interface {
 	CreateTeam(ctx context.Context, tx pgx.Tx, team models.Team) error
 	CreateTeamMember(ctx context.Context, tx pgx.Tx, member models.TeamMember) error
 	UpdateTeamName(ctx context.Context, tx pgx.Tx, teamID int64, name string, updatedAt time.Time) (*models.Team, error)
-	DeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64) error
+	SoftDeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64, deletedAt time.Time) (*models.Team, error)
+	RestoreTeam(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) (*models.Team, error)
+	PurgeDeletedTeams(ctx context.Context, tx pgx.Tx, deletedBefore time.Time) ([]int64, error)
+	ListTeamMembers(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamMemberWithUser, error)
+	CountTeamOwners(ctx context.Context, tx pgx.Tx, teamID int64) (int, error)
+	UpdateTeamMemberRole(ctx context.Context, tx pgx.Tx, teamID, userID int64, role models.MemberRole, updatedAt time.Time) (*models.TeamMember, error)
+	DeleteTeamMember(ctx context.Context, tx pgx.Tx, teamID, userID int64) error
+
+	// Team invites
+	CreateTeamInvite(ctx context.Context, tx pgx.Tx, invite models.TeamInvite) error
+	ListPendingTeamInvitesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamInvite, error)
+	GetTeamInviteByID(ctx context.Context, tx pgx.Tx, teamID, inviteID int64) (*models.TeamInvite, error)
+	GetPendingTeamInviteByEmail(ctx context.Context, tx pgx.Tx, teamID int64, email string) (*models.TeamInvite, error)
+	ListPendingTeamInvitesForUser(ctx context.Context, tx pgx.Tx, userID int64, now time.Time) ([]models.TeamInviteWithTeam, error)
+	GetPendingTeamInviteForUser(ctx context.Context, tx pgx.Tx, inviteID, userID int64, now time.Time) (*models.TeamInvite, error)
+	UpdateTeamInviteStatus(ctx context.Context, tx pgx.Tx, inviteID int64, status models.InviteStatus, invitedTo *int64, updatedAt time.Time) error
+
+	// API keys
+	CreateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) error
+	ListAPIKeysByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, tx pgx.Tx, teamID, keyID int64) (*models.APIKey, error)
+	GetAPIKeyByTokenHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*models.APIKey, error)
+	UpdateAPIKey(ctx context.Context, tx pgx.Tx, key models.APIKey) (*models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, tx pgx.Tx, teamID, keyID int64) error
+	TouchAPIKeyLastUsed(ctx context.Context, tx pgx.Tx, keyID int64, usedAt time.Time) error
 
 	// Notifications
 	ListNotificationsByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.Notification, error)
@@ -61,6 +145,13 @@ type Repository interface {
 	UpdateNotification(ctx context.Context, tx pgx.Tx, notification models.Notification) (*models.Notification, error)
 	DeleteNotification(ctx context.Context, tx pgx.Tx, teamID, notificationID int64) error
 
+	// Notification routes
+	CreateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) error
+	ListNotificationRoutesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.NotificationRoute, error)
+	GetNotificationRouteByID(ctx context.Context, tx pgx.Tx, teamID, routeID int64) (*models.NotificationRoute, error)
+	UpdateNotificationRoute(ctx context.Context, tx pgx.Tx, route models.NotificationRoute) (*models.NotificationRoute, error)
+	DeleteNotificationRoute(ctx context.Context, tx pgx.Tx, teamID, routeID int64) error
+
 	// Monitors
 	CreateMonitor(ctx context.Context, tx pgx.Tx, monitor models.Monitor) error
 	ListMonitorsByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.Monitor, error)
@@ -80,6 +171,11 @@ type Repository interface {
 	CreateMonitorRegions(ctx context.Context, tx pgx.Tx, monitorID int64, regions []models.Region) error
 	DeleteMonitorRegions(ctx context.Context, tx pgx.Tx, monitorID int64) error
 
+	// Monitor dependencies
+	CreateMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64, parentIDs []int64) error
+	DeleteMonitorDependencies(ctx context.Context, tx pgx.Tx, monitorID int64) error
+	ListMonitorDependenciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.MonitorDependency, error)
+
 	// Pings
 	BatchInsertPings(ctx context.Context, tx pgx.Tx, pings []models.Ping) error
 
@@ -90,6 +186,9 @@ type Repository interface {
 	GetOpenIncidentByMonitorID(ctx context.Context, tx pgx.Tx, monitorID int64) (*models.Incident, error)
 	CreateIncident(ctx context.Context, tx pgx.Tx, incident models.Incident) error
 	CreateIncidentMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) error
+	GetOpenIncidentByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64) (*models.Incident, error)
+	IsDownstreamIncidentMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) (bool, error)
+	AttachDownstreamMonitor(ctx context.Context, tx pgx.Tx, incidentID, monitorID int64) error
 	MarkIncidentResolved(ctx context.Context, tx pgx.Tx, incidentID int64, resolvedAt, updatedAt time.Time) error
 	CreateEventTimeline(ctx context.Context, tx pgx.Tx, timeline models.EventTimeline) error
 	GetLastEventTimeline(ctx context.Context, tx pgx.Tx, incidentID int64) (*models.EventTimeline, error)
@@ -99,11 +198,44 @@ type Repository interface {
 	GetIncidentByID(ctx context.Context, tx pgx.Tx, monitorID, incidentID int64) (*models.Incident, error)
 	GetIncidentByIDForTeam(ctx context.Context, tx pgx.Tx, teamID, incidentID int64) (*models.Incident, error)
 	ListEventTimelinesByIncidentID(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.EventTimeline, error)
+	ListEventTimelinesByIncidentIDs(ctx context.Context, tx pgx.Tx, incidentIDs []int64) ([]models.EventTimeline, error)
 	UpdateIncidentStatus(ctx context.Context, tx pgx.Tx, incidentID int64, status models.IncidentStatus, resolvedAt *time.Time, updatedAt time.Time) (*models.Incident, error)
 	UpdateIncidentSettings(ctx context.Context, tx pgx.Tx, incidentID int64, isPublic bool, autoResolve bool, updatedAt time.Time) (*models.Incident, error)
+	UpdateIncidentSeverity(ctx context.Context, tx pgx.Tx, incidentID int64, severity models.IncidentSeverity, updatedAt time.Time) (*models.Incident, error)
 	ListRecentPingsByMonitorIDAndRegion(ctx context.Context, tx pgx.Tx, monitorID int64, regionID int64, limit int) ([]models.Ping, error)
 	UpdateMonitorStatus(ctx context.Context, tx pgx.Tx, monitorID int64, status models.MonitorStatus, updatedAt time.Time) error
 
+	// Escalation policies
+	CreateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) error
+	ListEscalationPoliciesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.EscalationPolicy, error)
+	GetEscalationPolicyByID(ctx context.Context, tx pgx.Tx, teamID, policyID int64) (*models.EscalationPolicy, error)
+	UpdateEscalationPolicy(ctx context.Context, tx pgx.Tx, policy models.EscalationPolicy) (*models.EscalationPolicy, error)
+	DeleteEscalationPolicy(ctx context.Context, tx pgx.Tx, teamID, policyID int64) error
+	CreateEscalationSteps(ctx context.Context, tx pgx.Tx, steps []models.EscalationStep) error
+	DeleteEscalationStepsByPolicyID(ctx context.Context, tx pgx.Tx, policyID int64) error
+	ListEscalationStepsByPolicyIDs(ctx context.Context, tx pgx.Tx, policyIDs []int64) ([]models.EscalationStep, error)
+
+	// Incident escalations
+	CreateIncidentEscalations(ctx context.Context, tx pgx.Tx, escalations []models.IncidentEscalation) error
+	ListIncidentEscalationsByIncidentID(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.IncidentEscalation, error)
+	MarkIncidentEscalationNotified(ctx context.Context, tx pgx.Tx, taskID string, updatedAt time.Time) (bool, error)
+	CancelPendingIncidentEscalations(ctx context.Context, tx pgx.Tx, incidentID int64, updatedAt time.Time) ([]models.IncidentEscalation, error)
+	CancelPendingTeamIncidentEscalations(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) ([]models.IncidentEscalation, error)
+
+	// On-call schedules
+	CreateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) error
+	ListOnCallSchedulesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.OnCallSchedule, error)
+	GetOnCallScheduleByID(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) (*models.OnCallSchedule, error)
+	UpdateOnCallSchedule(ctx context.Context, tx pgx.Tx, schedule models.OnCallSchedule) (*models.OnCallSchedule, error)
+	DeleteOnCallSchedule(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64) error
+	CreateOnCallLayers(ctx context.Context, tx pgx.Tx, layers []models.OnCallLayer) error
+	DeleteOnCallLayersByScheduleID(ctx context.Context, tx pgx.Tx, scheduleID int64) error
+	ListOnCallLayersByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64) ([]models.OnCallLayer, error)
+	CreateOnCallOverride(ctx context.Context, tx pgx.Tx, override models.OnCallOverride) error
+	GetOnCallOverrideByID(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) (*models.OnCallOverride, error)
+	DeleteOnCallOverride(ctx context.Context, tx pgx.Tx, scheduleID, overrideID int64) error
+	ListOnCallOverridesByScheduleIDs(ctx context.Context, tx pgx.Tx, scheduleIDs []int64, since time.Time) ([]models.OnCallOverride, error)
+
 	// Analytics
 	GetMonitorAnalytics(ctx context.Context, tx pgx.Tx, monitorID int64, start time.Time, end time.Time, regionID *int64) ([]models.MonitorAnalyticsBucket, error)
 	ListMonitorDailySummaryByMonitorIDs(ctx context.Context, tx pgx.Tx, monitorIDs []int64, start time.Time, end time.Time) ([]models.MonitorDailySummary, error)
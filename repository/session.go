@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// ListSessionsByUserID returns the user's active sessions: refresh token families whose
+// latest token is unused, unrevoked and issued after the given time.
+func (r *PGRepository) ListSessionsByUserID(ctx context.Context, tx pgx.Tx, userID int64, issuedAfter time.Time) ([]models.Session, error) {
+	query := `
+		SELECT rt.family_id AS id, rt.user_agent, rt.ip, rt.created_at AS last_active_at,
+			(
+				SELECT MIN(f.created_at)
+				FROM refresh_tokens f
+				WHERE f.family_id = rt.family_id
+			) AS created_at
+		FROM refresh_tokens rt
+		WHERE rt.user_id = $1
+			AND rt.used_at IS NULL
+			AND rt.revoked_at IS NULL
+			AND rt.created_at > $2
+		ORDER BY rt.created_at DESC
+	`
+
+	var sessions []models.Session
+	if err := pgxscan.Select(ctx, tx, &sessions, query, userID, issuedAfter); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeRefreshTokenFamily revokes every token of a user's refresh token family
+// and returns how many tokens were still unrevoked.
+func (r *PGRepository) RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, userID, familyID int64, revokedAt time.Time) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE user_id = $2 AND family_id = $3 AND revoked_at IS NULL
+	`
+
+	cmd, err := tx.Exec(ctx, query, revokedAt, userID, familyID)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
+
+// RevokeAllRefreshTokensByUserID revokes every refresh token of a user, signing them out of all sessions,
+// and returns how many tokens were still unrevoked.
+func (r *PGRepository) RevokeAllRefreshTokensByUserID(ctx context.Context, tx pgx.Tx, userID int64, revokedAt time.Time) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+
+	cmd, err := tx.Exec(ctx, query, revokedAt, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
+
+// GetRefreshTokenByAccessTokenID retrieves the refresh token issued together with an access token
+func (r *PGRepository) GetRefreshTokenByAccessTokenID(ctx context.Context, tx pgx.Tx, accessTokenID string) (*models.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token, user_agent, ip, used_at, family_id, parent_id, revoked_at, access_token_id, created_at
+		FROM refresh_tokens
+		WHERE access_token_id = $1
+		LIMIT 1
+	`
+
+	var refreshToken models.RefreshToken
+	if err := pgxscan.Get(ctx, tx, &refreshToken, query, accessTokenID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+// ListIssuedAccessTokens returns the access tokens issued to a user after the given time,
+// limited to one refresh token family when familyID is set.
+func (r *PGRepository) ListIssuedAccessTokens(ctx context.Context, tx pgx.Tx, userID int64, familyID *int64, issuedAfter time.Time) ([]models.IssuedAccessToken, error) {
+	query := `
+		SELECT access_token_id, created_at
+		FROM refresh_tokens
+		WHERE user_id = $1
+			AND ($2::bigint IS NULL OR family_id = $2)
+			AND access_token_id IS NOT NULL
+			AND created_at > $3
+	`
+
+	var tokens []models.IssuedAccessToken
+	if err := pgxscan.Select(ctx, tx, &tokens, query, userID, familyID, issuedAfter); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// CreateSecurityEvent records a security-relevant event for a user.
+func (r *PGRepository) CreateSecurityEvent(ctx context.Context, tx pgx.Tx, event models.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, type, session_id, ip, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Type,
+		event.SessionID,
+		event.IP,
+		event.UserAgent,
+		event.CreatedAt,
+	)
+	return err
+}
@@ -12,8 +12,9 @@ import (
 // CreateStatusPage inserts a new status page.
 func (r *PGRepository) CreateStatusPage(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) error {
 	query := `
-		INSERT INTO status_pages (id, team_id, title, slug, icon, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO status_pages (id, team_id, title, slug, created_at, updated_at, visibility, password_hash, allowed_ip_ranges,
+			brand_color, background_color, text_color, header_links, footer_markdown, about_markdown, timezone, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
 	_, err := tx.Exec(ctx, query,
@@ -21,39 +22,74 @@ func (r *PGRepository) CreateStatusPage(ctx context.Context, tx pgx.Tx, statusPa
 		statusPage.TeamID,
 		statusPage.Title,
 		statusPage.Slug,
-		statusPage.Icon,
 		statusPage.CreatedAt,
 		statusPage.UpdatedAt,
+		statusPage.Visibility,
+		statusPage.PasswordHash,
+		statusPage.AllowedIPRanges,
+		statusPage.BrandColor,
+		statusPage.BackgroundColor,
+		statusPage.TextColor,
+		statusPage.HeaderLinks,
+		statusPage.FooterMarkdown,
+		statusPage.AboutMarkdown,
+		statusPage.Timezone,
+		statusPage.Language,
 	)
 
 	return err
 }
 
-// UpdateStatusPage updates slug/icon for a status page and returns the row.
+// UpdateStatusPage updates the settings of a status page and returns the row.
 func (r *PGRepository) UpdateStatusPage(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) (*models.StatusPage, error) {
 	query := `
 		UPDATE status_pages
-		SET title = $1, slug = $2, icon = $3, updated_at = $4
-		WHERE id = $5 AND team_id = $6
-		RETURNING id, team_id, title, slug, icon, created_at, updated_at
+		SET title = $1, slug = $2, updated_at = $3, visibility = $6, password_hash = $7, allowed_ip_ranges = $8,
+			brand_color = $9, background_color = $10, text_color = $11, header_links = $12, footer_markdown = $13, about_markdown = $14, timezone = $15, language = $16
+		WHERE id = $4 AND team_id = $5
+		RETURNING id, team_id, title, slug, created_at, updated_at, custom_domain, domain_verification_token, domain_verified_at, visibility, password_hash, allowed_ip_ranges,
+			brand_color, background_color, text_color, header_links, footer_markdown, about_markdown, timezone, language
 	`
 
 	var updated models.StatusPage
 	if err := tx.QueryRow(ctx, query,
 		statusPage.Title,
 		statusPage.Slug,
-		statusPage.Icon,
 		statusPage.UpdatedAt,
 		statusPage.ID,
 		statusPage.TeamID,
+		statusPage.Visibility,
+		statusPage.PasswordHash,
+		statusPage.AllowedIPRanges,
+		statusPage.BrandColor,
+		statusPage.BackgroundColor,
+		statusPage.TextColor,
+		statusPage.HeaderLinks,
+		statusPage.FooterMarkdown,
+		statusPage.AboutMarkdown,
+		statusPage.Timezone,
+		statusPage.Language,
 	).Scan(
 		&updated.ID,
 		&updated.TeamID,
 		&updated.Title,
 		&updated.Slug,
-		&updated.Icon,
 		&updated.CreatedAt,
 		&updated.UpdatedAt,
+		&updated.CustomDomain,
+		&updated.DomainVerificationToken,
+		&updated.DomainVerifiedAt,
+		&updated.Visibility,
+		&updated.PasswordHash,
+		&updated.AllowedIPRanges,
+		&updated.BrandColor,
+		&updated.BackgroundColor,
+		&updated.TextColor,
+		&updated.HeaderLinks,
+		&updated.FooterMarkdown,
+		&updated.AboutMarkdown,
+		&updated.Timezone,
+		&updated.Language,
 	); err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -67,7 +103,8 @@ func (r *PGRepository) UpdateStatusPage(ctx context.Context, tx pgx.Tx, statusPa
 // GetStatusPageByID fetches a status page ensuring it belongs to the team.
 func (r *PGRepository) GetStatusPageByID(ctx context.Context, tx pgx.Tx, teamID, statusPageID int64) (*models.StatusPage, error) {
 	query := `
-		SELECT id, team_id, title, slug, icon, created_at, updated_at
+		SELECT id, team_id, title, slug, created_at, updated_at, custom_domain, domain_verification_token, domain_verified_at, visibility, password_hash, allowed_ip_ranges,
+			brand_color, background_color, text_color, header_links, footer_markdown, about_markdown, timezone, language
 		FROM status_pages
 		WHERE id = $1 AND team_id = $2
 	`
@@ -84,15 +121,62 @@ func (r *PGRepository) GetStatusPageByID(ctx context.Context, tx pgx.Tx, teamID,
 }
 
 // GetStatusPageBySlug returns a status page matching the slug.
+// Pages of soft-deleted teams are treated as missing.
 func (r *PGRepository) GetStatusPageBySlug(ctx context.Context, tx pgx.Tx, slug string) (*models.StatusPage, error) {
 	query := `
-		SELECT id, team_id, title, slug, icon, created_at, updated_at
+		SELECT sp.id, sp.team_id, sp.title, sp.slug, sp.created_at, sp.updated_at, sp.custom_domain, sp.domain_verification_token, sp.domain_verified_at,
+			sp.visibility, sp.password_hash, sp.allowed_ip_ranges, sp.brand_color, sp.background_color, sp.text_color, sp.header_links,
+			sp.footer_markdown, sp.about_markdown, sp.timezone, sp.language
+		FROM status_pages sp
+		INNER JOIN teams t ON t.id = sp.team_id
+		WHERE sp.slug = $1 AND t.deleted_at IS NULL
+	`
+
+	var statusPage models.StatusPage
+	if err := pgxscan.Get(ctx, tx, &statusPage, query, slug); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &statusPage, nil
+}
+
+// GetStatusPageIDBySlug returns the ID of the status page holding the slug, or nil when it is free.
+// Unlike GetStatusPageBySlug it includes pages of soft-deleted teams, which still hold their slug.
+func (r *PGRepository) GetStatusPageIDBySlug(ctx context.Context, tx pgx.Tx, slug string) (*int64, error) {
+	query := `
+		SELECT id
 		FROM status_pages
 		WHERE slug = $1
 	`
 
+	var statusPageID int64
+	if err := tx.QueryRow(ctx, query, slug).Scan(&statusPageID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &statusPageID, nil
+}
+
+// GetStatusPageByCustomDomain returns the status page that verified the custom domain.
+// Pages of soft-deleted teams are treated as missing.
+func (r *PGRepository) GetStatusPageByCustomDomain(ctx context.Context, tx pgx.Tx, domain string) (*models.StatusPage, error) {
+	query := `
+		SELECT sp.id, sp.team_id, sp.title, sp.slug, sp.created_at, sp.updated_at, sp.custom_domain, sp.domain_verification_token, sp.domain_verified_at,
+			sp.visibility, sp.password_hash, sp.allowed_ip_ranges, sp.brand_color, sp.background_color, sp.text_color, sp.header_links,
+			sp.footer_markdown, sp.about_markdown, sp.timezone, sp.language
+		FROM status_pages sp
+		INNER JOIN teams t ON t.id = sp.team_id
+		WHERE sp.custom_domain = $1 AND sp.domain_verified_at IS NOT NULL AND t.deleted_at IS NULL
+	`
+
 	var statusPage models.StatusPage
-	if err := pgxscan.Get(ctx, tx, &statusPage, query, slug); err != nil {
+	if err := pgxscan.Get(ctx, tx, &statusPage, query, domain); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
@@ -102,10 +186,38 @@ func (r *PGRepository) GetStatusPageBySlug(ctx context.Context, tx pgx.Tx, slug
 	return &statusPage, nil
 }
 
+// UpdateStatusPageCustomDomain sets the custom domain of a status page and where its verification stands.
+func (r *PGRepository) UpdateStatusPageCustomDomain(ctx context.Context, tx pgx.Tx, statusPage models.StatusPage) error {
+	query := `
+		UPDATE status_pages
+		SET custom_domain = $1, domain_verification_token = $2, domain_verified_at = $3, updated_at = $4
+		WHERE id = $5 AND team_id = $6
+	`
+
+	result, err := tx.Exec(ctx, query,
+		statusPage.CustomDomain,
+		statusPage.DomainVerificationToken,
+		statusPage.DomainVerifiedAt,
+		statusPage.UpdatedAt,
+		statusPage.ID,
+		statusPage.TeamID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
 // ListStatusPagesByTeamID returns status pages belonging to a team.
 func (r *PGRepository) ListStatusPagesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.StatusPage, error) {
 	query := `
-		SELECT id, team_id, title, slug, icon, created_at, updated_at
+		SELECT id, team_id, title, slug, created_at, updated_at, custom_domain, domain_verification_token, domain_verified_at, visibility, password_hash, allowed_ip_ranges,
+			brand_color, background_color, text_color, header_links, footer_markdown, about_markdown, timezone, language
 		FROM status_pages
 		WHERE team_id = $1
 		ORDER BY created_at DESC
@@ -122,7 +234,7 @@ func (r *PGRepository) ListStatusPagesByTeamID(ctx context.Context, tx pgx.Tx, t
 // ListStatusPageGroupsByStatusPageID returns groups for a status page.
 func (r *PGRepository) ListStatusPageGroupsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageGroup, error) {
 	query := `
-		SELECT id, status_page_id, name, type, sort_order
+		SELECT id, status_page_id, name, type, sort_order, status_override, status_override_expires_at
 		FROM status_page_groups
 		WHERE status_page_id = $1
 		ORDER BY sort_order ASC
@@ -139,7 +251,8 @@ func (r *PGRepository) ListStatusPageGroupsByStatusPageID(ctx context.Context, t
 // ListStatusPageMonitorsByStatusPageID returns monitors for a status page.
 func (r *PGRepository) ListStatusPageMonitorsByStatusPageID(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageMonitor, error) {
 	query := `
-		SELECT id, status_page_id, monitor_id, group_id, name, type, sort_order
+		SELECT id, status_page_id, monitor_id, group_id, name, type, sort_order, badge_key,
+			status_override, status_override_expires_at
 		FROM status_page_monitors
 		WHERE status_page_id = $1
 		ORDER BY group_id NULLS FIRST, sort_order ASC
@@ -153,6 +266,46 @@ func (r *PGRepository) ListStatusPageMonitorsByStatusPageID(ctx context.Context,
 	return monitors, nil
 }
 
+// ListAllStatusPageMonitors returns the monitors of every status page, grouped by page.
+func (r *PGRepository) ListAllStatusPageMonitors(ctx context.Context, tx pgx.Tx) ([]models.StatusPageMonitor, error) {
+	query := `
+		SELECT id, status_page_id, monitor_id, group_id, name, type, sort_order, badge_key,
+			status_override, status_override_expires_at
+		FROM status_page_monitors
+		ORDER BY status_page_id, group_id NULLS FIRST, sort_order ASC
+	`
+
+	var monitors []models.StatusPageMonitor
+	if err := pgxscan.Select(ctx, tx, &monitors, query); err != nil {
+		return nil, err
+	}
+
+	return monitors, nil
+}
+
+// GetStatusPageMonitorByBadgeKey returns the status page monitor whose badges use the key.
+// Badges of soft-deleted teams are treated as missing.
+func (r *PGRepository) GetStatusPageMonitorByBadgeKey(ctx context.Context, tx pgx.Tx, badgeKey string) (*models.StatusPageBadgeMonitor, error) {
+	query := `
+		SELECT spm.id, spm.status_page_id, spm.monitor_id, spm.group_id, spm.name, spm.type, spm.sort_order, spm.badge_key,
+			spm.status_override, spm.status_override_expires_at, sp.team_id, sp.visibility
+		FROM status_page_monitors spm
+		INNER JOIN status_pages sp ON sp.id = spm.status_page_id
+		INNER JOIN teams t ON t.id = sp.team_id
+		WHERE spm.badge_key = $1 AND t.deleted_at IS NULL
+	`
+
+	var monitor models.StatusPageBadgeMonitor
+	if err := pgxscan.Get(ctx, tx, &monitor, query, badgeKey); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &monitor, nil
+}
+
 // CreateStatusPageGroups bulk inserts groups for a status page.
 func (r *PGRepository) CreateStatusPageGroups(ctx context.Context, tx pgx.Tx, groups []models.StatusPageGroup) error {
 	if len(groups) == 0 {
@@ -160,8 +313,8 @@ func (r *PGRepository) CreateStatusPageGroups(ctx context.Context, tx pgx.Tx, gr
 	}
 
 	query := `
-		INSERT INTO status_page_groups (id, status_page_id, name, type, sort_order)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO status_page_groups (id, status_page_id, name, type, sort_order, status_override, status_override_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	for _, group := range groups {
@@ -171,6 +324,8 @@ func (r *PGRepository) CreateStatusPageGroups(ctx context.Context, tx pgx.Tx, gr
 			group.Name,
 			group.Type,
 			group.SortOrder,
+			group.StatusOverride,
+			group.StatusOverrideExpiresAt,
 		); err != nil {
 			return err
 		}
@@ -186,8 +341,9 @@ func (r *PGRepository) CreateStatusPageMonitors(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		INSERT INTO status_page_monitors (id, status_page_id, monitor_id, group_id, name, type, sort_order)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO status_page_monitors (id, status_page_id, monitor_id, group_id, name, type, sort_order, badge_key,
+			status_override, status_override_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	for _, monitor := range monitors {
@@ -199,6 +355,9 @@ func (r *PGRepository) CreateStatusPageMonitors(ctx context.Context, tx pgx.Tx,
 			monitor.Name,
 			monitor.Type,
 			monitor.SortOrder,
+			monitor.BadgeKey,
+			monitor.StatusOverride,
+			monitor.StatusOverrideExpiresAt,
 		); err != nil {
 			return err
 		}
@@ -207,6 +366,25 @@ func (r *PGRepository) CreateStatusPageMonitors(ctx context.Context, tx pgx.Tx,
 	return nil
 }
 
+// UpdateStatusPageElementStatusOverride sets or clears the status override of a group or monitor of
+// a status page. It returns pgx.ErrNoRows when the page has no element with the ID.
+func (r *PGRepository) UpdateStatusPageElementStatusOverride(ctx context.Context, tx pgx.Tx, statusPageID, elementID int64, override models.ComponentStatusOverride) error {
+	for _, query := range []string{
+		`UPDATE status_page_groups SET status_override = $1, status_override_expires_at = $2 WHERE id = $3 AND status_page_id = $4`,
+		`UPDATE status_page_monitors SET status_override = $1, status_override_expires_at = $2 WHERE id = $3 AND status_page_id = $4`,
+	} {
+		result, err := tx.Exec(ctx, query, override.StatusOverride, override.StatusOverrideExpiresAt, elementID, statusPageID)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() > 0 {
+			return nil
+		}
+	}
+
+	return pgx.ErrNoRows
+}
+
 // DeleteStatusPage removes a status page belonging to a team.
 func (r *PGRepository) DeleteStatusPage(ctx context.Context, tx pgx.Tx, teamID, statusPageID int64) error {
 	result, err := tx.Exec(ctx, `DELETE FROM status_pages WHERE id = $1 AND team_id = $2`, statusPageID, teamID)
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// UpsertStatusPageImage stores the logo or favicon of a status page, replacing the previous one.
+func (r *PGRepository) UpsertStatusPageImage(ctx context.Context, tx pgx.Tx, image models.StatusPageImage) error {
+	query := `
+		INSERT INTO status_page_images (status_page_id, kind, content_type, data, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (status_page_id, kind) DO UPDATE
+		SET content_type = EXCLUDED.content_type, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := tx.Exec(ctx, query,
+		image.StatusPageID,
+		image.Kind,
+		image.ContentType,
+		image.Data,
+		image.UpdatedAt,
+	)
+	return err
+}
+
+// GetStatusPageImage returns an image of a status page with its data.
+func (r *PGRepository) GetStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) (*models.StatusPageImage, error) {
+	query := `
+		SELECT status_page_id, kind, content_type, data, updated_at
+		FROM status_page_images
+		WHERE status_page_id = $1 AND kind = $2
+	`
+
+	var image models.StatusPageImage
+	if err := pgxscan.Get(ctx, tx, &image, query, statusPageID, kind); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+// ListStatusPageImages returns the images of a status page without their data.
+func (r *PGRepository) ListStatusPageImages(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageImage, error) {
+	query := `
+		SELECT status_page_id, kind, content_type, updated_at
+		FROM status_page_images
+		WHERE status_page_id = $1
+		ORDER BY kind
+	`
+
+	var images []models.StatusPageImage
+	if err := pgxscan.Select(ctx, tx, &images, query, statusPageID); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// DeleteStatusPageImage removes an image of a status page.
+func (r *PGRepository) DeleteStatusPageImage(ctx context.Context, tx pgx.Tx, statusPageID int64, kind models.StatusPageImageKind) error {
+	cmd, err := tx.Exec(ctx, `DELETE FROM status_page_images WHERE status_page_id = $1 AND kind = $2`, statusPageID, kind)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+const statusPageMaintenanceColumns = `id, status_page_id, title, message, monitor_ids, starts_at, ends_at, updated_at, created_at`
+
+// CreateStatusPageMaintenance inserts scheduled maintenance of a status page.
+func (r *PGRepository) CreateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) error {
+	query := `
+		INSERT INTO status_page_maintenances (id, status_page_id, title, message, monitor_ids, starts_at, ends_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		maintenance.ID,
+		maintenance.StatusPageID,
+		maintenance.Title,
+		maintenance.Message,
+		maintenance.MonitorIDs,
+		maintenance.StartsAt,
+		maintenance.EndsAt,
+		maintenance.UpdatedAt,
+		maintenance.CreatedAt,
+	)
+	return err
+}
+
+// GetStatusPageMaintenanceByID returns scheduled maintenance of the status page.
+func (r *PGRepository) GetStatusPageMaintenanceByID(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) (*models.StatusPageMaintenance, error) {
+	query := `SELECT ` + statusPageMaintenanceColumns + `
+		FROM status_page_maintenances
+		WHERE id = $1 AND status_page_id = $2
+	`
+
+	var maintenance models.StatusPageMaintenance
+	if err := pgxscan.Get(ctx, tx, &maintenance, query, maintenanceID, statusPageID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &maintenance, nil
+}
+
+// ListStatusPageMaintenances returns all scheduled maintenance of a status page, latest first.
+func (r *PGRepository) ListStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageMaintenance, error) {
+	query := `SELECT ` + statusPageMaintenanceColumns + `
+		FROM status_page_maintenances
+		WHERE status_page_id = $1
+		ORDER BY starts_at DESC, id DESC
+	`
+
+	var maintenances []models.StatusPageMaintenance
+	if err := pgxscan.Select(ctx, tx, &maintenances, query, statusPageID); err != nil {
+		return nil, err
+	}
+
+	return maintenances, nil
+}
+
+// ListUpcomingStatusPageMaintenances returns the maintenance of a status page that has not ended
+// by now, soonest first.
+func (r *PGRepository) ListUpcomingStatusPageMaintenances(ctx context.Context, tx pgx.Tx, statusPageID int64, now time.Time) ([]models.StatusPageMaintenance, error) {
+	query := `SELECT ` + statusPageMaintenanceColumns + `
+		FROM status_page_maintenances
+		WHERE status_page_id = $1 AND ends_at > $2
+		ORDER BY starts_at ASC, id ASC
+	`
+
+	var maintenances []models.StatusPageMaintenance
+	if err := pgxscan.Select(ctx, tx, &maintenances, query, statusPageID, now); err != nil {
+		return nil, err
+	}
+
+	return maintenances, nil
+}
+
+// UpdateStatusPageMaintenance updates scheduled maintenance of a status page.
+// It returns pgx.ErrNoRows when the maintenance does not exist.
+func (r *PGRepository) UpdateStatusPageMaintenance(ctx context.Context, tx pgx.Tx, maintenance models.StatusPageMaintenance) (*models.StatusPageMaintenance, error) {
+	query := `
+		UPDATE status_page_maintenances
+		SET title = $1, message = $2, monitor_ids = $3, starts_at = $4, ends_at = $5, updated_at = $6
+		WHERE id = $7 AND status_page_id = $8
+		RETURNING ` + statusPageMaintenanceColumns
+
+	var updated models.StatusPageMaintenance
+	if err := pgxscan.Get(ctx, tx, &updated, query,
+		maintenance.Title,
+		maintenance.Message,
+		maintenance.MonitorIDs,
+		maintenance.StartsAt,
+		maintenance.EndsAt,
+		maintenance.UpdatedAt,
+		maintenance.ID,
+		maintenance.StatusPageID,
+	); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteStatusPageMaintenance removes scheduled maintenance of a status page.
+// It returns pgx.ErrNoRows when the maintenance does not exist.
+func (r *PGRepository) DeleteStatusPageMaintenance(ctx context.Context, tx pgx.Tx, statusPageID, maintenanceID int64) error {
+	cmd, err := tx.Exec(ctx, `DELETE FROM status_page_maintenances WHERE id = $1 AND status_page_id = $2`, maintenanceID, statusPageID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
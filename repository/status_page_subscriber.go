@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+const statusPageSubscriberColumns = `id, status_page_id, type, target, monitor_ids, confirmation_token_hash,
+	confirmation_expires_at, confirmed_at, updated_at, created_at`
+
+// CreateStatusPageSubscriber inserts a new status page subscriber.
+func (r *PGRepository) CreateStatusPageSubscriber(ctx context.Context, tx pgx.Tx, subscriber models.StatusPageSubscriber) error {
+	query := `
+		INSERT INTO status_page_subscribers (id, status_page_id, type, target, monitor_ids, confirmation_token_hash,
+			confirmation_expires_at, confirmed_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		subscriber.ID,
+		subscriber.StatusPageID,
+		subscriber.Type,
+		subscriber.Target,
+		subscriber.MonitorIDs,
+		subscriber.ConfirmationTokenHash,
+		subscriber.ConfirmationExpiresAt,
+		subscriber.ConfirmedAt,
+		subscriber.UpdatedAt,
+		subscriber.CreatedAt,
+	)
+	return err
+}
+
+// GetStatusPageSubscriberByID returns a subscriber of the status page.
+func (r *PGRepository) GetStatusPageSubscriberByID(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) (*models.StatusPageSubscriber, error) {
+	query := `SELECT ` + statusPageSubscriberColumns + `
+		FROM status_page_subscribers
+		WHERE id = $1 AND status_page_id = $2
+	`
+
+	var subscriber models.StatusPageSubscriber
+	if err := pgxscan.Get(ctx, tx, &subscriber, query, subscriberID, statusPageID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &subscriber, nil
+}
+
+// GetStatusPageSubscriberByTarget returns the subscriber of the status page with the given address or URL.
+func (r *PGRepository) GetStatusPageSubscriberByTarget(ctx context.Context, tx pgx.Tx, statusPageID int64, subscriberType models.StatusPageSubscriberType, target string) (*models.StatusPageSubscriber, error) {
+	query := `SELECT ` + statusPageSubscriberColumns + `
+		FROM status_page_subscribers
+		WHERE status_page_id = $1 AND type = $2 AND target = $3
+		FOR UPDATE
+	`
+
+	var subscriber models.StatusPageSubscriber
+	if err := pgxscan.Get(ctx, tx, &subscriber, query, statusPageID, subscriberType, target); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &subscriber, nil
+}
+
+// ListStatusPageSubscribers returns the subscribers of a status page, newest first.
+func (r *PGRepository) ListStatusPageSubscribers(ctx context.Context, tx pgx.Tx, statusPageID int64) ([]models.StatusPageSubscriber, error) {
+	query := `SELECT ` + statusPageSubscriberColumns + `
+		FROM status_page_subscribers
+		WHERE status_page_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var subscribers []models.StatusPageSubscriber
+	if err := pgxscan.Select(ctx, tx, &subscribers, query, statusPageID); err != nil {
+		return nil, err
+	}
+
+	return subscribers, nil
+}
+
+// RenewStatusPageSubscriberConfirmation replaces the confirmation token of a pending subscriber,
+// so only the latest confirmation email works.
+func (r *PGRepository) RenewStatusPageSubscriberConfirmation(ctx context.Context, tx pgx.Tx, subscriberID int64, monitorIDs []int64, tokenHash string, expiresAt, updatedAt time.Time) error {
+	query := `
+		UPDATE status_page_subscribers
+		SET monitor_ids = $1, confirmation_token_hash = $2, confirmation_expires_at = $3, updated_at = $4
+		WHERE id = $5 AND confirmed_at IS NULL
+	`
+
+	cmd, err := tx.Exec(ctx, query, monitorIDs, tokenHash, expiresAt, updatedAt, subscriberID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ConfirmStatusPageSubscriber confirms the pending subscriber holding an unexpired confirmation token.
+func (r *PGRepository) ConfirmStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID int64, tokenHash string, now time.Time) (*models.StatusPageSubscriber, error) {
+	query := `
+		UPDATE status_page_subscribers
+		SET confirmed_at = $1, confirmation_token_hash = NULL, confirmation_expires_at = NULL, updated_at = $1
+		WHERE status_page_id = $2 AND confirmation_token_hash = $3 AND confirmation_expires_at > $1
+		RETURNING ` + statusPageSubscriberColumns
+
+	var subscriber models.StatusPageSubscriber
+	if err := pgxscan.Get(ctx, tx, &subscriber, query, now, statusPageID, tokenHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &subscriber, nil
+}
+
+// DeleteStatusPageSubscriber removes a subscriber of the status page.
+func (r *PGRepository) DeleteStatusPageSubscriber(ctx context.Context, tx pgx.Tx, statusPageID, subscriberID int64) error {
+	cmd, err := tx.Exec(ctx, `DELETE FROM status_page_subscribers WHERE id = $1 AND status_page_id = $2`, subscriberID, statusPageID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListStatusPageSubscribersForIncident returns the confirmed subscribers of every status page showing
+// a monitor of the incident, skipping subscribers whose component filter leaves those monitors out.
+func (r *PGRepository) ListStatusPageSubscribersForIncident(ctx context.Context, tx pgx.Tx, incidentID int64) ([]models.StatusPageSubscriberWithPage, error) {
+	query := `
+		SELECT s.id, s.status_page_id, s.type, s.target, s.monitor_ids, s.confirmation_token_hash,
+			s.confirmation_expires_at, s.confirmed_at, s.updated_at, s.created_at,
+			sp.slug AS status_page_slug, sp.title AS status_page_title
+		FROM status_page_subscribers s
+		INNER JOIN status_pages sp ON sp.id = s.status_page_id
+		INNER JOIN teams t ON t.id = sp.team_id
+		WHERE s.confirmed_at IS NOT NULL
+			AND t.deleted_at IS NULL
+			AND EXISTS (
+				SELECT 1
+				FROM status_page_monitors spm
+				INNER JOIN incident_monitors im ON im.monitor_id = spm.monitor_id
+				WHERE spm.status_page_id = s.status_page_id
+					AND im.incident_id = $1
+					AND (cardinality(s.monitor_ids) = 0 OR spm.monitor_id = ANY(s.monitor_ids))
+			)
+		ORDER BY s.status_page_id, s.id
+	`
+
+	var subscribers []models.StatusPageSubscriberWithPage
+	if err := pgxscan.Select(ctx, tx, &subscribers, query, incidentID); err != nil {
+		return nil, err
+	}
+
+	return subscribers, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -9,10 +10,10 @@ import (
 	"github.com/yorukot/knocker/models"
 )
 
-// ListTeamsByUserID returns all teams the user is a member of.
+// ListTeamsByUserID returns all teams the user is a member of, including deleted teams that can still be restored.
 func (r *PGRepository) ListTeamsByUserID(ctx context.Context, tx pgx.Tx, userID int64) ([]models.TeamWithRole, error) {
 	query := `
-		SELECT t.id, t.name, t.updated_at, t.created_at, tm.role
+		SELECT t.id, t.name, t.deleted_at, t.updated_at, t.created_at, tm.role
 		FROM teams t
 		INNER JOIN team_members tm ON tm.team_id = t.id
 		WHERE tm.user_id = $1
@@ -27,10 +28,10 @@ func (r *PGRepository) ListTeamsByUserID(ctx context.Context, tx pgx.Tx, userID
 	return teams, nil
 }
 
-// GetTeamForUser returns the team if the user is a member of it.
+// GetTeamForUser returns the team if the user is a member of it, even while it is deleted.
 func (r *PGRepository) GetTeamForUser(ctx context.Context, tx pgx.Tx, teamID, userID int64) (*models.TeamWithRole, error) {
 	query := `
-		SELECT t.id, t.name, t.updated_at, t.created_at, tm.role
+		SELECT t.id, t.name, t.deleted_at, t.updated_at, t.created_at, tm.role
 		FROM teams t
 		INNER JOIN team_members tm ON tm.team_id = t.id
 		WHERE t.id = $1 AND tm.user_id = $2
@@ -41,6 +42,7 @@ func (r *PGRepository) GetTeamForUser(ctx context.Context, tx pgx.Tx, teamID, us
 	if err := tx.QueryRow(ctx, query, teamID, userID).Scan(
 		&team.ID,
 		&team.Name,
+		&team.DeletedAt,
 		&team.UpdatedAt,
 		&team.CreatedAt,
 		&team.Role,
@@ -55,11 +57,13 @@ func (r *PGRepository) GetTeamForUser(ctx context.Context, tx pgx.Tx, teamID, us
 }
 
 // GetTeamMemberByUserID returns the membership record for the user within the specified team.
+// Memberships of deleted teams are not returned, which locks the team until it is restored.
 func (r *PGRepository) GetTeamMemberByUserID(ctx context.Context, tx pgx.Tx, teamID, userID int64) (*models.TeamMember, error) {
 	query := `
-		SELECT id, team_id, user_id, role, updated_at, created_at
-		FROM team_members
-		WHERE team_id = $1 AND user_id = $2
+		SELECT tm.id, tm.team_id, tm.user_id, tm.role, tm.updated_at, tm.created_at
+		FROM team_members tm
+		INNER JOIN teams t ON t.id = tm.team_id
+		WHERE tm.team_id = $1 AND tm.user_id = $2 AND t.deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -109,13 +113,14 @@ func (r *PGRepository) UpdateTeamName(ctx context.Context, tx pgx.Tx, teamID int
 		UPDATE teams
 		SET name = $1, updated_at = $2
 		WHERE id = $3
-		RETURNING id, name, updated_at, created_at
+		RETURNING id, name, deleted_at, updated_at, created_at
 	`
 
 	var team models.Team
 	if err := tx.QueryRow(ctx, query, name, updatedAt, teamID).Scan(
 		&team.ID,
 		&team.Name,
+		&team.DeletedAt,
 		&team.UpdatedAt,
 		&team.CreatedAt,
 	); err != nil {
@@ -128,24 +133,59 @@ func (r *PGRepository) UpdateTeamName(ctx context.Context, tx pgx.Tx, teamID int
 	return &team, nil
 }
 
-// DeleteTeam removes a team and its direct membership/invite records.
-func (r *PGRepository) DeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64) error {
-	if _, err := tx.Exec(ctx, `DELETE FROM team_invites WHERE team_id = $1`, teamID); err != nil {
-		return err
-	}
+// SoftDeleteTeam marks a team as deleted. Its data is kept until PurgeDeletedTeams removes it.
+func (r *PGRepository) SoftDeleteTeam(ctx context.Context, tx pgx.Tx, teamID int64, deletedAt time.Time) (*models.Team, error) {
+	query := `
+		UPDATE teams
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+		RETURNING id, name, deleted_at, updated_at, created_at
+	`
 
-	if _, err := tx.Exec(ctx, `DELETE FROM team_members WHERE team_id = $1`, teamID); err != nil {
-		return err
+	var team models.Team
+	if err := pgxscan.Get(ctx, tx, &team, query, deletedAt, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	cmd, err := tx.Exec(ctx, `DELETE FROM teams WHERE id = $1`, teamID)
-	if err != nil {
-		return err
+	return &team, nil
+}
+
+// RestoreTeam clears the deletion mark of a team that has not been purged yet.
+func (r *PGRepository) RestoreTeam(ctx context.Context, tx pgx.Tx, teamID int64, updatedAt time.Time) (*models.Team, error) {
+	query := `
+		UPDATE teams
+		SET deleted_at = NULL, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+		RETURNING id, name, deleted_at, updated_at, created_at
+	`
+
+	var team models.Team
+	if err := pgxscan.Get(ctx, tx, &team, query, updatedAt, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+	return &team, nil
+}
+
+// PurgeDeletedTeams permanently removes teams deleted before the cutoff.
+// Everything the team owns, ping history included, goes with it through cascading foreign keys.
+func (r *PGRepository) PurgeDeletedTeams(ctx context.Context, tx pgx.Tx, deletedBefore time.Time) ([]int64, error) {
+	query := `
+		DELETE FROM teams
+		WHERE deleted_at IS NOT NULL AND deleted_at <= $1
+		RETURNING id
+	`
+
+	var teamIDs []int64
+	if err := pgxscan.Select(ctx, tx, &teamIDs, query, deletedBefore); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return teamIDs, nil
 }
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// CreateTeamInvite inserts a new team invite.
+func (r *PGRepository) CreateTeamInvite(ctx context.Context, tx pgx.Tx, invite models.TeamInvite) error {
+	query := `
+		INSERT INTO team_invites (id, team_id, invited_by, invited_to, email, role, status, expires_at, updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		invite.ID,
+		invite.TeamID,
+		invite.InvitedBy,
+		invite.InvitedTo,
+		invite.Email,
+		invite.Role,
+		invite.Status,
+		invite.ExpiresAt,
+		invite.UpdatedAt,
+		invite.CreatedAt,
+	)
+	return err
+}
+
+// ListPendingTeamInvitesByTeamID returns the pending invites of a team, newest first.
+func (r *PGRepository) ListPendingTeamInvitesByTeamID(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamInvite, error) {
+	query := `
+		SELECT id, team_id, invited_by, invited_to, email, role, status, expires_at, updated_at, created_at
+		FROM team_invites
+		WHERE team_id = $1 AND status = 'pending'
+		ORDER BY created_at DESC
+	`
+
+	var invites []models.TeamInvite
+	if err := pgxscan.Select(ctx, tx, &invites, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// GetTeamInviteByID returns an invite that belongs to the given team.
+func (r *PGRepository) GetTeamInviteByID(ctx context.Context, tx pgx.Tx, teamID, inviteID int64) (*models.TeamInvite, error) {
+	query := `
+		SELECT id, team_id, invited_by, invited_to, email, role, status, expires_at, updated_at, created_at
+		FROM team_invites
+		WHERE id = $1 AND team_id = $2
+		LIMIT 1
+	`
+
+	var invite models.TeamInvite
+	if err := pgxscan.Get(ctx, tx, &invite, query, inviteID, teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// GetPendingTeamInviteByEmail returns the pending invite for an email address within a team.
+func (r *PGRepository) GetPendingTeamInviteByEmail(ctx context.Context, tx pgx.Tx, teamID int64, email string) (*models.TeamInvite, error) {
+	query := `
+		SELECT id, team_id, invited_by, invited_to, email, role, status, expires_at, updated_at, created_at
+		FROM team_invites
+		WHERE team_id = $1 AND lower(email) = lower($2) AND status = 'pending'
+		LIMIT 1
+	`
+
+	var invite models.TeamInvite
+	if err := pgxscan.Get(ctx, tx, &invite, query, teamID, email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// ListPendingTeamInvitesForUser returns unexpired pending invites addressed to the user,
+// either directly or through any of the email addresses on their accounts.
+func (r *PGRepository) ListPendingTeamInvitesForUser(ctx context.Context, tx pgx.Tx, userID int64, now time.Time) ([]models.TeamInviteWithTeam, error) {
+	query := `
+		SELECT ti.id, ti.team_id, ti.invited_by, ti.invited_to, ti.email, ti.role, ti.status,
+			ti.expires_at, ti.updated_at, ti.created_at, t.name AS team_name
+		FROM team_invites ti
+		INNER JOIN teams t ON t.id = ti.team_id
+		WHERE ti.status = 'pending'
+			AND t.deleted_at IS NULL
+			AND ti.expires_at > $2
+			AND (
+				ti.invited_to = $1
+				OR lower(ti.email) IN (SELECT lower(email) FROM accounts WHERE user_id = $1 AND email_verified_at IS NOT NULL)
+			)
+		ORDER BY ti.created_at DESC
+	`
+
+	var invites []models.TeamInviteWithTeam
+	if err := pgxscan.Select(ctx, tx, &invites, query, userID, now); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// GetPendingTeamInviteForUser returns an unexpired pending invite if it is addressed to the user.
+func (r *PGRepository) GetPendingTeamInviteForUser(ctx context.Context, tx pgx.Tx, inviteID, userID int64, now time.Time) (*models.TeamInvite, error) {
+	query := `
+		SELECT id, team_id, invited_by, invited_to, email, role, status, expires_at, updated_at, created_at
+		FROM team_invites
+		WHERE id = $1
+			AND status = 'pending'
+			AND expires_at > $3
+			AND team_id IN (SELECT id FROM teams WHERE deleted_at IS NULL)
+			AND (
+				invited_to = $2
+				OR lower(email) IN (SELECT lower(email) FROM accounts WHERE user_id = $2 AND email_verified_at IS NOT NULL)
+			)
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	var invite models.TeamInvite
+	if err := pgxscan.Get(ctx, tx, &invite, query, inviteID, userID, now); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// UpdateTeamInviteStatus moves an invite to a new status and records who it ended up addressed to.
+func (r *PGRepository) UpdateTeamInviteStatus(ctx context.Context, tx pgx.Tx, inviteID int64, status models.InviteStatus, invitedTo *int64, updatedAt time.Time) error {
+	query := `
+		UPDATE team_invites
+		SET status = $1, invited_to = COALESCE($2, invited_to), updated_at = $3
+		WHERE id = $4
+	`
+
+	cmd, err := tx.Exec(ctx, query, status, invitedTo, updatedAt, inviteID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
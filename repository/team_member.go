@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/yorukot/knocker/models"
+)
+
+// ListTeamMembers returns all members of a team along with their profile and primary email.
+func (r *PGRepository) ListTeamMembers(ctx context.Context, tx pgx.Tx, teamID int64) ([]models.TeamMemberWithUser, error) {
+	query := `
+		SELECT tm.id, tm.team_id, tm.user_id, tm.role, tm.updated_at, tm.created_at,
+			u.display_name, u.avatar,
+			COALESCE((
+				SELECT a.email
+				FROM accounts a
+				WHERE a.user_id = u.id
+				ORDER BY a.created_at ASC
+				LIMIT 1
+			), '') AS email
+		FROM team_members tm
+		INNER JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_id = $1
+		ORDER BY tm.created_at ASC
+	`
+
+	var members []models.TeamMemberWithUser
+	if err := pgxscan.Select(ctx, tx, &members, query, teamID); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// CountTeamOwners returns the number of members holding the owner role in a team.
+func (r *PGRepository) CountTeamOwners(ctx context.Context, tx pgx.Tx, teamID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM team_members
+		WHERE team_id = $1 AND role = 'owner'
+	`
+
+	var count int
+	if err := tx.QueryRow(ctx, query, teamID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateTeamMemberRole changes the role of a team member.
+func (r *PGRepository) UpdateTeamMemberRole(ctx context.Context, tx pgx.Tx, teamID, userID int64, role models.MemberRole, updatedAt time.Time) (*models.TeamMember, error) {
+	query := `
+		UPDATE team_members
+		SET role = $1, updated_at = $2
+		WHERE team_id = $3 AND user_id = $4
+		RETURNING id, team_id, user_id, role, updated_at, created_at
+	`
+
+	var member models.TeamMember
+	if err := tx.QueryRow(ctx, query, role, updatedAt, teamID, userID).Scan(
+		&member.ID,
+		&member.TeamID,
+		&member.UserID,
+		&member.Role,
+		&member.UpdatedAt,
+		&member.CreatedAt,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// DeleteTeamMember removes a user from a team.
+func (r *PGRepository) DeleteTeamMember(ctx context.Context, tx pgx.Tx, teamID, userID int64) error {
+	cmd, err := tx.Exec(ctx, `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
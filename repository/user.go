@@ -35,3 +35,26 @@ func (r *PGRepository) GetUserByID(ctx context.Context, tx pgx.Tx, userID int64)
 
 	return &user, nil
 }
+
+// GetUserEmailByID returns the email of the user's oldest account, or an empty string if none exists.
+func (r *PGRepository) GetUserEmailByID(ctx context.Context, tx pgx.Tx, userID int64) (string, error) {
+	query := `
+		SELECT email
+		FROM accounts
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+		LIMIT 1`
+
+	var email string
+	err := tx.QueryRow(ctx, query, userID).Scan(&email)
+
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return email, nil
+}
@@ -15,16 +15,15 @@ import (
 )
 
 func Run(pgsql *pgxpool.Pool) {
-	redisAddr := fmt.Sprintf("%s:%s", config.Env().RedisHost, config.Env().RedisPort)
-	asynqClient := asynq.NewClient(asynq.RedisClientOpt{
-		Addr:     redisAddr,
-		Password: config.Env().RedisPassword,
-	})
+	asynqClient := asynq.NewClient(config.AsynqRedisOpt())
 	defer asynqClient.Close()
 
 	repo := repository.New(pgsql)
 	zap.L().Info("Starting scheduler")
 
+	go runTeamPurge(repo)
+	go runStatusPageTimelinePrecompute(repo)
+
 	// TODO: Implementing graceful shutdown
 	// Create ticker to run every 2 seconds
 	ticker := time.NewTicker(2 * time.Second)
@@ -0,0 +1,62 @@
+package schedular
+
+import (
+	"context"
+	"time"
+
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/repository"
+	"go.uber.org/zap"
+)
+
+// runStatusPageTimelinePrecompute keeps the daily timelines of status pages cached, so visitors
+// do not run the 90-day aggregate when the page itself is recomputed
+func runStatusPageTimelinePrecompute(repo repository.Repository) {
+	ticker := time.NewTicker(statuspagecore.TimelinePrecomputeInterval)
+	defer ticker.Stop()
+
+	precomputeStatusPageTimelines(repo)
+	for range ticker.C {
+		precomputeStatusPageTimelines(repo)
+	}
+}
+
+// precomputeStatusPageTimelines caches the daily summaries of every status page for the current window
+func precomputeStatusPageTimelines(repo repository.Repository) {
+	ctx := context.Background()
+
+	tx, err := repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to start transaction for status page timelines", zap.Error(err))
+		return
+	}
+	defer repo.DeferRollback(tx, ctx)
+
+	monitors, err := repo.ListAllStatusPageMonitors(ctx, tx)
+	if err != nil {
+		zap.L().Error("Failed to list status page monitors", zap.Error(err))
+		return
+	}
+
+	pageMonitorIDs := make(map[int64][]int64)
+	for _, monitor := range monitors {
+		pageMonitorIDs[monitor.StatusPageID] = append(pageMonitorIDs[monitor.StatusPageID], monitor.MonitorID)
+	}
+
+	start, end := statuspagecore.TimelineWindow(time.Now())
+	for pageID, monitorIDs := range pageMonitorIDs {
+		summaries, err := repo.ListMonitorDailySummaryByMonitorIDs(ctx, tx, monitorIDs, start, end)
+		if err != nil {
+			zap.L().Error("Failed to list daily summaries for status page", zap.Int64("status_page_id", pageID), zap.Error(err))
+			return
+		}
+		statuspagecore.CacheTimeline(ctx, pageID, monitorIDs, start, summaries)
+	}
+
+	if err := repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("Failed to commit status page timeline transaction", zap.Error(err))
+		return
+	}
+
+	zap.L().Debug("Precomputed status page timelines", zap.Int("count", len(pageMonitorIDs)))
+}
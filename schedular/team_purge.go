@@ -0,0 +1,52 @@
+package schedular
+
+import (
+	"context"
+	"time"
+
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/utils/config"
+	"go.uber.org/zap"
+)
+
+// teamPurgeInterval is how often deleted teams are checked for the end of their grace period
+const teamPurgeInterval = time.Hour
+
+// runTeamPurge permanently removes deleted teams once their grace period is over
+func runTeamPurge(repo repository.Repository) {
+	ticker := time.NewTicker(teamPurgeInterval)
+	defer ticker.Stop()
+
+	purgeDeletedTeams(repo)
+	for range ticker.C {
+		purgeDeletedTeams(repo)
+	}
+}
+
+// purgeDeletedTeams deletes every team whose grace period ended, along with everything it owns
+func purgeDeletedTeams(repo repository.Repository) {
+	ctx := context.Background()
+
+	tx, err := repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("Failed to start transaction for purging teams", zap.Error(err))
+		return
+	}
+	defer repo.DeferRollback(tx, ctx)
+
+	grace := time.Duration(config.Env().TeamDeletionGrace) * time.Second
+	teamIDs, err := repo.PurgeDeletedTeams(ctx, tx, time.Now().Add(-grace))
+	if err != nil {
+		zap.L().Error("Failed to purge deleted teams", zap.Error(err))
+		return
+	}
+
+	if err := repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("Failed to commit purge transaction", zap.Error(err))
+		return
+	}
+
+	if len(teamIDs) > 0 {
+		zap.L().Info("Purged deleted teams", zap.Int64s("team_ids", teamIDs))
+	}
+}
@@ -0,0 +1,179 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	authutil "github.com/yorukot/knocker/utils/auth"
+	"github.com/yorukot/knocker/utils/id"
+)
+
+// redactedValue replaces the value of redacted fields
+const redactedValue = `"[redacted]"`
+
+// ignoredFields change on every write and would only add noise to diffs
+var ignoredFields = []string{"updated_at"}
+
+// Entry describes a change to record. Before is nil for creations and After is nil for deletions.
+type Entry struct {
+	TeamID       int64
+	Action       models.AuditAction
+	ResourceType models.AuditResourceType
+	ResourceID   int64
+	Before       any
+	After        any
+	// Redact lists top-level JSON fields whose values must not be stored, e.g. channel credentials.
+	// A change to them is still recorded.
+	Redact []string
+}
+
+// Record appends an audit event for the request in the handler's transaction,
+// so the event is only kept if the change itself is committed.
+func Record(c echo.Context, repo repository.Repository, tx pgx.Tx, entry Entry) error {
+	event, err := newEvent(c, entry)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.CreateAuditEvent(c.Request().Context(), tx, event); err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+func newEvent(c echo.Context, entry Entry) (models.AuditEvent, error) {
+	eventID, err := id.GetID()
+	if err != nil {
+		return models.AuditEvent{}, fmt.Errorf("failed to generate audit event ID: %w", err)
+	}
+
+	actorUserID, err := authutil.GetUserIDFromContext(c)
+	if err != nil {
+		return models.AuditEvent{}, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+
+	actorAPIKeyID, err := authutil.GetAPIKeyIDFromContext(c)
+	if err != nil {
+		return models.AuditEvent{}, fmt.Errorf("failed to parse API key ID: %w", err)
+	}
+
+	before, after, err := Diff(entry.Before, entry.After, entry.Redact...)
+	if err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	var resourceID *int64
+	if entry.ResourceID != 0 {
+		resourceID = &entry.ResourceID
+	}
+
+	userAgent := c.Request().UserAgent()
+
+	return models.AuditEvent{
+		ID:            eventID,
+		TeamID:        entry.TeamID,
+		ActorUserID:   actorUserID,
+		ActorAPIKeyID: actorAPIKeyID,
+		Action:        entry.Action,
+		ResourceType:  entry.ResourceType,
+		ResourceID:    resourceID,
+		Before:        before,
+		After:         after,
+		IP:            net.ParseIP(c.RealIP()),
+		UserAgent:     &userAgent,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// Diff returns the JSON form of before and after reduced to the top-level fields that differ.
+// When only one side is given (a creation or a deletion) all of its fields are kept.
+func Diff(before, after any, redact ...string) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode audit before state: %w", err)
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode audit after state: %w", err)
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && bytes.Equal(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	for _, fieldSet := range []map[string]json.RawMessage{beforeFields, afterFields} {
+		for _, key := range ignoredFields {
+			delete(fieldSet, key)
+		}
+		for _, key := range redact {
+			if _, ok := fieldSet[key]; ok {
+				fieldSet[key] = json.RawMessage(redactedValue)
+			}
+		}
+	}
+
+	beforeJSON, err := encode(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterJSON, err := encode(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return beforeJSON, afterJSON, nil
+}
+
+// fields decodes the JSON form of value into its top-level fields, with compacted values so they compare reliably
+func fields(value any) (map[string]json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	for key, value := range decoded {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err != nil {
+			return nil, err
+		}
+		decoded[key] = compacted.Bytes()
+	}
+
+	return decoded, nil
+}
+
+func encode(fieldSet map[string]json.RawMessage) (json.RawMessage, error) {
+	if fieldSet == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(fieldSet)
+}
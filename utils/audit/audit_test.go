@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resource struct {
+	Name      string          `json:"name"`
+	Interval  int             `json:"interval"`
+	Config    json.RawMessage `json:"config"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func TestDiff_KeepsOnlyChangedFields(t *testing.T) {
+	before := resource{Name: "API", Interval: 60, Config: json.RawMessage(`{"url": "https://a"}`), UpdatedAt: time.Unix(1, 0)}
+	after := resource{Name: "API", Interval: 30, Config: json.RawMessage(`{"url":"https://a"}`), UpdatedAt: time.Unix(2, 0)}
+
+	beforeJSON, afterJSON, err := Diff(before, after)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"interval":60}`, string(beforeJSON))
+	assert.JSONEq(t, `{"interval":30}`, string(afterJSON))
+}
+
+func TestDiff_CreationKeepsEveryField(t *testing.T) {
+	after := resource{Name: "API", Interval: 60, Config: json.RawMessage(`{}`)}
+
+	beforeJSON, afterJSON, err := Diff(nil, &after)
+	require.NoError(t, err)
+
+	assert.Nil(t, beforeJSON)
+	assert.JSONEq(t, `{"name":"API","interval":60,"config":{}}`, string(afterJSON))
+}
+
+func TestDiff_RedactsFields(t *testing.T) {
+	before := resource{Name: "Alerts", Config: json.RawMessage(`{"bot_token":"old"}`)}
+	after := resource{Name: "Alerts", Config: json.RawMessage(`{"bot_token":"new"}`)}
+
+	beforeJSON, afterJSON, err := Diff(before, after, "config")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"config":"[redacted]"}`, string(beforeJSON))
+	assert.JSONEq(t, `{"config":"[redacted]"}`, string(afterJSON))
+}
+
+func TestDiff_NilPointerIsAbsent(t *testing.T) {
+	var deleted *resource
+
+	beforeJSON, afterJSON, err := Diff(resource{Name: "API"}, deleted)
+	require.NoError(t, err)
+
+	assert.NotNil(t, beforeJSON)
+	assert.Nil(t, afterJSON)
+}
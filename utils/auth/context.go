@@ -2,9 +2,11 @@ package auth
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/yorukot/knocker/api/middleware"
+	"github.com/yorukot/knocker/models"
 )
 
 // GetUserIDFromContext returns the user ID if present in context.
@@ -21,3 +23,45 @@ func GetUserIDFromContext(c echo.Context) (*int64, error) {
 
 	return &userID, nil
 }
+
+// IsAPIKeyRequest reports whether the request was authenticated with an API key.
+func IsAPIKeyRequest(c echo.Context) bool {
+	keyID, ok := c.Get(string(middleware.APIKeyIDKey)).(string)
+	return ok && keyID != ""
+}
+
+// GetAPIKeyIDFromContext returns the ID of the API key used for the request, or nil for other requests.
+func GetAPIKeyIDFromContext(c echo.Context) (*int64, error) {
+	keyIDStr, ok := c.Get(string(middleware.APIKeyIDKey)).(string)
+	if !ok || keyIDStr == "" {
+		return nil, nil
+	}
+
+	keyID, err := strconv.ParseInt(keyIDStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyID, nil
+}
+
+// GetAccessTokenFromContext returns the jti and expiry of the access token used for the request.
+// ok is false for API key requests and for tokens issued without a jti.
+func GetAccessTokenFromContext(c echo.Context) (tokenID string, expiresAt time.Time, ok bool) {
+	tokenID, _ = c.Get(string(middleware.AccessTokenIDKey)).(string)
+	expiresAt, _ = c.Get(string(middleware.AccessTokenExpiresAtKey)).(time.Time)
+	return tokenID, expiresAt, tokenID != ""
+}
+
+// GetTeamMemberFromContext returns the caller's membership of the route's team, or nil when it was not loaded.
+func GetTeamMemberFromContext(c echo.Context) *models.TeamMember {
+	member, _ := c.Get(string(middleware.TeamMemberKey)).(*models.TeamMember)
+	return member
+}
+
+// HasPermission reports whether the caller's team role allows action on resource.
+// It is always false when the route did not load the caller's membership.
+func HasPermission(c echo.Context, resource models.PermissionResource, action models.PermissionAction) bool {
+	member := GetTeamMemberFromContext(c)
+	return member != nil && member.Role.Can(resource, action)
+}
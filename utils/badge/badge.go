@@ -0,0 +1,111 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+)
+
+// Color is a badge color, named like the Shields.io palette so the JSON endpoint can pass it through
+type Color string
+
+// Color constants
+const (
+	ColorBrightGreen Color = "brightgreen"
+	ColorGreen       Color = "green"
+	ColorYellowGreen Color = "yellowgreen"
+	ColorYellow      Color = "yellow"
+	ColorOrange      Color = "orange"
+	ColorRed         Color = "red"
+	ColorLightGrey   Color = "lightgrey"
+	ColorBlue        Color = "blue"
+)
+
+var colorHex = map[Color]string{
+	ColorBrightGreen: "#4c1",
+	ColorGreen:       "#97ca00",
+	ColorYellowGreen: "#a4a61d",
+	ColorYellow:      "#dfb317",
+	ColorOrange:      "#fe7d37",
+	ColorRed:         "#e05d44",
+	ColorLightGrey:   "#9f9f9f",
+	ColorBlue:        "#007ec6",
+}
+
+// Badge is a two-part label/message badge in the style of Shields.io
+type Badge struct {
+	Label   string
+	Message string
+	Color   Color
+}
+
+// Endpoint is the Shields.io endpoint schema, see https://shields.io/badges/endpoint-badge
+type Endpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         Color  `json:"color"`
+}
+
+// Endpoint returns the badge as a Shields.io endpoint response
+func (b Badge) Endpoint() Endpoint {
+	return Endpoint{SchemaVersion: 1, Label: b.Label, Message: b.Message, Color: b.Color}
+}
+
+// SVG renders the badge in the flat Shields.io style
+func (b Badge) SVG() []byte {
+	fill, ok := colorHex[b.Color]
+	if !ok {
+		fill = colorHex[ColorLightGrey]
+	}
+
+	labelWidth := textWidth(b.Label) + 10
+	messageWidth := textWidth(b.Message) + 10
+	width := labelWidth + messageWidth
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, message)
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`,
+		labelWidth, labelWidth, messageWidth, fill, width)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" text-rendering="geometricPrecision" font-size="11">`)
+	writeText(&buf, float64(labelWidth)/2, label)
+	writeText(&buf, float64(labelWidth)+float64(messageWidth)/2, message)
+	buf.WriteString(`</g></svg>`)
+
+	return buf.Bytes()
+}
+
+// writeText writes a text with the drop shadow Shields.io badges use
+func writeText(buf *bytes.Buffer, x float64, text string) {
+	fmt.Fprintf(buf, `<text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`, x, text, x, text)
+}
+
+// textWidth estimates the width of text in 11px Verdana. It only has to be close: the text is centered.
+func textWidth(text string) int {
+	var width float64
+	for _, r := range text {
+		switch {
+		case r == ' ':
+			width += 3.9
+		case r == 'i' || r == 'l' || r == 'j' || r == '.' || r == ',' || r == ':' || r == ';' || r == '\'' || r == '|' || r == '!':
+			width += 3.5
+		case r == 'f' || r == 't' || r == 'r' || r == 'I' || r == '(' || r == ')' || r == '[' || r == ']' || r == '/' || r == '-':
+			width += 4.6
+		case r == 'm' || r == 'w' || r == 'M' || r == 'W' || r == '%':
+			width += 10.5
+		case r >= 'A' && r <= 'Z':
+			width += 7.5
+		case r >= '0' && r <= '9':
+			width += 7
+		default:
+			width += 6.6
+		}
+	}
+	return int(math.Ceil(width))
+}
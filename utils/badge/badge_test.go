@@ -0,0 +1,39 @@
+package badge
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVG_IsWellFormed(t *testing.T) {
+	svg := Badge{Label: "uptime 30d", Message: "99.95%", Color: ColorBrightGreen}.SVG()
+
+	var doc struct {
+		XMLName xml.Name `xml:"svg"`
+		Title   string   `xml:"title"`
+	}
+	require.NoError(t, xml.Unmarshal(svg, &doc))
+	require.Equal(t, "uptime 30d: 99.95%", doc.Title)
+	require.Contains(t, string(svg), `fill="#4c1"`)
+}
+
+func TestSVG_EscapesText(t *testing.T) {
+	svg := Badge{Label: `<script>`, Message: `"a" & b`, Color: "unknown"}.SVG()
+
+	require.NoError(t, xml.Unmarshal(svg, new(struct{})))
+	require.NotContains(t, string(svg), "<script>")
+	require.Contains(t, string(svg), `fill="#9f9f9f"`)
+}
+
+func TestSVG_WidthGrowsWithText(t *testing.T) {
+	require.Less(t, textWidth("up"), textWidth("operational"))
+	require.Equal(t, 0, textWidth(""))
+}
+
+func TestEndpoint(t *testing.T) {
+	endpoint := Badge{Label: "status", Message: "up", Color: ColorBrightGreen}.Endpoint()
+
+	require.Equal(t, Endpoint{SchemaVersion: 1, Label: "status", Message: "up", Color: ColorBrightGreen}, endpoint)
+}
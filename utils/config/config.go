@@ -15,6 +15,14 @@ const (
 	AppEnvProd AppEnv = "prod"
 )
 
+// TLSMode is how the API gets certificates for status page custom domains
+type TLSMode string
+
+const (
+	TLSModeNone TLSMode = "none" // TLS is terminated by a proxy in front of the API
+	TLSModeACME TLSMode = "acme" // The API issues certificates itself through ACME
+)
+
 // EnvConfig holds all environment variables for the application
 type EnvConfig struct {
 	AppEnv       AppEnv   `env:"APP_ENV" envDefault:"prod"`
@@ -27,6 +35,19 @@ type EnvConfig struct {
 	// Security Settings
 	JWTSecretKey   string `env:"JWT_SECRET_KEY,required" envDefault:"change_me_to_a_secure_key"`
 	FrontendDomain string `env:"FRONTEND_DOMAIN" envDefault:"localhost"`
+	FrontendURL    string `env:"FRONTEND_URL" envDefault:"http://localhost:5173"` // Base of links sent by email
+	// Proxies whose X-Forwarded-For is believed, as CIDR ranges. Without them the client IP is the
+	// peer address, so status page IP allowlists only work behind a proxy when it is listed here.
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+
+	// Status page custom domains, off unless STATUS_PAGE_DOMAIN_TARGET is set.
+	// Customers point a CNAME for their domain at the target host.
+	StatusPageDomainTarget string  `env:"STATUS_PAGE_DOMAIN_TARGET"`
+	TLSMode                TLSMode `env:"TLS_MODE" envDefault:"none"`
+	TLSPort                string  `env:"TLS_PORT" envDefault:"443"`
+	ACMEEmail              string  `env:"ACME_EMAIL"`
+	ACMEDirectoryURL       string  `env:"ACME_DIRECTORY_URL"` // Let's Encrypt when empty
+	ACMECacheDir           string  `env:"ACME_CACHE_DIR" envDefault:"certs"`
 
 	// PostgreSQL Settings
 	DBHost     string `env:"DB_HOST,required"`
@@ -36,6 +57,14 @@ type EnvConfig struct {
 	DBName     string `env:"DB_NAME,required"`
 	DBSSLMode  string `env:"DB_SSL_MODE,required"`
 
+	// SMTP Settings, emails are only logged when SMTP_HOST is empty
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM" envDefault:"Knocker <noreply@localhost>"`
+	SMTPTLS      string `env:"SMTP_TLS" envDefault:"starttls"` // starttls, tls or none
+
 	// Redis/Dragonfly Settings
 	RedisHost     string `env:"REDIS_HOST" envDefault:"localhost"`
 	RedisPort     string `env:"REDIS_PORT" envDefault:"6379"`
@@ -45,10 +74,16 @@ type EnvConfig struct {
 	OAuthStateExpiresAt   int `env:"OAUTH_STATE_EXPIRES_AT" envDefault:"600"`        // 10 minutes
 	AccessTokenExpiresAt  int `env:"ACCESS_TOKEN_EXPIRES_AT" envDefault:"900"`       // 15 minutes
 	RefreshTokenExpiresAt int `env:"REFRESH_TOKEN_EXPIRES_AT" envDefault:"31536000"` // 365 days
+	TeamDeletionGrace     int `env:"TEAM_DELETION_GRACE" envDefault:"2592000"`       // 30 days before a deleted team is purged
+
+	// OAuth login providers, configured through OAUTH_<KEY>_* variables (see oauth.go)
+	OAuthProviders []string `env:"OAUTH_PROVIDERS" envSeparator:","`
 
-	GoogleClientID     string `env:"GOOGLE_CLIENT_ID,required"`
-	GoogleClientSecret string `env:"GOOGLE_CLIENT_SECRET,required"`
-	GoogleRedirectURL  string `env:"GOOGLE_REDIRECT_URL,required"`
+	// Deprecated: use OAUTH_PROVIDERS=google with OAUTH_GOOGLE_* instead.
+	// Still honored so existing deployments keep Google login.
+	GoogleClientID     string `env:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string `env:"GOOGLE_CLIENT_SECRET"`
+	GoogleRedirectURL  string `env:"GOOGLE_REDIRECT_URL"`
 }
 
 var (
@@ -2,44 +2,215 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/caarlos0/env/v10"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/yorukot/knocker/models"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 )
 
+// OAuthProviderType is the protocol used to sign in with a provider
+type OAuthProviderType string
+
+// OAuthProviderType constants
+const (
+	OAuthProviderTypeOIDC   OAuthProviderType = "oidc"   // Any OpenID Connect issuer, configured through discovery
+	OAuthProviderTypeGitHub OAuthProviderType = "github" // GitHub or GitHub Enterprise
+	OAuthProviderTypeGitLab OAuthProviderType = "gitlab" // GitLab.com or a self-hosted GitLab
+)
+
+const (
+	googleIssuerURL = "https://accounts.google.com"
+	githubBaseURL   = "https://github.com"
+	gitlabBaseURL   = "https://gitlab.com"
+)
+
+var providerKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// oauthProviderEnv holds the OAUTH_<KEY>_* variables of one provider
+type oauthProviderEnv struct {
+	Type         OAuthProviderType `env:"TYPE"`
+	Name         string            `env:"NAME"`
+	ClientID     string            `env:"CLIENT_ID,required"`
+	ClientSecret string            `env:"CLIENT_SECRET,required"`
+	RedirectURL  string            `env:"REDIRECT_URL,required"`
+	IssuerURL    string            `env:"ISSUER_URL"` // OIDC only
+	BaseURL      string            `env:"BASE_URL"`   // GitHub Enterprise or self-hosted GitLab
+	Scopes       []string          `env:"SCOPES" envSeparator:","`
+}
+
+// OAuthProvider is a configured login provider
+type OAuthProvider struct {
+	Key    models.Provider
+	Name   string
+	Type   OAuthProviderType
+	OAuth2 *oauth2.Config
+	// OIDC is only set for OIDC providers
+	OIDC *oidc.Provider
+	// APIURL is the REST API root used to fetch the profile of GitHub and GitLab users
+	APIURL string
+}
+
 // OAuthConfig is the configuration for the OAuth providers
 type OAuthConfig struct {
-	Providers     map[models.Provider]*oauth2.Config
-	OIDCProviders map[models.Provider]*oidc.Provider
+	Providers map[models.Provider]*OAuthProvider
+	// Keys keeps the configured order for listing providers
+	Keys []models.Provider
 }
 
-// GetOAuthConfig returns the OAuth2 configuration for Google
+// GetOAuthConfig builds every provider listed in OAUTH_PROVIDERS.
+// With no providers configured only email/password login is available.
 func GetOAuthConfig() (*OAuthConfig, error) {
-	googleOauthConfig := &oauth2.Config{
-		RedirectURL:  Env().GoogleRedirectURL,
-		ClientID:     Env().GoogleClientID,
-		ClientSecret: Env().GoogleClientSecret,
-		Scopes:       []string{"openid", "email", "profile"},
-		Endpoint:     google.Endpoint,
+	oauthConfig := &OAuthConfig{
+		Providers: map[models.Provider]*OAuthProvider{},
+	}
+
+	for _, key := range Env().OAuthProviders {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		providerEnv, err := loadOAuthProviderEnv(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := oauthConfig.add(key, providerEnv); err != nil {
+			return nil, err
+		}
 	}
 
-	// Create OIDC provider for Google
-	ctx := context.Background()
-	googleOIDCProvider, err := oidc.NewProvider(ctx, "https://accounts.google.com")
-	if err != nil {
-		zap.L().Error("failed to create google oidc provider", zap.Error(err))
-		return nil, err
+	// Legacy Google settings from before providers were configurable
+	if _, ok := oauthConfig.Providers[models.ProviderGoogle]; !ok && Env().GoogleClientID != "" {
+		if err := oauthConfig.add(string(models.ProviderGoogle), oauthProviderEnv{
+			Type:         OAuthProviderTypeOIDC,
+			Name:         "Google",
+			ClientID:     Env().GoogleClientID,
+			ClientSecret: Env().GoogleClientSecret,
+			RedirectURL:  Env().GoogleRedirectURL,
+			IssuerURL:    googleIssuerURL,
+		}); err != nil {
+			return nil, err
+		}
 	}
 
-	return &OAuthConfig{
-		Providers: map[models.Provider]*oauth2.Config{
-			models.ProviderGoogle: googleOauthConfig,
-		},
-		OIDCProviders: map[models.Provider]*oidc.Provider{
-			models.ProviderGoogle: googleOIDCProvider,
+	return oauthConfig, nil
+}
+
+// loadOAuthProviderEnv reads the OAUTH_<KEY>_* variables of a provider
+func loadOAuthProviderEnv(key string) (oauthProviderEnv, error) {
+	if !providerKeyPattern.MatchString(key) || key == string(models.ProviderEmail) {
+		return oauthProviderEnv{}, fmt.Errorf("invalid oauth provider key %q", key)
+	}
+
+	prefix := "OAUTH_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_")) + "_"
+
+	var providerEnv oauthProviderEnv
+	if err := env.ParseWithOptions(&providerEnv, env.Options{Prefix: prefix}); err != nil {
+		return oauthProviderEnv{}, fmt.Errorf("invalid oauth provider %q: %w", key, err)
+	}
+
+	return providerEnv, nil
+}
+
+// add builds a provider and registers it under key
+func (o *OAuthConfig) add(key string, providerEnv oauthProviderEnv) error {
+	providerType := providerEnv.Type
+	if providerType == "" {
+		// The well-known keys need no explicit type
+		switch OAuthProviderType(key) {
+		case OAuthProviderTypeGitHub, OAuthProviderTypeGitLab:
+			providerType = OAuthProviderType(key)
+		default:
+			providerType = OAuthProviderTypeOIDC
+		}
+	}
+
+	if key == string(models.ProviderGoogle) && providerEnv.IssuerURL == "" {
+		providerEnv.IssuerURL = googleIssuerURL
+	}
+
+	name := providerEnv.Name
+	if name == "" {
+		name = key
+	}
+
+	provider := &OAuthProvider{
+		Key:  models.Provider(key),
+		Name: name,
+		Type: providerType,
+		OAuth2: &oauth2.Config{
+			ClientID:     providerEnv.ClientID,
+			ClientSecret: providerEnv.ClientSecret,
+			RedirectURL:  providerEnv.RedirectURL,
+			Scopes:       providerEnv.Scopes,
 		},
-	}, nil
+	}
+
+	switch providerType {
+	case OAuthProviderTypeOIDC:
+		if providerEnv.IssuerURL == "" {
+			return fmt.Errorf("oauth provider %q: issuer URL is required for oidc providers", key)
+		}
+
+		oidcProvider, err := oidc.NewProvider(context.Background(), providerEnv.IssuerURL)
+		if err != nil {
+			zap.L().Error("failed to discover oidc provider", zap.String("provider", key), zap.Error(err))
+			return fmt.Errorf("oauth provider %q: %w", key, err)
+		}
+
+		provider.OIDC = oidcProvider
+		provider.OAuth2.Endpoint = oidcProvider.Endpoint()
+		if len(provider.OAuth2.Scopes) == 0 {
+			provider.OAuth2.Scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+		}
+
+	case OAuthProviderTypeGitHub:
+		baseURL := strings.TrimRight(providerEnv.BaseURL, "/")
+		provider.APIURL = baseURL + "/api/v3"
+		if baseURL == "" {
+			baseURL = githubBaseURL
+			provider.APIURL = "https://api.github.com"
+		}
+
+		provider.OAuth2.Endpoint = oauth2.Endpoint{
+			AuthURL:  baseURL + "/login/oauth/authorize",
+			TokenURL: baseURL + "/login/oauth/access_token",
+		}
+		if len(provider.OAuth2.Scopes) == 0 {
+			provider.OAuth2.Scopes = []string{"read:user", "user:email"}
+		}
+
+	case OAuthProviderTypeGitLab:
+		baseURL := strings.TrimRight(providerEnv.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = gitlabBaseURL
+		}
+
+		provider.APIURL = baseURL + "/api/v4"
+		provider.OAuth2.Endpoint = oauth2.Endpoint{
+			AuthURL:  baseURL + "/oauth/authorize",
+			TokenURL: baseURL + "/oauth/token",
+		}
+		if len(provider.OAuth2.Scopes) == 0 {
+			provider.OAuth2.Scopes = []string{"read_user"}
+		}
+
+	default:
+		return fmt.Errorf("oauth provider %q: unknown type %q", key, providerType)
+	}
+
+	if _, exists := o.Providers[provider.Key]; exists {
+		return fmt.Errorf("oauth provider %q is configured twice", key)
+	}
+
+	o.Providers[provider.Key] = provider
+	o.Keys = append(o.Keys, provider.Key)
+
+	return nil
 }
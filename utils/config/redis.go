@@ -0,0 +1,15 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// AsynqRedisOpt returns the asynq connection options for the configured Redis/Dragonfly instance.
+func AsynqRedisOpt() asynq.RedisClientOpt {
+	return asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%s", Env().RedisHost, Env().RedisPort),
+		Password: Env().RedisPassword,
+	}
+}
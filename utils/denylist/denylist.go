@@ -0,0 +1,59 @@
+package denylist
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yorukot/knocker/models"
+)
+
+const keyPrefix = "auth:denylist:"
+
+var client *redis.Client
+
+// Init sets the Redis client used to store revoked access token IDs.
+// Must be called before using Add or Contains.
+func Init(redisClient *redis.Client) {
+	client = redisClient
+}
+
+// Add revokes an access token until it would have expired anyway.
+func Add(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if client == nil {
+		return errors.New("denylist is not initialized")
+	}
+
+	ttl := time.Until(expiresAt)
+	if tokenID == "" || ttl <= 0 {
+		return nil
+	}
+
+	return client.Set(ctx, keyPrefix+tokenID, 1, ttl).Err()
+}
+
+// AddIssued revokes a batch of issued access tokens that live for the given lifetime.
+func AddIssued(ctx context.Context, tokens []models.IssuedAccessToken, lifetime time.Duration) error {
+	for _, token := range tokens {
+		if err := Add(ctx, token.ID, token.IssuedAt.Add(lifetime)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Contains reports whether an access token has been revoked.
+func Contains(ctx context.Context, tokenID string) (bool, error) {
+	if client == nil {
+		return false, errors.New("denylist is not initialized")
+	}
+
+	n, err := client.Exists(ctx, keyPrefix+tokenID).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,38 @@
+package encrypt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix marks a bearer token as an API key rather than a JWT access token.
+const APIKeyPrefix = "kn_"
+
+// apiKeyDisplayLength is how many characters of the token are kept for display.
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// GenerateAPIKey generates a new API key and returns the plaintext token and its display prefix.
+func GenerateAPIKey() (token string, prefix string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	token = APIKeyPrefix + base64.URLEncoding.WithPadding(base64.NoPadding).EncodeToString(bytes)
+	return token, token[:apiKeyDisplayLength], nil
+}
+
+// IsAPIKey reports whether the token has the API key format.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// HashToken returns the hex-encoded SHA-256 hash of a token for storage and lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
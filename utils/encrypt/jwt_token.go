@@ -15,6 +15,7 @@ type JWTSecret struct {
 
 // AccessTokenClaims is the claims for the access token
 type AccessTokenClaims struct {
+	ID        string `json:"jti"`
 	Issuer    string `json:"iss"`
 	Subject   string `json:"sub"`
 	ExpiresAt int64  `json:"exp"`
@@ -22,8 +23,10 @@ type AccessTokenClaims struct {
 }
 
 // GenerateAccessToken generate an access token
-func (j *JWTSecret) GenerateAccessToken(issuer string, subject string, expiresAt time.Time) (string, error) {
+// tokenID becomes the jti claim, which is what the denylist revokes
+func (j *JWTSecret) GenerateAccessToken(issuer string, subject string, tokenID string, expiresAt time.Time) (string, error) {
 	claims := AccessTokenClaims{
+		ID:        tokenID,
 		Issuer:    issuer,
 		Subject:   subject,
 		ExpiresAt: expiresAt.Unix(),
@@ -32,6 +35,7 @@ func (j *JWTSecret) GenerateAccessToken(issuer string, subject string, expiresAt
 
 	// TODO: Maybe need a way to covert the struct to map
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti": claims.ID,
 		"iss": claims.Issuer,
 		"sub": claims.Subject,
 		"exp": claims.ExpiresAt,
@@ -56,6 +60,11 @@ func (j *JWTSecret) ValidateAccessTokenAndGetClaims(token string) (bool, AccessT
 		return false, AccessTokenClaims{}, err
 	}
 
+	// Other token kinds signed with the same secret (e.g. MFA challenges) carry a typ claim
+	if _, ok := claims["typ"]; ok {
+		return false, AccessTokenClaims{}, nil
+	}
+
 	// TODO: Maybe need a more clean way to covert the map to struct
 	issuer, ok := claims["iss"].(string)
 	if !ok {
@@ -77,7 +86,11 @@ func (j *JWTSecret) ValidateAccessTokenAndGetClaims(token string) (bool, AccessT
 		return false, AccessTokenClaims{}, nil
 	}
 
+	// Tokens issued before jti was introduced have none and simply cannot be revoked early
+	tokenID, _ := claims["jti"].(string)
+
 	accessTokenClaims := AccessTokenClaims{
+		ID:        tokenID,
 		Issuer:    issuer,
 		Subject:   subject,
 		ExpiresAt: int64(expiresAt),
@@ -154,3 +167,59 @@ func (j *JWTSecret) ValidateOAuthStateAndGetClaims(token string) (bool, OAuthSta
 
 	return true, oauthStateClaims, nil
 }
+
+// mfaChallengeType is the typ claim of MFA challenge tokens
+const mfaChallengeType = "mfa_challenge"
+
+// MFAChallengeClaims is the claims for the MFA challenge issued after a correct password
+type MFAChallengeClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// GenerateMFAChallenge generate an MFA challenge token for the user who passed the first factor
+func (j *JWTSecret) GenerateMFAChallenge(subject string, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ": mfaChallengeType,
+		"sub": subject,
+		"exp": expiresAt.Unix(),
+		"iat": time.Now().Unix(),
+	})
+
+	return token.SignedString([]byte(j.Secret))
+}
+
+// ValidateMFAChallengeAndGetClaims validate the MFA challenge token and get the claims
+func (j *JWTSecret) ValidateMFAChallengeAndGetClaims(token string) (bool, MFAChallengeClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		return []byte(j.Secret), nil
+	})
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenInvalidClaims) || errors.Is(err, jwt.ErrTokenExpired) {
+			return false, MFAChallengeClaims{}, nil
+		}
+
+		return false, MFAChallengeClaims{}, err
+	}
+
+	if tokenType, _ := claims["typ"].(string); tokenType != mfaChallengeType {
+		return false, MFAChallengeClaims{}, nil
+	}
+
+	subject, ok := claims["sub"].(string)
+	if !ok {
+		return false, MFAChallengeClaims{}, nil
+	}
+
+	expiresAt, ok := claims["exp"].(float64)
+	if !ok {
+		return false, MFAChallengeClaims{}, nil
+	}
+
+	return true, MFAChallengeClaims{
+		Subject:   subject,
+		ExpiresAt: int64(expiresAt),
+	}, nil
+}
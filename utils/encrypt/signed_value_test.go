@@ -0,0 +1,38 @@
+package encrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignValue_RoundTrip(t *testing.T) {
+	secret := &JWTSecret{Secret: "test-secret"}
+
+	token := secret.SignValue("unsubscribe", "42")
+	value, ok := secret.VerifySignedValue("unsubscribe", token)
+	require.True(t, ok)
+	require.Equal(t, "42", value)
+}
+
+func TestVerifySignedValue_Rejects(t *testing.T) {
+	secret := &JWTSecret{Secret: "test-secret"}
+	token := secret.SignValue("unsubscribe", "42")
+
+	cases := map[string]struct {
+		secret  *JWTSecret
+		purpose string
+		token   string
+	}{
+		"other purpose":  {secret, "confirm", token},
+		"other secret":   {&JWTSecret{Secret: "other"}, "unsubscribe", token},
+		"tampered value": {secret, "unsubscribe", "43" + token[2:]},
+		"no signature":   {secret, "unsubscribe", "42"},
+		"empty":          {secret, "unsubscribe", ""},
+	}
+
+	for name, tc := range cases {
+		_, ok := tc.secret.VerifySignedValue(tc.purpose, tc.token)
+		require.False(t, ok, name)
+	}
+}
@@ -0,0 +1,52 @@
+package encrypt
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 key used by the RFC 6238 test vectors
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 lists 8-digit codes; the 6-digit code is their last six digits
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range vectors {
+		code, err := GenerateTOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, want, code, "unix time %d", unix)
+	}
+}
+
+func TestValidateTOTP_AcceptsAdjacentStep(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, err := GenerateTOTPCode(rfc6238Secret, now.Add(-30*time.Second))
+	require.NoError(t, err)
+
+	step, ok, err := ValidateTOTP(rfc6238Secret, previous, now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, now.Unix()/30-1, step)
+
+	tooOld, err := GenerateTOTPCode(rfc6238Secret, now.Add(-90*time.Second))
+	require.NoError(t, err)
+	_, ok, err = ValidateTOTP(rfc6238Secret, tooOld, now)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("knocker", "alice@example.com", "JBSWY3DPEHPK3PXP")
+	require.Equal(t, "otpauth://totp/knocker:alice@example.com?algorithm=SHA1&digits=6&issuer=knocker&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/yorukot/knocker/utils/config"
+	"go.uber.org/zap"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer delivers emails
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+var (
+	mu      sync.RWMutex
+	current Mailer
+)
+
+// Init sets the mailer used by Send
+func Init(m Mailer) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
+
+// InitFromEnv sets up an SMTP mailer from the SMTP_* settings,
+// falling back to logging emails when no SMTP host is configured.
+func InitFromEnv() error {
+	cfg := config.Env()
+	if cfg.SMTPHost == "" {
+		zap.L().Warn("SMTP_HOST is not set, emails will only be logged")
+		Init(LogMailer{})
+		return nil
+	}
+
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		TLS:      SMTPTLSMode(cfg.SMTPTLS),
+	})
+	if err != nil {
+		return err
+	}
+
+	Init(m)
+	return nil
+}
+
+// Send delivers a message with the mailer set by Init
+func Send(ctx context.Context, message Message) error {
+	mu.RLock()
+	m := current
+	mu.RUnlock()
+
+	if m == nil {
+		return errors.New("mailer is not initialized")
+	}
+
+	return m.Send(ctx, message)
+}
+
+// LogMailer writes emails to the log instead of delivering them.
+// It is used when no SMTP server is configured, so links can still be picked up in development.
+type LogMailer struct{}
+
+// Send logs the message
+func (LogMailer) Send(_ context.Context, message Message) error {
+	zap.L().Warn("SMTP is not configured, email not delivered",
+		zap.String("to", message.To),
+		zap.String("subject", message.Subject),
+		zap.String("body", message.Text))
+	return nil
+}
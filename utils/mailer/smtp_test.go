@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts one session and records the envelope and message
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	go server.serve()
+
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			s.from = line
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			s.to = append(s.to, line)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(server.listener.Addr().String())
+	require.NoError(t, err)
+
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "Knocker <noreply@example.com>",
+		TLS:  SMTPTLSNone,
+	})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), Message{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Text:    "Hello\nOpen the link below.",
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "MAIL FROM:<noreply@example.com>", strings.SplitN(server.from, " BODY", 2)[0])
+	assert.Equal(t, []string{"RCPT TO:<user@example.com>"}, server.to)
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(server.data)))
+	header, err := reader.ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "Reset your password", header.Get("Subject"))
+	assert.Equal(t, "<user@example.com>", header.Get("To"))
+	assert.Contains(t, server.data, "Hello\nOpen the link below.")
+}
+
+func TestNewSMTPMailerRejectsInvalidConfig(t *testing.T) {
+	_, err := NewSMTPMailer(SMTPConfig{Host: "localhost", Port: "25", From: "not an address"})
+	assert.Error(t, err)
+
+	_, err = NewSMTPMailer(SMTPConfig{Host: "localhost", Port: "25", From: "noreply@example.com", TLS: "ssl"})
+	assert.Error(t, err)
+}
+
+func TestSendWithoutInit(t *testing.T) {
+	Init(nil)
+	assert.Error(t, Send(context.Background(), Message{To: "user@example.com"}))
+}
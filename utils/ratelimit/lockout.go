@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Lockout blocks a key after repeated failures, doubling the lock for every failure past the threshold.
+// Failures are forgotten after FailureWindow without another failure, or on Reset.
+type Lockout struct {
+	Name          string
+	Threshold     int64
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	FailureWindow time.Duration
+}
+
+func (l Lockout) failuresKey(id string) string {
+	return l.Name + ":failures:" + id
+}
+
+func (l Lockout) lockKey(id string) string {
+	return l.Name + ":locked:" + id
+}
+
+// Check returns how long id stays locked, or zero when it is not locked
+func (l Lockout) Check(ctx context.Context, id string) (time.Duration, error) {
+	if client == nil {
+		return 0, ErrNotInitialized
+	}
+
+	ttl, err := client.PTTL(ctx, keyPrefix+l.lockKey(id)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// PTTL is negative when the key does not exist
+	return max(ttl, 0), nil
+}
+
+// Fail records a failure for id and returns the consecutive failures so far
+// and how long id is now locked, or zero below the threshold
+func (l Lockout) Fail(ctx context.Context, id string) (int64, time.Duration, error) {
+	failures, _, err := increment(ctx, l.failuresKey(id), l.FailureWindow)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Every failure extends the memory of earlier ones
+	if err := client.PExpire(ctx, keyPrefix+l.failuresKey(id), l.FailureWindow).Err(); err != nil {
+		return 0, 0, err
+	}
+
+	delay := l.delay(failures)
+	if delay == 0 {
+		return failures, 0, nil
+	}
+
+	if err := client.Set(ctx, keyPrefix+l.lockKey(id), failures, delay).Err(); err != nil {
+		return 0, 0, err
+	}
+
+	return failures, delay, nil
+}
+
+// Reset forgets the failures of id, e.g. after a successful login
+func (l Lockout) Reset(ctx context.Context, id string) error {
+	if client == nil {
+		return ErrNotInitialized
+	}
+
+	return client.Del(ctx, keyPrefix+l.failuresKey(id), keyPrefix+l.lockKey(id)).Err()
+}
+
+// delay is the lock duration after the given number of consecutive failures
+func (l Lockout) delay(failures int64) time.Duration {
+	if failures < l.Threshold {
+		return 0
+	}
+
+	delay := l.BaseDelay
+	for i := l.Threshold; i < failures && delay < l.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, l.MaxDelay)
+}
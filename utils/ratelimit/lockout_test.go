@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutDelay(t *testing.T) {
+	lockout := Lockout{
+		Threshold: 5,
+		BaseDelay: time.Minute,
+		MaxDelay:  time.Hour,
+	}
+
+	assert.Equal(t, time.Duration(0), lockout.delay(1))
+	assert.Equal(t, time.Duration(0), lockout.delay(4))
+	assert.Equal(t, time.Minute, lockout.delay(5))
+	assert.Equal(t, 2*time.Minute, lockout.delay(6))
+	assert.Equal(t, 32*time.Minute, lockout.delay(10))
+	assert.Equal(t, time.Hour, lockout.delay(11))
+	assert.Equal(t, time.Hour, lockout.delay(1000))
+}
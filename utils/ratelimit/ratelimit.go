@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+var client *redis.Client
+
+// ErrNotInitialized is returned when Init has not been called
+var ErrNotInitialized = errors.New("rate limiter is not initialized")
+
+// Init sets the Redis client used to count requests and failures.
+// Must be called before using a Limit or a Lockout.
+func Init(redisClient *redis.Client) {
+	client = redisClient
+}
+
+// incrementScript counts a hit and starts the window on the first one, returning the count and the window's remaining milliseconds
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// increment counts a hit on key within a fixed window
+func increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if client == nil {
+		return 0, 0, ErrNotInitialized
+	}
+
+	values, err := incrementScript.Run(ctx, client, []string{keyPrefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ttl := time.Duration(values[1]) * time.Millisecond
+	if ttl < 0 {
+		ttl = window
+	}
+
+	return values[0], ttl, nil
+}
+
+// Limit allows a number of requests per fixed window for each key
+type Limit struct {
+	Name   string
+	Limit  int64
+	Window time.Duration
+}
+
+// Result is the state of a key after a request was counted
+type Result struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// Reset is how long until the window starts over
+	Reset time.Duration
+}
+
+// Allow counts a request for id and reports whether it is within the limit
+func (l Limit) Allow(ctx context.Context, id string) (Result, error) {
+	count, ttl, err := increment(ctx, l.Name+":"+id, l.Window)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:   count <= l.Limit,
+		Limit:     l.Limit,
+		Remaining: max(l.Limit-count, 0),
+		Reset:     ttl,
+	}, nil
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "strings"
+
 // UniqueInt64s returns a slice with duplicates removed, preserving first-seen order.
 func UniqueInt64s(ids []int64) []int64 {
 	seen := make(map[int64]struct{}, len(ids))
@@ -16,3 +18,21 @@ func UniqueInt64s(ids []int64) []int64 {
 	}
 	return out
 }
+
+// NormalizeTags lowercases and trims tags, dropping blanks and duplicates.
+func NormalizeTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	monitorcore "github.com/yorukot/knocker/core/monitor"
+	"github.com/yorukot/knocker/models"
+)
+
+// handleIncidentDegradation opens a minor incident once enough recent pings are slow or failing,
+// using the same window as failure detection.
+func (h *Handler) handleIncidentDegradation(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, openIncident *models.Incident) (*incidentEvent, error) {
+	failureThreshold := int(monitor.FailureThreshold)
+	if failureThreshold <= 0 {
+		return nil, nil
+	}
+
+	window := int(math.Ceil(float64(failureThreshold) * 1.5))
+	recent, err := h.repo.ListRecentPingsByMonitorIDAndRegion(ctx, tx, monitor.ID, regionID, window-1)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := append([]models.Ping{ping}, recent...)
+	unhealthy := 0
+	for i := range min(len(samples), window) {
+		if monitorcore.PingHealth(monitor, samples[i]) != models.MonitorStatusUp {
+			unhealthy++
+		}
+	}
+
+	now := time.Now().UTC()
+	// The threshold rather than the latency keeps the message stable across slow pings.
+	message := incidentMessage(strconv.FormatInt(regionID, 10), fmt.Sprintf("response time above %d ms", latencyThresholdExceeded(monitor, ping)), ping, "")
+
+	if unhealthy >= failureThreshold && len(samples) >= failureThreshold && openIncident == nil {
+		// Slow responses caused by an upstream outage join the parent's incident as well.
+		attached, err := h.attachToUpstreamIncident(ctx, tx, monitor, message, now)
+		if err != nil {
+			return nil, err
+		}
+		if attached {
+			return nil, nil
+		}
+
+		createdIncident, created, err := h.createIncidentIfAbsent(ctx, tx, monitor.ID, ping.Time, message, now, models.IncidentSeverityMinor)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return &incidentEvent{kind: models.NotificationEventOpened, incident: *createdIncident, detail: message}, nil
+		}
+		openIncident = createdIncident
+	}
+
+	if openIncident != nil {
+		if err := h.recordIncidentUpdate(ctx, tx, openIncident.ID, message, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// raiseDegradedIncident turns a minor slow-response incident into a major one when the monitor goes
+// down, and reports it as opened so the outage is notified and escalated.
+func (h *Handler) raiseDegradedIncident(ctx context.Context, tx pgx.Tx, incident models.Incident, message string, now time.Time) (*incidentEvent, error) {
+	updated, err := h.repo.UpdateIncidentSeverity(ctx, tx, incident.ID, models.IncidentSeverityMajor, now)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("incident %d disappeared while raising its severity", incident.ID)
+	}
+
+	if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
+		IncidentID: updated.ID,
+		Message:    message,
+		EventType:  models.IncidentEventTypeUpdate,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &incidentEvent{kind: models.NotificationEventOpened, incident: *updated, detail: message}, nil
+}
+
+// latencyThresholdExceeded returns the highest latency threshold the ping went over.
+func latencyThresholdExceeded(monitor models.Monitor, ping models.Ping) int {
+	if monitor.LatencyCriticalMs != nil && ping.Latency > *monitor.LatencyCriticalMs {
+		return *monitor.LatencyCriticalMs
+	}
+	if monitor.LatencyWarnMs != nil {
+		return *monitor.LatencyWarnMs
+	}
+	if monitor.LatencyCriticalMs != nil {
+		return *monitor.LatencyCriticalMs
+	}
+	return 0
+}
@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	escalationcore "github.com/yorukot/knocker/core/escalation"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/utils/id"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// incidentEvent is the notification-worthy outcome of processing a ping.
+type incidentEvent struct {
+	kind      models.NotificationEventKind
+	incident  models.Incident
+	detail    string
+	cancelled []models.IncidentEscalation
+	// escalate restarts paging for an incident that was already open, e.g. once flapping settles down.
+	escalate bool
+}
+
+// notifyIncidentEvent routes an incident event through the monitor's escalation policy,
+// falling back to the team's notification routes when no policy applies.
+func (h *Handler) notifyIncidentEvent(monitor models.Monitor, ping models.Ping, regionID int64, event incidentEvent) {
+	if h.notifier == nil {
+		return
+	}
+
+	// Slow responses go to the notification channels only; paging starts once they turn into an outage.
+	if monitor.EscalationPolicyID != nil && event.incident.Severity != models.IncidentSeverityMinor {
+		var handled bool
+		switch event.kind {
+		case models.NotificationEventOpened:
+			handled = h.startEscalation(monitor, event.incident, ping, regionID, event.detail)
+		case models.NotificationEventFlappingEnded:
+			if event.escalate {
+				handled = h.startEscalation(monitor, event.incident, ping, regionID, event.detail)
+			}
+		case models.NotificationEventResolved:
+			handled = h.notifyEscalatedTargets(monitor, event.incident, ping, regionID, event.detail)
+		}
+		if handled {
+			return
+		}
+	}
+
+	h.enqueueNotificationTasks(monitor, ping, regionID, event)
+}
+
+// startEscalation records and enqueues one delayed task per policy step.
+// It returns false when the policy has no steps so the caller can fall back.
+func (h *Handler) startEscalation(monitor models.Monitor, incident models.Incident, ping models.Ping, regionID int64, detail string) bool {
+	ctx := context.Background()
+	tx, err := h.repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("failed to start transaction for escalation",
+			zap.Int64("monitor_id", monitor.ID),
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return false
+	}
+	defer h.repo.DeferRollback(tx, ctx)
+
+	steps, err := h.repo.ListEscalationStepsByPolicyIDs(ctx, tx, []int64{*monitor.EscalationPolicyID})
+	if err != nil {
+		zap.L().Error("failed to load escalation steps",
+			zap.Int64("monitor_id", monitor.ID),
+			zap.Int64("policy_id", *monitor.EscalationPolicyID),
+			zap.Error(err))
+		return false
+	}
+
+	if len(steps) == 0 {
+		return false
+	}
+
+	now := time.Now().UTC()
+	var delay time.Duration
+	escalations := make([]models.IncidentEscalation, 0, len(steps))
+	for _, step := range steps {
+		escalationID, err := id.GetID()
+		if err != nil {
+			zap.L().Error("failed to generate escalation ID", zap.Error(err))
+			return false
+		}
+
+		// Step delays are relative to the previous step.
+		delay += time.Duration(step.DelaySeconds) * time.Second
+		escalations = append(escalations, models.IncidentEscalation{
+			ID:          escalationID,
+			IncidentID:  incident.ID,
+			PolicyID:    monitor.EscalationPolicyID,
+			Position:    step.Position,
+			TaskID:      escalationcore.TaskID(incident.ID, escalationID),
+			Status:      models.EscalationStatusPending,
+			ScheduledAt: now.Add(delay),
+			UpdatedAt:   now,
+			CreatedAt:   now,
+		})
+	}
+
+	if err := h.repo.CreateIncidentEscalations(ctx, tx, escalations); err != nil {
+		zap.L().Error("failed to record incident escalations",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return false
+	}
+
+	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("failed to commit escalation transaction",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return false
+	}
+
+	for i, step := range steps {
+		escalation := escalations[i]
+		task, err := tasks.NewEscalationStep(tasks.EscalationStepPayload{
+			TeamID:     monitor.TeamID,
+			MonitorID:  monitor.ID,
+			IncidentID: incident.ID,
+			Position:   step.Position,
+			TaskID:     escalation.TaskID,
+			Targets:    step.Targets,
+			RegionID:   regionID,
+			Ping:       ping,
+			Detail:     detail,
+		})
+		if err != nil {
+			zap.L().Error("failed to create escalation task",
+				zap.Int64("incident_id", incident.ID),
+				zap.Int16("position", step.Position),
+				zap.Error(err))
+			continue
+		}
+
+		if _, err := h.notifier.Enqueue(task,
+			asynq.Queue(escalationcore.Queue),
+			asynq.TaskID(escalation.TaskID),
+			asynq.ProcessIn(escalation.ScheduledAt.Sub(now)),
+		); err != nil {
+			zap.L().Error("failed to enqueue escalation task",
+				zap.Int64("incident_id", incident.ID),
+				zap.Int16("position", step.Position),
+				zap.Error(err))
+		}
+	}
+
+	return true
+}
+
+// notifyEscalatedTargets tells everyone who was already paged for an incident about its resolution.
+// It returns false when the incident was never escalated so the caller can fall back.
+func (h *Handler) notifyEscalatedTargets(monitor models.Monitor, incident models.Incident, ping models.Ping, regionID int64, detail string) bool {
+	ctx := context.Background()
+	tx, err := h.repo.StartTransaction(ctx)
+	if err != nil {
+		zap.L().Error("failed to start transaction for escalation resolve",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return false
+	}
+	defer h.repo.DeferRollback(tx, ctx)
+
+	escalations, err := h.repo.ListIncidentEscalationsByIncidentID(ctx, tx, incident.ID)
+	if err != nil {
+		zap.L().Error("failed to load incident escalations",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return false
+	}
+
+	if len(escalations) == 0 {
+		return false
+	}
+
+	notified := make(map[int16]struct{}, len(escalations))
+	for _, escalation := range escalations {
+		if escalation.Status == models.EscalationStatusNotified {
+			notified[escalation.Position] = struct{}{}
+		}
+	}
+
+	steps, err := h.repo.ListEscalationStepsByPolicyIDs(ctx, tx, []int64{*monitor.EscalationPolicyID})
+	if err != nil {
+		zap.L().Error("failed to load escalation steps",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return true
+	}
+
+	var targets []models.EscalationTarget
+	for _, step := range steps {
+		if _, ok := notified[step.Position]; ok {
+			targets = append(targets, step.Targets...)
+		}
+	}
+
+	notificationIDs, userIDs, err := h.resolveEscalationTargets(ctx, tx, monitor.TeamID, targets, time.Now().UTC())
+	if err != nil {
+		zap.L().Error("failed to resolve escalation targets",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return true
+	}
+
+	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
+		zap.L().Error("failed to commit escalation resolve transaction",
+			zap.Int64("incident_id", incident.ID),
+			zap.Error(err))
+		return true
+	}
+
+	h.enqueueTargetNotifications(monitor.TeamID, monitor.ID, notificationIDs, userIDs, regionID, ping, detail)
+	return true
+}
+
+// HandleEscalationStep processes a delayed escalation step task.
+func (h *Handler) HandleEscalationStep(ctx context.Context, t *asynq.Task) error {
+	var payload tasks.EscalationStepPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		zap.L().Error("invalid escalation payload", zap.Error(err))
+		return err
+	}
+
+	tx, err := h.repo.StartTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer h.repo.DeferRollback(tx, ctx)
+
+	incident, err := h.repo.GetIncidentByIDForTeam(ctx, tx, payload.TeamID, payload.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	// Any status change away from detected counts as an acknowledgement.
+	if incident == nil || incident.Status != models.IncidentStatusDetected {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	pending, err := h.repo.MarkIncidentEscalationNotified(ctx, tx, payload.TaskID, now)
+	if err != nil {
+		return err
+	}
+
+	if !pending {
+		return nil
+	}
+
+	notificationIDs, userIDs, err := h.resolveEscalationTargets(ctx, tx, payload.TeamID, payload.Targets, now)
+	if err != nil {
+		return err
+	}
+
+	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
+		return err
+	}
+
+	h.enqueueTargetNotifications(payload.TeamID, payload.MonitorID, notificationIDs, userIDs, payload.RegionID, payload.Ping, payload.Detail)
+
+	zap.L().Info("escalation step dispatched",
+		zap.Int64("incident_id", payload.IncidentID),
+		zap.Int16("position", payload.Position),
+		zap.Int("notifications", len(notificationIDs)),
+		zap.Int("users", len(userIDs)))
+
+	return nil
+}
+
+// resolveEscalationTargets expands step targets into notification channel IDs and user IDs,
+// resolving schedules to whoever is on call at the given time.
+func (h *Handler) resolveEscalationTargets(ctx context.Context, tx pgx.Tx, teamID int64, targets []models.EscalationTarget, at time.Time) ([]int64, []int64, error) {
+	var notificationIDs, userIDs []int64
+	seenNotifications := make(map[int64]struct{})
+	seenUsers := make(map[int64]struct{})
+
+	addUser := func(userID int64) {
+		if _, ok := seenUsers[userID]; ok {
+			return
+		}
+		seenUsers[userID] = struct{}{}
+		userIDs = append(userIDs, userID)
+	}
+
+	for _, target := range targets {
+		switch target.Type {
+		case models.EscalationTargetTypeNotification:
+			if _, ok := seenNotifications[target.TargetID]; ok {
+				continue
+			}
+			seenNotifications[target.TargetID] = struct{}{}
+			notificationIDs = append(notificationIDs, target.TargetID)
+		case models.EscalationTargetTypeUser:
+			addUser(target.TargetID)
+		case models.EscalationTargetTypeSchedule:
+			schedule, err := h.loadOnCallSchedule(ctx, tx, teamID, target.TargetID, at)
+			if err != nil {
+				return nil, nil, err
+			}
+			if schedule == nil {
+				continue
+			}
+			for _, userID := range escalationcore.OnCallUserIDs(*schedule, at) {
+				addUser(userID)
+			}
+		}
+	}
+
+	return notificationIDs, userIDs, nil
+}
+
+func (h *Handler) loadOnCallSchedule(ctx context.Context, tx pgx.Tx, teamID, scheduleID int64, at time.Time) (*models.OnCallSchedule, error) {
+	schedule, err := h.repo.GetOnCallScheduleByID(ctx, tx, teamID, scheduleID)
+	if err != nil || schedule == nil {
+		return schedule, err
+	}
+
+	layers, err := h.repo.ListOnCallLayersByScheduleIDs(ctx, tx, []int64{scheduleID})
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := h.repo.ListOnCallOverridesByScheduleIDs(ctx, tx, []int64{scheduleID}, at)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Layers = layers
+	schedule.Overrides = overrides
+	return schedule, nil
+}
+
+func (h *Handler) enqueueTargetNotifications(teamID, monitorID int64, notificationIDs, userIDs []int64, regionID int64, ping models.Ping, detail string) {
+	for _, notificationID := range notificationIDs {
+		h.enqueueNotification(tasks.NotificationPayload{
+			TeamID:         teamID,
+			MonitorID:      monitorID,
+			NotificationID: notificationID,
+			RegionID:       regionID,
+			Ping:           ping,
+			Detail:         detail,
+		})
+	}
+
+	for _, userID := range userIDs {
+		h.enqueueNotification(tasks.NotificationPayload{
+			TeamID:    teamID,
+			MonitorID: monitorID,
+			UserID:    userID,
+			RegionID:  regionID,
+			Ping:      ping,
+			Detail:    detail,
+		})
+	}
+}
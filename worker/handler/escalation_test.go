@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+// recordingEnqueuer captures queued tasks instead of sending them to Redis.
+type recordingEnqueuer struct {
+	tasks []*asynq.Task
+	opts  [][]asynq.Option
+}
+
+func (r *recordingEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.tasks = append(r.tasks, task)
+	r.opts = append(r.opts, opts)
+	return &asynq.TaskInfo{}, nil
+}
+
+func (r *recordingEnqueuer) ofType(taskType string) []*asynq.Task {
+	var matched []*asynq.Task
+	for _, task := range r.tasks {
+		if task.Type() == taskType {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+func notificationPayloads(t *testing.T, queued []*asynq.Task) []tasks.NotificationPayload {
+	t.Helper()
+
+	payloads := make([]tasks.NotificationPayload, 0, len(queued))
+	for _, task := range queued {
+		var payload tasks.NotificationPayload
+		require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+func TestStartEscalation_EnqueuesEveryStep(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	policyID := int64(30)
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListEscalationStepsByPolicyIDs", mock.Anything, mock.Anything, []int64{policyID}).
+		Return([]models.EscalationStep{
+			{ID: 1, PolicyID: policyID, Position: 0, DelaySeconds: 0, Targets: []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 8}}},
+			{ID: 2, PolicyID: policyID, Position: 1, DelaySeconds: 300, Targets: []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 9}}},
+		}, nil)
+	mockRepo.On("CreateIncidentEscalations", mock.Anything, mock.Anything, mock.MatchedBy(func(escalations []models.IncidentEscalation) bool {
+		return len(escalations) == 2 &&
+			escalations[0].Status == models.EscalationStatusPending &&
+			escalations[1].ScheduledAt.Sub(escalations[0].ScheduledAt) == 300*time.Second
+	})).Return(nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+	monitor := models.Monitor{ID: 5, TeamID: 7, EscalationPolicyID: &policyID}
+	incident := models.Incident{ID: 11, Status: models.IncidentStatusDetected, Severity: models.IncidentSeverityMajor}
+
+	handled := h.startEscalation(monitor, incident, models.Ping{MonitorID: 5}, 1, "down")
+	require.True(t, handled)
+
+	steps := enqueuer.ofType(tasks.TypeEscalationStep)
+	require.Len(t, steps, 2)
+	for i, task := range steps {
+		var payload tasks.EscalationStepPayload
+		require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+		require.Equal(t, int64(11), payload.IncidentID)
+		require.Equal(t, int16(i), payload.Position)
+	}
+	require.Empty(t, enqueuer.ofType(tasks.TypeNotificationDispatch))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStartEscalation_NoStepsFallsBack(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	policyID := int64(30)
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("ListEscalationStepsByPolicyIDs", mock.Anything, mock.Anything, []int64{policyID}).
+		Return([]models.EscalationStep{}, nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+	monitor := models.Monitor{ID: 5, TeamID: 7, EscalationPolicyID: &policyID}
+
+	handled := h.startEscalation(monitor, models.Incident{ID: 11}, models.Ping{}, 1, "down")
+	require.False(t, handled)
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertNotCalled(t, "CreateIncidentEscalations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEscalationStep_PagesTargets(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetIncidentByIDForTeam", mock.Anything, mock.Anything, int64(7), int64(11)).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusDetected}, nil)
+	mockRepo.On("MarkIncidentEscalationNotified", mock.Anything, mock.Anything, "escalation:11:0", mock.AnythingOfType("time.Time")).
+		Return(true, nil)
+	mockRepo.On("GetOnCallScheduleByID", mock.Anything, mock.Anything, int64(7), int64(40)).
+		Return(&models.OnCallSchedule{ID: 40, TeamID: 7, Timezone: "UTC"}, nil)
+	mockRepo.On("ListOnCallLayersByScheduleIDs", mock.Anything, mock.Anything, []int64{40}).
+		Return([]models.OnCallLayer{{ID: 41, ScheduleID: 40, RotationSeconds: 86400, StartsAt: time.Now().Add(-time.Hour), UserIDs: []int64{10}}}, nil)
+	mockRepo.On("ListOnCallOverridesByScheduleIDs", mock.Anything, mock.Anything, []int64{40}, mock.AnythingOfType("time.Time")).
+		Return([]models.OnCallOverride{}, nil)
+
+	task, err := tasks.NewEscalationStep(tasks.EscalationStepPayload{
+		TeamID:     7,
+		MonitorID:  5,
+		IncidentID: 11,
+		Position:   0,
+		TaskID:     "escalation:11:0",
+		Targets: []models.EscalationTarget{
+			{Type: models.EscalationTargetTypeNotification, TargetID: 20},
+			{Type: models.EscalationTargetTypeUser, TargetID: 8},
+			{Type: models.EscalationTargetTypeSchedule, TargetID: 40},
+			{Type: models.EscalationTargetTypeUser, TargetID: 10},
+		},
+	})
+	require.NoError(t, err)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	require.NoError(t, h.HandleEscalationStep(context.Background(), task))
+
+	payloads := notificationPayloads(t, enqueuer.ofType(tasks.TypeNotificationDispatch))
+	require.Len(t, payloads, 3)
+	require.Equal(t, int64(20), payloads[0].NotificationID)
+	require.Equal(t, int64(8), payloads[1].UserID)
+	require.Equal(t, int64(10), payloads[2].UserID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHandleEscalationStep_SkipsAcknowledgedIncident(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetIncidentByIDForTeam", mock.Anything, mock.Anything, int64(7), int64(11)).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusInvestigating}, nil)
+
+	task, err := tasks.NewEscalationStep(tasks.EscalationStepPayload{
+		TeamID:     7,
+		IncidentID: 11,
+		TaskID:     "escalation:11:1",
+		Targets:    []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 8}},
+	})
+	require.NoError(t, err)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	require.NoError(t, h.HandleEscalationStep(context.Background(), task))
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertNotCalled(t, "MarkIncidentEscalationNotified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEscalationStep_SkipsDeletedTeam(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	// Incidents of soft-deleted teams are not found by the team-scoped lookup.
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetIncidentByIDForTeam", mock.Anything, mock.Anything, int64(7), int64(11)).Return(nil, nil)
+
+	task, err := tasks.NewEscalationStep(tasks.EscalationStepPayload{
+		TeamID:     7,
+		IncidentID: 11,
+		TaskID:     "escalation:11:1",
+		Targets:    []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 8}},
+	})
+	require.NoError(t, err)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	require.NoError(t, h.HandleEscalationStep(context.Background(), task))
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertNotCalled(t, "MarkIncidentEscalationNotified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEscalationStep_SkipsCancelledStep(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("GetIncidentByIDForTeam", mock.Anything, mock.Anything, int64(7), int64(11)).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusDetected}, nil)
+	mockRepo.On("MarkIncidentEscalationNotified", mock.Anything, mock.Anything, "escalation:11:1", mock.AnythingOfType("time.Time")).
+		Return(false, nil)
+
+	task, err := tasks.NewEscalationStep(tasks.EscalationStepPayload{
+		TeamID:     7,
+		IncidentID: 11,
+		TaskID:     "escalation:11:1",
+		Targets:    []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 8}},
+	})
+	require.NoError(t, err)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	require.NoError(t, h.HandleEscalationStep(context.Background(), task))
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertNotCalled(t, "CommitTransaction", mock.Anything, mock.Anything)
+}
+
+func TestHandleIncidentRecovery_CancelsPendingEscalations(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	cancelled := []models.IncidentEscalation{{ID: 50, IncidentID: 11, Position: 1, Status: models.EscalationStatusCancelled}}
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(5), int64(1), 0).
+		Return([]models.Ping{}, nil)
+	mockRepo.On("MarkIncidentResolved", mock.Anything, mock.Anything, int64(11), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.EventTimeline) bool {
+		return event.IncidentID == 11 && event.EventType == models.IncidentEventTypeAutoResolved
+	})).Return(nil)
+	mockRepo.On("CancelPendingIncidentEscalations", mock.Anything, mock.Anything, int64(11), mock.AnythingOfType("time.Time")).
+		Return(cancelled, nil)
+
+	h := &Handler{repo: mockRepo}
+	monitor := models.Monitor{ID: 5, TeamID: 7, RecoveryThreshold: 1}
+	ping := models.Ping{Time: time.Now().UTC(), MonitorID: 5, Status: models.PingStatusSuccessful}
+	open := &models.Incident{ID: 11, Status: models.IncidentStatusDetected, AutoResolve: true}
+
+	event, err := h.handleIncidentRecovery(context.Background(), nil, monitor, ping, 1, "ok", open)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	require.Equal(t, models.NotificationEventResolved, event.kind)
+	require.Equal(t, models.IncidentStatusResolved, event.incident.Status)
+	require.Equal(t, cancelled, event.cancelled)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	monitorcore "github.com/yorukot/knocker/core/monitor"
+	"github.com/yorukot/knocker/models"
+)
+
+// handleFlapping holds the monitor's incident open in the flapping state while its pings
+// oscillate. It reports handled=true when the regular failure/recovery logic must be skipped.
+func (h *Handler) handleFlapping(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident) (*incidentEvent, bool, error) {
+	wasFlapping := openIncident != nil && openIncident.Status == models.IncidentStatusFlapping
+	if monitor.FlapStartThreshold <= 0 && !wasFlapping {
+		return nil, false, nil
+	}
+
+	var samples []models.Ping
+	if window := int(monitor.FlapWindow); window > 1 {
+		recent, err := h.repo.ListRecentPingsByMonitorIDAndRegion(ctx, tx, monitor.ID, regionID, window-1)
+		if err != nil {
+			return nil, false, err
+		}
+		samples = append([]models.Ping{ping}, recent...)
+	}
+
+	flapping, percent := monitorcore.DetectFlapping(monitor, samples, wasFlapping)
+	now := time.Now().UTC()
+
+	switch {
+	case flapping && wasFlapping:
+		// Still flapping: keep the incident open and stay quiet.
+		return nil, true, nil
+	case flapping:
+		return h.startFlapping(ctx, tx, monitor, ping, regionID, detail, openIncident, percent, now)
+	case wasFlapping:
+		return h.stopFlapping(ctx, tx, ping, *openIncident, percent, now)
+	default:
+		return nil, false, nil
+	}
+}
+
+func (h *Handler) startFlapping(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident, percent float64, now time.Time) (*incidentEvent, bool, error) {
+	incident := openIncident
+	if incident == nil {
+		message := incidentMessage(strconv.FormatInt(regionID, 10), detail, ping, string(ping.Status))
+		created, _, err := h.createIncidentIfAbsent(ctx, tx, monitor.ID, ping.Time, message, now, models.IncidentSeverityMajor)
+		if err != nil {
+			return nil, false, err
+		}
+		incident = created
+	}
+
+	updated, err := h.repo.UpdateIncidentStatus(ctx, tx, incident.ID, models.IncidentStatusFlapping, nil, now)
+	if err != nil {
+		return nil, false, err
+	}
+	if updated == nil {
+		return nil, false, fmt.Errorf("incident %d disappeared while flapping", incident.ID)
+	}
+
+	message := fmt.Sprintf("Flapping started (%.0f%% state change)", percent)
+	if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
+		IncidentID: updated.ID,
+		Message:    message,
+		EventType:  models.IncidentEventTypeFlappingStarted,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		return nil, false, err
+	}
+
+	// Paging people about a flapping monitor is noise; stop any pending escalation.
+	cancelled, err := h.repo.CancelPendingIncidentEscalations(ctx, tx, updated.ID, now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &incidentEvent{kind: models.NotificationEventFlappingStarted, incident: *updated, detail: message, cancelled: cancelled}, true, nil
+}
+
+func (h *Handler) stopFlapping(ctx context.Context, tx pgx.Tx, ping models.Ping, incident models.Incident, percent float64, now time.Time) (*incidentEvent, bool, error) {
+	// Settle the incident according to where the monitor ended up.
+	status := models.IncidentStatusDetected
+	var resolvedAt *time.Time
+	if ping.Status == models.PingStatusSuccessful && incident.AutoResolve {
+		status = models.IncidentStatusResolved
+		resolvedAt = &ping.Time
+	}
+
+	updated, err := h.repo.UpdateIncidentStatus(ctx, tx, incident.ID, status, resolvedAt, now)
+	if err != nil {
+		return nil, false, err
+	}
+	if updated == nil {
+		return nil, false, fmt.Errorf("incident %d disappeared while flapping", incident.ID)
+	}
+
+	message := fmt.Sprintf("Flapping ended (%.0f%% state change), monitor is %s", percent, ping.Status)
+	if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
+		IncidentID: updated.ID,
+		Message:    message,
+		EventType:  models.IncidentEventTypeFlappingEnded,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		return nil, false, err
+	}
+
+	// Escalation was cancelled when flapping started; page again if the monitor settled down.
+	escalate := ping.Status != models.PingStatusSuccessful
+	return &incidentEvent{kind: models.NotificationEventFlappingEnded, incident: *updated, detail: message, escalate: escalate}, true, nil
+}
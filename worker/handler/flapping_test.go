@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+	"github.com/yorukot/knocker/worker/tasks"
+)
+
+func flappingMonitor(policyID *int64) models.Monitor {
+	return models.Monitor{
+		ID:                 5,
+		TeamID:             7,
+		Status:             models.MonitorStatusDown,
+		FailureThreshold:   1,
+		RecoveryThreshold:  1,
+		FlapWindow:         4,
+		FlapStartThreshold: 50,
+		FlapStopThreshold:  25,
+		EscalationPolicyID: policyID,
+	}
+}
+
+func TestProcessIncident_FlappingSettlesDownEscalates(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	policyID := int64(30)
+	now := time.Now().UTC()
+	failed := models.Ping{Time: now, MonitorID: 5, RegionID: 1, Status: models.PingStatusFailed}
+	open := &models.Incident{ID: 11, Status: models.IncidentStatusFlapping, Severity: models.IncidentSeverityMajor, AutoResolve: true}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOpenIncidentByMonitorID", mock.Anything, mock.Anything, int64(5)).Return(open, nil)
+	mockRepo.On("IsDownstreamIncidentMonitor", mock.Anything, mock.Anything, int64(11), int64(5)).Return(false, nil)
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(5), int64(1), 3).
+		Return([]models.Ping{failed, failed, failed}, nil)
+	mockRepo.On("UpdateIncidentStatus", mock.Anything, mock.Anything, int64(11), models.IncidentStatusDetected, mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusDetected, Severity: models.IncidentSeverityMajor, AutoResolve: true}, nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.EventTimeline) bool {
+		return event.IncidentID == 11 && event.EventType == models.IncidentEventTypeFlappingEnded
+	})).Return(nil)
+	mockRepo.On("ListEscalationStepsByPolicyIDs", mock.Anything, mock.Anything, []int64{policyID}).
+		Return([]models.EscalationStep{
+			{ID: 1, PolicyID: policyID, Position: 0, Targets: []models.EscalationTarget{{Type: models.EscalationTargetTypeUser, TargetID: 8}}},
+		}, nil)
+	mockRepo.On("CreateIncidentEscalations", mock.Anything, mock.Anything, mock.MatchedBy(func(escalations []models.IncidentEscalation) bool {
+		return len(escalations) == 1 && escalations[0].IncidentID == 11
+	})).Return(nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	h.processIncident(context.Background(), flappingMonitor(&policyID), failed, 1, "connection refused")
+
+	require.Len(t, enqueuer.ofType(tasks.TypeEscalationStep), 1)
+	require.Empty(t, enqueuer.ofType(tasks.TypeNotificationDispatch))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessIncident_FlappingSettlesUpDoesNotEscalate(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	policyID := int64(30)
+	now := time.Now().UTC()
+	ok := models.Ping{Time: now, MonitorID: 5, RegionID: 1, Status: models.PingStatusSuccessful}
+	open := &models.Incident{ID: 11, Status: models.IncidentStatusFlapping, Severity: models.IncidentSeverityMajor, AutoResolve: true}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOpenIncidentByMonitorID", mock.Anything, mock.Anything, int64(5)).Return(open, nil)
+	mockRepo.On("UpdateMonitorStatus", mock.Anything, mock.Anything, int64(5), models.MonitorStatusUp, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("IsDownstreamIncidentMonitor", mock.Anything, mock.Anything, int64(11), int64(5)).Return(false, nil)
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(5), int64(1), 3).
+		Return([]models.Ping{ok, ok, ok}, nil)
+	mockRepo.On("UpdateIncidentStatus", mock.Anything, mock.Anything, int64(11), models.IncidentStatusResolved, mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusResolved, Severity: models.IncidentSeverityMajor, IsPublic: true, AutoResolve: true}, nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListNotificationRoutesByTeamID", mock.Anything, mock.Anything, int64(7)).Return([]models.NotificationRoute{}, nil)
+	mockRepo.On("GetNotificationIDsByMonitorID", mock.Anything, mock.Anything, int64(5)).Return([]int64{20}, nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+
+	h.processIncident(context.Background(), flappingMonitor(&policyID), ok, 1, "")
+
+	// The channels hear that flapping ended, but nobody is paged.
+	require.Empty(t, enqueuer.ofType(tasks.TypeEscalationStep))
+	require.Len(t, enqueuer.ofType(tasks.TypeNotificationDispatch), 1)
+	mockRepo.AssertNotCalled(t, "ListEscalationStepsByPolicyIDs", mock.Anything, mock.Anything, mock.Anything)
+
+	// Status page subscribers hear that the incident they saw open is resolved.
+	updates := enqueuer.ofType(tasks.TypeStatusPageUpdate)
+	require.Len(t, updates, 1)
+	var update tasks.StatusPageUpdatePayload
+	require.NoError(t, json.Unmarshal(updates[0].Payload(), &update))
+	require.Equal(t, int64(11), update.Incident.ID)
+	require.Equal(t, models.StatusPageUpdateResolved, update.Kind)
+}
@@ -5,16 +5,27 @@ import (
 	"github.com/yorukot/knocker/repository"
 )
 
+// taskEnqueuer is the part of asynq.Client the handlers use to queue follow-up tasks
+type taskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
 type Handler struct {
 	repo       repository.Repository
-	notifier   *asynq.Client
+	notifier   taskEnqueuer
+	inspector  *asynq.Inspector
 	pingBuffer *PingRecorder
 }
 
-func NewHandler(repo repository.Repository, notifier *asynq.Client) *Handler {
-	return &Handler{
+func NewHandler(repo repository.Repository, notifier *asynq.Client, inspector *asynq.Inspector) *Handler {
+	h := &Handler{
 		repo:       repo,
-		notifier:   notifier,
+		inspector:  inspector,
 		pingBuffer: NewPingRecorder(repo),
 	}
+	// A nil client must stay a nil interface so notifications are skipped
+	if notifier != nil {
+		h.notifier = notifier
+	}
+	return h
 }
@@ -13,7 +13,10 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	escalationcore "github.com/yorukot/knocker/core/escalation"
 	monitorcore "github.com/yorukot/knocker/core/monitor"
+	notificationcore "github.com/yorukot/knocker/core/notification"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils/config"
 	"github.com/yorukot/knocker/utils/id"
@@ -67,15 +70,21 @@ func (h *Handler) pingMonitor(ctx context.Context, monitor models.Monitor, regio
 		ping.Latency = int(clampLatencyMs(result.Duration))
 	}
 
+	if monitor.LatencyCriticalMs != nil {
+		criticalMs := *monitor.LatencyCriticalMs
+		ping.LatencyCriticalMs = &criticalMs
+	}
+
 	return ping, message, err
 }
 
-func (h *Handler) enqueueNotificationTasks(monitor models.Monitor, ping models.Ping, regionID int64, detail string) {
+// enqueueNotificationTasks sends an incident event to the channels chosen by the team's
+// notification routes, or to the monitor's linked channels when no route matches.
+func (h *Handler) enqueueNotificationTasks(monitor models.Monitor, ping models.Ping, regionID int64, event incidentEvent) {
 	if h.notifier == nil {
 		return
 	}
 
-	// Fetch notification IDs from junction table
 	ctx := context.Background()
 	tx, err := h.repo.StartTransaction(ctx)
 	if err != nil {
@@ -86,14 +95,42 @@ func (h *Handler) enqueueNotificationTasks(monitor models.Monitor, ping models.P
 	}
 	defer h.repo.DeferRollback(tx, ctx)
 
-	notificationIDs, err := h.repo.GetNotificationIDsByMonitorID(ctx, tx, monitor.ID)
+	routes, err := h.repo.ListNotificationRoutesByTeamID(ctx, tx, monitor.TeamID)
 	if err != nil {
-		zap.L().Error("failed to fetch notification IDs",
-			zap.Int64("monitor_id", monitor.ID),
+		zap.L().Error("failed to fetch notification routes",
+			zap.Int64("team_id", monitor.TeamID),
 			zap.Error(err))
 		return
 	}
 
+	var notificationIDs []int64
+	route := notificationcore.MatchRoute(routes, notificationcore.RouteEvent{
+		Kind:        event.kind,
+		Severity:    event.incident.Severity,
+		RegionID:    regionID,
+		MonitorType: monitor.Type,
+		Tags:        monitor.Tags,
+	})
+	if route != nil {
+		if notificationcore.InQuietHours(*route, time.Now()) {
+			zap.L().Info("notification suppressed by quiet hours",
+				zap.Int64("monitor_id", monitor.ID),
+				zap.Int64("route_id", route.ID),
+				zap.String("event", string(event.kind)))
+			return
+		}
+		notificationIDs = route.NotificationIDs
+	} else {
+		// Fetch notification IDs from junction table
+		notificationIDs, err = h.repo.GetNotificationIDsByMonitorID(ctx, tx, monitor.ID)
+		if err != nil {
+			zap.L().Error("failed to fetch notification IDs",
+				zap.Int64("monitor_id", monitor.ID),
+				zap.Error(err))
+			return
+		}
+	}
+
 	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
 		zap.L().Error("failed to commit transaction",
 			zap.Int64("monitor_id", monitor.ID),
@@ -106,31 +143,36 @@ func (h *Handler) enqueueNotificationTasks(monitor models.Monitor, ping models.P
 	}
 
 	for _, notificationID := range notificationIDs {
-
-		payload := tasks.NotificationPayload{
+		h.enqueueNotification(tasks.NotificationPayload{
 			TeamID:         monitor.TeamID,
 			MonitorID:      monitor.ID,
 			NotificationID: notificationID,
 			RegionID:       regionID,
 			Ping:           ping,
-			Detail:         detail,
-		}
+			Detail:         event.detail,
+			Event:          event.kind,
+		})
+	}
+}
 
-		task, err := tasks.NewNotificationDispatch(payload)
-		if err != nil {
-			zap.L().Error("failed to create notification task",
-				zap.Int64("monitor_id", monitor.ID),
-				zap.Int64("notification_id", notificationID),
-				zap.Error(err))
-			continue
-		}
+// enqueueNotification enqueues a single notification dispatch task.
+func (h *Handler) enqueueNotification(payload tasks.NotificationPayload) {
+	task, err := tasks.NewNotificationDispatch(payload)
+	if err != nil {
+		zap.L().Error("failed to create notification task",
+			zap.Int64("monitor_id", payload.MonitorID),
+			zap.Int64("notification_id", payload.NotificationID),
+			zap.Int64("user_id", payload.UserID),
+			zap.Error(err))
+		return
+	}
 
-		if _, err := h.notifier.Enqueue(task); err != nil {
-			zap.L().Error("failed to enqueue notification task",
-				zap.Int64("monitor_id", monitor.ID),
-				zap.Int64("notification_id", notificationID),
-				zap.Error(err))
-		}
+	if _, err := h.notifier.Enqueue(task); err != nil {
+		zap.L().Error("failed to enqueue notification task",
+			zap.Int64("monitor_id", payload.MonitorID),
+			zap.Int64("notification_id", payload.NotificationID),
+			zap.Int64("user_id", payload.UserID),
+			zap.Error(err))
 	}
 }
 
@@ -169,33 +211,42 @@ func (h *Handler) processIncident(ctx context.Context, monitor models.Monitor, p
 		return
 	}
 
-	var notify bool
-	var notifyDetail string
-
 	// Update monitor status based on latest ping before incident logic.
-	targetStatus := monitor.Status
-	if ping.Status == models.PingStatusSuccessful {
-		targetStatus = models.MonitorStatusUp
-	} else {
-		targetStatus = models.MonitorStatusDown
-	}
-	if targetStatus != monitor.Status {
+	targetStatus := monitorcore.PingHealth(monitor, ping)
+	statusChanged := targetStatus != monitor.Status
+	if statusChanged {
 		if err := h.repo.UpdateMonitorStatus(ctx, tx, monitor.ID, targetStatus, time.Now().UTC()); err != nil {
 			zap.L().Error("failed to update monitor status",
-			zap.Int64("monitor_id", monitor.ID),
-			zap.Int64("region_id", regionID),
-			zap.String("region_name", region.Name),
-			zap.String("target_status", string(targetStatus)),
-			zap.Error(err))
-		return
-	}
+				zap.Int64("monitor_id", monitor.ID),
+				zap.Int64("region_id", regionID),
+				zap.String("region_name", region.Name),
+				zap.String("target_status", string(targetStatus)),
+				zap.Error(err))
+			return
+		}
 		monitor.Status = targetStatus
 	}
 
-	if ping.Status == models.PingStatusSuccessful {
-		notify, notifyDetail, err = h.handleIncidentRecovery(ctx, tx, monitor, ping, regionID, detail, openIncident)
-	} else {
-		notify, notifyDetail, err = h.handleIncidentFailure(ctx, tx, monitor, ping, regionID, detail, openIncident)
+	// A child attached to its parent's incident leaves that incident to the parent.
+	downstream := false
+	if openIncident != nil {
+		downstream, err = h.repo.IsDownstreamIncidentMonitor(ctx, tx, openIncident.ID, monitor.ID)
+	}
+
+	var event *incidentEvent
+	if err == nil && !downstream {
+		var flapping bool
+		event, flapping, err = h.handleFlapping(ctx, tx, monitor, ping, regionID, detail, openIncident)
+		if err == nil && !flapping {
+			switch targetStatus {
+			case models.MonitorStatusUp:
+				event, err = h.handleIncidentRecovery(ctx, tx, monitor, ping, regionID, detail, openIncident)
+			case models.MonitorStatusDegraded:
+				event, err = h.handleIncidentDegradation(ctx, tx, monitor, ping, regionID, openIncident)
+			default:
+				event, err = h.handleIncidentFailure(ctx, tx, monitor, ping, regionID, detail, openIncident)
+			}
+		}
 	}
 
 	if err != nil {
@@ -216,22 +267,31 @@ func (h *Handler) processIncident(ctx context.Context, monitor models.Monitor, p
 		return
 	}
 
-	if notify {
-		h.enqueueNotificationTasks(monitor, ping, regionID, notifyDetail)
+	if statusChanged || event != nil {
+		statuspagecore.InvalidateTeamPages(ctx, monitor.TeamID)
+	}
+
+	if event != nil {
+		escalationcore.Cancel(h.inspector, event.cancelled)
+		h.notifyIncidentEvent(monitor, ping, regionID, *event)
+		// Subscribers only hear about recoveries, including flapping that settles up; the ping detail stays internal.
+		if event.incident.Status == models.IncidentStatusResolved {
+			h.notifyStatusPageSubscribers(event.incident, models.StatusPageUpdateResolved, "This incident has been resolved.")
+		}
 	}
 }
 
-func (h *Handler) handleIncidentFailure(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident) (bool, string, error) {
+func (h *Handler) handleIncidentFailure(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident) (*incidentEvent, error) {
 	// Maintain only one active incident per monitor; use the region-specific window for detection.
 	failureThreshold := int(monitor.FailureThreshold)
 	if failureThreshold <= 0 {
-		return false, "", nil
+		return nil, nil
 	}
 
 	window := int(math.Ceil(float64(failureThreshold) * 1.5))
 	recent, err := h.repo.ListRecentPingsByMonitorIDAndRegion(ctx, tx, monitor.ID, regionID, window-1)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 
 	samples := append([]models.Ping{ping}, recent...)
@@ -242,82 +302,98 @@ func (h *Handler) handleIncidentFailure(ctx context.Context, tx pgx.Tx, monitor
 
 	// Create a new incident when the failure threshold is met.
 	if failureCount >= failureThreshold && len(samples) >= failureThreshold && openIncident == nil {
-		createdIncident, created, err := h.createIncidentIfAbsent(ctx, tx, monitor.ID, ping.Time, message, now)
+		// Failures caused by an upstream outage join the parent's incident instead of paging again.
+		attached, err := h.attachToUpstreamIncident(ctx, tx, monitor, message, now)
 		if err != nil {
-			return false, "", err
+			return nil, err
+		}
+		if attached {
+			return nil, nil
+		}
+
+		createdIncident, created, err := h.createIncidentIfAbsent(ctx, tx, monitor.ID, ping.Time, message, now, models.IncidentSeverityMajor)
+		if err != nil {
+			return nil, err
 		}
 		if created {
-			return true, message, nil
+			return &incidentEvent{kind: models.NotificationEventOpened, incident: *createdIncident, detail: message}, nil
 		}
 		// If not created, fall through to update handling below.
 		openIncident = createdIncident
 	}
 
+	// A slow-response incident turns into an outage once the monitor is actually down.
+	if openIncident != nil && openIncident.Severity == models.IncidentSeverityMinor && failureCount >= failureThreshold {
+		return h.raiseDegradedIncident(ctx, tx, *openIncident, message, now)
+	}
+
 	// If an incident is already open, record meaningful changes in failure type.
 	if openIncident != nil {
-		lastEvent, err := h.repo.GetLastEventTimeline(ctx, tx, openIncident.ID)
-		if err != nil {
-			return false, "", err
+		if err := h.recordIncidentUpdate(ctx, tx, openIncident.ID, message, now); err != nil {
+			return nil, err
 		}
+	}
 
-		if lastEvent == nil || strings.TrimSpace(lastEvent.Message) != message {
-			if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
-				IncidentID: openIncident.ID,
-				Message:    message,
-				EventType:  models.IncidentEventTypeUpdate,
-				CreatedAt:  now,
-				UpdatedAt:  now,
-			}); err != nil {
-				return false, "", err
-			}
-		}
+	return nil, nil
+}
+
+// recordIncidentUpdate adds an update to the incident timeline unless it repeats the last entry.
+func (h *Handler) recordIncidentUpdate(ctx context.Context, tx pgx.Tx, incidentID int64, message string, now time.Time) error {
+	lastEvent, err := h.repo.GetLastEventTimeline(ctx, tx, incidentID)
+	if err != nil {
+		return err
+	}
+
+	if lastEvent != nil && strings.TrimSpace(lastEvent.Message) == message {
+		return nil
 	}
 
-	return false, "", nil
+	return h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
+		IncidentID: incidentID,
+		Message:    message,
+		EventType:  models.IncidentEventTypeUpdate,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
 }
 
-func (h *Handler) handleIncidentRecovery(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident) (bool, string, error) {
+func (h *Handler) handleIncidentRecovery(ctx context.Context, tx pgx.Tx, monitor models.Monitor, ping models.Ping, regionID int64, detail string, openIncident *models.Incident) (*incidentEvent, error) {
 	// Nothing to do if no incident is open.
 	if openIncident == nil {
-		return false, "", nil
+		return nil, nil
 	}
 	if openIncident.AutoResolve == false {
-		return false, "", nil
+		return nil, nil
 	}
 
 	recoveryThreshold := int(monitor.RecoveryThreshold)
 	if recoveryThreshold <= 0 {
-		return false, "", nil
+		return nil, nil
 	}
 
 	// Pull only enough recent pings (region-specific) to evaluate recovery, include current ping first.
 	recent, err := h.repo.ListRecentPingsByMonitorIDAndRegion(ctx, tx, monitor.ID, regionID, recoveryThreshold-1)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 
 	samples := append([]models.Ping{ping}, recent...)
 	if len(samples) < recoveryThreshold {
-		return false, "", nil
+		return nil, nil
 	}
 
-	allSuccessful := true
-	for i := range(recoveryThreshold) {
-		if samples[i].Status != models.PingStatusSuccessful {
-			allSuccessful = false
-			break
+	// Slow responses keep the incident open just like failures do.
+	for i := range recoveryThreshold {
+		if monitorcore.PingHealth(monitor, samples[i]) != models.MonitorStatusUp {
+			return nil, nil
 		}
 	}
 
-	if !allSuccessful {
-		return false, "", nil
-	}
-
 	now := time.Now().UTC()
 	message := incidentMessage(strconv.FormatInt(regionID, 10), detail, ping, "recovered")
 
 	if err := h.repo.MarkIncidentResolved(ctx, tx, openIncident.ID, ping.Time, now); err != nil {
-		return false, "", err
+		return nil, err
 	}
 
 	if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
@@ -327,10 +403,20 @@ func (h *Handler) handleIncidentRecovery(ctx context.Context, tx pgx.Tx, monitor
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}); err != nil {
-		return false, "", err
+		return nil, err
+	}
+
+	// Stop paging once the incident is resolved.
+	cancelled, err := h.repo.CancelPendingIncidentEscalations(ctx, tx, openIncident.ID, now)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, message, nil
+	resolved := *openIncident
+	resolved.Status = models.IncidentStatusResolved
+	resolved.ResolvedAt = &ping.Time
+
+	return &incidentEvent{kind: models.NotificationEventResolved, incident: resolved, detail: message, cancelled: cancelled}, nil
 }
 
 func countFailures(pings []models.Ping, window int) int {
@@ -350,8 +436,36 @@ func countFailures(pings []models.Ping, window int) int {
 	return failures
 }
 
+// attachToUpstreamIncident links the monitor to an open incident of one of its parents, if any.
+func (h *Handler) attachToUpstreamIncident(ctx context.Context, tx pgx.Tx, monitor models.Monitor, message string, now time.Time) (bool, error) {
+	if len(monitor.ParentIDs) == 0 {
+		return false, nil
+	}
+
+	upstream, err := h.repo.GetOpenIncidentByMonitorIDs(ctx, tx, monitor.ParentIDs)
+	if err != nil || upstream == nil {
+		return false, err
+	}
+
+	if err := h.repo.AttachDownstreamMonitor(ctx, tx, upstream.ID, monitor.ID); err != nil {
+		return false, err
+	}
+
+	if err := h.repo.CreateEventTimeline(ctx, tx, models.EventTimeline{
+		IncidentID: upstream.ID,
+		Message:    fmt.Sprintf("Downstream monitor %s is failing: %s", monitor.Name, message),
+		EventType:  models.IncidentEventTypeUpdate,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // createIncidentIfAbsent tries to create a new incident, handling unique constraint races gracefully.
-func (h *Handler) createIncidentIfAbsent(ctx context.Context, tx pgx.Tx, monitorID int64, startedAt time.Time, message string, now time.Time) (*models.Incident, bool, error) {
+func (h *Handler) createIncidentIfAbsent(ctx context.Context, tx pgx.Tx, monitorID int64, startedAt time.Time, message string, now time.Time, severity models.IncidentSeverity) (*models.Incident, bool, error) {
 	newID, err := id.GetID()
 	if err != nil {
 		return nil, false, err
@@ -360,7 +474,7 @@ func (h *Handler) createIncidentIfAbsent(ctx context.Context, tx pgx.Tx, monitor
 	incident := models.Incident{
 		ID:          newID,
 		Status:      models.IncidentStatusDetected,
-		Severity:    models.IncidentSeverityMajor,
+		Severity:    severity,
 		IsPublic:    false,
 		AutoResolve: true,
 		StartedAt:   startedAt,
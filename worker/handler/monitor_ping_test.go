@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yorukot/knocker/internal/testutil"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/repository"
+)
+
+func TestProcessIncident_DownstreamFailureAttachesToUpstream(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	failed := models.Ping{Time: time.Now().UTC(), MonitorID: 6, RegionID: 1, Status: models.PingStatusFailed}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOpenIncidentByMonitorID", mock.Anything, mock.Anything, int64(6)).Return(nil, nil)
+	mockRepo.On("UpdateMonitorStatus", mock.Anything, mock.Anything, int64(6), models.MonitorStatusDown, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(6), int64(1), 1).Return([]models.Ping{}, nil)
+	mockRepo.On("GetOpenIncidentByMonitorIDs", mock.Anything, mock.Anything, []int64{5}).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusDetected, Severity: models.IncidentSeverityMajor}, nil)
+	mockRepo.On("AttachDownstreamMonitor", mock.Anything, mock.Anything, int64(11), int64(6)).Return(nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.EventTimeline) bool {
+		return event.IncidentID == 11 && event.EventType == models.IncidentEventTypeUpdate
+	})).Return(nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+	monitor := models.Monitor{
+		ID:                6,
+		TeamID:            7,
+		Name:              "api",
+		Status:            models.MonitorStatusUp,
+		FailureThreshold:  1,
+		RecoveryThreshold: 1,
+		ParentIDs:         []int64{5},
+	}
+
+	h.processIncident(context.Background(), monitor, failed, 1, "connection refused")
+
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ListNotificationRoutesByTeamID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessIncident_FailureWithoutUpstreamIncidentOpensIncident(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	failed := models.Ping{Time: time.Now().UTC(), MonitorID: 6, RegionID: 1, Status: models.PingStatusFailed}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOpenIncidentByMonitorID", mock.Anything, mock.Anything, int64(6)).Return(nil, nil)
+	mockRepo.On("UpdateMonitorStatus", mock.Anything, mock.Anything, int64(6), models.MonitorStatusDown, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(6), int64(1), 1).Return([]models.Ping{}, nil)
+	mockRepo.On("GetOpenIncidentByMonitorIDs", mock.Anything, mock.Anything, []int64{5}).Return(nil, nil)
+	mockRepo.On("CreateIncident", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateIncidentMonitor", mock.Anything, mock.Anything, mock.Anything, int64(6)).Return(nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListNotificationRoutesByTeamID", mock.Anything, mock.Anything, int64(7)).Return([]models.NotificationRoute{}, nil)
+	mockRepo.On("GetNotificationIDsByMonitorID", mock.Anything, mock.Anything, int64(6)).Return([]int64{20}, nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+	monitor := models.Monitor{
+		ID:                6,
+		TeamID:            7,
+		Name:              "api",
+		Status:            models.MonitorStatusUp,
+		FailureThreshold:  1,
+		RecoveryThreshold: 1,
+		ParentIDs:         []int64{5},
+	}
+
+	h.processIncident(context.Background(), monitor, failed, 1, "connection refused")
+
+	require.Len(t, enqueuer.tasks, 1)
+	mockRepo.AssertNotCalled(t, "AttachDownstreamMonitor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessIncident_DegradedDownstreamAttachesToUpstream(t *testing.T) {
+	testutil.InitTestEnv(t)
+
+	slow := models.Ping{Time: time.Now().UTC(), MonitorID: 6, RegionID: 1, Status: models.PingStatusSuccessful, Latency: 900}
+
+	mockRepo := &repository.MockRepository{}
+	mockRepo.On("StartTransaction", mock.Anything).Return(nil, nil)
+	mockRepo.On("DeferRollback", mock.Anything, mock.Anything)
+	mockRepo.On("CommitTransaction", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOpenIncidentByMonitorID", mock.Anything, mock.Anything, int64(6)).Return(nil, nil)
+	mockRepo.On("UpdateMonitorStatus", mock.Anything, mock.Anything, int64(6), models.MonitorStatusDegraded, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("ListRecentPingsByMonitorIDAndRegion", mock.Anything, mock.Anything, int64(6), int64(1), 1).Return([]models.Ping{}, nil)
+	mockRepo.On("GetOpenIncidentByMonitorIDs", mock.Anything, mock.Anything, []int64{5}).
+		Return(&models.Incident{ID: 11, Status: models.IncidentStatusDetected, Severity: models.IncidentSeverityMajor}, nil)
+	mockRepo.On("AttachDownstreamMonitor", mock.Anything, mock.Anything, int64(11), int64(6)).Return(nil)
+	mockRepo.On("CreateEventTimeline", mock.Anything, mock.Anything, mock.MatchedBy(func(event models.EventTimeline) bool {
+		return event.IncidentID == 11 && event.EventType == models.IncidentEventTypeUpdate
+	})).Return(nil)
+
+	enqueuer := &recordingEnqueuer{}
+	h := &Handler{repo: mockRepo, notifier: enqueuer}
+	warn := 500
+	monitor := models.Monitor{
+		ID:                6,
+		TeamID:            7,
+		Name:              "api",
+		Status:            models.MonitorStatusUp,
+		FailureThreshold:  1,
+		RecoveryThreshold: 1,
+		ParentIDs:         []int64{5},
+		LatencyWarnMs:     &warn,
+	}
+
+	h.processIncident(context.Background(), monitor, slow, 1, "")
+
+	require.Empty(t, enqueuer.tasks)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything, mock.Anything)
+}
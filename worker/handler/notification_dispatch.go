@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 	notificationcore "github.com/yorukot/knocker/core/notification"
 	"github.com/yorukot/knocker/models"
 	"github.com/yorukot/knocker/utils/config"
@@ -26,6 +27,7 @@ func (h *Handler) HandleNotificationDispatch(ctx context.Context, t *asynq.Task)
 		zap.L().Error("failed to load notification context",
 			zap.Int64("monitor_id", payload.MonitorID),
 			zap.Int64("notification_id", payload.NotificationID),
+			zap.Int64("user_id", payload.UserID),
 			zap.Error(err))
 		return err
 	}
@@ -53,11 +55,13 @@ func (h *Handler) HandleNotificationDispatch(ctx context.Context, t *asynq.Task)
 		LatencyMs:         payload.Ping.Latency,
 		CheckedAt:         payload.Ping.Time,
 		Detail:            detail,
+		Event:             payload.Event,
 	})
 	if err := notificationcore.Send(ctx, *notification, title, description, payload.Ping.Status); err != nil {
 		zap.L().Error("failed to send notification",
 			zap.Int64("monitor_id", payload.MonitorID),
 			zap.Int64("notification_id", payload.NotificationID),
+			zap.Int64("user_id", payload.UserID),
 			zap.String("notification_type", string(notification.Type)),
 			zap.Error(err))
 		return err
@@ -66,6 +70,7 @@ func (h *Handler) HandleNotificationDispatch(ctx context.Context, t *asynq.Task)
 	zap.L().Info("notification dispatched",
 		zap.Int64("monitor_id", payload.MonitorID),
 		zap.Int64("notification_id", payload.NotificationID),
+		zap.Int64("user_id", payload.UserID),
 		zap.String("notification_type", string(notification.Type)),
 		zap.Int64("region_id", payload.RegionID),
 		zap.String("region", region.Name),
@@ -86,7 +91,12 @@ func (h *Handler) fetchMonitorAndNotification(ctx context.Context, payload tasks
 		return monitor, nil, err
 	}
 
-	notification, err := h.repo.GetNotificationByID(ctx, tx, payload.TeamID, payload.NotificationID)
+	var notification *models.Notification
+	if payload.NotificationID == 0 && payload.UserID != 0 {
+		notification, err = h.userNotification(ctx, tx, payload.TeamID, payload.UserID)
+	} else {
+		notification, err = h.repo.GetNotificationByID(ctx, tx, payload.TeamID, payload.NotificationID)
+	}
 	if err != nil || notification == nil {
 		return monitor, notification, err
 	}
@@ -97,3 +107,23 @@ func (h *Handler) fetchMonitorAndNotification(ctx context.Context, payload tasks
 
 	return monitor, notification, nil
 }
+
+// userNotification builds an email notification addressed to a paged team member.
+func (h *Handler) userNotification(ctx context.Context, tx pgx.Tx, teamID, userID int64) (*models.Notification, error) {
+	email, err := h.repo.GetUserEmailByID(ctx, tx, userID)
+	if err != nil || email == "" {
+		return nil, err
+	}
+
+	config, err := json.Marshal(models.EmailNotificationConfig{Email: email})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Notification{
+		TeamID: teamID,
+		Type:   models.NotificationTypeEmail,
+		Name:   "Knocker",
+		Config: config,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	notificationcore "github.com/yorukot/knocker/core/notification"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// HandleSecurityAlert emails a user about a security event on their account.
+func (h *Handler) HandleSecurityAlert(ctx context.Context, t *asynq.Task) error {
+	var payload tasks.SecurityAlertPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		zap.L().Error("invalid security alert payload", zap.Error(err))
+		return err
+	}
+
+	tx, err := h.repo.StartTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer h.repo.DeferRollback(tx, ctx)
+
+	notification, err := h.userNotification(ctx, tx, 0, payload.UserID)
+	if err != nil {
+		zap.L().Error("failed to load user for security alert", zap.Int64("user_id", payload.UserID), zap.Error(err))
+		return err
+	}
+
+	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
+		return err
+	}
+
+	if notification == nil {
+		zap.L().Warn("user has no email for security alert", zap.Int64("user_id", payload.UserID))
+		return nil
+	}
+
+	title, description := formatSecurityAlert(payload)
+	if err := notificationcore.Send(ctx, *notification, title, description, models.PingStatusFailed); err != nil {
+		zap.L().Error("failed to send security alert",
+			zap.Int64("user_id", payload.UserID),
+			zap.String("type", string(payload.Type)),
+			zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("security alert sent",
+		zap.Int64("user_id", payload.UserID),
+		zap.String("type", string(payload.Type)))
+
+	return nil
+}
+
+func formatSecurityAlert(payload tasks.SecurityAlertPayload) (string, string) {
+	switch payload.Type {
+	case models.SecurityEventRefreshTokenReuse:
+		return "Possible stolen session on your Knocker account",
+			fmt.Sprintf("A sign-in token that had already been used was presented again at %s from %s (%s). "+
+				"This usually means the token was copied, so the affected session has been signed out everywhere. "+
+				"If this wasn't you, change your password and review your active sessions.",
+				payload.OccurredAt.UTC().Format("2006-01-02 15:04:05 UTC"), payload.IP, payload.UserAgent)
+	case models.SecurityEventPasswordReset:
+		return "Your Knocker password was reset",
+			fmt.Sprintf("The password of your account was reset at %s from %s (%s) and every session was signed out. "+
+				"If this wasn't you, reset your password again right away.",
+				payload.OccurredAt.UTC().Format("2006-01-02 15:04:05 UTC"), payload.IP, payload.UserAgent)
+	case models.SecurityEventLoginLocked:
+		return "Sign-ins to your Knocker account were paused",
+			fmt.Sprintf("Several sign-ins with a wrong password were attempted at %s, most recently from %s (%s), "+
+				"so password sign-in is paused for a while. If this wasn't you, consider changing your password and enabling two-factor authentication.",
+				payload.OccurredAt.UTC().Format("2006-01-02 15:04:05 UTC"), payload.IP, payload.UserAgent)
+	default:
+		return "Security activity on your Knocker account",
+			fmt.Sprintf("A security event (%s) was recorded on your account at %s from %s.",
+				payload.Type, payload.OccurredAt.UTC().Format("2006-01-02 15:04:05 UTC"), payload.IP)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/utils/mailer"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+// HandleSendEmail delivers an email queued by the API.
+func (h *Handler) HandleSendEmail(ctx context.Context, t *asynq.Task) error {
+	var payload tasks.SendEmailPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		zap.L().Error("invalid send email payload", zap.Error(err))
+		return err
+	}
+
+	if err := mailer.Send(ctx, mailer.Message{
+		To:      payload.To,
+		Subject: payload.Subject,
+		Text:    payload.Text,
+	}); err != nil {
+		zap.L().Error("failed to send email", zap.String("subject", payload.Subject), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	notificationcore "github.com/yorukot/knocker/core/notification"
+	statuspagecore "github.com/yorukot/knocker/core/statuspage"
+	"github.com/yorukot/knocker/models"
+	"github.com/yorukot/knocker/worker/tasks"
+	"go.uber.org/zap"
+)
+
+type subscriberWebhookStatusPage struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type subscriberWebhookIncident struct {
+	ID         int64                   `json:"id,string"`
+	Status     models.IncidentStatus   `json:"status"`
+	Severity   models.IncidentSeverity `json:"severity"`
+	StartedAt  time.Time               `json:"started_at"`
+	ResolvedAt *time.Time              `json:"resolved_at,omitempty"`
+}
+
+// subscriberWebhookBody is what a subscriber's webhook receives for each update
+type subscriberWebhookBody struct {
+	Kind           models.StatusPageUpdateKind `json:"kind"`
+	Message        string                      `json:"message"`
+	OccurredAt     time.Time                   `json:"occurred_at"`
+	StatusPage     subscriberWebhookStatusPage `json:"status_page"`
+	Incident       subscriberWebhookIncident   `json:"incident"`
+	UnsubscribeURL string                      `json:"unsubscribe_url"`
+}
+
+// HandleStatusPageUpdate fans a public incident update out to the matching status page subscribers.
+// Each delivery is its own task so one failing webhook or mailbox is retried on its own.
+func (h *Handler) HandleStatusPageUpdate(ctx context.Context, t *asynq.Task) error {
+	var payload tasks.StatusPageUpdatePayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		zap.L().Error("invalid status page update payload", zap.Error(err))
+		return err
+	}
+
+	tx, err := h.repo.StartTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer h.repo.DeferRollback(tx, ctx)
+
+	subscribers, err := h.repo.ListStatusPageSubscribersForIncident(ctx, tx, payload.Incident.ID)
+	if err != nil {
+		zap.L().Error("failed to list status page subscribers", zap.Int64("incident_id", payload.Incident.ID), zap.Error(err))
+		return err
+	}
+
+	if err := h.repo.CommitTransaction(tx, ctx); err != nil {
+		return err
+	}
+
+	for _, subscriber := range subscribers {
+		var (
+			task *asynq.Task
+			err  error
+		)
+
+		switch subscriber.Type {
+		case models.StatusPageSubscriberEmail:
+			task, err = tasks.NewSendEmail(subscriberEmail(subscriber, payload))
+		case models.StatusPageSubscriberWebhook:
+			var body []byte
+			body, err = json.Marshal(newSubscriberWebhookBody(subscriber, payload))
+			if err == nil {
+				task, err = tasks.NewSubscriberWebhook(tasks.SubscriberWebhookPayload{
+					SubscriberID: subscriber.ID,
+					URL:          subscriber.Target,
+					Body:         body,
+				})
+			}
+		default:
+			continue
+		}
+
+		if err == nil {
+			_, err = h.notifier.Enqueue(task)
+		}
+		if err != nil {
+			zap.L().Error("failed to enqueue status page subscriber update",
+				zap.Int64("incident_id", payload.Incident.ID),
+				zap.Int64("subscriber_id", subscriber.ID),
+				zap.Error(err))
+		}
+	}
+
+	zap.L().Info("status page update fanned out",
+		zap.Int64("incident_id", payload.Incident.ID),
+		zap.String("kind", string(payload.Kind)),
+		zap.Int("subscribers", len(subscribers)))
+
+	return nil
+}
+
+// HandleSubscriberWebhook delivers one update to a subscriber's webhook.
+func (h *Handler) HandleSubscriberWebhook(ctx context.Context, t *asynq.Task) error {
+	var payload tasks.SubscriberWebhookPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		zap.L().Error("invalid subscriber webhook payload", zap.Error(err))
+		return err
+	}
+
+	if err := notificationcore.SendWebhook(ctx, payload.URL, payload.Body); err != nil {
+		zap.L().Warn("failed to deliver subscriber webhook",
+			zap.Int64("subscriber_id", payload.SubscriberID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// notifyStatusPageSubscribers queues an update of a public incident for status page subscribers.
+// Private incidents never reach subscribers.
+func (h *Handler) notifyStatusPageSubscribers(incident models.Incident, kind models.StatusPageUpdateKind, message string) {
+	if h.notifier == nil || !incident.IsPublic {
+		return
+	}
+
+	task, err := tasks.NewStatusPageUpdate(tasks.StatusPageUpdatePayload{
+		Incident:   incident,
+		Kind:       kind,
+		Message:    message,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err == nil {
+		_, err = h.notifier.Enqueue(task)
+	}
+	if err != nil {
+		zap.L().Error("failed to enqueue status page update",
+			zap.Int64("incident_id", incident.ID),
+			zap.String("kind", string(kind)),
+			zap.Error(err))
+	}
+}
+
+func newSubscriberWebhookBody(subscriber models.StatusPageSubscriberWithPage, payload tasks.StatusPageUpdatePayload) subscriberWebhookBody {
+	return subscriberWebhookBody{
+		Kind:       payload.Kind,
+		Message:    payload.Message,
+		OccurredAt: payload.OccurredAt,
+		StatusPage: subscriberWebhookStatusPage{
+			Slug:  subscriber.StatusPageSlug,
+			Title: subscriber.StatusPageTitle,
+			URL:   statuspagecore.PageURL(subscriber.StatusPageSlug),
+		},
+		Incident: subscriberWebhookIncident{
+			ID:         payload.Incident.ID,
+			Status:     payload.Incident.Status,
+			Severity:   payload.Incident.Severity,
+			StartedAt:  payload.Incident.StartedAt,
+			ResolvedAt: payload.Incident.ResolvedAt,
+		},
+		UnsubscribeURL: statuspagecore.UnsubscribeURL(subscriber.StatusPageSlug, subscriber.ID),
+	}
+}
+
+func subscriberEmail(subscriber models.StatusPageSubscriberWithPage, payload tasks.StatusPageUpdatePayload) tasks.SendEmailPayload {
+	var subject string
+	switch payload.Kind {
+	case models.StatusPageUpdateOpened:
+		subject = fmt.Sprintf("[%s] New incident", subscriber.StatusPageTitle)
+	case models.StatusPageUpdateResolved:
+		subject = fmt.Sprintf("[%s] Incident resolved", subscriber.StatusPageTitle)
+	default:
+		subject = fmt.Sprintf("[%s] Incident update: %s", subscriber.StatusPageTitle, payload.Incident.Status)
+	}
+
+	var text strings.Builder
+	if payload.Message != "" {
+		text.WriteString(payload.Message + "\n\n")
+	}
+	fmt.Fprintf(&text, "Status: %s\nStarted: %s\n", payload.Incident.Status, payload.Incident.StartedAt.UTC().Format(time.RFC1123))
+	if payload.Incident.ResolvedAt != nil {
+		fmt.Fprintf(&text, "Resolved: %s\n", payload.Incident.ResolvedAt.UTC().Format(time.RFC1123))
+	}
+	fmt.Fprintf(&text, "\nFollow the incident on %s\n\n", statuspagecore.PageURL(subscriber.StatusPageSlug))
+	fmt.Fprintf(&text, "You are receiving this because you subscribed to updates from %s. To stop, open %s",
+		subscriber.StatusPageTitle, statuspagecore.UnsubscribeURL(subscriber.StatusPageSlug, subscriber.ID))
+
+	return tasks.SendEmailPayload{
+		To:      subscriber.Target,
+		Subject: subject,
+		Text:    text.String(),
+	}
+}
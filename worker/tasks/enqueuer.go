@@ -0,0 +1,8 @@
+package tasks
+
+import "github.com/hibiken/asynq"
+
+// Enqueuer is the part of asynq.Client used to queue tasks, so callers can be tested without Redis.
+type Enqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
@@ -0,0 +1,30 @@
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/models"
+)
+
+// EscalationStepPayload represents a delayed escalation policy step for an open incident.
+type EscalationStepPayload struct {
+	TeamID     int64                     `json:"team_id,string"`
+	MonitorID  int64                     `json:"monitor_id,string"`
+	IncidentID int64                     `json:"incident_id,string"`
+	Position   int16                     `json:"position"`
+	TaskID     string                    `json:"task_id"`
+	Targets    []models.EscalationTarget `json:"targets"`
+	RegionID   int64                     `json:"region_id,string"`
+	Ping       models.Ping               `json:"ping"`
+	Detail     string                    `json:"detail,omitempty"`
+}
+
+func NewEscalationStep(payload EscalationStepPayload, opts ...asynq.Option) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeEscalationStep, body, opts...), nil
+}
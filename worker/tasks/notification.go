@@ -8,13 +8,16 @@ import (
 )
 
 // NotificationPayload represents a notification dispatch request.
+// Either NotificationID (a team channel) or UserID (a paged member) is set.
 type NotificationPayload struct {
-	TeamID         int64       `json:"team_id,string"`
-	MonitorID      int64       `json:"monitor_id,string"`
-	NotificationID int64       `json:"notification_id,string"`
-	RegionID       int64       `json:"region_id,string"`
-	Ping           models.Ping `json:"ping"`
-	Detail         string      `json:"detail,omitempty"`
+	TeamID         int64                        `json:"team_id,string"`
+	MonitorID      int64                        `json:"monitor_id,string"`
+	NotificationID int64                        `json:"notification_id,string"`
+	UserID         int64                        `json:"user_id,string,omitempty"`
+	RegionID       int64                        `json:"region_id,string"`
+	Ping           models.Ping                  `json:"ping"`
+	Detail         string                       `json:"detail,omitempty"`
+	Event          models.NotificationEventKind `json:"event,omitempty"`
 }
 
 func NewNotificationDispatch(payload NotificationPayload) (*asynq.Task, error) {
@@ -0,0 +1,27 @@
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/models"
+)
+
+// SecurityAlertPayload tells a user about a security event on their account.
+type SecurityAlertPayload struct {
+	UserID     int64                    `json:"user_id,string"`
+	Type       models.SecurityEventType `json:"type"`
+	IP         string                   `json:"ip,omitempty"`
+	UserAgent  string                   `json:"user_agent,omitempty"`
+	OccurredAt time.Time                `json:"occurred_at"`
+}
+
+func NewSecurityAlert(payload SecurityAlertPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSecurityAlert, body, asynq.MaxRetry(5)), nil
+}
@@ -0,0 +1,23 @@
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// SendEmailPayload is a plain-text email to deliver through the configured mailer.
+type SendEmailPayload struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+}
+
+func NewSendEmail(payload SendEmailPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSendEmail, body, asynq.MaxRetry(5)), nil
+}
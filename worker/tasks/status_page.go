@@ -0,0 +1,43 @@
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/yorukot/knocker/models"
+)
+
+// StatusPageUpdatePayload announces a change of a public incident to the subscribers
+// of every status page showing one of its monitors.
+type StatusPageUpdatePayload struct {
+	Incident   models.Incident             `json:"incident"`
+	Kind       models.StatusPageUpdateKind `json:"kind"`
+	Message    string                      `json:"message"`
+	OccurredAt time.Time                   `json:"occurred_at"`
+}
+
+func NewStatusPageUpdate(payload StatusPageUpdatePayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeStatusPageUpdate, body, asynq.MaxRetry(5)), nil
+}
+
+// SubscriberWebhookPayload is a single delivery to a status page subscriber's webhook.
+type SubscriberWebhookPayload struct {
+	SubscriberID int64           `json:"subscriber_id,string"`
+	URL          string          `json:"url"`
+	Body         json.RawMessage `json:"body"`
+}
+
+func NewSubscriberWebhook(payload SubscriberWebhookPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSubscriberWebhook, body, asynq.MaxRetry(5)), nil
+}
@@ -3,4 +3,9 @@ package tasks
 const (
 	TypeMonitorPingPattern   = "monitor:ping:{region}"
 	TypeNotificationDispatch = "notification:dispatch"
+	TypeEscalationStep       = "escalation:step"
+	TypeSecurityAlert        = "user:security_alert"
+	TypeSendEmail            = "mail:send"
+	TypeStatusPageUpdate     = "status_page:update"
+	TypeSubscriberWebhook    = "status_page:webhook"
 )
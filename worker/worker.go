@@ -1,7 +1,6 @@
 package worker
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/hibiken/asynq"
@@ -17,11 +16,7 @@ func Run(db *pgxpool.Pool) {
 	zap.L().Info("Starting worker")
 	cfg := config.Env()
 
-	redisAddr := fmt.Sprintf("%s:%s", cfg.RedisHost, cfg.RedisPort)
-	redisOpt := asynq.RedisClientOpt{
-		Addr:     redisAddr,
-		Password: cfg.RedisPassword,
-	}
+	redisOpt := config.AsynqRedisOpt()
 	regionIDString := strconv.FormatInt(config.RegionByName(cfg.AppRegion).ID, 10)
 
 	queues := map[string]int{
@@ -42,12 +37,20 @@ func Run(db *pgxpool.Pool) {
 	notifier := asynq.NewClient(redisOpt)
 	defer notifier.Close()
 
+	inspector := asynq.NewInspector(redisOpt)
+	defer inspector.Close()
+
 	repo := repository.New(db)
-	h := handler.NewHandler(repo, notifier)
+	h := handler.NewHandler(repo, notifier, inspector)
 
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(tasks.TypeMonitorPingPattern, h.HandleStartServiceTask)
 	mux.HandleFunc(tasks.TypeNotificationDispatch, h.HandleNotificationDispatch)
+	mux.HandleFunc(tasks.TypeEscalationStep, h.HandleEscalationStep)
+	mux.HandleFunc(tasks.TypeSecurityAlert, h.HandleSecurityAlert)
+	mux.HandleFunc(tasks.TypeSendEmail, h.HandleSendEmail)
+	mux.HandleFunc(tasks.TypeStatusPageUpdate, h.HandleStatusPageUpdate)
+	mux.HandleFunc(tasks.TypeSubscriberWebhook, h.HandleSubscriberWebhook)
 
 	if err := srv.Run(mux); err != nil {
 		panic(err)